@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
 )
 
 var (
@@ -13,6 +14,6 @@ var (
 
 func main() {
 	if err := cmdline.Execute(buildVersion, buildDate); err != nil {
-		os.Exit(1)
+		os.Exit(entity.ExitCode(err))
 	}
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/alkurbatov/goph-keeper/internal/libraries/creds"
 	"github.com/spf13/viper"
@@ -15,13 +16,24 @@ type Config struct {
 	Address  string
 	CAPath   string
 	Verbose  bool
+
+	// ClipboardTimeout is delay before clearing of copied secrets, 0 disables clearing.
+	ClipboardTimeout time.Duration
+
+	// BreachDB is path to local copy of the Pwned Passwords database,
+	// new passwords are checked against it if set.
+	BreachDB string
 }
 
+// DefaultClipboardTimeout is default delay before clearing of copied secrets.
+const DefaultClipboardTimeout = 45 * time.Second
+
 // New create application config by reading environment variables and
 // commandline flags. The flags are read inderectly through binding in cobra.
 func New() *Config {
 	viper.SetDefault("address", "127.0.0.1:50051")
 	viper.SetDefault("verbose", false)
+	viper.SetDefault("clipboard-timeout", DefaultClipboardTimeout)
 
 	viper.SetEnvPrefix("GOPH")
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
@@ -33,6 +45,9 @@ func New() *Config {
 		Address:  viper.GetString("address"),
 		CAPath:   viper.GetString("ca-path"),
 		Verbose:  viper.GetBool("verbose"),
+
+		ClipboardTimeout: viper.GetDuration("clipboard-timeout"),
+		BreachDB:         viper.GetString("breach-db"),
 	}
 
 	return cfg
@@ -46,7 +61,9 @@ func (c *Config) String() string {
 	sb.WriteString(fmt.Sprintf("\t\tPassword: %s\n", c.Password))
 	sb.WriteString(fmt.Sprintf("\t\tKeeper address: %s\n", c.Address))
 	sb.WriteString(fmt.Sprintf("\t\tCertificate authority path: %s\n", c.CAPath))
-	sb.WriteString(fmt.Sprintf("\t\tVerbose: %t", c.Verbose))
+	sb.WriteString(fmt.Sprintf("\t\tVerbose: %t\n", c.Verbose))
+	sb.WriteString(fmt.Sprintf("\t\tClipboard timeout: %s\n", c.ClipboardTimeout))
+	sb.WriteString(fmt.Sprintf("\t\tBreached passwords database: %s", c.BreachDB))
 
 	return sb.String()
 }
@@ -34,6 +34,8 @@ func TestConfigFromEnv(t *testing.T) {
 	os.Setenv("GOPH_ADDRESS", "192.168.0.10:8080")
 	os.Setenv("GOPH_CA_PATH", "/etc/ssl/root.crt")
 	os.Setenv("GOPH_VERBOSE", "1")
+	os.Setenv("GOPH_CLIPBOARD_TIMEOUT", "10s")
+	os.Setenv("GOPH_BREACH_DB", "/var/lib/hibp")
 
 	t.Cleanup(unsetGophEnv)
 
@@ -0,0 +1,73 @@
+package cmdline
+
+import (
+	"os"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mimeType string
+
+	attachCmd = &cobra.Command{
+		Use:   "attach [secret] [file] [flags]",
+		Short: "Attach encrypted file to the secret",
+		Long: "Attach encrypted file to the secret.\n" +
+			"Attached files are removed together with the secret.",
+		Args: cobra.ExactArgs(2),
+		RunE: doAttach,
+	}
+)
+
+func init() {
+	attachCmd.Flags().StringVar(
+		&mimeType,
+		"mime-type",
+		"",
+		"MIME type of the file, detected from the file name and content if omitted",
+	)
+
+	rootCmd.AddCommand(attachCmd)
+}
+
+func doAttach(cmd *cobra.Command, args []string) error {
+	content, err := os.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	secretID, err := resolveSecret(cmd, clientApp, args[0])
+	if err != nil {
+		return err
+	}
+
+	id, err := clientApp.Usecases.Attachments.Attach(
+		cmd.Context(),
+		clientApp.AccessToken,
+		secretID,
+		args[1],
+		mimeType,
+		content,
+	)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	if output.Machine() {
+		return output.PrintID(cmd.OutOrStdout(), id.String())
+	}
+
+	clientApp.Log.Info().Str("attachment-id", id.String()).Msg("File attached successfully")
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package cmdline
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attachmentOutput string
+
+	attachmentsCmd = &cobra.Command{
+		Use:   "attachments [secret] [attachment id] [flags]",
+		Short: "List files attached to the secret or save one of them",
+		Long: "List files attached to the secret.\n" +
+			"If attachment id is provided, the file is decrypted and saved locally.",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: doAttachments,
+	}
+)
+
+func init() {
+	attachmentsCmd.Flags().StringVarP(
+		&attachmentOutput,
+		"save-as",
+		"o",
+		"",
+		"Path to save the attached file, original file name in current directory if omitted",
+	)
+
+	rootCmd.AddCommand(attachmentsCmd)
+}
+
+func doAttachments(cmd *cobra.Command, args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	secretID, err := resolveSecret(cmd, clientApp, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		return saveAttachment(cmd, clientApp, args[1])
+	}
+
+	data, err := clientApp.Usecases.Attachments.List(cmd.Context(), clientApp.AccessToken, secretID)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	v := output.View{
+		Header: []string{"ID", "File name", "MIME type", "Size"},
+		Rows:   make([][]string, 0, len(data)),
+	}
+	records := make([]attachmentRecord, 0, len(data))
+
+	for _, attachment := range data {
+		v.Rows = append(v.Rows, []string{
+			attachment.ID,
+			attachment.Info.GetFilename(),
+			attachment.Info.GetMimeType(),
+			strconv.FormatUint(attachment.Info.GetSize(), 10),
+		})
+		records = append(records, attachmentRecord{
+			ID:       attachment.ID,
+			Filename: attachment.Info.GetFilename(),
+			MimeType: attachment.Info.GetMimeType(),
+			Size:     attachment.Info.GetSize(),
+		})
+	}
+
+	v.Records = records
+
+	return output.Print(cmd.OutOrStdout(), v)
+}
+
+// saveAttachment downloads attached file and saves it without overwriting existing files.
+func saveAttachment(cmd *cobra.Command, clientApp *app.App, rawID string) error {
+	id, err := uuid.FromString(rawID)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := clientApp.Usecases.Attachments.Get(cmd.Context(), clientApp.AccessToken, id)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	path := attachmentOutput
+	if path == "" {
+		path = attachment.Info.GetFilename()
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(attachment.Content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	clientApp.Log.Info().Str("path", path).Msg("File saved successfully")
+
+	return nil
+}
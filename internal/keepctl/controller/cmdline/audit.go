@@ -0,0 +1,134 @@
+package cmdline
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/spf13/cobra"
+)
+
+// _defaultMinEntropy is minimal estimated entropy of strong password in bits.
+const _defaultMinEntropy = 50
+
+var (
+	auditChecks     []string
+	auditMinEntropy float64
+	auditMaxAge     string
+	auditExpiring   string
+
+	auditCmd = &cobra.Command{
+		Use:   "audit [flags]",
+		Short: "Report weak, reused and old passwords, expiring cards and missing TOTP",
+		Long: "Report problems of stored secrets, all credentials and cards are decrypted locally.\n" +
+			"Checks: reused (credentials sharing the same password, compared by hash), " +
+			"weak (common passwords and passwords with low estimated entropy), " +
+			"old (passwords unchanged for a long time), expiring (expired cards and " +
+			"cards expiring soon), no-totp (credentials without TOTP secret).",
+		RunE: doAudit,
+	}
+)
+
+func init() {
+	auditCmd.Flags().StringSliceVar(
+		&auditChecks,
+		"check",
+		nil,
+		"Checks to perform, all if omitted: "+strings.Join(entity.AuditChecks(), ", "),
+	)
+	auditCmd.Flags().Float64Var(
+		&auditMinEntropy,
+		"min-entropy",
+		_defaultMinEntropy,
+		"Minimal estimated entropy of a strong password in bits",
+	)
+	auditCmd.Flags().StringVar(
+		&auditMaxAge,
+		"max-age",
+		"365d",
+		"Period passwords should be changed within, e.g. 180d or 26w",
+	)
+	auditCmd.Flags().StringVar(
+		&auditExpiring,
+		"expiring-within",
+		"60d",
+		"Period to look ahead for expiring cards, e.g. 60d or 8w",
+	)
+
+	rootCmd.AddCommand(auditCmd)
+}
+
+func doAudit(cmd *cobra.Command, _args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	policy := entity.AuditPolicy{Now: time.Now(), MinEntropy: auditMinEntropy}
+
+	if policy.Checks, err = entity.ParseAuditChecks(auditChecks); err != nil {
+		return err
+	}
+
+	if policy.MaxPasswordAge, err = entity.ParseDuration(auditMaxAge); err != nil {
+		return err
+	}
+
+	if policy.ExpiringWithin, err = entity.ParseDuration(auditExpiring); err != nil {
+		return err
+	}
+
+	secrets, err := clientApp.Usecases.Secrets.Fetch(
+		cmd.Context(),
+		clientApp.AccessToken,
+		goph.DataKind_CREDENTIALS,
+		goph.DataKind_CARD,
+	)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	findings, err := entity.Audit(secrets, policy)
+	if err != nil {
+		return err
+	}
+
+	v := output.View{
+		Header: []string{"Check", "ID", "Path", "Details"},
+		Rows:   make([][]string, 0, len(findings)),
+	}
+	records := make([]auditRecord, 0, len(findings))
+
+	for i := range findings {
+		found := &findings[i]
+
+		v.Rows = append(v.Rows, []string{
+			found.Check.String(),
+			found.Secret.GetId(),
+			found.Path(),
+			found.Details,
+		})
+
+		records = append(records, auditRecord{
+			Check:   found.Check.String(),
+			ID:      found.Secret.GetId(),
+			Path:    found.Path(),
+			Kind:    kindName(found.Secret.GetKind()),
+			Details: found.Details,
+		})
+	}
+
+	v.Records = records
+
+	clientApp.Log.Debug().
+		Int("checked", len(secrets)).
+		Int("found", len(findings)).
+		Msg("Audit finished")
+
+	return output.Print(cmd.OutOrStdout(), v)
+}
@@ -0,0 +1,144 @@
+package cmdline
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/hibp"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var errNoBreachDB = errors.New("path to the breached passwords database is required")
+
+var breachCheckCmd = &cobra.Command{
+	Use:   "breach-check [flags]",
+	Short: "Check stored passwords against local copy of the Pwned Passwords database",
+	Long: "Check stored passwords against local copy of the Have I Been Pwned " +
+		"Pwned Passwords database, nothing is sent over network.\n" +
+		"The database is either a directory of SHA-1 range files (e.g. 21BD1.txt) " +
+		"or a single file of SHA-1 hashes ordered by hash, lines look like HASH:COUNT. " +
+		"If the database is set with GOPH_BREACH_DB, new passwords are checked on push, edit " +
+		"and in tui as well.",
+	Args: cobra.NoArgs,
+	RunE: doBreachCheck,
+}
+
+func init() {
+	breachCheckCmd.Flags().String(
+		"db",
+		"",
+		"Path to the breached passwords database (GOPH_BREACH_DB)",
+	)
+
+	rootCmd.AddCommand(breachCheckCmd)
+}
+
+func doBreachCheck(cmd *cobra.Command, _args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// NB (alkurbatov): The key is bound here, as push and edit read it from environment only.
+	viper.BindPFlag("breach-db", cmd.Flags().Lookup("db"))
+
+	path := viper.GetString("breach-db")
+	if path == "" {
+		return entity.NewValidationError(errNoBreachDB)
+	}
+
+	db, err := hibp.Open(path)
+	if err != nil {
+		return err
+	}
+
+	kinds := make([]goph.DataKind, 0)
+
+	for _, kind := range entity.Kinds() {
+		if kind.HasPasswords() {
+			kinds = append(kinds, kind.DataKind)
+		}
+	}
+
+	secrets, err := clientApp.Usecases.Secrets.Fetch(cmd.Context(), clientApp.AccessToken, kinds...)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	records, err := lookupBreached(db, secrets)
+	if err != nil {
+		return err
+	}
+
+	v := output.View{
+		Header:  []string{"ID", "Path", "Kind", "Attribute", "Breaches"},
+		Rows:    make([][]string, 0, len(records)),
+		Records: records,
+	}
+
+	for _, r := range records {
+		v.Rows = append(v.Rows, []string{r.ID, r.Path, r.Kind, r.Attribute, strconv.Itoa(r.Count)})
+	}
+
+	clientApp.Log.Debug().
+		Int("checked", len(secrets)).
+		Int("breached", len(records)).
+		Msg("Breach check finished")
+
+	return output.Print(cmd.OutOrStdout(), v)
+}
+
+// lookupBreached looks up passwords of the secrets in the database,
+// returns the breached ones ordered by number of breaches.
+func lookupBreached(db *hibp.DB, secrets []entity.SecretData) ([]breachRecord, error) {
+	rv := make([]breachRecord, 0)
+
+	for _, s := range secrets {
+		kind, err := entity.KindOf(s.Secret.GetKind())
+		if err != nil {
+			return nil, err
+		}
+
+		labels, err := entity.LabelsOf(s.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, password := range kind.Passwords(s.Data) {
+			count, err := db.Lookup(password)
+			if err != nil {
+				return nil, err
+			}
+
+			if count == 0 {
+				continue
+			}
+
+			rv = append(rv, breachRecord{
+				ID:        s.Secret.GetId(),
+				Path:      entity.SecretPath(s.Secret, labels),
+				Kind:      kind.Name,
+				Attribute: name,
+				Count:     count,
+			})
+		}
+	}
+
+	sort.SliceStable(rv, func(i, j int) bool {
+		if rv[i].Count != rv[j].Count {
+			return rv[i].Count > rv[j].Count
+		}
+
+		return rv[i].Path < rv[j].Path
+	})
+
+	return rv, nil
+}
@@ -0,0 +1,160 @@
+package cmdline
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/spf13/cobra"
+)
+
+var (
+	within string
+
+	certsCmd = &cobra.Command{
+		Use:   "certs",
+		Short: "Inspect stored X.509 certificates",
+	}
+
+	certsListCmd = &cobra.Command{
+		Use:   "list [flags]",
+		Short: "List stored certificates with their details",
+		RunE:  doListCerts,
+	}
+
+	certsExpiringCmd = &cobra.Command{
+		Use:   "expiring [flags]",
+		Short: "Report certificates expiring within the specified period",
+		RunE:  doListExpiringCerts,
+	}
+)
+
+func init() {
+	certsExpiringCmd.Flags().StringVar(
+		&within,
+		"within",
+		"30d",
+		"Period to look ahead, e.g. 30d, 2w or 12h",
+	)
+
+	certsCmd.AddCommand(certsListCmd)
+	certsCmd.AddCommand(certsExpiringCmd)
+	rootCmd.AddCommand(certsCmd)
+}
+
+func doListCerts(cmd *cobra.Command, _args []string) error {
+	return printCerts(cmd, 0)
+}
+
+func doListExpiringCerts(cmd *cobra.Command, _args []string) error {
+	period, err := entity.ParseDuration(within)
+	if err != nil {
+		return err
+	}
+
+	return printCerts(cmd, period)
+}
+
+// printCerts shows stored certificates ordered by expiration date.
+// If period is not zero, only certificates expiring within the period are shown.
+func printCerts(cmd *cobra.Command, period time.Duration) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	certs, err := clientApp.Usecases.Secrets.Fetch(
+		cmd.Context(),
+		clientApp.AccessToken,
+		goph.DataKind_CERTIFICATE,
+	)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	sort.SliceStable(certs, func(i, j int) bool {
+		return notAfter(certs[i]).Before(notAfter(certs[j]))
+	})
+
+	now := time.Now()
+
+	v := output.View{
+		Header: []string{"ID", "Name", "Subject", "SANs", "Issuer", "Not after", "Days left"},
+		Rows:   make([][]string, 0, len(certs)),
+	}
+	records := make([]certRecord, 0, len(certs))
+
+	for _, cert := range certs {
+		data, ok := cert.Data.(*goph.Certificate)
+		if !ok {
+			continue
+		}
+
+		expires := notAfter(cert)
+		if period != 0 && expires.After(now.Add(period)) {
+			continue
+		}
+
+		daysLeft := int(expires.Sub(now).Hours() / 24)
+
+		v.Rows = append(v.Rows, []string{
+			cert.Secret.GetId(),
+			cert.Secret.GetName(),
+			data.GetSubject(),
+			strings.Join(data.GetSans(), ", "),
+			data.GetIssuer(),
+			expires.Format(entity.DateLayout),
+			strconv.Itoa(daysLeft),
+		})
+
+		record, err := newCertRecord(cert, expires, daysLeft)
+		if err != nil {
+			return err
+		}
+
+		records = append(records, record)
+	}
+
+	v.Records = records
+
+	return output.Print(cmd.OutOrStdout(), v)
+}
+
+// newCertRecord converts the stored certificate into record, the private key is masked.
+func newCertRecord(cert entity.SecretData, expires time.Time, daysLeft int) (certRecord, error) {
+	labels, err := entity.LabelsOf(cert.Secret)
+	if err != nil {
+		return certRecord{}, err
+	}
+
+	kind, err := entity.KindOf(cert.Secret.GetKind())
+	if err != nil {
+		return certRecord{}, err
+	}
+
+	record := certRecord{
+		secretRecord: newSecretRecord(cert.Secret, labels),
+		NotAfter:     expires,
+		DaysLeft:     daysLeft,
+	}
+	record.Data = kind.Values(cert.Data, false)
+
+	return record, nil
+}
+
+// notAfter returns expiration moment of the stored certificate.
+func notAfter(cert entity.SecretData) time.Time {
+	data, ok := cert.Data.(*goph.Certificate)
+	if !ok {
+		return time.Time{}
+	}
+
+	return data.GetNotAfter().AsTime()
+}
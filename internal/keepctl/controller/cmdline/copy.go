@@ -0,0 +1,235 @@
+package cmdline
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/config"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/clipboard"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/proto"
+)
+
+var errNothingToCopy = errors.New("nothing to copy, the value is empty")
+
+var (
+	copyField  string
+	forceOSC52 bool
+
+	copyCmd = &cobra.Command{
+		Use:   "copy [secret] [flags]",
+		Short: "Copy value of the secret to clipboard without showing it",
+		Long: "Copy value of the secret to clipboard without showing it, " +
+			"e.g. password of credentials or number of a card.\n" +
+			"The clipboard is cleared after the timeout if it still holds the copied value. " +
+			"If no clipboard tool (wl-copy, xclip, xsel or pbcopy) is available, " +
+			"the value is passed to the terminal with OSC 52 escape sequence.",
+		Args: cobra.ExactArgs(1),
+		RunE: doCopy,
+	}
+
+	// clipboardClearCmd is run in background by copy to clear the clipboard later.
+	// Digest of the copied value is passed through stdin.
+	clipboardClearCmd = &cobra.Command{
+		Use:                "clipboard-clear [delay]",
+		Hidden:             true,
+		Args:               cobra.ExactArgs(1),
+		DisableFlagParsing: true,
+		PersistentPreRunE:  func(*cobra.Command, []string) error { return nil },
+		PersistentPostRun:  func(*cobra.Command, []string) {},
+		RunE:               doClipboardClear,
+	}
+)
+
+func init() {
+	copyCmd.Flags().StringVar(
+		&copyField,
+		"field",
+		"",
+		"Name of the field to copy, e.g. login, primary value (e.g. password) if omitted",
+	)
+	copyCmd.Flags().Duration(
+		"timeout",
+		config.DefaultClipboardTimeout,
+		"Clear the clipboard after the timeout, 0 disables clearing",
+	)
+	copyCmd.Flags().BoolVar(
+		&forceOSC52,
+		"osc52",
+		false,
+		"Use OSC 52 escape sequence even if clipboard tool is available, e.g. over SSH",
+	)
+
+	viper.BindPFlag("clipboard-timeout", copyCmd.Flags().Lookup("timeout"))
+
+	rootCmd.AddCommand(copyCmd)
+	rootCmd.AddCommand(clipboardClearCmd)
+}
+
+func doCopy(cmd *cobra.Command, args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	secret, data, err := clientApp.Usecases.Secrets.Find(cmd.Context(), clientApp.AccessToken, args[0])
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	kind, err := entity.KindOf(secret.GetKind())
+	if err != nil {
+		return err
+	}
+
+	field, value, err := copiedValue(kind, data, copyField)
+	if err != nil {
+		return err
+	}
+
+	return copyToClipboard(cmd, clientApp, value, fmt.Sprintf("%s of %s", field, secret.GetName()))
+}
+
+// copiedValue returns name and value of the field to copy, the primary one if name is empty.
+func copiedValue(kind *entity.Kind, data proto.Message, name string) (string, string, error) {
+	if name != "" {
+		value, err := kind.Value(data, name)
+
+		return name, value, err
+	}
+
+	attr, err := kind.PrimaryAttribute()
+	if err != nil {
+		return "", "", err
+	}
+
+	return attr.Name, attr.Get(data), nil
+}
+
+// copyToClipboard puts the value into clipboard and schedules clearing of the clipboard.
+// OSC 52 is used if no clipboard tool is available.
+func copyToClipboard(cmd *cobra.Command, clientApp *app.App, value, what string) error {
+	if value == "" {
+		return errNothingToCopy
+	}
+
+	if !forceOSC52 {
+		board, err := clipboard.Detect()
+		if err == nil {
+			if err := board.Write(value); err != nil {
+				return err
+			}
+
+			clientApp.Log.Info().Msgf("Copied %s to clipboard", what)
+
+			return scheduleClipboardClear(clientApp, clipboard.Digest(value))
+		}
+
+		clientApp.Log.Debug().Err(err).Msg("")
+	}
+
+	if err := clipboard.WriteOSC52(cmd.ErrOrStderr(), value); err != nil {
+		return err
+	}
+
+	clientApp.Log.Info().Msgf("Copied %s to clipboard of the terminal", what)
+
+	if cfg.ClipboardTimeout > 0 {
+		clientApp.Log.Warn().Msg("Content of terminal clipboard can't be checked, clear it manually")
+	}
+
+	return nil
+}
+
+// scheduleClipboardClear starts background process clearing the clipboard after the timeout.
+func scheduleClipboardClear(clientApp *app.App, digest string) error {
+	if cfg.ClipboardTimeout <= 0 {
+		return nil
+	}
+
+	if err := spawnClipboardClear(digest); err != nil {
+		return err
+	}
+
+	clientApp.Log.Info().Msgf("The clipboard will be cleared in %s", cfg.ClipboardTimeout)
+
+	return nil
+}
+
+// spawnClipboardClear starts process clearing the clipboard after the timeout
+// if the clipboard still holds value with the digest.
+func spawnClipboardClear(digest string) error {
+	timeout := cfg.ClipboardTimeout
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// NB (alkurbatov): The digest is passed through pipe to keep it away
+	// from commandline and environment of the process visible to others.
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	child := exec.Command(exe, clipboardClearCmd.Name(), timeout.String()) //nolint:gosec // self
+	child.Stdin = r
+
+	err = child.Start()
+	r.Close()
+
+	if err != nil {
+		w.Close()
+
+		return err
+	}
+
+	_, err = io.WriteString(w, digest)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return child.Process.Release()
+}
+
+func doClipboardClear(cmd *cobra.Command, args []string) error {
+	delay, err := time.ParseDuration(args[0])
+	if err != nil {
+		return err
+	}
+
+	digest, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	// NB (alkurbatov): Keep waiting if the terminal started keepctl is closed.
+	signal.Ignore(syscall.SIGHUP)
+	time.Sleep(delay)
+
+	board, err := clipboard.Detect()
+	if err != nil {
+		return err
+	}
+
+	_, err = board.ClearIfHolds(strings.TrimSpace(string(digest)))
+
+	return err
+}
@@ -3,13 +3,12 @@ package cmdline
 import (
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
-	uuid "github.com/satori/go.uuid"
 	"github.com/spf13/cobra"
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete [secret id] [flags]",
-	Short: "Delete the secret",
+	Use:   "delete [secret] [flags]",
+	Short: "Delete the secret and files attached to it",
 	Args:  cobra.MinimumNArgs(1),
 	RunE:  doDelete,
 }
@@ -19,12 +18,12 @@ func init() {
 }
 
 func doDelete(cmd *cobra.Command, args []string) error {
-	id, err := uuid.FromString(args[0])
+	clientApp, err := app.FromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
-	clientApp, err := app.FromContext(cmd.Context())
+	id, err := resolveSecret(cmd, clientApp, args[0])
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,43 @@
+package cmdline
+
+import (
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+)
+
+var detachCmd = &cobra.Command{
+	Use:   "detach [attachment id] [flags]",
+	Short: "Delete the file attached to a secret",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  doDetach,
+}
+
+func init() {
+	rootCmd.AddCommand(detachCmd)
+}
+
+func doDetach(cmd *cobra.Command, args []string) error {
+	id, err := uuid.FromString(args[0])
+	if err != nil {
+		return err
+	}
+
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := clientApp.Usecases.Attachments.Detach(
+		cmd.Context(),
+		clientApp.AccessToken,
+		id,
+	); err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	return nil
+}
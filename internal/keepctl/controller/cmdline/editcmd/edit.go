@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
 	uuid "github.com/satori/go.uuid"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +18,7 @@ var (
 	secretName    string
 	description   string
 	noDescription bool
+	allowBreached bool
 )
 
 var EditCmd = &cobra.Command{
@@ -46,24 +48,35 @@ func init() {
 		"Remove description from the secret",
 	)
 
+	EditCmd.PersistentFlags().BoolVar(
+		&allowBreached,
+		"allow-breached",
+		false,
+		"Save password found in the breached passwords database (GOPH_BREACH_DB)",
+	)
+
 	EditCmd.MarkFlagsMutuallyExclusive("description", "no-description")
 
-	EditCmd.AddCommand(binCmd)
-	EditCmd.AddCommand(cardCmd)
-	EditCmd.AddCommand(credsCmd)
-	EditCmd.AddCommand(textCmd)
+	for _, kind := range entity.Kinds() {
+		EditCmd.AddCommand(newKindCmd(kind))
+	}
 }
 
 // preRun executes preparational operations common for all sub commands.
 func preRun(cmd *cobra.Command, args []string) error {
 	var err error
 
-	secretID, err = uuid.FromString(args[0])
+	clientApp, err = app.FromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
-	clientApp, err = app.FromContext(cmd.Context())
+	secretID, err = clientApp.Usecases.Secrets.Resolve(cmd.Context(), clientApp.AccessToken, args[0])
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
 
-	return err
+	return nil
 }
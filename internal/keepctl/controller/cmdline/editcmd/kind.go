@@ -0,0 +1,66 @@
+package editcmd
+
+import (
+	"errors"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/kindflags"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/usecase"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newKindCmd creates command editing secret of the provided kind.
+func newKindCmd(kind *entity.Kind) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     kind.Name + " [secret] [flags]",
+		Short:   "Edit stored " + kind.Title,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: preRun,
+		RunE: func(cmd *cobra.Command, _args []string) error {
+			return doEdit(cmd, kind)
+		},
+	}
+
+	kindflags.Bind(cmd, kind, false)
+
+	return cmd
+}
+
+func doEdit(cmd *cobra.Command, kind *entity.Kind) error {
+	changes, err := kindflags.Changes(cmd, kind, false)
+	if err != nil {
+		return err
+	}
+
+	if !allowBreached {
+		if err := kindflags.CheckBreached(kind, changes, viper.GetString("breach-db")); err != nil {
+			return err
+		}
+	}
+
+	if secretName == "" && description == "" && !noDescription && len(changes) == 0 {
+		return errFlagsRequired
+	}
+
+	if err := clientApp.Usecases.Secrets.Edit(
+		cmd.Context(),
+		clientApp.AccessToken,
+		secretID,
+		secretName,
+		description,
+		noDescription,
+		kind.DataKind,
+		changes,
+	); err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		if errors.Is(err, usecase.ErrKindMismatch) {
+			return usecase.ErrKindMismatch
+		}
+
+		return entity.Unwrap(err)
+	}
+
+	return nil
+}
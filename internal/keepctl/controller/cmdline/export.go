@@ -0,0 +1,353 @@
+package cmdline
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/prompt"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/backup"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Formats of exported vault.
+const (
+	_exportArchive = "archive"
+	_exportJSON    = "json"
+	_exportCSV     = "csv"
+	_exportKDBX    = "kdbx"
+)
+
+// _kdbxName is name of exported KeePass database.
+const _kdbxName = "goph-keeper"
+
+// _passphraseLabel is shown when user is asked for passphrase of the archive.
+const _passphraseLabel = "passphrase of the archive"
+
+var (
+	errBadExportFormat = errors.New(
+		"unknown export format, should be one of archive, json, csv, kdbx",
+	)
+	errNoPassphrase      = errors.New("passphrase is required to encrypt the archive")
+	errNoPasswordFile    = errors.New("--password-file is required if stdin is not a terminal")
+	errEmptyPassword     = errors.New("password of KeePass database is empty")
+	errExportNotApproved = errors.New("plaintext export is not confirmed")
+)
+
+var (
+	exportFormat   string
+	exportFolder   string
+	kdbxPasswdPath string
+	assumeYes      bool
+
+	exportCmd = &cobra.Command{
+		Use:   "export [file] [flags]",
+		Short: "Export all secrets to a file",
+		Long: "Export all secrets with files attached to them to a file, '-' writes to stdout.\n" +
+			"By default the secrets are exported into archive encrypted with a passphrase, " +
+			"which can be restored with restore-backup. Plaintext JSON (restorable as well) " +
+			"and CSV (without attached files) exports require confirmation.\n" +
+			"KeePass database (KDBX 4) keeps folders as groups, custom fields and attached " +
+			"files, secrets of other kinds than credentials, custom and text are stored " +
+			"as fields named after their attributes.",
+		Args: cobra.ExactArgs(1),
+		RunE: doExport,
+	}
+)
+
+func init() {
+	exportCmd.Flags().StringVar(
+		&exportFormat,
+		"format",
+		_exportArchive,
+		"Format of the file: archive (encrypted), json, csv or kdbx (KeePass database)",
+	)
+	exportCmd.Flags().StringVar(
+		&exportFolder,
+		"folder",
+		"",
+		"Export only secrets from the folder and its subfolders, e.g. work/aws",
+	)
+	exportCmd.Flags().StringVar(
+		&kdbxPasswdPath,
+		"password-file",
+		"",
+		"Path to file containing password of exported KeePass database, "+
+			prompt.Stdin+" reads stdin, prompted with hidden echo if omitted",
+	)
+	exportCmd.Flags().String(
+		"passphrase",
+		"",
+		"Passphrase used to encrypt the archive, prompted with hidden echo if omitted, "+
+			prompt.Stdin+" reads it from stdin",
+	)
+	exportCmd.Flags().BoolVarP(
+		&assumeYes,
+		"yes",
+		"y",
+		false,
+		"Don't ask for confirmation of plaintext export",
+	)
+
+	rootCmd.AddCommand(exportCmd)
+}
+
+func doExport(cmd *cobra.Command, args []string) error {
+	var (
+		passphrase string
+		err        error
+	)
+
+	switch exportFormat {
+	case _exportArchive:
+		passphrase, err = askSecret(backupPassphrase(cmd), _passphraseLabel, true)
+		if err != nil {
+			return err
+		}
+
+		if passphrase == "" {
+			return entity.NewValidationError(errNoPassphrase)
+		}
+
+	case _exportKDBX:
+		if passphrase, err = readKDBXPassword(); err != nil {
+			return err
+		}
+
+	case _exportJSON, _exportCSV:
+		if !assumeYes && !confirm(cmd, "Exported secrets will NOT be encrypted, "+
+			"anyone having access to the file can read them. Continue?") {
+			return errExportNotApproved
+		}
+
+	default:
+		return entity.NewValidationError(fmt.Errorf("%w: %s", errBadExportFormat, exportFormat))
+	}
+
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	folder, err := entity.NormalizeFolder(exportFolder)
+	if err != nil {
+		return err
+	}
+
+	doc, err := collectBackup(cmd, clientApp, folder, exportFormat != _exportCSV)
+	if err != nil {
+		return err
+	}
+
+	err = writeExport(cmd, args[0], func(w io.Writer) error {
+		switch exportFormat {
+		case _exportJSON:
+			return backup.WriteJSON(w, doc)
+
+		case _exportCSV:
+			return backup.WriteCSV(w, doc)
+
+		case _exportKDBX:
+			return backup.WriteKDBX(w, doc, _kdbxName, passphrase, nil)
+
+		default:
+			return backup.Seal(w, doc, passphrase, nil)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	clientApp.Log.Info().Int("secrets", len(doc.Secrets)).Msg("Export finished")
+
+	return nil
+}
+
+// backupPassphrase returns passphrase of the archive provided by the flag
+// or GOPH_BACKUP_PASSPHRASE environment variable.
+func backupPassphrase(cmd *cobra.Command) string {
+	// NB (alkurbatov): The key is bound to the flag of running command,
+	// as several commands share it.
+	viper.BindPFlag("backup-passphrase", cmd.Flags().Lookup("passphrase"))
+
+	return viper.GetString("backup-passphrase")
+}
+
+// askSecret returns sensitive value of a flag: '-' reads the value from stdin,
+// omitted value is asked with hidden echo if stdin is a terminal.
+func askSecret(value, label string, confirm bool) (string, error) {
+	switch {
+	case value == prompt.Stdin:
+		return prompt.Value(label, confirm)
+
+	case value == "" && prompt.IsTerminal():
+		return prompt.Secret(label, confirm)
+
+	default:
+		return value, nil
+	}
+}
+
+// readKDBXPassword reads password of KeePass database from the file, '-' reads stdin,
+// trailing line break is ignored. Without the file the password is asked twice
+// with hidden echo.
+func readKDBXPassword() (string, error) {
+	if kdbxPasswdPath == "" {
+		if !prompt.IsTerminal() {
+			return "", entity.NewValidationError(errNoPasswordFile)
+		}
+
+		rv, err := prompt.Secret("password of KeePass database", true)
+		if err != nil {
+			return "", err
+		}
+
+		if rv == "" {
+			return "", entity.NewValidationError(errEmptyPassword)
+		}
+
+		return rv, nil
+	}
+
+	raw, err := prompt.ReadFile(kdbxPasswdPath)
+	if err != nil {
+		return "", err
+	}
+
+	rv := strings.TrimRight(string(raw), "\r\n")
+	if rv == "" {
+		return "", entity.NewValidationError(errEmptyPassword)
+	}
+
+	return rv, nil
+}
+
+// collectBackup downloads and decrypts secrets from the folder and its subfolders
+// and, optionally, files attached to them.
+func collectBackup(
+	cmd *cobra.Command,
+	clientApp *app.App,
+	folder string,
+	withAttachments bool,
+) (*backup.Document, error) {
+	secrets, err := clientApp.Usecases.Secrets.Fetch(cmd.Context(), clientApp.AccessToken)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return nil, entity.Unwrap(err)
+	}
+
+	doc := backup.New(time.Now())
+
+	for _, s := range secrets {
+		labels, err := entity.LabelsOf(s.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if !entity.InFolder(labels, folder) {
+			continue
+		}
+
+		var attachments []entity.Attachment
+
+		if withAttachments {
+			attachments, err = downloadAttachments(cmd, clientApp, s.Secret.GetId())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := doc.Add(s.Secret, labels, s.Data, attachments); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// downloadAttachments downloads and decrypts all files attached to the secret.
+func downloadAttachments(
+	cmd *cobra.Command,
+	clientApp *app.App,
+	secretID string,
+) ([]entity.Attachment, error) {
+	id, err := uuid.FromString(secretID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := clientApp.Usecases.Attachments.List(cmd.Context(), clientApp.AccessToken, id)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return nil, entity.Unwrap(err)
+	}
+
+	rv := make([]entity.Attachment, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		attachmentID, err := uuid.FromString(attachment.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		full, err := clientApp.Usecases.Attachments.Get(
+			cmd.Context(),
+			clientApp.AccessToken,
+			attachmentID,
+		)
+		if err != nil {
+			clientApp.Log.Debug().Err(err).Msg("")
+
+			return nil, entity.Unwrap(err)
+		}
+
+		rv = append(rv, *full)
+	}
+
+	return rv, nil
+}
+
+// writeExport writes exported data to the file accessible by owner only, '-' means stdout.
+// The file is replaced only if the data is written completely.
+func writeExport(cmd *cobra.Command, path string, write func(w io.Writer) error) error {
+	if path == "-" {
+		return write(cmd.OutOrStdout())
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return err
+	}
+
+	return writePrivateFile(path, buf.String())
+}
+
+// confirm asks user to confirm the action, only explicit "yes" or "y" answers are accepted.
+func confirm(cmd *cobra.Command, question string) bool {
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s [y/N]: ", question)
+
+	input := bufio.NewScanner(cmd.InOrStdin())
+	if !input.Scan() {
+		fmt.Fprintln(cmd.ErrOrStderr())
+
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(input.Text())) {
+	case "y", "yes":
+		return true
+
+	default:
+		return false
+	}
+}
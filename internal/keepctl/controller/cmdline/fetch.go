@@ -0,0 +1,97 @@
+package cmdline
+
+import (
+	"fmt"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+// decryptedSecret keeps decrypted data of a secret fetched from keeper.
+type decryptedSecret struct {
+	name string
+	kind *entity.Kind
+	data proto.Message
+}
+
+// fieldFetcher decrypts fields of secrets requesting every secret from keeper only once.
+type fieldFetcher struct {
+	cmd       *cobra.Command
+	clientApp *app.App
+
+	ids     map[string]uuid.UUID
+	secrets map[uuid.UUID]decryptedSecret
+
+	// values are all fetched values, e.g. to hide them in output.
+	values []string
+}
+
+func newFieldFetcher(cmd *cobra.Command, clientApp *app.App) *fieldFetcher {
+	return &fieldFetcher{
+		cmd:       cmd,
+		clientApp: clientApp,
+		ids:       make(map[string]uuid.UUID),
+		secrets:   make(map[uuid.UUID]decryptedSecret),
+		values:    make([]string, 0),
+	}
+}
+
+// Fetch returns value of the field, primary value of the secret if the field is empty.
+// Fails if the secret or the field doesn't exist.
+func (f *fieldFetcher) Fetch(ref entity.FieldRef) (string, error) {
+	secret, err := f.secret(ref.Secret)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+
+	_, value, err := copiedValue(secret.kind, secret.data, ref.Field)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+
+	f.values = append(f.values, value)
+
+	return value, nil
+}
+
+func (f *fieldFetcher) secret(ref string) (decryptedSecret, error) {
+	id, ok := f.ids[ref]
+	if !ok {
+		var err error
+
+		id, err = resolveSecret(f.cmd, f.clientApp, ref)
+		if err != nil {
+			return decryptedSecret{}, err
+		}
+
+		f.ids[ref] = id
+	}
+
+	if secret, ok := f.secrets[id]; ok {
+		return secret, nil
+	}
+
+	secret, data, err := f.clientApp.Usecases.Secrets.Get(
+		f.cmd.Context(),
+		f.clientApp.AccessToken,
+		id,
+	)
+	if err != nil {
+		f.clientApp.Log.Debug().Err(err).Msg("")
+
+		return decryptedSecret{}, entity.Unwrap(err)
+	}
+
+	kind, err := entity.KindOf(secret.GetKind())
+	if err != nil {
+		return decryptedSecret{}, err
+	}
+
+	rv := decryptedSecret{name: secret.GetName(), kind: kind, data: data}
+	f.secrets[id] = rv
+
+	return rv, nil
+}
@@ -0,0 +1,92 @@
+package cmdline
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/genflags"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/passgen"
+	"github.com/spf13/cobra"
+)
+
+// _maxGenerated is maximum number of passwords generated at once.
+const _maxGenerated = 100
+
+var errBadCount = errors.New("number of passwords should be from 1 to 100")
+
+var (
+	generateCount int
+
+	generateCmd = &cobra.Command{
+		Use:   "generate [flags]",
+		Short: "Generate random password or passphrase",
+		Long: "Generate random password or passphrase without connecting to the keeper service.\n" +
+			"The password is based on a preset adjusted with other flags, e.g. " +
+			"--preset diceware --words 8. Entropy of the password is printed to stderr, " +
+			"so that the password can be piped to other commands. " +
+			"The same flags together with --generate set password of stored credentials, " +
+			"e.g. with push creds or edit creds.",
+		Args: cobra.NoArgs,
+		// NB (alkurbatov): Passwords are generated locally, so no login is required.
+		PersistentPreRunE: func(*cobra.Command, []string) error { return output.Validate() },
+		PersistentPostRun: func(*cobra.Command, []string) {},
+		RunE:              doGenerate,
+	}
+)
+
+func init() {
+	genflags.Bind(generateCmd.Flags())
+	generateCmd.Flags().IntVar(&generateCount, "count", 1, "Number of passwords to generate")
+
+	rootCmd.AddCommand(generateCmd)
+}
+
+func doGenerate(cmd *cobra.Command, _args []string) error {
+	if generateCount < 1 || generateCount > _maxGenerated {
+		return entity.NewValidationError(errBadCount)
+	}
+
+	records := make([]passwordRecord, 0, generateCount)
+	rows := make([][]string, 0, generateCount)
+
+	for i := 0; i < generateCount; i++ {
+		password, err := genflags.Generate(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		record := passwordRecord{
+			Password: password.Value,
+			Entropy:  password.Entropy,
+			Strength: passgen.Strength(password.Entropy),
+		}
+
+		records = append(records, record)
+		rows = append(rows, []string{
+			record.Password,
+			strconv.FormatFloat(record.Entropy, 'f', 1, 64),
+			record.Strength,
+		})
+	}
+
+	if output.Machine() {
+		return output.Print(cmd.OutOrStdout(), output.View{Rows: rows, Records: records})
+	}
+
+	for _, record := range records {
+		fmt.Fprintln(cmd.OutOrStdout(), record.Password)
+	}
+
+	// NB (alkurbatov): All passwords are generated with the same spec, so entropy is the same.
+	fmt.Fprintf(
+		cmd.ErrOrStderr(),
+		"Entropy: %.1f bits (%s)\n",
+		records[0].Entropy,
+		records[0].Strength,
+	)
+
+	return nil
+}
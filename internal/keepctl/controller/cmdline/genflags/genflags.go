@@ -0,0 +1,140 @@
+// Package genflags maps parameters of the password generator to commandline flags.
+package genflags
+
+import (
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/passgen"
+	"github.com/spf13/pflag"
+)
+
+// Names of the generator flags.
+const (
+	_preset           = "preset"
+	_length           = "length"
+	_words            = "words"
+	_classes          = "classes"
+	_excludeAmbiguous = "exclude-ambiguous"
+	_separator        = "separator"
+	_capitalize       = "capitalize"
+)
+
+// Bind registers flags of the password generator.
+func Bind(flags *pflag.FlagSet) {
+	presets := passgen.Presets()
+	usage := make([]string, 0, len(presets))
+
+	for _, name := range presets {
+		usage = append(usage, name+" ("+passgen.DescribePreset(name)+")")
+	}
+
+	flags.String(
+		_preset,
+		passgen.DefaultPreset,
+		"Preset of generated password, adjusted by other generator flags: "+
+			strings.Join(usage, ", "),
+	)
+	flags.Int(_length, 0, "Number of characters of generated password")
+	flags.Int(_words, 0, "Number of words of generated diceware passphrase")
+	flags.StringSlice(
+		_classes,
+		nil,
+		"Character classes of generated password: lower, upper, digits, symbols",
+	)
+	flags.Bool(
+		_excludeAmbiguous,
+		false,
+		"Exclude characters easily confused with each other from generated password, e.g. 0 and O",
+	)
+	flags.String(_separator, "", "Separator of words of generated diceware passphrase")
+	flags.Bool(
+		_capitalize,
+		false,
+		"Capitalize words of generated passphrase or first letter of pronounceable password",
+	)
+}
+
+// Changed reports whether any generator flag is set by user.
+func Changed(flags *pflag.FlagSet) bool {
+	for _, name := range []string{
+		_preset, _length, _words, _classes, _excludeAmbiguous, _separator, _capitalize,
+	} {
+		if flags.Changed(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Generate creates password according to the preset adjusted by the generator flags.
+func Generate(flags *pflag.FlagSet) (passgen.Password, error) {
+	spec, err := specOf(flags)
+	if err != nil {
+		return passgen.Password{}, err
+	}
+
+	rv, err := passgen.Generate(spec)
+	if err != nil {
+		return rv, entity.NewValidationError(err)
+	}
+
+	return rv, nil
+}
+
+// specOf builds spec of the password from the preset and the generator flags set by user.
+func specOf(flags *pflag.FlagSet) (passgen.Spec, error) {
+	preset, err := flags.GetString(_preset)
+	if err != nil {
+		return passgen.Spec{}, err
+	}
+
+	spec, err := passgen.Preset(preset)
+	if err != nil {
+		return spec, entity.NewValidationError(err)
+	}
+
+	if flags.Changed(_length) {
+		if spec.Length, err = flags.GetInt(_length); err != nil {
+			return spec, err
+		}
+	}
+
+	if flags.Changed(_words) {
+		if spec.Words, err = flags.GetInt(_words); err != nil {
+			return spec, err
+		}
+	}
+
+	if flags.Changed(_classes) {
+		var names []string
+		if names, err = flags.GetStringSlice(_classes); err != nil {
+			return spec, err
+		}
+
+		if spec.Classes, err = passgen.ParseClasses(names); err != nil {
+			return spec, entity.NewValidationError(err)
+		}
+	}
+
+	if flags.Changed(_excludeAmbiguous) {
+		if spec.ExcludeAmbiguous, err = flags.GetBool(_excludeAmbiguous); err != nil {
+			return spec, err
+		}
+	}
+
+	if flags.Changed(_separator) {
+		if spec.Separator, err = flags.GetString(_separator); err != nil {
+			return spec, err
+		}
+	}
+
+	if flags.Changed(_capitalize) {
+		if spec.Capitalize, err = flags.GetBool(_capitalize); err != nil {
+			return spec, err
+		}
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,236 @@
+package cmdline
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/prompt"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/importer"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	importFormat    string
+	importDryRun    bool
+	allowDuplicates bool
+
+	importCmd = &cobra.Command{
+		Use:   "import [file] [flags]",
+		Short: "Import secrets from other password manager",
+		Long: "Import secrets exported from other password manager.\n" +
+			"Logins become credentials, or custom secrets if they have custom fields; " +
+			"cards, identities and notes keep their kinds, attached files are attached " +
+			"to the imported secrets.\n" +
+			"Secrets having the same data as already stored ones are skipped, " +
+			"secrets with taken names are renamed. " +
+			"Use --dry-run to preview the result without storing anything.",
+		Args: cobra.ExactArgs(1),
+		RunE: doImport,
+	}
+)
+
+func init() {
+	importCmd.Flags().StringVar(
+		&importFormat,
+		"format",
+		"",
+		"Format of the file: "+strings.Join(importer.Formats(), ", "),
+	)
+	importCmd.Flags().BoolVar(
+		&importDryRun,
+		"dry-run",
+		false,
+		"Show what would be imported without storing anything",
+	)
+	importCmd.Flags().BoolVar(
+		&allowDuplicates,
+		"allow-duplicates",
+		false,
+		"Import secrets even if the same data is already stored",
+	)
+	importCmd.Flags().String(
+		"source-password",
+		"",
+		"Password of the imported KeePass database (kdbx format only), "+
+			"prompted with hidden echo if omitted, "+prompt.Stdin+" reads it from stdin",
+	)
+
+	importCmd.MarkFlagRequired("format")
+
+	viper.BindPFlag("source-password", importCmd.Flags().Lookup("source-password"))
+
+	rootCmd.AddCommand(importCmd)
+}
+
+func doImport(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var password string
+
+	if importFormat == importer.FormatKDBX {
+		password, err = askSecret(
+			viper.GetString("source-password"),
+			"password of the imported database",
+			false,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	secrets, err := importer.Parse(importFormat, f, password)
+	if err != nil {
+		return entity.NewValidationError(err)
+	}
+
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	existing, err := clientApp.Usecases.Secrets.Fetch(cmd.Context(), clientApp.AccessToken)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	plan, err := entity.PlanImport(secrets, existing, allowDuplicates)
+	if err != nil {
+		return err
+	}
+
+	if importDryRun {
+		return output.Print(cmd.OutOrStdout(), importView(plan, nil))
+	}
+
+	return storePlan(cmd, clientApp, plan)
+}
+
+// storePlan stores secrets according to the plan: new ones are pushed in batches,
+// files attached to them are uploaded right after every batch is stored.
+// Replaced secrets are deleted by the server only when their replacements are created.
+func storePlan(cmd *cobra.Command, clientApp *app.App, plan []entity.ImportItem) error {
+	created := make([]entity.NewSecret, 0, len(plan))
+
+	for _, item := range plan {
+		if item.Created() {
+			created = append(created, item.Secret)
+		}
+	}
+
+	ids, err := clientApp.Usecases.Secrets.PushBatch(
+		cmd.Context(),
+		clientApp.AccessToken,
+		created,
+		func(secrets []entity.NewSecret, ids []uuid.UUID) error {
+			return attachImported(cmd, clientApp, secrets, ids)
+		},
+	)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		clientApp.Log.Error().
+			Int("stored", len(ids)).
+			Int("total", len(created)).
+			Msg("Interrupted")
+
+		return entity.Unwrap(err)
+	}
+
+	if err := output.Print(cmd.OutOrStdout(), importView(plan, ids)); err != nil {
+		return err
+	}
+
+	if !output.Machine() {
+		clientApp.Log.Info().
+			Int("stored", len(ids)).
+			Int("skipped", len(plan)-len(ids)).
+			Msg("Finished")
+	}
+
+	return nil
+}
+
+// attachImported uploads files attached to the created secrets.
+func attachImported(
+	cmd *cobra.Command,
+	clientApp *app.App,
+	created []entity.NewSecret,
+	ids []uuid.UUID,
+) error {
+	for i, secret := range created {
+		for _, attachment := range secret.Attachments {
+			_, err := clientApp.Usecases.Attachments.Attach(
+				cmd.Context(),
+				clientApp.AccessToken,
+				ids[i],
+				attachment.Filename,
+				attachment.MimeType,
+				attachment.Content,
+			)
+			if err != nil {
+				clientApp.Log.Debug().Err(err).Msg("")
+
+				return fmt.Errorf("%s: %w", secret.Path(), entity.Unwrap(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// importView shows what happens to every imported secret,
+// IDs are known for created secrets only after the import.
+func importView(plan []entity.ImportItem, ids []uuid.UUID) output.View {
+	v := output.View{
+		Header: []string{"Path", "Kind", "Action", "Reason", "ID"},
+		Rows:   make([][]string, 0, len(plan)),
+	}
+	records := make([]importRecord, 0, len(plan))
+	created := 0
+
+	for _, item := range plan {
+		record := importRecord{
+			Path:        item.Secret.Path(),
+			Action:      item.Action.String(),
+			Reason:      item.Reason,
+			Attachments: len(item.Secret.Attachments),
+		}
+
+		if kind, err := entity.KindOfMessage(item.Secret.Data); err == nil {
+			record.Kind = kind.Name
+		}
+
+		if item.Created() {
+			if created < len(ids) {
+				record.ID = ids[created].String()
+			}
+
+			created++
+		}
+
+		v.Rows = append(v.Rows, []string{
+			record.Path,
+			record.Kind,
+			record.Action,
+			record.Reason,
+			record.ID,
+		})
+		records = append(records, record)
+	}
+
+	v.Records = records
+
+	return v
+}
@@ -0,0 +1,136 @@
+package cmdline
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/prompt"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	injectInput    string
+	injectOutput   string
+	injectTemplate bool
+
+	injectCmd = &cobra.Command{
+		Use:   "inject [flags]",
+		Short: "Substitute values of secrets into configuration file",
+		Long: "Substitute values of secrets for references like goph://<name-or-id>/<field>.\n" +
+			"Secret may be referred by path, e.g. goph://work/db/prod/password, " +
+			"primary value (e.g. password) is used if field is omitted, e.g. goph://prod-db.\n" +
+			"Input is rendered as Go template if it has .tmpl extension or --template is set, " +
+			"values are accessed as {{ secret \"goph://prod-db/password\" }} in this case.\n" +
+			"Every secret is requested only once. Nothing is written if any reference " +
+			"can't be resolved. Output file is accessible by its owner only.",
+		Args: cobra.NoArgs,
+		RunE: doInject,
+	}
+)
+
+func init() {
+	injectCmd.Flags().StringVarP(
+		&injectInput,
+		"input",
+		"i",
+		prompt.Stdin,
+		"Path to file with references to secrets, stdin if omitted",
+	)
+	injectCmd.Flags().StringVarP(
+		&injectOutput,
+		"save-as",
+		"o",
+		"",
+		"Path to file to save result to, stdout if omitted",
+	)
+	injectCmd.Flags().BoolVar(
+		&injectTemplate,
+		"template",
+		false,
+		"Render input as Go template",
+	)
+
+	rootCmd.AddCommand(injectCmd)
+}
+
+func doInject(cmd *cobra.Command, _ []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	raw, err := prompt.ReadFile(injectInput)
+	if err != nil {
+		return err
+	}
+
+	text := string(raw)
+
+	fetcher := newFieldFetcher(cmd, clientApp)
+
+	var rendered string
+	if injectTemplate || filepath.Ext(injectInput) == ".tmpl" {
+		rendered, err = entity.RenderTemplate(filepath.Base(injectInput), text, fetcher.Fetch)
+	} else {
+		rendered, err = entity.ReplaceSecretURIs(text, fetcher.Fetch)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if injectOutput == "" {
+		_, err = io.WriteString(cmd.OutOrStdout(), rendered)
+
+		return err
+	}
+
+	if err := writePrivateFile(injectOutput, rendered); err != nil {
+		return err
+	}
+
+	clientApp.Log.Info().Msgf("Saved to %s", injectOutput)
+
+	return nil
+}
+
+// writePrivateFile replaces content of the file making it accessible by its owner only.
+// The content is written to temporary file first, so that the target never holds
+// partially written data.
+func writePrivateFile(path, content string) error {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	// NB (alkurbatov): CreateTemp creates files with 0600 permissions.
+	f, err := os.CreateTemp(dir, "."+strings.TrimPrefix(name, ".")+".*")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(f, content)
+	if err == nil {
+		err = f.Sync()
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+
+		return err
+	}
+
+	return nil
+}
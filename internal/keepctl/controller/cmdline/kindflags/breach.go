@@ -0,0 +1,48 @@
+package kindflags
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/hibp"
+)
+
+var errBreachedPassword = errors.New("password appeared in data breaches")
+
+// CheckBreached looks up new values of password attributes in local copy of
+// the Pwned Passwords database, nothing is sent over network.
+// Does nothing if path to the database is empty.
+func CheckBreached(kind *entity.Kind, changes []entity.Change, dbPath string) error {
+	if dbPath == "" {
+		return nil
+	}
+
+	db, err := hibp.Open(dbPath)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		attr, err := kind.Attribute(change.Attribute)
+		if err != nil || !attr.Password || change.Value == "" {
+			continue
+		}
+
+		count, err := db.Lookup(change.Value)
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return entity.NewValidationError(fmt.Errorf(
+				"%w: %s was seen %d times, choose another one",
+				errBreachedPassword,
+				attr.Name,
+				count,
+			))
+		}
+	}
+
+	return nil
+}
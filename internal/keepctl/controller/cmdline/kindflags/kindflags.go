@@ -0,0 +1,310 @@
+// Package kindflags maps attributes of secret kinds to commandline flags.
+package kindflags
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/genflags"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/prompt"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/passgen"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// _generate is name of the flag requesting random value of generated attribute.
+	_generate = "generate"
+	// _fromFile is name of the flag providing path to the file holding value of the attribute.
+	_fromFile = "from-file"
+	// _promptUsage complements usage of attributes kept out of commandline.
+	_promptUsage = ", " + prompt.Stdin + " to type it with hidden echo or read it from stdin"
+	// _promptListUsage complements usage of list attributes kept out of commandline.
+	_promptListUsage = ", name=" + prompt.Stdin +
+		" to type the value with hidden echo or read it from stdin"
+	// _fileUsage complements usage of attributes read from files.
+	_fileUsage = ", " + prompt.Stdin + " reads stdin"
+)
+
+var (
+	errValueRequired    = errors.New("value required")
+	errValueAndGenerate = errors.New("generator flags can't be combined with the value")
+)
+
+// Bind registers flags for attributes of the kind, read-only attributes are skipped.
+// If creation is true, edit-only attributes are skipped and required attributes are enforced,
+// missing prompted attributes are asked by Changes.
+// Generated attribute gets --generate flag and flags of the password generator,
+// attribute read from file gets --from-file flag.
+func Bind(cmd *cobra.Command, kind *entity.Kind, creation bool) {
+	for i := range kind.Attributes {
+		attr := &kind.Attributes[i]
+		if attr.ReadOnly || (creation && attr.EditOnly) {
+			continue
+		}
+
+		switch attr.Type {
+		case entity.AttrString:
+			usage := attr.Usage
+
+			switch {
+			case attr.File:
+				usage += _fileUsage
+			case attr.Prompted():
+				usage += _promptUsage
+			}
+
+			cmd.Flags().StringP(attr.Name, attr.Shorthand, "", usage)
+
+		case entity.AttrList:
+			usage := attr.Usage
+			if attr.Prompted() {
+				usage += _promptListUsage
+			}
+
+			cmd.Flags().StringArrayP(attr.Name, attr.Shorthand, nil, usage)
+
+		case entity.AttrBool:
+			cmd.Flags().BoolP(attr.Name, attr.Shorthand, false, attr.Usage)
+		}
+
+		if attr.FromFile {
+			cmd.Flags().String(
+				_fromFile,
+				"",
+				"Read "+attr.Name+" from the file as is, "+prompt.Stdin+" reads stdin",
+			)
+			cmd.MarkFlagsMutuallyExclusive(attr.Name, _fromFile)
+		}
+
+		if attr.Generated {
+			cmd.Flags().Bool(
+				_generate,
+				false,
+				"Generate random "+attr.Name+" instead of providing it, implied by generator flags",
+			)
+			genflags.Bind(cmd.Flags())
+			cmd.MarkFlagsMutuallyExclusive(attr.Name, _generate)
+
+			// NB (alkurbatov): Presence of either the value or --generate is checked by Changes.
+			continue
+		}
+
+		if creation && attr.Required && !attr.Prompted() {
+			cmd.MarkFlagRequired(attr.Name)
+		}
+	}
+}
+
+// Changes collects values of the attribute flags explicitly set by user.
+// If creation is true, missing required prompted attributes are asked with hidden echo,
+// required generated attribute can be generated instead.
+func Changes(cmd *cobra.Command, kind *entity.Kind, creation bool) ([]entity.Change, error) {
+	changes := make([]entity.Change, 0)
+
+	for i := range kind.Attributes {
+		attr := &kind.Attributes[i]
+
+		flag := cmd.Flags().Lookup(attr.Name)
+		if flag == nil {
+			continue
+		}
+
+		if attr.FromFile && cmd.Flags().Changed(_fromFile) {
+			change, err := readFile(cmd, kind, attr)
+			if err != nil {
+				return nil, err
+			}
+
+			changes = append(changes, change)
+
+			continue
+		}
+
+		if attr.Generated && !flag.Changed {
+			change, ok, err := generate(cmd, attr)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				changes = append(changes, change)
+
+				continue
+			}
+		}
+
+		if !flag.Changed {
+			if creation && attr.Required && attr.Prompted() {
+				change, err := ask(attr)
+				if err != nil {
+					return nil, err
+				}
+
+				changes = append(changes, change)
+			}
+
+			continue
+		}
+
+		if attr.Generated && genflags.Changed(cmd.Flags()) {
+			return nil, entity.NewValidationError(
+				fmt.Errorf("%w of --%s", errValueAndGenerate, attr.Name),
+			)
+		}
+
+		switch attr.Type {
+		case entity.AttrString:
+			value := flag.Value.String()
+
+			if value == prompt.Stdin && attr.Prompted() && !attr.File {
+				var err error
+				if value, err = prompt.Value(attr.Name, attr.Password); err != nil {
+					return nil, entity.NewValidationError(err)
+				}
+			}
+
+			if attr.File {
+				data, err := prompt.ReadFile(value)
+				if err != nil {
+					return nil, err
+				}
+
+				value = string(data)
+			}
+
+			changes = append(changes, entity.Change{Attribute: attr.Name, Value: value})
+
+		case entity.AttrList:
+			values, err := cmd.Flags().GetStringArray(attr.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, val := range values {
+				if attr.Prompted() {
+					if val, err = askItem(attr, val); err != nil {
+						return nil, err
+					}
+				}
+
+				changes = append(changes, entity.Change{Attribute: attr.Name, Value: val})
+			}
+
+		case entity.AttrBool:
+			val, err := cmd.Flags().GetBool(attr.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			changes = append(changes, entity.Change{Attribute: attr.Name, Value: strconv.FormatBool(val)})
+		}
+	}
+
+	return changes, nil
+}
+
+// ask prompts user for value of the required attribute missing from commandline.
+// New passwords are asked twice.
+func ask(attr *entity.Attribute) (entity.Change, error) {
+	alternatives := fmt.Sprintf("set --%s", attr.Name)
+	if attr.Generated {
+		alternatives += fmt.Sprintf(", --%s", _generate)
+	}
+
+	if attr.FromFile {
+		alternatives += fmt.Sprintf(", --%s", _fromFile)
+	}
+
+	if !prompt.IsTerminal() {
+		return entity.Change{}, entity.NewValidationError(fmt.Errorf(
+			"%w: %s or pipe it with --%s %s",
+			errValueRequired,
+			alternatives,
+			attr.Name,
+			prompt.Stdin,
+		))
+	}
+
+	value, err := prompt.Secret(attr.Name, attr.Password)
+	if err != nil {
+		return entity.Change{}, entity.NewValidationError(err)
+	}
+
+	if value == "" {
+		return entity.Change{}, entity.NewValidationError(
+			fmt.Errorf("%w: %s", errValueRequired, alternatives),
+		)
+	}
+
+	return entity.Change{Attribute: attr.Name, Value: value}, nil
+}
+
+// askItem reads value of the list item in form of name=value requested with Stdin value.
+func askItem(attr *entity.Attribute, item string) (string, error) {
+	name, value, found := strings.Cut(item, "=")
+	if !found || value != prompt.Stdin {
+		return item, nil
+	}
+
+	value, err := prompt.Value(attr.Name+" "+name, false)
+	if err != nil {
+		return "", entity.NewValidationError(err)
+	}
+
+	return name + "=" + value, nil
+}
+
+// readFile reads value of the attribute from the file passed with --from-file.
+func readFile(
+	cmd *cobra.Command,
+	kind *entity.Kind,
+	attr *entity.Attribute,
+) (entity.Change, error) {
+	path, err := cmd.Flags().GetString(_fromFile)
+	if err != nil {
+		return entity.Change{}, err
+	}
+
+	data, err := prompt.ReadFile(path)
+	if err != nil {
+		return entity.Change{}, err
+	}
+
+	value, err := kind.RawValue(attr.Name, data)
+	if err != nil {
+		return entity.Change{}, err
+	}
+
+	return entity.Change{Attribute: attr.Name, Value: value}, nil
+}
+
+// generate sets the attribute to random password if requested by --generate
+// or the generator flags. Entropy of the password is reported to stderr.
+func generate(cmd *cobra.Command, attr *entity.Attribute) (entity.Change, bool, error) {
+	requested, err := cmd.Flags().GetBool(_generate)
+	if err != nil {
+		return entity.Change{}, false, err
+	}
+
+	if !requested && !genflags.Changed(cmd.Flags()) {
+		return entity.Change{}, false, nil
+	}
+
+	password, err := genflags.Generate(cmd.Flags())
+	if err != nil {
+		return entity.Change{}, false, err
+	}
+
+	fmt.Fprintf(
+		cmd.ErrOrStderr(),
+		"Generated %s with %.1f bits of entropy (%s)\n",
+		attr.Name,
+		password.Entropy,
+		passgen.Strength(password.Entropy),
+	)
+
+	return entity.Change{Attribute: attr.Name, Value: password.Value}, true, nil
+}
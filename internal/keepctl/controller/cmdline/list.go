@@ -1,43 +1,130 @@
 package cmdline
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
-	"github.com/cheynewallace/tabby"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
 	"github.com/spf13/cobra"
 )
 
-var listCmd = &cobra.Command{
-	Use:   "list [flags]",
-	Short: "List secrets of current user (without data)",
-	RunE:  doList,
+var (
+	filterFolder string
+	filterTags   []string
+
+	listCmd = &cobra.Command{
+		Use:   "list [flags]",
+		Short: "List secrets of current user (without data)",
+		RunE:  doList,
+	}
+)
+
+// labeledSecret is a secret accompanied with its decrypted labels.
+type labeledSecret struct {
+	secret *goph.Secret
+	labels *goph.Labels
 }
 
 func init() {
+	bindFilterFlags(listCmd)
+
 	rootCmd.AddCommand(listCmd)
 }
 
-func doList(cmd *cobra.Command, args []string) error {
-	clientApp, err := app.FromContext(cmd.Context())
+// bindFilterFlags adds flags selecting secrets by folder and tags.
+func bindFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&filterFolder,
+		"folder",
+		"",
+		"Show only secrets from the folder and its subfolders, e.g. work/aws",
+	)
+	cmd.Flags().StringSliceVar(
+		&filterTags,
+		"tag",
+		nil,
+		"Show only secrets having the tag, can be specified multiple times",
+	)
+}
+
+// selectSecrets lists user's secrets matching the filter flags sorted by folder and name.
+func selectSecrets(cmd *cobra.Command, clientApp *app.App) ([]labeledSecret, error) {
+	folder, err := entity.NormalizeFolder(filterFolder)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	data, err := clientApp.Usecases.Secrets.List(cmd.Context(), clientApp.AccessToken)
 	if err != nil {
 		clientApp.Log.Debug().Err(err).Msg("")
 
-		return entity.Unwrap(err)
+		return nil, entity.Unwrap(err)
 	}
 
-	t := tabby.New()
-	t.AddHeader("ID", "Name", "Kind", "Description")
+	rv := make([]labeledSecret, 0, len(data))
 
 	for _, secret := range data {
-		t.AddLine(secret.GetId(), secret.GetName(), secret.Kind.String(), string(secret.GetMetadata()))
+		labels, err := entity.LabelsOf(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if !entity.InFolder(labels, folder) || !entity.HasTags(labels, filterTags...) {
+			continue
+		}
+
+		rv = append(rv, labeledSecret{secret, labels})
+	}
+
+	sort.SliceStable(rv, func(i, j int) bool {
+		if rv[i].labels.GetFolder() != rv[j].labels.GetFolder() {
+			return rv[i].labels.GetFolder() < rv[j].labels.GetFolder()
+		}
+
+		return rv[i].secret.GetName() < rv[j].secret.GetName()
+	})
+
+	return rv, nil
+}
+
+func doList(cmd *cobra.Command, args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	data, err := selectSecrets(cmd, clientApp)
+	if err != nil {
+		return err
+	}
+
+	return output.Print(cmd.OutOrStdout(), listView(data))
+}
+
+// listView shows secrets with their labels, one secret per line.
+func listView(data []labeledSecret) output.View {
+	v := output.View{
+		Header: []string{"ID", "Name", "Kind", "Folder", "Tags", "Description"},
+		Rows:   make([][]string, 0, len(data)),
+	}
+	records := make([]secretRecord, 0, len(data))
+
+	for _, item := range data {
+		v.Rows = append(v.Rows, []string{
+			item.secret.GetId(),
+			item.secret.GetName(),
+			item.secret.GetKind().String(),
+			entity.FolderSeparator + item.labels.GetFolder(),
+			strings.Join(item.labels.GetTags(), ","),
+			string(item.secret.GetMetadata()),
+		})
+		records = append(records, newSecretRecord(item.secret, item.labels))
 	}
 
-	t.Print()
+	v.Records = records
 
-	return nil
+	return v
 }
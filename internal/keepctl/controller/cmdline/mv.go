@@ -0,0 +1,43 @@
+package cmdline
+
+import (
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/spf13/cobra"
+)
+
+var mvCmd = &cobra.Command{
+	Use:   "mv [secret] [folder] [flags]",
+	Short: "Move the secret to the folder, use / for the root folder",
+	Args:  cobra.ExactArgs(2),
+	RunE:  doMove,
+}
+
+func init() {
+	rootCmd.AddCommand(mvCmd)
+}
+
+func doMove(cmd *cobra.Command, args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	id, err := resolveSecret(cmd, clientApp, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := clientApp.Usecases.Secrets.Move(
+		cmd.Context(),
+		clientApp.AccessToken,
+		id,
+		args[1],
+	); err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	return nil
+}
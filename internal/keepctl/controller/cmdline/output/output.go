@@ -0,0 +1,191 @@
+// Package output renders results of commands in human-readable and machine-readable formats.
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/cheynewallace/tabby"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported output formats.
+const (
+	Table = "table"
+	JSON  = "json"
+	YAML  = "yaml"
+	Env   = "env"
+	Raw   = "raw"
+)
+
+// Parameters of the table writer, the same as tabby uses by default.
+const (
+	_tablePadding = 2
+	_jsonIndent   = "  "
+)
+
+var (
+	ErrBadFormat   = errors.New("unknown output format")
+	ErrUnsupported = errors.New("output format is not supported by the command")
+)
+
+// _envUnsafe matches characters not allowed in names of environment variables.
+var _envUnsafe = regexp.MustCompile(`[^A-Z0-9_]`)
+
+var format string
+
+// Bind registers the global output flag.
+func Bind(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&format,
+		"output",
+		Table,
+		"Output format: table, json, yaml, env (only for pull) or raw (tab-separated without headers)",
+	)
+}
+
+// Format returns the requested output format.
+func Format() string {
+	return format
+}
+
+// Validate checks the requested output format.
+func Validate() error {
+	switch format {
+	case Table, JSON, YAML, Env, Raw:
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %s", ErrBadFormat, format)
+	}
+}
+
+// Machine reports whether machine-readable output is requested.
+func Machine() bool {
+	return format != Table
+}
+
+// View is result of a command: table for humans and records for machines.
+// Records are marshaled as is, so their field names form stable schema of the output.
+type View struct {
+	Header  []string
+	Rows    [][]string
+	Records any
+}
+
+// Print renders the view in the requested format.
+func Print(w io.Writer, v View) error {
+	switch format {
+	case Table:
+		t := tabby.NewCustom(tabwriter.NewWriter(w, 0, 0, _tablePadding, ' ', 0))
+		t.AddHeader(toAny(v.Header)...)
+
+		for _, row := range v.Rows {
+			t.AddLine(toAny(row)...)
+		}
+
+		t.Print()
+
+		return nil
+
+	case Raw:
+		for _, row := range v.Rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+
+		return nil
+
+	default:
+		return Marshal(w, v.Records)
+	}
+}
+
+// Marshal writes the records as JSON or YAML.
+func Marshal(w io.Writer, records any) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", _jsonIndent)
+
+		return enc.Encode(records)
+
+	case YAML:
+		enc := yaml.NewEncoder(w)
+		if err := enc.Encode(records); err != nil {
+			return err
+		}
+
+		return enc.Close()
+
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupported, format)
+	}
+}
+
+// PrintValues renders flat set of named values ordered by name:
+// as environment variables in env format and as tab-separated pairs in raw format.
+func PrintValues(w io.Writer, values map[string]string) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch format {
+		case Env:
+			fmt.Fprintf(w, "%s=%s\n", EnvName(key), entity.ShellQuote(values[key]))
+
+		case Raw:
+			fmt.Fprintf(w, "%s\t%s\n", key, strings.ReplaceAll(values[key], "\n", "\\n"))
+
+		default:
+			return fmt.Errorf("%w: %s", ErrUnsupported, format)
+		}
+	}
+
+	return nil
+}
+
+// PrintID renders ID of created object, nothing is printed in table format.
+func PrintID(w io.Writer, id string) error {
+	switch format {
+	case Table:
+		return nil
+
+	case Raw:
+		fmt.Fprintln(w, id)
+
+		return nil
+
+	case Env:
+		return PrintValues(w, map[string]string{"id": id})
+
+	default:
+		return Marshal(w, map[string]string{"id": id})
+	}
+}
+
+// EnvName converts name of a value to name of environment variable, e.g. ssl-mode to SSL_MODE.
+func EnvName(key string) string {
+	return _envUnsafe.ReplaceAllString(strings.ToUpper(key), "_")
+}
+
+// toAny converts list of strings to list of arbitrary values accepted by tabby.
+func toAny(src []string) []any {
+	rv := make([]any, len(src))
+	for i, val := range src {
+		rv[i] = val
+	}
+
+	return rv
+}
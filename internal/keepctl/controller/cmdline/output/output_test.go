@@ -0,0 +1,125 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+func setFormat(t *testing.T, format string) {
+	t.Helper()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	output.Bind(flags)
+
+	require.NoError(t, flags.Set("output", format))
+}
+
+func TestValidate(t *testing.T) {
+	setFormat(t, output.JSON)
+	require.NoError(t, output.Validate())
+
+	setFormat(t, "xml")
+	require.ErrorIs(t, output.Validate(), output.ErrBadFormat)
+}
+
+func TestPrint(t *testing.T) {
+	view := output.View{
+		Header:  []string{"ID", "Name"},
+		Rows:    [][]string{{"1", "aws"}, {"2", "gcp"}},
+		Records: []map[string]string{{"id": "1", "name": "aws"}, {"id": "2", "name": "gcp"}},
+	}
+
+	tt := []struct {
+		name     string
+		format   string
+		expected string
+		err      error
+	}{
+		{
+			name:     "Table",
+			format:   output.Table,
+			expected: "ID  Name\n--  ----\n1   aws\n2   gcp\n",
+		},
+		{
+			name:     "Raw",
+			format:   output.Raw,
+			expected: "1\taws\n2\tgcp\n",
+		},
+		{
+			name:   "JSON",
+			format: output.JSON,
+			expected: "[\n  {\n    \"id\": \"1\",\n    \"name\": \"aws\"\n  },\n" +
+				"  {\n    \"id\": \"2\",\n    \"name\": \"gcp\"\n  }\n]\n",
+		},
+		{
+			name:     "YAML",
+			format:   output.YAML,
+			expected: "- id: \"1\"\n  name: aws\n- id: \"2\"\n  name: gcp\n",
+		},
+		{
+			name:   "Env",
+			format: output.Env,
+			err:    output.ErrUnsupported,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			setFormat(t, tc.format)
+			err := output.Print(&buf, view)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestPrintValues(t *testing.T) {
+	values := map[string]string{"ssl-mode": "require", "password": "it's"}
+
+	tt := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:     "Env",
+			format:   output.Env,
+			expected: "PASSWORD='it'\\''s'\nSSL_MODE='require'\n",
+		},
+		{
+			name:     "Raw",
+			format:   output.Raw,
+			expected: "password\tit's\nssl-mode\trequire\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			setFormat(t, tc.format)
+
+			require.NoError(t, output.PrintValues(&buf, values))
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestPrintID(t *testing.T) {
+	var buf bytes.Buffer
+
+	setFormat(t, output.Table)
+	require.NoError(t, output.PrintID(&buf, "42"))
+	require.Empty(t, buf.String())
+
+	setFormat(t, output.JSON)
+	require.NoError(t, output.PrintID(&buf, "42"))
+	require.Equal(t, "{\n  \"id\": \"42\"\n}\n", buf.String())
+}
@@ -0,0 +1,106 @@
+// Package prompt reads sensitive values from terminal with hidden echo or from stdin,
+// keeping them away from commandline and shell history.
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Stdin is value of a flag requesting to read the value from stdin.
+const Stdin = "-"
+
+var (
+	ErrNoTerminal = errors.New("stdin is not a terminal, can't prompt for the value")
+	ErrMismatch   = errors.New("entered values don't match")
+	ErrStdinTaken = errors.New("only one value can be read from stdin")
+)
+
+// stdinTaken is set once stdin is read to the end.
+var stdinTaken bool
+
+// IsTerminal reports whether stdin is a terminal, i.e. user can be prompted.
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Secret asks user for the value with hidden echo, the prompt is printed to stderr.
+// If confirm is true, the value is asked twice, e.g. for new passwords.
+func Secret(label string, confirm bool) (string, error) {
+	if !IsTerminal() {
+		return "", fmt.Errorf("%w: %s", ErrNoTerminal, label)
+	}
+
+	value, err := readHidden("Enter " + label + ": ")
+	if err != nil || !confirm {
+		return value, err
+	}
+
+	again, err := readHidden("Repeat " + label + ": ")
+	if err != nil {
+		return "", err
+	}
+
+	if value != again {
+		return "", fmt.Errorf("%w: %s", ErrMismatch, label)
+	}
+
+	return value, nil
+}
+
+// Value reads the value requested with Stdin flag value: if stdin is a terminal,
+// user is asked with hidden echo, otherwise the value is read from stdin
+// without trailing line break.
+func Value(label string, confirm bool) (string, error) {
+	if IsTerminal() {
+		return Secret(label, confirm)
+	}
+
+	data, err := ReadStdin()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// ReadStdin reads raw content of stdin, stdin can be read only once.
+func ReadStdin() ([]byte, error) {
+	if stdinTaken {
+		return nil, ErrStdinTaken
+	}
+
+	stdinTaken = true
+
+	return io.ReadAll(os.Stdin)
+}
+
+// ReadFile reads raw content of the file, Stdin path denotes stdin.
+func ReadFile(path string) ([]byte, error) {
+	if path == Stdin {
+		return ReadStdin()
+	}
+
+	return os.ReadFile(path)
+}
+
+// readHidden reads single line from terminal without echo.
+func readHidden(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	value, err := term.ReadPassword(int(os.Stdin.Fd()))
+
+	// NB (alkurbatov): The line break typed by user is not echoed as well.
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", fmt.Errorf("prompt - readHidden - term.ReadPassword: %w", err)
+	}
+
+	return string(value), nil
+}
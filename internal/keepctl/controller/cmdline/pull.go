@@ -1,45 +1,114 @@
 package cmdline
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/termqr"
 	"github.com/alkurbatov/goph-keeper/pkg/goph"
-	"github.com/cheynewallace/tabby"
-	uuid "github.com/satori/go.uuid"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
 )
 
-var pullCmd = &cobra.Command{
-	Use:   "pull [secret id] [flags]",
-	Short: "Show the secret and stored data",
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  doPull,
-}
+var (
+	reveal    bool
+	format    string
+	qrCode    bool
+	pullField string
+
+	pullCmd = &cobra.Command{
+		Use:   "pull [secret] [flags]",
+		Short: "Show the secret and stored data",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  doPull,
+	}
+)
 
 func init() {
+	pullCmd.Flags().BoolVar(&reveal, "reveal", false, "Show values of hidden fields")
+	pullCmd.Flags().StringVar(
+		&format,
+		"format",
+		"",
+		"Print the data in format consumed by other tools, e.g. dsn, url, env or pgpass for db",
+	)
+
+	pullCmd.Flags().BoolVar(
+		&qrCode,
+		"qr",
+		false,
+		"Show QR code to share the data, e.g. Wi-Fi network or TOTP secret",
+	)
+	pullCmd.Flags().StringVar(
+		&pullField,
+		"field",
+		"",
+		"Print only value of the field, e.g. password, name or folder",
+	)
+	pullCmd.MarkFlagsMutuallyExclusive("format", "qr", "field")
+
 	rootCmd.AddCommand(pullCmd)
 }
 
 func doPull(cmd *cobra.Command, args []string) error {
-	id, err := uuid.FromString(args[0])
-	if err != nil {
-		return err
-	}
-
 	clientApp, err := app.FromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
-	secret, data, err := clientApp.Usecases.Secrets.Get(cmd.Context(), clientApp.AccessToken, id)
+	secret, data, err := clientApp.Usecases.Secrets.Find(cmd.Context(), clientApp.AccessToken, args[0])
 	if err != nil {
 		clientApp.Log.Debug().Err(err).Msg("")
 
 		return entity.Unwrap(err)
 	}
 
-	header := []any{"ID", "Name", "Kind", "Description"}
-	line := []any{
+	kind, err := entity.KindOf(secret.GetKind())
+	if err != nil {
+		return err
+	}
+
+	if pullField != "" {
+		return printField(cmd, secret, kind, data)
+	}
+
+	if format != "" {
+		rendered, err := kind.Format(format, data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), rendered)
+
+		return nil
+	}
+
+	if qrCode {
+		content, err := kind.QRCode(secret.GetName(), data)
+		if err != nil {
+			return err
+		}
+
+		rendered, err := termqr.Render(content)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), rendered)
+
+		return nil
+	}
+
+	if output.Machine() {
+		return printSecret(cmd, secret, kind, data)
+	}
+
+	header := []string{"ID", "Name", "Kind", "Description"}
+	line := []string{
 		secret.GetId(),
 		secret.GetName(),
 		secret.GetKind().String(),
@@ -47,30 +116,107 @@ func doPull(cmd *cobra.Command, args []string) error {
 	}
 	messages := make([]string, 0)
 
-	switch d := data.(type) {
-	case *goph.Binary:
-		messages = append(messages, string(d.GetBinary()))
+	for i := range kind.Attributes {
+		attr := &kind.Attributes[i]
 
-	case *goph.Card:
-		header = append(header, "Number", "Expiration", "Holder", "CVV")
-		line = append(line, d.GetNumber(), d.GetExpiration(), d.GetHolder(), d.GetCvv())
+		value := attr.Display(data, reveal)
 
-	case *goph.Credentials:
-		header = append(header, "Login", "Password")
-		line = append(line, d.GetLogin(), d.GetPassword())
+		if attr.Column != "" {
+			header = append(header, attr.Column)
+			line = append(line, value)
 
-	case *goph.Text:
-		messages = append(messages, d.GetText())
+			continue
+		}
+
+		if value != "" {
+			messages = append(messages, value)
+		}
 	}
 
-	t := tabby.New()
-	t.AddHeader(header...)
-	t.AddLine(line...)
-	t.Print()
+	err = output.Print(cmd.OutOrStdout(), output.View{Header: header, Rows: [][]string{line}})
+	if err != nil {
+		return err
+	}
+
+	for _, table := range kind.Tables(data, reveal) {
+		err := output.Print(cmd.OutOrStdout(), output.View{Header: table.Header, Rows: table.Rows})
+		if err != nil {
+			return err
+		}
+	}
 
 	for _, msg := range messages {
-		clientApp.Log.Info().Msg(msg)
+		fmt.Fprintln(cmd.OutOrStdout(), msg)
+	}
+
+	for _, warning := range kind.Warnings(data, time.Now()) {
+		clientApp.Log.Warn().Msg(warning)
+	}
+
+	return nil
+}
+
+// printSecret renders the secret in machine-readable format.
+// Sensitive values are masked unless revealed.
+func printSecret(
+	cmd *cobra.Command,
+	secret *goph.Secret,
+	kind *entity.Kind,
+	data proto.Message,
+) error {
+	values := kind.Values(data, reveal)
+
+	if output.Format() == output.Env || output.Format() == output.Raw {
+		return output.PrintValues(cmd.OutOrStdout(), values)
 	}
 
+	labels, err := entity.LabelsOf(secret)
+	if err != nil {
+		return err
+	}
+
+	record := newSecretRecord(secret, labels)
+	record.Data = values
+
+	return output.Marshal(cmd.OutOrStdout(), record)
+}
+
+// printField prints single value of the secret as is: value of data attribute
+// or one of the common fields, e.g. name or folder.
+func printField(
+	cmd *cobra.Command,
+	secret *goph.Secret,
+	kind *entity.Kind,
+	data proto.Message,
+) error {
+	value, err := kind.Value(data, pullField)
+	if err != nil {
+		labels, lErr := entity.LabelsOf(secret)
+		if lErr != nil {
+			return lErr
+		}
+
+		record := newSecretRecord(secret, labels)
+
+		switch pullField {
+		case "id":
+			value = record.ID
+		case "name":
+			value = record.Name
+		case "kind":
+			value = record.Kind
+		case "description":
+			value = record.Description
+		case "folder":
+			value = record.Folder
+		case "tags":
+			value = strings.Join(record.Tags, ",")
+		default:
+			return err
+		}
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), value)
+
 	return nil
 }
@@ -0,0 +1,62 @@
+package pushcmd
+
+import (
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/kindflags"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newKindCmd creates command saving secret of the provided kind.
+func newKindCmd(kind *entity.Kind) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     kind.Name + " [flags]",
+		Short:   "Save " + kind.Title,
+		PreRunE: preRun,
+		RunE: func(cmd *cobra.Command, _args []string) error {
+			return doPush(cmd, kind)
+		},
+	}
+
+	kindflags.Bind(cmd, kind, true)
+
+	return cmd
+}
+
+func doPush(cmd *cobra.Command, kind *entity.Kind) error {
+	changes, err := kindflags.Changes(cmd, kind, true)
+	if err != nil {
+		return err
+	}
+
+	if !allowBreached {
+		if err := kindflags.CheckBreached(kind, changes, viper.GetString("breach-db")); err != nil {
+			return err
+		}
+	}
+
+	data := kind.New()
+	if err := kind.Apply(data, changes); err != nil {
+		return err
+	}
+
+	id, err := clientApp.Usecases.Secrets.Push(
+		cmd.Context(),
+		clientApp.AccessToken,
+		secretName,
+		description,
+		&goph.Labels{Folder: folder, Tags: tags},
+		data,
+	)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	clientApp.Log.Debug().Str("secret-id", id.String()).Msg("Secret saved successfully")
+
+	return output.PrintID(cmd.OutOrStdout(), id.String())
+}
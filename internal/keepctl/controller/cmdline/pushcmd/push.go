@@ -2,6 +2,7 @@ package pushcmd
 
 import (
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
 	"github.com/spf13/cobra"
 )
 
@@ -10,6 +11,10 @@ var (
 
 	secretName  string
 	description string
+	folder      string
+	tags        []string
+
+	allowBreached bool
 
 	PushCmd = &cobra.Command{
 		Use:   "push",
@@ -33,12 +38,31 @@ func init() {
 		"Additional description of stored data (activation codes, names of banks etc)",
 	)
 
+	PushCmd.PersistentFlags().StringVar(
+		&folder,
+		"folder",
+		"",
+		"Slash-separated path of the folder to put the secret into, e.g. work/aws",
+	)
+	PushCmd.PersistentFlags().StringSliceVar(
+		&tags,
+		"tag",
+		nil,
+		"Tag of the secret, can be specified multiple times",
+	)
+
+	PushCmd.PersistentFlags().BoolVar(
+		&allowBreached,
+		"allow-breached",
+		false,
+		"Save password found in the breached passwords database (GOPH_BREACH_DB)",
+	)
+
 	PushCmd.MarkPersistentFlagRequired("name")
 
-	PushCmd.AddCommand(binCmd)
-	PushCmd.AddCommand(cardCmd)
-	PushCmd.AddCommand(credsCmd)
-	PushCmd.AddCommand(textCmd)
+	for _, kind := range entity.Kinds() {
+		PushCmd.AddCommand(newKindCmd(kind))
+	}
 }
 
 // preRun executes preparational operations common for all sub commands.
@@ -0,0 +1,111 @@
+package cmdline
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+// secretRecord is machine-readable representation of a secret.
+// Data contains values of the kind attributes keyed by names of the attributes.
+type secretRecord struct {
+	ID          string            `json:"id"             yaml:"id"`
+	Name        string            `json:"name"           yaml:"name"`
+	Kind        string            `json:"kind"           yaml:"kind"`
+	Description string            `json:"description"    yaml:"description"`
+	Folder      string            `json:"folder"         yaml:"folder"`
+	Tags        []string          `json:"tags"           yaml:"tags"`
+	CreatedAt   time.Time         `json:"created_at"     yaml:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"     yaml:"updated_at"`
+	Data        map[string]string `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// newSecretRecord converts the secret with decrypted labels and metadata into record.
+func newSecretRecord(secret *goph.Secret, labels *goph.Labels) secretRecord {
+	tags := labels.GetTags()
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return secretRecord{
+		ID:          secret.GetId(),
+		Name:        secret.GetName(),
+		Kind:        kindName(secret.GetKind()),
+		Description: string(secret.GetMetadata()),
+		Folder:      entity.FolderSeparator + labels.GetFolder(),
+		Tags:        tags,
+		CreatedAt:   secret.GetCreatedAt().AsTime(),
+		UpdatedAt:   secret.GetUpdatedAt().AsTime(),
+	}
+}
+
+// kindName returns short name of the kind used in commands, e.g. creds.
+func kindName(kind goph.DataKind) string {
+	k, err := entity.KindOf(kind)
+	if err != nil {
+		return strings.ToLower(kind.String())
+	}
+
+	return k.Name
+}
+
+// searchRecord is machine-readable representation of a search result.
+type searchRecord struct {
+	secretRecord `yaml:",inline"`
+
+	Matched string `json:"matched" yaml:"matched"`
+	Score   int    `json:"score"   yaml:"score"`
+}
+
+// certRecord is machine-readable representation of a stored certificate.
+type certRecord struct {
+	secretRecord `yaml:",inline"`
+
+	NotAfter time.Time `json:"not_after" yaml:"not_after"`
+	DaysLeft int       `json:"days_left" yaml:"days_left"`
+}
+
+// attachmentRecord is machine-readable representation of an attached file.
+type attachmentRecord struct {
+	ID       string `json:"id"        yaml:"id"`
+	Filename string `json:"filename"  yaml:"filename"`
+	MimeType string `json:"mime_type" yaml:"mime_type"`
+	Size     uint64 `json:"size"      yaml:"size"`
+}
+
+// importRecord is machine-readable representation of an imported secret.
+type importRecord struct {
+	Path        string `json:"path"             yaml:"path"`
+	Kind        string `json:"kind"             yaml:"kind"`
+	Action      string `json:"action"           yaml:"action"`
+	Reason      string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	ID          string `json:"id,omitempty"     yaml:"id,omitempty"`
+	Attachments int    `json:"attachments"      yaml:"attachments"`
+}
+
+// passwordRecord is machine-readable representation of a generated password.
+type passwordRecord struct {
+	Password string  `json:"password" yaml:"password"`
+	Entropy  float64 `json:"entropy"  yaml:"entropy"`
+	Strength string  `json:"strength" yaml:"strength"`
+}
+
+// auditRecord is machine-readable representation of a problem found by audit.
+type auditRecord struct {
+	Check   string `json:"check"   yaml:"check"`
+	ID      string `json:"id"      yaml:"id"`
+	Path    string `json:"path"    yaml:"path"`
+	Kind    string `json:"kind"    yaml:"kind"`
+	Details string `json:"details" yaml:"details"`
+}
+
+// breachRecord is machine-readable representation of a breached password.
+type breachRecord struct {
+	ID        string `json:"id"        yaml:"id"`
+	Path      string `json:"path"      yaml:"path"`
+	Kind      string `json:"kind"      yaml:"kind"`
+	Attribute string `json:"attribute" yaml:"attribute"`
+	Count     int    `json:"count"     yaml:"count"`
+}
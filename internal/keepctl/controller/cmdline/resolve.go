@@ -0,0 +1,21 @@
+package cmdline
+
+import (
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+)
+
+// resolveSecret finds ID of the secret by reference provided by user:
+// ID, unique ID prefix, unique name or path like work/aws.
+func resolveSecret(cmd *cobra.Command, clientApp *app.App, ref string) (uuid.UUID, error) {
+	id, err := clientApp.Usecases.Secrets.Resolve(cmd.Context(), clientApp.AccessToken, ref)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return uuid.Nil, entity.Unwrap(err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,123 @@
+package cmdline
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/prompt"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/backup"
+	"github.com/spf13/cobra"
+)
+
+var (
+	onConflict    string
+	restoreDryRun bool
+
+	restoreCmd = &cobra.Command{
+		Use:   "restore-backup [file] [flags]",
+		Short: "Restore secrets from backup created by export",
+		Long: "Restore secrets and files attached to them from encrypted archive " +
+			"or plaintext JSON created by export, '-' reads stdin.\n" +
+			"Secrets with names already taken in the folder are skipped, overwritten " +
+			"(the stored secret is deleted together with its files) or renamed. " +
+			"Use --dry-run to preview the result without storing anything.",
+		Args: cobra.ExactArgs(1),
+		RunE: doRestore,
+	}
+)
+
+func init() {
+	restoreCmd.Flags().StringVar(
+		&onConflict,
+		"on-conflict",
+		"skip",
+		"What to do if name of a secret is already taken: skip, overwrite or rename",
+	)
+	restoreCmd.Flags().String(
+		"passphrase",
+		"",
+		"Passphrase used to encrypt the archive, prompted with hidden echo if omitted, "+
+			prompt.Stdin+" reads it from stdin",
+	)
+	restoreCmd.Flags().BoolVar(
+		&restoreDryRun,
+		"dry-run",
+		false,
+		"Show what would be restored without storing anything",
+	)
+
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func doRestore(cmd *cobra.Command, args []string) error {
+	policy, err := entity.ParseConflictPolicy(onConflict)
+	if err != nil {
+		return err
+	}
+
+	raw, err := prompt.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	doc, err := readBackup(cmd, raw)
+	if err != nil {
+		return entity.NewValidationError(err)
+	}
+
+	secrets, err := doc.NewSecrets()
+	if err != nil {
+		return entity.NewValidationError(err)
+	}
+
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	existing, err := clientApp.Usecases.Secrets.List(cmd.Context(), clientApp.AccessToken)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	plan, err := entity.PlanRestore(secrets, existing, policy)
+	if err != nil {
+		return err
+	}
+
+	if restoreDryRun {
+		return output.Print(cmd.OutOrStdout(), importView(plan, nil))
+	}
+
+	return storePlan(cmd, clientApp, plan)
+}
+
+// readBackup reads the backup, passphrase of encrypted archive is asked
+// if it is omitted and stdin is a terminal. Plain JSON requires no passphrase.
+func readBackup(cmd *cobra.Command, raw []byte) (*backup.Document, error) {
+	passphrase := backupPassphrase(cmd)
+
+	if passphrase == prompt.Stdin {
+		var err error
+
+		if passphrase, err = prompt.Value(_passphraseLabel, false); err != nil {
+			return nil, err
+		}
+	}
+
+	doc, err := backup.Read(bytes.NewReader(raw), passphrase)
+	if !errors.Is(err, backup.ErrPassphraseRequired) || !prompt.IsTerminal() {
+		return doc, err
+	}
+
+	if passphrase, err = prompt.Secret(_passphraseLabel, false); err != nil {
+		return nil, err
+	}
+
+	return backup.Read(bytes.NewReader(raw), passphrase)
+}
@@ -1,16 +1,22 @@
 package cmdline
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/config"
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/editcmd"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/prompt"
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/pushcmd"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/creds"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+var errNoMasterPassword = errors.New("master password is required")
+
 var (
 	cfg *config.Config
 
@@ -21,8 +27,11 @@ var (
 	password string
 
 	rootCmd = &cobra.Command{
-		Use:               "keepctl",
-		Short:             "keepctl is an intercative commandline client for the goph-keeper service",
+		Use:   "keepctl",
+		Short: "keepctl is an intercative commandline client for the goph-keeper service",
+		Long: "keepctl is an intercative commandline client for the goph-keeper service.\n" +
+			"Secrets are referred by ID, unique ID prefix (at least 4 characters), " +
+			"unique name or path like work/aws.",
 		PersistentPreRunE: initApp,
 		PersistentPostRun: finalizeApp,
 	}
@@ -51,11 +60,17 @@ func init() {
 		"",
 		"Path to certificate authority to verify server certificate",
 	)
+	output.Bind(rootCmd.PersistentFlags())
 	rootCmd.PersistentFlags().StringVarP(&username, "username", "u", "", "Name of a user")
-	rootCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "Master password")
+	rootCmd.PersistentFlags().StringVarP(
+		&password,
+		"password",
+		"p",
+		"",
+		"Master password, prompted with hidden echo if omitted, "+prompt.Stdin+" reads it from stdin",
+	)
 
 	rootCmd.MarkFlagRequired("username")
-	rootCmd.MarkFlagRequired("password")
 
 	viper.BindPFlag("username", rootCmd.PersistentFlags().Lookup("username"))
 	viper.BindPFlag("password", rootCmd.PersistentFlags().Lookup("password"))
@@ -79,8 +94,16 @@ func initApp(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := output.Validate(); err != nil {
+		return err
+	}
+
 	cfg := config.New()
 
+	if err := askPassword(cmd, cfg); err != nil {
+		return err
+	}
+
 	clientApp, err := app.New(cfg)
 	if err != nil {
 		return err
@@ -95,6 +118,39 @@ func initApp(cmd *cobra.Command, args []string) error {
 	return login(cmd, args)
 }
 
+// askPassword prompts for master password if it is omitted or requested from stdin.
+// Master password of new user is asked twice.
+func askPassword(cmd *cobra.Command, cfg *config.Config) error {
+	if cfg.Password != "" && cfg.Password != prompt.Stdin {
+		return nil
+	}
+
+	var (
+		value string
+		err   error
+	)
+
+	confirm := cmd.Name() == registerCmd.Name()
+
+	if cfg.Password == prompt.Stdin {
+		value, err = prompt.Value("master password", confirm)
+	} else {
+		value, err = prompt.Secret("master password", confirm)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if value == "" {
+		return errNoMasterPassword
+	}
+
+	cfg.Password = creds.Password(value)
+
+	return nil
+}
+
 // finalizeApp does cleanup at the end of commandline application.
 func finalizeApp(cmd *cobra.Command, _ []string) {
 	clientApp, err := app.FromContext(cmd.Context())
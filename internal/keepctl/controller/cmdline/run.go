@@ -0,0 +1,210 @@
+package cmdline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/redact"
+	"github.com/spf13/cobra"
+)
+
+// _signalExitBase is added to number of the signal terminated child process,
+// the same way as shells do.
+const _signalExitBase = 128
+
+var (
+	envBindings []string
+	envFile     string
+
+	runCmd = &cobra.Command{
+		Use:   "run [flags] -- command [args]",
+		Short: "Run command with secrets in its environment",
+		Long: "Run command with values of secrets in its environment variables.\n" +
+			"Variables are bound to secrets like DB_PASSWORD=prod-db:password, " +
+			"primary value (e.g. password) is used if field is omitted.\n" +
+			"SIGTERM and SIGHUP are forwarded to the command, interrupts from terminal " +
+			"reach it directly, keepctl exits with exit code of the command. " +
+			"The values are replaced with " + entity.HiddenValue +
+			" in output of the command.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: doRun,
+	}
+)
+
+func init() {
+	runCmd.Flags().StringArrayVar(
+		&envBindings,
+		"env",
+		nil,
+		"Environment variable bound to field of secret, e.g. DB_PASSWORD=prod-db:password",
+	)
+	runCmd.Flags().StringVar(
+		&envFile,
+		"env-file",
+		"",
+		"Path to file with bindings of environment variables, one per line",
+	)
+
+	// NB (alkurbatov): Flags after the command belong to the command.
+	runCmd.Flags().SetInterspersed(false)
+
+	rootCmd.AddCommand(runCmd)
+}
+
+func doRun(cmd *cobra.Command, args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	vars, err := envVars()
+	if err != nil {
+		return err
+	}
+
+	env, secrets, err := fetchEnv(cmd, clientApp, vars)
+	if err != nil {
+		return err
+	}
+
+	stdout := redact.NewWriter(cmd.OutOrStdout(), secrets, entity.HiddenValue)
+	stderr := redact.NewWriter(cmd.ErrOrStderr(), secrets, entity.HiddenValue)
+
+	child := exec.Command(args[0], args[1:]...) //nolint:gosec // requested by user
+	child.Env = append(childEnviron(), env...)
+	child.Stdin = os.Stdin
+	child.Stdout = stdout
+	child.Stderr = stderr
+
+	err = runChild(child)
+
+	stdout.Flush()
+	stderr.Flush()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+
+	// NB (alkurbatov): The command has already reported its failure,
+	// keepctl only passes the exit code through.
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	return entity.ExitStatusError{Code: childExitCode(exitErr)}
+}
+
+// envVars collects bindings of environment variables from the flags and the file.
+func envVars() ([]entity.EnvVar, error) {
+	vars := make([]entity.EnvVar, 0, len(envBindings))
+
+	if envFile != "" {
+		f, err := os.Open(envFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		fromFile, err := entity.ParseEnvFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", envFile, err)
+		}
+
+		vars = append(vars, fromFile...)
+	}
+
+	for _, binding := range envBindings {
+		v, err := entity.ParseEnvVar(binding)
+		if err != nil {
+			return nil, err
+		}
+
+		vars = append(vars, v)
+	}
+
+	return vars, nil
+}
+
+// fetchEnv decrypts values of the environment variables requesting every secret only once.
+// Returns the variables in form NAME=value and the values to hide in output.
+func fetchEnv(
+	cmd *cobra.Command,
+	clientApp *app.App,
+	vars []entity.EnvVar,
+) ([]string, []string, error) {
+	fetcher := newFieldFetcher(cmd, clientApp)
+	env := make([]string, 0, len(vars))
+
+	for _, v := range vars {
+		value, err := fetcher.Fetch(v.Ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", v.Name, err)
+		}
+
+		env = append(env, v.Name+"="+value)
+	}
+
+	return env, fetcher.values, nil
+}
+
+// childEnviron returns environment of keepctl without the master password.
+func childEnviron() []string {
+	parent := os.Environ()
+	rv := make([]string, 0, len(parent))
+
+	for _, v := range parent {
+		if !strings.HasPrefix(v, "GOPH_PASSWORD=") {
+			rv = append(rv, v)
+		}
+	}
+
+	return rv
+}
+
+// runChild starts the command and forwards received signals to it until it exits.
+// SIGINT and SIGQUIT are not forwarded: the command shares process group with keepctl,
+// so Ctrl-C or Ctrl-\ typed in terminal reach it directly.
+func runChild(child *exec.Cmd) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT)
+
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- child.Wait()
+	}()
+
+	for {
+		select {
+		case sig := <-signals:
+			if sig != os.Interrupt && sig != syscall.SIGQUIT {
+				child.Process.Signal(sig)
+			}
+
+		case err := <-done:
+			return err
+		}
+	}
+}
+
+// childExitCode returns exit code of the command, 128+N if it was killed by signal N.
+func childExitCode(exitErr *exec.ExitError) int {
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return _signalExitBase + int(status.Signal())
+	}
+
+	return exitErr.ExitCode()
+}
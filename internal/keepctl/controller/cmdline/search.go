@@ -0,0 +1,278 @@
+package cmdline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/spf13/cobra"
+)
+
+// _pickerSize is number of results offered by interactive picker.
+const _pickerSize = 10
+
+var errNothingPicked = errors.New("no secret picked")
+
+var (
+	searchKinds   []string
+	changedAfter  string
+	changedBefore string
+	deepSearch    bool
+	searchLimit   int
+	interactive   bool
+	copyPicked    bool
+
+	searchCmd = &cobra.Command{
+		Use:   "search [query] [flags]",
+		Short: "Find secrets by fuzzy matching of names, folders, tags and descriptions",
+		RunE:  doSearch,
+	}
+)
+
+func init() {
+	bindFilterFlags(searchCmd)
+
+	searchCmd.Flags().StringSliceVar(
+		&searchKinds,
+		"kind",
+		nil,
+		"Show only secrets of the kind, e.g. creds, can be specified multiple times",
+	)
+	searchCmd.Flags().StringVar(
+		&changedAfter,
+		"changed-after",
+		"",
+		"Show only secrets changed after the date (YYYY-MM-DD) or during the period, e.g. 30d",
+	)
+	searchCmd.Flags().StringVar(
+		&changedBefore,
+		"changed-before",
+		"",
+		"Show only secrets changed before the date (YYYY-MM-DD) or the period ago, e.g. 90d",
+	)
+	searchCmd.Flags().BoolVar(
+		&deepSearch,
+		"deep",
+		false,
+		"Also match non-sensitive data, e.g. logins and URIs (downloads all secrets)",
+	)
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 0, "Show only the best results")
+	searchCmd.Flags().BoolVarP(
+		&interactive,
+		"interactive",
+		"i",
+		false,
+		"Pick one of the found secrets interactively and show it",
+	)
+	searchCmd.Flags().BoolVar(
+		&copyPicked,
+		"copy",
+		false,
+		"Copy primary value of the picked secret (e.g. password) to clipboard instead of showing it",
+	)
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+// newSearchQuery creates search query from the commandline.
+func newSearchQuery(args []string) (entity.SearchQuery, error) {
+	var err error
+
+	query := entity.SearchQuery{
+		Text: strings.Join(args, " "),
+		Tags: filterTags,
+		Deep: deepSearch,
+	}
+
+	query.Folder, err = entity.NormalizeFolder(filterFolder)
+	if err != nil {
+		return query, err
+	}
+
+	for _, name := range searchKinds {
+		kind, err := entity.KindByName(name)
+		if err != nil {
+			return query, err
+		}
+
+		query.Kinds = append(query.Kinds, kind.DataKind)
+	}
+
+	now := time.Now()
+
+	query.ChangedAfter, err = entity.ParseMoment("changed-after", changedAfter, now)
+	if err != nil {
+		return query, err
+	}
+
+	query.ChangedBefore, err = entity.ParseMoment("changed-before", changedBefore, now)
+	if err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+func doSearch(cmd *cobra.Command, args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	query, err := newSearchQuery(args)
+	if err != nil {
+		return err
+	}
+
+	if interactive {
+		return pick(cmd, clientApp, query)
+	}
+
+	if copyPicked {
+		clientApp.Log.Warn().Msg("--copy has no effect without --interactive")
+	}
+
+	results, err := search(cmd, clientApp, query, searchLimit)
+	if err != nil {
+		return err
+	}
+
+	v := output.View{
+		Header: []string{"ID", "Name", "Kind", "Folder", "Matched"},
+		Rows:   make([][]string, 0, len(results)),
+	}
+	records := make([]searchRecord, 0, len(results))
+
+	for _, result := range results {
+		v.Rows = append(v.Rows, []string{
+			result.Secret.GetId(),
+			result.Secret.GetName(),
+			result.Secret.GetKind().String(),
+			entity.FolderSeparator + result.Labels.GetFolder(),
+			result.Field,
+		})
+		records = append(records, searchRecord{
+			secretRecord: newSecretRecord(result.Secret, result.Labels),
+			Matched:      result.Field,
+			Score:        result.Score,
+		})
+	}
+
+	v.Records = records
+
+	return output.Print(cmd.OutOrStdout(), v)
+}
+
+// search runs the query and returns not more than limit results, all results if limit is 0.
+func search(
+	cmd *cobra.Command,
+	clientApp *app.App,
+	query entity.SearchQuery,
+	limit int,
+) ([]entity.SearchResult, error) {
+	results, err := clientApp.Usecases.Secrets.Search(cmd.Context(), clientApp.AccessToken, query)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return nil, entity.Unwrap(err)
+	}
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// pick offers the best results to user until one is picked, user can refine the query meanwhile.
+// The picked secret is shown or copied to clipboard.
+func pick(cmd *cobra.Command, clientApp *app.App, query entity.SearchQuery) error {
+	input := bufio.NewScanner(cmd.InOrStdin())
+	out := cmd.ErrOrStderr()
+
+	for {
+		results, err := search(cmd, clientApp, query, _pickerSize)
+		if err != nil {
+			return err
+		}
+
+		printPickerResults(out, results)
+		fmt.Fprint(out, "Pick a number, refine the query or press Enter to pick the first one: ")
+
+		if !input.Scan() {
+			fmt.Fprintln(out)
+
+			return errNothingPicked
+		}
+
+		answer := strings.TrimSpace(input.Text())
+
+		if answer == "" && len(results) > 0 {
+			return usePicked(cmd, clientApp, results[0].Secret)
+		}
+
+		if num, err := strconv.Atoi(answer); err == nil && num >= 1 && num <= len(results) {
+			return usePicked(cmd, clientApp, results[num-1].Secret)
+		}
+
+		query.Text = answer
+	}
+}
+
+// printPickerResults shows numbered list of results offered to user.
+func printPickerResults(out io.Writer, results []entity.SearchResult) {
+	if len(results) == 0 {
+		fmt.Fprintln(out, "Nothing found")
+
+		return
+	}
+
+	for i, result := range results {
+		fmt.Fprintf(
+			out,
+			"%2d. %s (%s, %s)\n",
+			i+1,
+			result.Secret.GetName(),
+			result.Secret.GetKind().String(),
+			entity.FolderSeparator+result.Labels.GetFolder(),
+		)
+	}
+}
+
+// usePicked shows the picked secret or copies its primary value to clipboard.
+func usePicked(cmd *cobra.Command, clientApp *app.App, secret *goph.Secret) error {
+	if !copyPicked {
+		return doPull(cmd, []string{secret.GetId()})
+	}
+
+	_, data, err := clientApp.Usecases.Secrets.Find(
+		cmd.Context(),
+		clientApp.AccessToken,
+		secret.GetId(),
+	)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	kind, err := entity.KindOf(secret.GetKind())
+	if err != nil {
+		return err
+	}
+
+	field, value, err := copiedValue(kind, data, "")
+	if err != nil {
+		return err
+	}
+
+	return copyToClipboard(cmd, clientApp, value, fmt.Sprintf("%s of %s", field, secret.GetName()))
+}
@@ -0,0 +1,78 @@
+package cmdline
+
+import (
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagCmd = &cobra.Command{
+		Use:   "tag [secret] [tag]... [flags]",
+		Short: "Add tags to the secret",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  doTag,
+	}
+
+	untagCmd = &cobra.Command{
+		Use:   "untag [secret] [tag]... [flags]",
+		Short: "Remove tags from the secret",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  doUntag,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(untagCmd)
+}
+
+func doTag(cmd *cobra.Command, args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	id, err := resolveSecret(cmd, clientApp, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := clientApp.Usecases.Secrets.Tag(
+		cmd.Context(),
+		clientApp.AccessToken,
+		id,
+		args[1:]...,
+	); err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	return nil
+}
+
+func doUntag(cmd *cobra.Command, args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	id, err := resolveSecret(cmd, clientApp, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := clientApp.Usecases.Secrets.Untag(
+		cmd.Context(),
+		clientApp.AccessToken,
+		id,
+		args[1:]...,
+	); err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return entity.Unwrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package cmdline
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/output"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/spf13/cobra"
+)
+
+var treeCmd = &cobra.Command{
+	Use:   "tree [flags]",
+	Short: "Show secrets of current user organized by folders",
+	RunE:  doTree,
+}
+
+// folderNode is a folder with its subfolders and secrets.
+type folderNode struct {
+	folders map[string]*folderNode
+	secrets []labeledSecret
+}
+
+func newFolderNode() *folderNode {
+	return &folderNode{folders: make(map[string]*folderNode)}
+}
+
+func init() {
+	bindFilterFlags(treeCmd)
+
+	rootCmd.AddCommand(treeCmd)
+}
+
+func doTree(cmd *cobra.Command, _args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	data, err := selectSecrets(cmd, clientApp)
+	if err != nil {
+		return err
+	}
+
+	// NB (alkurbatov): Machine-readable output doesn't need the drawing, folders are in records.
+	if output.Machine() {
+		return output.Print(cmd.OutOrStdout(), listView(data))
+	}
+
+	root := newFolderNode()
+
+	for _, item := range data {
+		node := root
+
+		if item.labels.GetFolder() != "" {
+			for _, part := range strings.Split(item.labels.GetFolder(), entity.FolderSeparator) {
+				child, ok := node.folders[part]
+				if !ok {
+					child = newFolderNode()
+					node.folders[part] = child
+				}
+
+				node = child
+			}
+		}
+
+		// NB (alkurbatov): Secrets are already sorted by name.
+		node.secrets = append(node.secrets, item)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), entity.FolderSeparator)
+	printFolder(cmd.OutOrStdout(), root, "")
+
+	return nil
+}
+
+// printFolder draws content of the folder, subfolders go first.
+func printFolder(out io.Writer, node *folderNode, indent string) {
+	names := make([]string, 0, len(node.folders))
+	for name := range node.folders {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	total := len(names) + len(node.secrets)
+	pos := 0
+
+	branch := func() (string, string) {
+		pos++
+		if pos == total {
+			return "└── ", "    "
+		}
+
+		return "├── ", "│   "
+	}
+
+	for _, name := range names {
+		head, tail := branch()
+		fmt.Fprintln(out, indent+head+name+entity.FolderSeparator)
+		printFolder(out, node.folders[name], indent+tail)
+	}
+
+	for _, item := range node.secrets {
+		head, _ := branch()
+
+		line := fmt.Sprintf(
+			"%s (%s, %s)",
+			item.secret.GetName(),
+			item.secret.GetKind().String(),
+			item.secret.GetId(),
+		)
+		if len(item.labels.GetTags()) > 0 {
+			line += " #" + strings.Join(item.labels.GetTags(), " #")
+		}
+
+		fmt.Fprintln(out, indent+head+line)
+	}
+}
@@ -0,0 +1,90 @@
+package cmdline
+
+import (
+	"fmt"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/app"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/tui"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/clipboard"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and edit secrets in full-screen terminal interface",
+	Long: "Browse and edit secrets in full-screen terminal interface: " +
+		"search the secrets, view them with sensitive values masked until revealed, " +
+		"create, edit and delete secrets and copy their values to clipboard.\n" +
+		"The clipboard is cleared after the timeout (GOPH_CLIPBOARD_TIMEOUT) " +
+		"if it still holds the copied value.",
+	Args: cobra.NoArgs,
+	RunE: doTUI,
+}
+
+func init() {
+	tuiCmd.Flags().BoolVar(
+		&forceOSC52,
+		"osc52",
+		false,
+		"Use OSC 52 escape sequence even if clipboard tool is available, e.g. over SSH",
+	)
+
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func doTUI(cmd *cobra.Command, _args []string) error {
+	clientApp, err := app.FromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	err = tui.Run(
+		cmd.Context(),
+		clientApp.Usecases.Secrets,
+		clientApp.AccessToken,
+		cfg.BreachDB,
+		func(value, what string) (string, error) {
+			return copyFromTUI(cmd, value, what)
+		},
+	)
+	if err != nil {
+		clientApp.Log.Debug().Err(err).Msg("")
+
+		return err
+	}
+
+	return nil
+}
+
+// copyFromTUI puts the value into clipboard and schedules clearing of the clipboard.
+// Unlike copyToClipboard, nothing is logged to keep the screen intact,
+// result is described by the returned message.
+func copyFromTUI(cmd *cobra.Command, value, what string) (string, error) {
+	if !forceOSC52 {
+		if board, err := clipboard.Detect(); err == nil {
+			if err := board.Write(value); err != nil {
+				return "", err
+			}
+
+			if cfg.ClipboardTimeout <= 0 {
+				return fmt.Sprintf("Copied %s to clipboard", what), nil
+			}
+
+			if err := spawnClipboardClear(clipboard.Digest(value)); err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf(
+				"Copied %s to clipboard, it will be cleared in %s",
+				what,
+				cfg.ClipboardTimeout,
+			), nil
+		}
+	}
+
+	if err := clipboard.WriteOSC52(cmd.ErrOrStderr(), value); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Copied %s to clipboard of the terminal, clear it manually", what), nil
+}
@@ -0,0 +1,220 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/protobuf/proto"
+)
+
+// detailView shows data of a secret, sensitive values are masked unless revealed.
+type detailView struct {
+	secret *goph.Secret
+	labels *goph.Labels
+	kind   *entity.Kind
+	data   proto.Message
+	// fields are attributes having values, the cursor selects the one to copy.
+	fields  []*entity.Attribute
+	cursor  int
+	reveal  bool
+	confirm bool
+}
+
+func newDetailView(secret *goph.Secret, data proto.Message) (detailView, error) {
+	kind, err := entity.KindOf(secret.GetKind())
+	if err != nil {
+		return detailView{}, err
+	}
+
+	labels, err := entity.LabelsOf(secret)
+	if err != nil {
+		return detailView{}, err
+	}
+
+	v := detailView{secret: secret, labels: labels, kind: kind, data: data}
+
+	for i := range kind.Attributes {
+		attr := &kind.Attributes[i]
+		if attr.EditOnly || attr.Display(data, true) == "" {
+			continue
+		}
+
+		if attr.Primary {
+			v.cursor = len(v.fields)
+		}
+
+		v.fields = append(v.fields, attr)
+	}
+
+	return v, nil
+}
+
+// path returns full path to the secret.
+func (v *detailView) path() string {
+	return entity.SecretPath(v.secret, v.labels)
+}
+
+func (m *Model) updateDetail(msg tea.KeyMsg) tea.Cmd {
+	if m.detail.confirm {
+		m.detail.confirm = false
+
+		if msg.String() != "y" {
+			m.status = "Deletion cancelled"
+
+			return nil
+		}
+
+		id, err := idOf(m.detail.secret)
+		if err != nil {
+			m.err = err
+
+			return nil
+		}
+
+		m.loading = true
+
+		return m.deleteSecret(id, m.detail.path())
+	}
+
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+
+	case "esc", "backspace", "left", "h":
+		m.screen = screenList
+
+	case "up", "k":
+		if m.detail.cursor > 0 {
+			m.detail.cursor--
+		}
+
+	case "down", "j":
+		if m.detail.cursor < len(m.detail.fields)-1 {
+			m.detail.cursor++
+		}
+
+	case "r":
+		m.detail.reveal = !m.detail.reveal
+
+	case "c":
+		if len(m.detail.fields) == 0 {
+			m.err = errNothingToCopy
+
+			return nil
+		}
+
+		attr := m.detail.fields[m.detail.cursor]
+
+		return m.copyValue(attr.Get(m.detail.data), attr.Name+" of "+m.detail.secret.GetName())
+
+	case "e":
+		m.form = newEditForm(&m.detail)
+		m.screen = screenForm
+
+		return m.form.focus(0)
+
+	case "d":
+		m.detail.confirm = true
+	}
+
+	return nil
+}
+
+func (v *detailView) view(width int) string {
+	var b strings.Builder
+
+	b.WriteString(_titleStyle.Render(truncate(v.path(), width)))
+	b.WriteString("  ")
+	b.WriteString(_helpStyle.Render(v.kind.Title))
+	b.WriteString("\n\n")
+
+	writeValue(&b, "description", string(v.secret.GetMetadata()), false)
+	writeValue(&b, "tags", strings.Join(v.labels.GetTags(), ", "), false)
+	writeValue(
+		&b,
+		"updated",
+		v.secret.GetUpdatedAt().AsTime().Local().Format(entity.DateLayout),
+		false,
+	)
+
+	b.WriteString("\n")
+
+	for i, attr := range v.fields {
+		writeValue(&b, attr.Name, display(attr, v.data, v.reveal), i == v.cursor)
+	}
+
+	for _, table := range v.kind.Tables(v.data, v.reveal) {
+		b.WriteString("\n")
+		b.WriteString(renderTable(table))
+	}
+
+	for _, warning := range v.kind.Warnings(v.data, time.Now()) {
+		b.WriteString("\n")
+		b.WriteString(_warningStyle.Render("Warning: " + warning))
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (v *detailView) help() string {
+	reveal := "r: reveal"
+	if v.reveal {
+		reveal = "r: hide"
+	}
+
+	return "↑/↓: select • c: copy • " + reveal + " • e: edit • d: delete • esc: back • q: quit"
+}
+
+// display returns value of the attribute masked unless revealed.
+// Unlike pull, passwords are masked as well since the screen stays open for a while.
+func display(attr *entity.Attribute, data proto.Message, reveal bool) string {
+	if attr.Password && !reveal && attr.Get(data) != "" {
+		return entity.HiddenValue
+	}
+
+	return attr.Display(data, reveal)
+}
+
+// writeValue adds labeled value to the view, lines of multiline values are indented.
+func writeValue(b *strings.Builder, label, value string, selected bool) {
+	if value == "" {
+		return
+	}
+
+	style := _labelStyle
+	if selected {
+		style = _selectedLabelStyle
+	}
+
+	b.WriteString(style.Render(label + ":"))
+	b.WriteString(" ")
+	b.WriteString(strings.ReplaceAll(value, "\n", "\n  "))
+	b.WriteString("\n")
+}
+
+// renderTable aligns columns of the table.
+func renderTable(table entity.Table) string {
+	var buf bytes.Buffer
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(table.Header, "\t"))
+
+	for _, row := range table.Rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	w.Flush()
+
+	// NB (alkurbatov): The header is styled after alignment, escape sequences
+	// would break widths of the columns.
+	lines := strings.SplitN(strings.TrimRight(buf.String(), "\n"), "\n", 2)
+	lines[0] = _labelStyle.Render(lines[0])
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,575 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/cmdline/kindflags"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/passgen"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Positions of the fields common for all kinds.
+const (
+	_nameField = iota
+	_folderField
+	_descriptionField
+)
+
+const (
+	kindsHelp = "↑/↓: move • enter: select • esc: back"
+	formHelp  = "tab/↓: next • shift+tab/↑: previous • space: toggle • " +
+		"ctrl+g: generate password • ctrl+s: save • esc: cancel"
+)
+
+var (
+	errNameRequired  = errors.New("name of the secret is required")
+	errValueRequired = errors.New("value required")
+	errNoChanges     = errors.New("nothing changed")
+)
+
+// kindsView lets user pick kind of the new secret.
+type kindsView struct {
+	kinds  []*entity.Kind
+	cursor int
+}
+
+func newKindsView() kindsView {
+	return kindsView{kinds: entity.Kinds()}
+}
+
+func (m *Model) updateKinds(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "q":
+		m.screen = screenList
+
+	case "up", "k":
+		if m.kinds.cursor > 0 {
+			m.kinds.cursor--
+		}
+
+	case "down", "j":
+		if m.kinds.cursor < len(m.kinds.kinds)-1 {
+			m.kinds.cursor++
+		}
+
+	case "enter":
+		m.form = newCreateForm(m.kinds.kinds[m.kinds.cursor])
+		m.screen = screenForm
+
+		return m.form.focus(0)
+	}
+
+	return nil
+}
+
+func (v *kindsView) view() string {
+	var b strings.Builder
+
+	b.WriteString(_titleStyle.Render("Kind of the new secret"))
+	b.WriteString("\n")
+
+	for i, kind := range v.kinds {
+		row := fmt.Sprintf("%-*s  %s", _kindWidth, kind.Name, kind.Title)
+		if i == v.cursor {
+			row = _selectedStyle.Render(row)
+		}
+
+		b.WriteString("\n")
+		b.WriteString(row)
+	}
+
+	return b.String()
+}
+
+// formField is single input of the form.
+type formField struct {
+	label string
+	hint  string
+	// attr is nil for name, folder and description of the secret.
+	attr    *entity.Attribute
+	input   textinput.Model
+	checked bool
+	// initial values detect fields changed by user.
+	initial        string
+	initialChecked bool
+}
+
+// isBool reports whether the field is toggled instead of typed.
+func (f *formField) isBool() bool {
+	return f.attr != nil && f.attr.Type == entity.AttrBool
+}
+
+// formView creates new secret or edits existing one.
+type formView struct {
+	kind *entity.Kind
+	// secret and labels are nil on creation.
+	secret *goph.Secret
+	labels *goph.Labels
+	fields []formField
+	cursor int
+}
+
+// formRequest is the change of the secret requested by user.
+type formRequest struct {
+	name          string
+	folder        string
+	folderChanged bool
+	description   string
+	noDescription bool
+	changes       []entity.Change
+}
+
+func newCreateForm(kind *entity.Kind) formView {
+	f := formView{kind: kind}
+	f.addCommonFields("", "", "")
+
+	for i := range kind.Attributes {
+		attr := &kind.Attributes[i]
+		if attr.ReadOnly || attr.EditOnly {
+			continue
+		}
+
+		f.addAttribute(attr, "", attr.Required)
+	}
+
+	return f
+}
+
+func newEditForm(detail *detailView) formView {
+	f := formView{kind: detail.kind, secret: detail.secret, labels: detail.labels}
+	f.addCommonFields(
+		detail.secret.GetName(),
+		detail.labels.GetFolder(),
+		string(detail.secret.GetMetadata()),
+	)
+
+	for i := range f.kind.Attributes {
+		attr := &f.kind.Attributes[i]
+		if attr.ReadOnly {
+			continue
+		}
+
+		value := ""
+		if !attr.File && attr.Type != entity.AttrList {
+			value = attr.Get(detail.data)
+		}
+
+		f.addAttribute(attr, value, false)
+	}
+
+	return f
+}
+
+// addCommonFields adds fields available for secrets of all kinds.
+func (f *formView) addCommonFields(name, folder, description string) {
+	required := ""
+	if f.secret == nil {
+		required = "required"
+	}
+
+	f.addField(formField{label: "name", hint: required}, name)
+	f.addField(formField{label: "folder", hint: "e.g. work/aws"}, folder)
+	f.addField(formField{label: "description"}, description)
+}
+
+// addAttribute adds field of the data attribute.
+func (f *formView) addAttribute(attr *entity.Attribute, value string, required bool) {
+	hints := make([]string, 0)
+
+	if required {
+		hints = append(hints, "required")
+	}
+
+	if attr.File {
+		hints = append(hints, "path to file")
+	}
+
+	if attr.Type == entity.AttrList {
+		if f.secret == nil {
+			hints = append(hints, "comma-separated")
+		} else {
+			hints = append(hints, "comma-separated, added to existing")
+		}
+	}
+
+	if attr.Generated {
+		hints = append(hints, "ctrl+g to generate")
+	}
+
+	field := formField{label: attr.Name, hint: strings.Join(hints, ", "), attr: attr}
+
+	if field.isBool() {
+		field.checked, field.initialChecked = value == "true", value == "true"
+	}
+
+	f.addField(field, value)
+
+	input := &f.fields[len(f.fields)-1].input
+	input.Placeholder = attr.Usage
+
+	if attr.Prompted() {
+		input.EchoMode = textinput.EchoPassword
+	}
+}
+
+func (f *formView) addField(field formField, value string) {
+	field.input = textinput.New()
+	field.input.Prompt = ""
+	field.input.SetValue(value)
+	field.initial = value
+
+	f.fields = append(f.fields, field)
+}
+
+// focus moves input focus to the field.
+func (f *formView) focus(i int) tea.Cmd {
+	if i < 0 || i >= len(f.fields) {
+		return nil
+	}
+
+	f.fields[f.cursor].input.Blur()
+	f.cursor = i
+
+	return f.fields[i].input.Focus()
+}
+
+func (m *Model) updateForm(msg tea.KeyMsg) tea.Cmd {
+	field := &m.form.fields[m.form.cursor]
+
+	switch msg.String() {
+	case "esc":
+		m.screen = screenList
+		if m.form.secret != nil {
+			m.screen = screenDetail
+		}
+
+		return nil
+
+	case "tab", "down":
+		return m.form.focus(m.form.cursor + 1)
+
+	case "shift+tab", "up":
+		return m.form.focus(m.form.cursor - 1)
+
+	case "enter":
+		if m.form.cursor < len(m.form.fields)-1 {
+			return m.form.focus(m.form.cursor + 1)
+		}
+
+		return m.saveForm()
+
+	case "ctrl+s":
+		return m.saveForm()
+
+	case "ctrl+g":
+		if field.attr == nil || !field.attr.Generated {
+			return nil
+		}
+
+		spec, err := passgen.Preset(passgen.DefaultPreset)
+		if err != nil {
+			m.err = err
+
+			return nil
+		}
+
+		password, err := passgen.Generate(spec)
+		if err != nil {
+			m.err = err
+
+			return nil
+		}
+
+		field.input.SetValue(password.Value)
+		m.status = fmt.Sprintf(
+			"Generated %s with %.1f bits of entropy (%s)",
+			field.attr.Name,
+			password.Entropy,
+			passgen.Strength(password.Entropy),
+		)
+
+		return nil
+
+	case " ":
+		if field.isBool() {
+			field.checked = !field.checked
+
+			return nil
+		}
+	}
+
+	if field.isBool() {
+		return nil
+	}
+
+	var cmd tea.Cmd
+
+	field.input, cmd = field.input.Update(msg)
+
+	return cmd
+}
+
+// saveForm validates the form and requests creation or update of the secret.
+func (m *Model) saveForm() tea.Cmd {
+	req, err := m.form.request()
+	if err != nil {
+		if errors.Is(err, errNoChanges) {
+			m.status = "Nothing to save"
+			m.screen = screenDetail
+
+			return nil
+		}
+
+		m.err = entity.Unwrap(err)
+
+		return nil
+	}
+
+	m.loading = true
+
+	if m.form.secret == nil {
+		return m.createSecret(m.form.kind, &req)
+	}
+
+	id, err := idOf(m.form.secret)
+	if err != nil {
+		m.loading = false
+		m.err = err
+
+		return nil
+	}
+
+	return m.editSecret(id, m.form.kind, &req)
+}
+
+// createSecret requests creation of new secret of the kind.
+func (m *Model) createSecret(kind *entity.Kind, req *formRequest) tea.Cmd {
+	ctx, secrets, token, breachDB := m.ctx, m.secrets, m.token, m.breachDB
+
+	return func() tea.Msg {
+		if err := kindflags.CheckBreached(kind, req.changes, breachDB); err != nil {
+			return errMsg{err}
+		}
+
+		data := kind.New()
+		if err := kind.Apply(data, req.changes); err != nil {
+			return errMsg{err}
+		}
+
+		id, err := secrets.Push(
+			ctx,
+			token,
+			req.name,
+			req.description,
+			&goph.Labels{Folder: req.folder},
+			data,
+		)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return savedMsg{id, req.name}
+	}
+}
+
+// editSecret requests update of the secret, the secret is moved if its folder is changed.
+func (m *Model) editSecret(id uuid.UUID, kind *entity.Kind, req *formRequest) tea.Cmd {
+	ctx, secrets, token, breachDB := m.ctx, m.secrets, m.token, m.breachDB
+	name := m.form.secret.GetName()
+
+	return func() tea.Msg {
+		if err := kindflags.CheckBreached(kind, req.changes, breachDB); err != nil {
+			return errMsg{err}
+		}
+
+		if req.name != "" || req.description != "" || req.noDescription || len(req.changes) > 0 {
+			if err := secrets.Edit(
+				ctx,
+				token,
+				id,
+				req.name,
+				req.description,
+				req.noDescription,
+				kind.DataKind,
+				req.changes,
+			); err != nil {
+				return errMsg{err}
+			}
+		}
+
+		if req.folderChanged {
+			if err := secrets.Move(ctx, token, id, req.folder); err != nil {
+				return errMsg{err}
+			}
+		}
+
+		if req.name != "" {
+			name = req.name
+		}
+
+		return savedMsg{id, name}
+	}
+}
+
+// request collects values of the form.
+// On creation all values are taken, on editing only the changed ones.
+func (f *formView) request() (formRequest, error) {
+	var (
+		req formRequest
+		err error
+	)
+
+	creation := f.secret == nil
+
+	name := strings.TrimSpace(f.fields[_nameField].input.Value())
+	if name == "" {
+		return req, entity.NewValidationError(errNameRequired)
+	}
+
+	if creation || f.fields[_nameField].changed() {
+		req.name = name
+	}
+
+	req.folder, err = entity.NormalizeFolder(f.fields[_folderField].input.Value())
+	if err != nil {
+		return req, err
+	}
+
+	req.folderChanged = !creation && req.folder != f.labels.GetFolder()
+
+	if description := f.fields[_descriptionField]; creation || description.changed() {
+		req.description = description.input.Value()
+		req.noDescription = !creation && req.description == ""
+	}
+
+	for i := _descriptionField + 1; i < len(f.fields); i++ {
+		changes, err := f.fields[i].changes(creation)
+		if err != nil {
+			return req, err
+		}
+
+		req.changes = append(req.changes, changes...)
+	}
+
+	if !creation && req.name == "" && req.description == "" && !req.noDescription &&
+		!req.folderChanged && len(req.changes) == 0 {
+		return req, errNoChanges
+	}
+
+	return req, nil
+}
+
+// changed reports whether user modified the field.
+func (f *formField) changed() bool {
+	if f.isBool() {
+		return f.checked != f.initialChecked
+	}
+
+	return f.input.Value() != f.initial
+}
+
+// changes converts value of the attribute field into changes of the secret data.
+func (f *formField) changes(creation bool) ([]entity.Change, error) {
+	value := f.input.Value()
+
+	switch {
+	case f.isBool():
+		if !f.changed() {
+			return nil, nil
+		}
+
+		return []entity.Change{{Attribute: f.attr.Name, Value: strconv.FormatBool(f.checked)}}, nil
+
+	case value == "":
+		if creation && f.attr.Required {
+			return nil, entity.NewValidationError(fmt.Errorf("%w: %s", errValueRequired, f.label))
+		}
+
+		if creation || !f.changed() || f.attr.File || f.attr.Type == entity.AttrList {
+			return nil, nil
+		}
+
+	case !creation && !f.changed():
+		return nil, nil
+	}
+
+	if f.attr.File && value != "" {
+		data, err := os.ReadFile(value)
+		if err != nil {
+			return nil, err
+		}
+
+		value = string(data)
+	}
+
+	if f.attr.Type != entity.AttrList {
+		return []entity.Change{{Attribute: f.attr.Name, Value: value}}, nil
+	}
+
+	rv := make([]entity.Change, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			rv = append(rv, entity.Change{Attribute: f.attr.Name, Value: item})
+		}
+	}
+
+	return rv, nil
+}
+
+func (f *formView) view(width int) string {
+	var b strings.Builder
+
+	title := "New " + f.kind.Title
+	if f.secret != nil {
+		title = "Edit " + entity.SecretPath(f.secret, f.labels)
+	}
+
+	b.WriteString(_titleStyle.Render(truncate(title, width)))
+	b.WriteString("\n")
+
+	labelWidth := 0
+
+	for i := range f.fields {
+		if n := len(f.fields[i].label); n > labelWidth {
+			labelWidth = n
+		}
+	}
+
+	for i := range f.fields {
+		field := &f.fields[i]
+
+		style := _labelStyle
+		if i == f.cursor {
+			style = _selectedLabelStyle
+		}
+
+		b.WriteString("\n")
+		b.WriteString(style.Render(fmt.Sprintf("%*s", labelWidth, field.label)))
+		b.WriteString(" ")
+
+		if field.isBool() {
+			if field.checked {
+				b.WriteString("[x]")
+			} else {
+				b.WriteString("[ ]")
+			}
+		} else {
+			b.WriteString(field.input.View())
+		}
+
+		if field.hint != "" {
+			b.WriteString(_helpStyle.Render("  (" + field.hint + ")"))
+		}
+	}
+
+	return b.String()
+}
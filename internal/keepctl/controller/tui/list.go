@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// _kindWidth is width of the kind column of the list.
+	_kindWidth = 8
+	// _dateWidth is width of the column holding date of the last change.
+	_dateWidth = len(entity.DateLayout)
+	// _minPathWidth is minimal width of the path column.
+	_minPathWidth = 10
+)
+
+// listView is list of secrets filtered by the search text.
+type listView struct {
+	all       []entity.SearchResult
+	shown     []entity.SearchResult
+	cursor    int
+	offset    int
+	search    textinput.Model
+	searching bool
+}
+
+func newListView() listView {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search by name, folder, tag or description"
+
+	return listView{search: search}
+}
+
+// setResults replaces the list of secrets and applies the search text to it.
+func (v *listView) setResults(results []entity.SearchResult, page int) {
+	v.all = results
+	v.filter(page)
+}
+
+// filter shows secrets matching the search text, the best matches go first.
+func (v *listView) filter(page int) {
+	query := entity.SearchQuery{Text: strings.TrimSpace(v.search.Value())}
+	shown := make([]entity.SearchResult, 0, len(v.all))
+
+	for _, result := range v.all {
+		field, score, ok := query.Match(entity.SearchFields(result.Secret, result.Labels))
+		if !ok {
+			continue
+		}
+
+		result.Field, result.Score = field, score
+		shown = append(shown, result)
+	}
+
+	entity.SortSearchResults(shown)
+
+	v.shown = shown
+	v.move(0, page)
+}
+
+// move shifts the cursor and scrolls the list to keep the cursor visible.
+func (v *listView) move(delta, page int) {
+	v.cursor += delta
+
+	if v.cursor >= len(v.shown) {
+		v.cursor = len(v.shown) - 1
+	}
+
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+
+	if v.cursor >= v.offset+page {
+		v.offset = v.cursor - page + 1
+	}
+}
+
+// selected returns the secret under the cursor, nil if the list is empty.
+func (v *listView) selected() *entity.SearchResult {
+	if len(v.shown) == 0 {
+		return nil
+	}
+
+	return &v.shown[v.cursor]
+}
+
+func (m *Model) updateList(msg tea.KeyMsg) tea.Cmd {
+	page := m.pageSize()
+
+	if m.list.searching {
+		switch msg.Type { //nolint:exhaustive // other keys are passed to the input
+		case tea.KeyEnter:
+			m.list.searching = false
+			m.list.search.Blur()
+
+			return nil
+
+		case tea.KeyEsc:
+			m.list.searching = false
+			m.list.search.Blur()
+			m.list.search.SetValue("")
+			m.list.filter(page)
+
+			return nil
+		}
+
+		var cmd tea.Cmd
+
+		m.list.search, cmd = m.list.search.Update(msg)
+		m.list.filter(page)
+
+		return cmd
+	}
+
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+
+	case "up", "k":
+		m.list.move(-1, page)
+
+	case "down", "j":
+		m.list.move(1, page)
+
+	case "pgup":
+		m.list.move(-page, page)
+
+	case "pgdown":
+		m.list.move(page, page)
+
+	case "home", "g":
+		m.list.move(-len(m.list.shown), page)
+
+	case "end", "G":
+		m.list.move(len(m.list.shown), page)
+
+	case "/":
+		m.list.searching = true
+
+		return m.list.search.Focus()
+
+	case "esc":
+		m.list.search.SetValue("")
+		m.list.filter(page)
+
+	case "r":
+		m.loading = true
+
+		return m.loadSecrets()
+
+	case "n":
+		m.kinds = newKindsView()
+		m.screen = screenKinds
+
+	case "enter":
+		result := m.list.selected()
+		if result == nil {
+			return nil
+		}
+
+		id, err := idOf(result.Secret)
+		if err != nil {
+			m.err = err
+
+			return nil
+		}
+
+		m.loading = true
+
+		return m.loadSecret(id)
+	}
+
+	return nil
+}
+
+func (v *listView) view(width, page int) string {
+	var b strings.Builder
+
+	b.WriteString(_titleStyle.Render(
+		fmt.Sprintf("GophKeeper: %d of %d secrets", len(v.shown), len(v.all)),
+	))
+	b.WriteString("\n")
+
+	if v.searching || v.search.Value() != "" {
+		b.WriteString(v.search.View())
+	}
+
+	b.WriteString("\n")
+
+	if len(v.shown) == 0 {
+		b.WriteString(_helpStyle.Render("No secrets found, press n to create one"))
+
+		return b.String()
+	}
+
+	pathWidth := width - _kindWidth - _dateWidth - 4
+	if pathWidth < _minPathWidth {
+		pathWidth = _minPathWidth
+	}
+
+	end := v.offset + page
+	if end > len(v.shown) {
+		end = len(v.shown)
+	}
+
+	for i := v.offset; i < end; i++ {
+		result := &v.shown[i]
+
+		kind := result.Secret.GetKind().String()
+		if k, err := entity.KindOf(result.Secret.GetKind()); err == nil {
+			kind = k.Name
+		}
+
+		row := fmt.Sprintf(
+			"%-*s  %-*s  %s",
+			pathWidth,
+			truncate(entity.SecretPath(result.Secret, result.Labels), pathWidth),
+			_kindWidth,
+			kind,
+			result.Secret.GetUpdatedAt().AsTime().Local().Format(entity.DateLayout),
+		)
+
+		if i == v.cursor {
+			row = _selectedStyle.Render(row)
+		}
+
+		b.WriteString(row)
+
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+func (v *listView) help() string {
+	if v.searching {
+		return "enter: keep filter • esc: clear filter"
+	}
+
+	return "↑/↓: move • enter: open • /: search • n: new • r: reload • q: quit"
+}
@@ -0,0 +1,14 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	_titleStyle         = lipgloss.NewStyle().Bold(true)
+	_selectedStyle      = lipgloss.NewStyle().Reverse(true)
+	_labelStyle         = lipgloss.NewStyle().Bold(true)
+	_selectedLabelStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+	_helpStyle          = lipgloss.NewStyle().Faint(true)
+	_errorStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	_warningStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	_statusStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+)
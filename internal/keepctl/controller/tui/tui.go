@@ -0,0 +1,365 @@
+// Package tui implements full-screen terminal interface of keepctl
+// on top of the secrets use case.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/usecase"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	tea "github.com/charmbracelet/bubbletea"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// _reservedLines is number of lines occupied by header and footer of the screens.
+const _reservedLines = 5
+
+var errNothingToCopy = errors.New("nothing to copy, the value is empty")
+
+// CopyFunc puts the value into clipboard, what describes the value, e.g. "password of github".
+// Returns message reported to user.
+type CopyFunc func(value, what string) (string, error)
+
+// screen identifies view currently shown to user.
+type screen int
+
+const (
+	screenList screen = iota
+	screenDetail
+	screenKinds
+	screenForm
+)
+
+// Messages produced by commands accessing the keeper service.
+type (
+	secretsLoadedMsg struct {
+		results []entity.SearchResult
+	}
+
+	secretLoadedMsg struct {
+		secret *goph.Secret
+		data   proto.Message
+	}
+
+	savedMsg struct {
+		id   uuid.UUID
+		name string
+	}
+
+	deletedMsg struct {
+		name string
+	}
+
+	statusMsg string
+
+	errMsg struct {
+		err error
+	}
+)
+
+// Model is state of the terminal interface, implements tea.Model.
+type Model struct {
+	ctx     context.Context
+	secrets usecase.Secrets
+	token   string
+	// breachDB is path to local copy of the Pwned Passwords database, new passwords
+	// are checked against it if set.
+	breachDB string
+	copy     CopyFunc
+
+	screen screen
+	width  int
+	height int
+
+	list   listView
+	detail detailView
+	kinds  kindsView
+	form   formView
+
+	loading bool
+	status  string
+	err     error
+}
+
+// New creates model of the terminal interface operating secrets of the user.
+func New(
+	ctx context.Context,
+	secrets usecase.Secrets,
+	token, breachDB string,
+	copyFn CopyFunc,
+) *Model {
+	return &Model{
+		ctx:      ctx,
+		secrets:  secrets,
+		token:    token,
+		breachDB: breachDB,
+		copy:     copyFn,
+		list:     newListView(),
+		loading:  true,
+	}
+}
+
+// Run shows the terminal interface until user quits.
+func Run(
+	ctx context.Context,
+	secrets usecase.Secrets,
+	token, breachDB string,
+	copyFn CopyFunc,
+) error {
+	p := tea.NewProgram(
+		New(ctx, secrets, token, breachDB, copyFn),
+		tea.WithAltScreen(),
+		tea.WithContext(ctx),
+	)
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("tui - Run - p.Run: %w", err)
+	}
+
+	return nil
+}
+
+// Init requests list of secrets.
+func (m *Model) Init() tea.Cmd {
+	return m.loadSecrets()
+}
+
+// Update handles keys and results of the commands.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		m.status, m.err = "", nil
+
+		return m, m.handleKey(msg)
+
+	case secretsLoadedMsg:
+		m.loading = false
+		m.list.setResults(msg.results, m.pageSize())
+
+		return m, nil
+
+	case secretLoadedMsg:
+		m.loading = false
+
+		detail, err := newDetailView(msg.secret, msg.data)
+		if err != nil {
+			m.err = err
+
+			return m, nil
+		}
+
+		m.detail = detail
+		m.screen = screenDetail
+
+		return m, nil
+
+	case savedMsg:
+		m.status = "Saved " + msg.name
+
+		return m, tea.Batch(m.loadSecrets(), m.loadSecret(msg.id))
+
+	case deletedMsg:
+		m.status = "Deleted " + msg.name
+		m.screen = screenList
+
+		return m, m.loadSecrets()
+
+	case statusMsg:
+		m.status = string(msg)
+
+		return m, nil
+
+	case errMsg:
+		m.loading = false
+		m.err = entity.Unwrap(msg.err)
+
+		return m, nil
+	}
+
+	// NB (alkurbatov): Pass other messages, e.g. cursor blinking, to the focused input.
+	return m, m.updateInput(msg)
+}
+
+// handleKey passes the key to the current screen.
+func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch m.screen {
+	case screenDetail:
+		return m.updateDetail(msg)
+
+	case screenKinds:
+		return m.updateKinds(msg)
+
+	case screenForm:
+		return m.updateForm(msg)
+
+	default:
+		return m.updateList(msg)
+	}
+}
+
+// updateInput passes the message to the text input focused on the current screen.
+func (m *Model) updateInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch {
+	case m.screen == screenList && m.list.searching:
+		m.list.search, cmd = m.list.search.Update(msg)
+
+	case m.screen == screenForm && len(m.form.fields) > 0:
+		field := &m.form.fields[m.form.cursor]
+		field.input, cmd = field.input.Update(msg)
+	}
+
+	return cmd
+}
+
+// View renders the current screen along with status and help lines.
+func (m *Model) View() string {
+	var body, help string
+
+	switch m.screen {
+	case screenDetail:
+		body, help = m.detail.view(m.width), m.detail.help()
+
+	case screenKinds:
+		body, help = m.kinds.view(), kindsHelp
+
+	case screenForm:
+		body, help = m.form.view(m.width), formHelp
+
+	default:
+		body, help = m.list.view(m.width, m.pageSize()), m.list.help()
+	}
+
+	var b strings.Builder
+
+	b.WriteString(body)
+	b.WriteString("\n\n")
+
+	switch {
+	case m.err != nil:
+		b.WriteString(_errorStyle.Render("Error: " + m.err.Error()))
+
+	case m.screen == screenDetail && m.detail.confirm:
+		b.WriteString(_warningStyle.Render(
+			"Delete " + m.detail.path() + " and files attached to it? [y/N]",
+		))
+
+	case m.loading:
+		b.WriteString(_helpStyle.Render("Loading..."))
+
+	default:
+		b.WriteString(_statusStyle.Render(m.status))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(_helpStyle.Render(help))
+
+	return b.String()
+}
+
+// pageSize returns number of list rows fitting the screen.
+func (m *Model) pageSize() int {
+	if rows := m.height - _reservedLines; rows > 0 {
+		return rows
+	}
+
+	return 1
+}
+
+// loadSecrets requests list of all secrets of the user.
+func (m *Model) loadSecrets() tea.Cmd {
+	ctx, secrets, token := m.ctx, m.secrets, m.token
+
+	return func() tea.Msg {
+		results, err := secrets.Search(ctx, token, entity.SearchQuery{})
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return secretsLoadedMsg{results}
+	}
+}
+
+// loadSecret requests full secret including its data.
+func (m *Model) loadSecret(id uuid.UUID) tea.Cmd {
+	ctx, secrets, token := m.ctx, m.secrets, m.token
+
+	return func() tea.Msg {
+		secret, data, err := secrets.Get(ctx, token, id)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return secretLoadedMsg{secret, data}
+	}
+}
+
+// deleteSecret requests removal of the secret.
+func (m *Model) deleteSecret(id uuid.UUID, name string) tea.Cmd {
+	ctx, secrets, token := m.ctx, m.secrets, m.token
+
+	return func() tea.Msg {
+		if err := secrets.Delete(ctx, token, id); err != nil {
+			return errMsg{err}
+		}
+
+		return deletedMsg{name}
+	}
+}
+
+// copyValue puts the value into clipboard.
+func (m *Model) copyValue(value, what string) tea.Cmd {
+	copyFn := m.copy
+
+	return func() tea.Msg {
+		if value == "" {
+			return errMsg{errNothingToCopy}
+		}
+
+		status, err := copyFn(value, what)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return statusMsg(status)
+	}
+}
+
+// idOf extracts ID of the secret.
+func idOf(secret *goph.Secret) (uuid.UUID, error) {
+	id, err := uuid.FromString(secret.GetId())
+	if err != nil {
+		return id, fmt.Errorf("tui - idOf - uuid.FromString: %w", err)
+	}
+
+	return id, nil
+}
+
+// truncate shortens the text to the width, the cut is marked with ellipsis.
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if width <= 0 || len(runes) <= width {
+		return text
+	}
+
+	if width == 1 {
+		return "…"
+	}
+
+	return string(runes[:width-1]) + "…"
+}
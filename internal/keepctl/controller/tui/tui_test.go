@@ -0,0 +1,367 @@
+package tui_test
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // HIBP database consists of SHA-1 hashes
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/controller/tui"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/usecase"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	tea "github.com/charmbracelet/bubbletea"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const _password = "k#9Vq!mZ2@wLr8&T"
+
+// _cmdTimeout limits execution of commands, e.g. blinking of cursor is never awaited.
+const _cmdTimeout = 100 * time.Millisecond
+
+type fakeSecrets struct {
+	usecase.Secrets
+
+	secrets map[uuid.UUID]*goph.Secret
+	data    map[uuid.UUID]proto.Message
+
+	pushedName string
+	pushed     proto.Message
+	edited     []entity.Change
+	moved      string
+	deleted    []uuid.UUID
+}
+
+func newFakeSecrets(t *testing.T) *fakeSecrets {
+	t.Helper()
+
+	f := &fakeSecrets{
+		secrets: make(map[uuid.UUID]*goph.Secret),
+		data:    make(map[uuid.UUID]proto.Message),
+	}
+
+	f.add(t, "github", "dev", &goph.Credentials{Login: "octocat", Password: _password})
+	f.add(t, "bank", "", &goph.Text{Text: "account 42"})
+
+	return f
+}
+
+func (f *fakeSecrets) add(t *testing.T, name, folder string, data proto.Message) {
+	t.Helper()
+
+	labels, err := proto.Marshal(&goph.Labels{Folder: folder})
+	require.NoError(t, err)
+
+	kind, err := entity.KindOfMessage(data)
+	require.NoError(t, err)
+
+	id := uuid.NewV4()
+	f.secrets[id] = &goph.Secret{
+		Id:        id.String(),
+		Name:      name,
+		Kind:      kind.DataKind,
+		Labels:    labels,
+		UpdatedAt: timestamppb.Now(),
+	}
+	f.data[id] = data
+}
+
+func (f *fakeSecrets) Search(
+	_ context.Context,
+	_ string,
+	_ entity.SearchQuery,
+) ([]entity.SearchResult, error) {
+	rv := make([]entity.SearchResult, 0, len(f.secrets))
+
+	for _, secret := range f.secrets {
+		labels, err := entity.LabelsOf(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		rv = append(rv, entity.SearchResult{Secret: secret, Labels: labels})
+	}
+
+	entity.SortSearchResults(rv)
+
+	return rv, nil
+}
+
+func (f *fakeSecrets) Get(
+	_ context.Context,
+	_ string,
+	id uuid.UUID,
+) (*goph.Secret, proto.Message, error) {
+	return f.secrets[id], f.data[id], nil
+}
+
+func (f *fakeSecrets) Push(
+	_ context.Context,
+	_, name, _ string,
+	_ *goph.Labels,
+	data proto.Message,
+) (uuid.UUID, error) {
+	f.pushedName, f.pushed = name, data
+
+	id := uuid.NewV4()
+	f.secrets[id] = &goph.Secret{Id: id.String(), Name: name, Kind: goph.DataKind_CREDENTIALS}
+	f.data[id] = data
+
+	return id, nil
+}
+
+func (f *fakeSecrets) Edit(
+	_ context.Context,
+	_ string,
+	_ uuid.UUID,
+	_, _ string,
+	_ bool,
+	_ goph.DataKind,
+	changes []entity.Change,
+) error {
+	f.edited = changes
+
+	return nil
+}
+
+func (f *fakeSecrets) Move(_ context.Context, _ string, _ uuid.UUID, folder string) error {
+	f.moved = folder
+
+	return nil
+}
+
+func (f *fakeSecrets) Delete(_ context.Context, _ string, id uuid.UUID) error {
+	f.deleted = append(f.deleted, id)
+
+	return nil
+}
+
+// driver feeds the model with keys and results of the commands.
+type driver struct {
+	t      *testing.T
+	model  *tui.Model
+	copied []string
+}
+
+func newDriver(t *testing.T, secrets usecase.Secrets) *driver {
+	t.Helper()
+
+	return newDriverWithBreachDB(t, secrets, "")
+}
+
+func newDriverWithBreachDB(t *testing.T, secrets usecase.Secrets, breachDB string) *driver {
+	t.Helper()
+
+	d := &driver{t: t}
+	copyFn := func(value, what string) (string, error) {
+		d.copied = append(d.copied, value)
+
+		return "Copied " + what, nil
+	}
+
+	d.model = tui.New(context.Background(), secrets, "token", breachDB, copyFn)
+
+	d.send(tea.WindowSizeMsg{Width: 100, Height: 30})
+	d.run(d.model.Init())
+
+	return d
+}
+
+func (d *driver) send(msg tea.Msg) {
+	d.t.Helper()
+
+	_, cmd := d.model.Update(msg)
+	d.run(cmd)
+}
+
+func (d *driver) run(cmd tea.Cmd) {
+	d.t.Helper()
+
+	if cmd == nil {
+		return
+	}
+
+	done := make(chan tea.Msg, 1)
+
+	go func() { done <- cmd() }()
+
+	var msg tea.Msg
+
+	select {
+	case msg = <-done:
+	case <-time.After(_cmdTimeout):
+		return
+	}
+
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			d.run(c)
+		}
+
+		return
+	}
+
+	if msg != nil {
+		d.send(msg)
+	}
+}
+
+func (d *driver) keys(keys ...string) {
+	d.t.Helper()
+
+	for _, key := range keys {
+		switch key {
+		case "enter":
+			d.send(tea.KeyMsg{Type: tea.KeyEnter})
+		case "esc":
+			d.send(tea.KeyMsg{Type: tea.KeyEsc})
+		case "tab":
+			d.send(tea.KeyMsg{Type: tea.KeyTab})
+		case "down":
+			d.send(tea.KeyMsg{Type: tea.KeyDown})
+		case "ctrl+s":
+			d.send(tea.KeyMsg{Type: tea.KeyCtrlS})
+		case "backspace":
+			d.send(tea.KeyMsg{Type: tea.KeyBackspace})
+		default:
+			d.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+	}
+}
+
+func TestListAndSearch(t *testing.T) {
+	d := newDriver(t, newFakeSecrets(t))
+
+	view := d.model.View()
+	require.Contains(t, view, "/dev/github")
+	require.Contains(t, view, "/bank")
+
+	d.keys("/", "git")
+
+	view = d.model.View()
+	require.Contains(t, view, "/dev/github")
+	require.NotContains(t, view, "/bank")
+
+	d.keys("esc")
+
+	require.Contains(t, d.model.View(), "/bank")
+}
+
+func TestDetailRevealAndCopy(t *testing.T) {
+	d := newDriver(t, newFakeSecrets(t))
+
+	d.keys("/", "github", "enter", "enter")
+
+	view := d.model.View()
+	require.Contains(t, view, "octocat")
+	require.Contains(t, view, entity.HiddenValue)
+	require.NotContains(t, view, _password)
+
+	d.keys("r")
+
+	require.Contains(t, d.model.View(), _password)
+
+	d.keys("c")
+
+	require.Equal(t, []string{_password}, d.copied)
+	require.Contains(t, d.model.View(), "Copied password of github")
+}
+
+func TestDeleteWithConfirmation(t *testing.T) {
+	secrets := newFakeSecrets(t)
+	d := newDriver(t, secrets)
+
+	d.keys("/", "bank", "enter", "enter", "d")
+
+	require.Contains(t, d.model.View(), "Delete /bank")
+
+	d.keys("n")
+
+	require.Empty(t, secrets.deleted)
+	require.Contains(t, d.model.View(), "Deletion cancelled")
+
+	d.keys("d", "y")
+
+	require.Len(t, secrets.deleted, 1)
+	require.Contains(t, d.model.View(), "Deleted /bank")
+}
+
+func TestCreateCredentials(t *testing.T) {
+	secrets := newFakeSecrets(t)
+	d := newDriver(t, secrets)
+
+	d.keys("n")
+
+	for _, kind := range entity.Kinds() {
+		if kind.DataKind == goph.DataKind_CREDENTIALS {
+			break
+		}
+
+		d.keys("down")
+	}
+
+	d.keys("enter", "ctrl+s")
+
+	require.Contains(t, d.model.View(), "name of the secret is required")
+	require.Nil(t, secrets.pushed)
+
+	// name, folder, description, login, password
+	d.keys("gitlab", "tab", "tab", "tab", "tanuki", "tab", _password, "ctrl+s")
+
+	require.Equal(t, "gitlab", secrets.pushedName)
+	require.IsType(t, &goph.Credentials{}, secrets.pushed)
+	require.Equal(t, "tanuki", secrets.pushed.(*goph.Credentials).GetLogin())
+	require.Equal(t, _password, secrets.pushed.(*goph.Credentials).GetPassword())
+	require.Contains(t, d.model.View(), "Saved gitlab")
+}
+
+func TestCreateBreachedCredentials(t *testing.T) {
+	digest := sha1.Sum([]byte(_password)) //nolint:gosec // HIBP database consists of SHA-1 hashes
+	line := strings.ToUpper(hex.EncodeToString(digest[:])) + ":42\r\n"
+
+	breachDB := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	require.NoError(t, os.WriteFile(breachDB, []byte(line), 0o600))
+
+	secrets := newFakeSecrets(t)
+	d := newDriverWithBreachDB(t, secrets, breachDB)
+
+	d.keys("n")
+
+	for _, kind := range entity.Kinds() {
+		if kind.DataKind == goph.DataKind_CREDENTIALS {
+			break
+		}
+
+		d.keys("down")
+	}
+
+	// name, folder, description, login, password
+	d.keys("enter", "gitlab", "tab", "tab", "tab", "tanuki", "tab", _password, "ctrl+s")
+
+	require.Nil(t, secrets.pushed)
+	require.Contains(t, d.model.View(), "password appeared in data breaches")
+}
+
+func TestEditChangedFieldsOnly(t *testing.T) {
+	secrets := newFakeSecrets(t)
+	d := newDriver(t, secrets)
+
+	d.keys("/", "github", "enter", "enter", "e", "ctrl+s")
+
+	require.Contains(t, d.model.View(), "Nothing to save")
+	require.Nil(t, secrets.edited)
+
+	// name, folder, description, login
+	d.keys("e", "tab", "backspace", "backspace", "backspace", "work")
+	d.keys("tab", "tab", "-bot", "ctrl+s")
+
+	require.Equal(t, []entity.Change{{Attribute: "login", Value: "octocat-bot"}}, secrets.edited)
+	require.Equal(t, "work", secrets.moved)
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+// Attachment is a file attached to a secret accompanied with its decrypted info.
+// Content is set only if the attachment was downloaded.
+type Attachment struct {
+	ID       string
+	SecretID string
+	Info     *goph.AttachmentInfo
+	Content  []byte
+}
+
+// DetectMIMEType guesses MIME type of the file by extension and, as fallback, by content.
+func DetectMIMEType(filename string, content []byte) string {
+	if rv := mime.TypeByExtension(filepath.Ext(filename)); rv != "" {
+		return rv
+	}
+
+	return http.DetectContentType(content)
+}
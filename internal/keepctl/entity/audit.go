@@ -0,0 +1,288 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/passgen"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+// AuditCheck is a kind of problem looked for by the vault audit.
+type AuditCheck int
+
+const (
+	// AuditReused reports credentials sharing the same password.
+	AuditReused AuditCheck = iota
+	// AuditWeak reports common passwords and passwords with low estimated entropy.
+	AuditWeak
+	// AuditOld reports passwords unchanged for a long time.
+	AuditOld
+	// AuditExpiring reports expired cards and cards expiring soon.
+	AuditExpiring
+	// AuditNoTOTP reports credentials without TOTP secret.
+	AuditNoTOTP
+)
+
+var _auditChecks = []struct {
+	check AuditCheck
+	name  string
+}{
+	{AuditReused, "reused"},
+	{AuditWeak, "weak"},
+	{AuditOld, "old"},
+	{AuditExpiring, "expiring"},
+	{AuditNoTOTP, "no-totp"},
+}
+
+// String returns name of the check.
+func (c AuditCheck) String() string {
+	for _, v := range _auditChecks {
+		if v.check == c {
+			return v.name
+		}
+	}
+
+	return fmt.Sprintf("AuditCheck(%d)", int(c))
+}
+
+// AuditChecks returns names of all the checks.
+func AuditChecks() []string {
+	rv := make([]string, 0, len(_auditChecks))
+	for _, v := range _auditChecks {
+		rv = append(rv, v.name)
+	}
+
+	return rv
+}
+
+// ParseAuditChecks converts names of the checks into AuditCheck values.
+func ParseAuditChecks(names []string) ([]AuditCheck, error) {
+	rv := make([]AuditCheck, 0, len(names))
+
+	for _, name := range names {
+		found := false
+
+		for _, v := range _auditChecks {
+			if v.name == name {
+				rv = append(rv, v.check)
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return nil, NewValidationError(fmt.Errorf(
+				"%w %q: should be one of %s",
+				ErrBadAttribute,
+				name,
+				strings.Join(AuditChecks(), ", "),
+			))
+		}
+	}
+
+	return rv, nil
+}
+
+// AuditPolicy defines thresholds of the vault audit.
+type AuditPolicy struct {
+	// Checks to perform, all checks are performed if empty.
+	Checks []AuditCheck
+	// Now is the moment ages and expiration dates are compared with.
+	Now time.Time
+	// MinEntropy is minimal estimated entropy of a strong password in bits.
+	MinEntropy float64
+	// MaxPasswordAge is the period passwords should be changed within.
+	MaxPasswordAge time.Duration
+	// ExpiringWithin is the period to look ahead for expiring cards.
+	ExpiringWithin time.Duration
+}
+
+// AuditFinding is a problem of a stored secret found by the audit.
+type AuditFinding struct {
+	Secret *goph.Secret
+	Labels *goph.Labels
+	Check  AuditCheck
+	// Details explain the problem, e.g. paths of secrets sharing the password.
+	// Never contain sensitive data.
+	Details string
+}
+
+// Path returns full path to the secret, e.g. "/work/aws".
+func (f *AuditFinding) Path() string {
+	return SecretPath(f.Secret, f.Labels)
+}
+
+// Audit looks for problems of the stored credentials and cards.
+// Passwords are compared by their hashes and never leave the client.
+// Labels of the secrets must be decrypted. Findings are ordered by check and path.
+func Audit(secrets []SecretData, policy AuditPolicy) ([]AuditFinding, error) {
+	enabled := make(map[AuditCheck]bool, len(_auditChecks))
+	for _, c := range policy.Checks {
+		enabled[c] = true
+	}
+
+	if len(enabled) == 0 {
+		for _, v := range _auditChecks {
+			enabled[v.check] = true
+		}
+	}
+
+	rv := make([]AuditFinding, 0)
+	owners := make(map[[sha256.Size]byte][]AuditFinding)
+
+	for _, s := range secrets {
+		labels, err := LabelsOf(s.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("Audit - LabelsOf: %w", err)
+		}
+
+		found := AuditFinding{Secret: s.Secret, Labels: labels}
+
+		switch data := s.Data.(type) {
+		case *goph.Credentials:
+			if enabled[AuditReused] && data.GetPassword() != "" {
+				hash := sha256.Sum256([]byte(data.GetPassword()))
+				owners[hash] = append(owners[hash], found)
+			}
+
+			rv = append(rv, auditCredentials(found, data, s.Secret, &policy, enabled)...)
+
+		case *goph.Card:
+			if enabled[AuditExpiring] {
+				rv = append(rv, auditCard(found, data, &policy)...)
+			}
+		}
+	}
+
+	rv = append(rv, reusedFindings(owners)...)
+
+	sort.SliceStable(rv, func(i, j int) bool {
+		if rv[i].Check != rv[j].Check {
+			return rv[i].Check < rv[j].Check
+		}
+
+		return rv[i].Path() < rv[j].Path()
+	})
+
+	return rv, nil
+}
+
+// auditCredentials checks strength, age and TOTP of the credentials.
+func auditCredentials(
+	found AuditFinding,
+	data *goph.Credentials,
+	secret *goph.Secret,
+	policy *AuditPolicy,
+	enabled map[AuditCheck]bool,
+) []AuditFinding {
+	rv := make([]AuditFinding, 0)
+
+	if data.GetPassword() != "" && enabled[AuditWeak] {
+		estimate := passgen.EstimateStrength(data.GetPassword())
+
+		if estimate.IsWeak(policy.MinEntropy) {
+			found.Check = AuditWeak
+			found.Details = fmt.Sprintf("about %.0f bits of entropy", estimate.Entropy)
+
+			if estimate.Common {
+				found.Details = "common password, " + found.Details
+			}
+
+			rv = append(rv, found)
+		}
+	}
+
+	if data.GetPassword() != "" && enabled[AuditOld] && policy.MaxPasswordAge > 0 {
+		changed := secret.GetCreatedAt().AsTime()
+		if data.GetPasswordChanged() != nil {
+			changed = data.GetPasswordChanged().AsTime()
+		}
+
+		if age := policy.Now.Sub(changed); age > policy.MaxPasswordAge {
+			found.Check = AuditOld
+			found.Details = fmt.Sprintf(
+				"changed %d days ago, on %s",
+				int(age/_day),
+				changed.Local().Format(DateLayout),
+			)
+
+			rv = append(rv, found)
+		}
+	}
+
+	if enabled[AuditNoTOTP] && data.GetTotp() == "" {
+		found.Check = AuditNoTOTP
+		found.Details = "no TOTP secret"
+
+		rv = append(rv, found)
+	}
+
+	return rv
+}
+
+// auditCard checks expiration date of the card.
+func auditCard(found AuditFinding, data *goph.Card, policy *AuditPolicy) []AuditFinding {
+	if data.GetExpiration() == "" {
+		return nil
+	}
+
+	expires, err := ParseExpiration(data.GetExpiration())
+	if err != nil {
+		return nil
+	}
+
+	found.Check = AuditExpiring
+
+	switch left := expires.Sub(policy.Now); {
+	case left <= 0:
+		found.Details = "expired in " + data.GetExpiration()
+
+	case left <= policy.ExpiringWithin:
+		found.Details = fmt.Sprintf(
+			"expires in %d days, valid through %s",
+			int(left/_day),
+			data.GetExpiration(),
+		)
+
+	default:
+		return nil
+	}
+
+	return []AuditFinding{found}
+}
+
+// reusedFindings reports each credentials sharing password with others.
+func reusedFindings(owners map[[sha256.Size]byte][]AuditFinding) []AuditFinding {
+	rv := make([]AuditFinding, 0)
+
+	for _, group := range owners {
+		if len(group) < 2 {
+			continue
+		}
+
+		for i := range group {
+			others := make([]string, 0, len(group)-1)
+
+			for j := range group {
+				if i != j {
+					others = append(others, group[j].Path())
+				}
+			}
+
+			sort.Strings(others)
+
+			found := group[i]
+			found.Check = AuditReused
+			found.Details = "same password as " + strings.Join(others, ", ")
+
+			rv = append(rv, found)
+		}
+	}
+
+	return rv
+}
@@ -0,0 +1,162 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const _strongPassword = "k#9Vq!mZ2@wLr8&T"
+
+func auditPolicy(checks ...entity.AuditCheck) entity.AuditPolicy {
+	return entity.AuditPolicy{
+		Checks:         checks,
+		Now:            time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+		MinEntropy:     50,
+		MaxPasswordAge: 365 * 24 * time.Hour,
+		ExpiringWithin: 60 * 24 * time.Hour,
+	}
+}
+
+type auditResult struct {
+	check entity.AuditCheck
+	path  string
+}
+
+func auditResults(findings []entity.AuditFinding) []auditResult {
+	rv := make([]auditResult, 0, len(findings))
+	for i := range findings {
+		rv = append(rv, auditResult{findings[i].Check, findings[i].Path()})
+	}
+
+	return rv
+}
+
+func TestAuditReused(t *testing.T) {
+	secrets := []entity.SecretData{
+		newStoredSecret(t, "github", "dev", &goph.Credentials{Password: _strongPassword}),
+		newStoredSecret(t, "gitlab", "dev", &goph.Credentials{Password: _strongPassword}),
+		newStoredSecret(t, "bank", "", &goph.Credentials{Password: _strongPassword + "!"}),
+		newStoredSecret(t, "mail", "", &goph.Credentials{Password: _strongPassword}),
+		newStoredSecret(t, "empty", "", &goph.Credentials{Login: "admin"}),
+		newStoredSecret(t, "empty2", "", &goph.Credentials{Login: "root"}),
+	}
+
+	findings, err := entity.Audit(secrets, auditPolicy(entity.AuditReused))
+
+	require.NoError(t, err)
+	require.Equal(t, []auditResult{
+		{entity.AuditReused, "/dev/github"},
+		{entity.AuditReused, "/dev/gitlab"},
+		{entity.AuditReused, "/mail"},
+	}, auditResults(findings))
+	require.Equal(t, "same password as /dev/gitlab, /mail", findings[0].Details)
+	require.NotContains(t, findings[0].Details, _strongPassword)
+}
+
+func TestAuditWeak(t *testing.T) {
+	secrets := []entity.SecretData{
+		newStoredSecret(t, "common", "", &goph.Credentials{Password: "Password123"}),
+		newStoredSecret(t, "short", "", &goph.Credentials{Password: "x7Qa"}),
+		newStoredSecret(t, "strong", "", &goph.Credentials{Password: _strongPassword}),
+	}
+
+	findings, err := entity.Audit(secrets, auditPolicy(entity.AuditWeak))
+
+	require.NoError(t, err)
+	require.Equal(t, []auditResult{
+		{entity.AuditWeak, "/common"},
+		{entity.AuditWeak, "/short"},
+	}, auditResults(findings))
+	require.Contains(t, findings[0].Details, "common password")
+	require.NotContains(t, findings[1].Details, "common password")
+}
+
+func TestAuditOld(t *testing.T) {
+	policy := auditPolicy(entity.AuditOld)
+
+	neverChanged := newStoredSecret(
+		t,
+		"never-changed",
+		"",
+		&goph.Credentials{Password: _strongPassword},
+	)
+	neverChanged.Secret.CreatedAt = timestamppb.New(policy.Now.AddDate(-1, -1, 0))
+
+	secrets := []entity.SecretData{
+		newStoredSecret(t, "changed-long-ago", "", &goph.Credentials{
+			Password:        _strongPassword,
+			PasswordChanged: timestamppb.New(policy.Now.AddDate(-2, 0, 0)),
+		}),
+		newStoredSecret(t, "changed-recently", "", &goph.Credentials{
+			Password:        _strongPassword,
+			PasswordChanged: timestamppb.New(policy.Now.AddDate(0, -1, 0)),
+		}),
+		neverChanged,
+	}
+
+	findings, err := entity.Audit(secrets, policy)
+
+	require.NoError(t, err)
+	require.Equal(t, []auditResult{
+		{entity.AuditOld, "/changed-long-ago"},
+		{entity.AuditOld, "/never-changed"},
+	}, auditResults(findings))
+	require.Contains(t, findings[0].Details, "changed 731 days ago")
+}
+
+func TestAuditExpiringCards(t *testing.T) {
+	secrets := []entity.SecretData{
+		newStoredSecret(t, "expired", "", &goph.Card{Expiration: "02/24"}),
+		newStoredSecret(t, "expiring", "", &goph.Card{Expiration: "04/24"}),
+		newStoredSecret(t, "valid", "", &goph.Card{Expiration: "12/29"}),
+		newStoredSecret(t, "unknown", "", &goph.Card{}),
+	}
+
+	findings, err := entity.Audit(secrets, auditPolicy(entity.AuditExpiring))
+
+	require.NoError(t, err)
+	require.Equal(t, []auditResult{
+		{entity.AuditExpiring, "/expired"},
+		{entity.AuditExpiring, "/expiring"},
+	}, auditResults(findings))
+	require.Equal(t, "expired in 02/24", findings[0].Details)
+	require.Equal(t, "expires in 47 days, valid through 04/24", findings[1].Details)
+}
+
+func TestAuditAllChecks(t *testing.T) {
+	secrets := []entity.SecretData{
+		newStoredSecret(t, "weak", "", &goph.Credentials{Password: "qwerty"}),
+		newStoredSecret(t, "totp", "", &goph.Credentials{
+			Password:        _strongPassword,
+			PasswordChanged: timestamppb.New(auditPolicy().Now),
+			Totp:            "JBSWY3DPEHPK3PXP",
+		}),
+		newStoredSecret(t, "note", "", &goph.Text{Text: "qwerty"}),
+	}
+
+	findings, err := entity.Audit(secrets, auditPolicy())
+
+	require.NoError(t, err)
+	require.Equal(t, []auditResult{
+		{entity.AuditWeak, "/weak"},
+		{entity.AuditOld, "/weak"},
+		{entity.AuditNoTOTP, "/weak"},
+	}, auditResults(findings))
+}
+
+func TestParseAuditChecks(t *testing.T) {
+	rv, err := entity.ParseAuditChecks([]string{"weak", "no-totp"})
+
+	require.NoError(t, err)
+	require.Equal(t, []entity.AuditCheck{entity.AuditWeak, entity.AuditNoTOTP}, rv)
+	require.Equal(t, "no-totp", rv[1].String())
+
+	_, err = entity.ParseAuditChecks([]string{"breached"})
+
+	require.ErrorIs(t, err, entity.ErrBadAttribute)
+}
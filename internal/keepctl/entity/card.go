@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExpirationLayout is format of bank card expiration date.
+const ExpirationLayout = "01/06"
+
+var (
+	ErrBadCardNumber = errors.New("invalid card number")
+	ErrBadExpiration = errors.New("expiration date should be in the MM/YY format")
+	ErrBadCVV        = errors.New("card verification value should contain 3 or 4 digits")
+	ErrBadPIN        = errors.New("PIN should contain from 4 to 12 digits")
+)
+
+const (
+	_minCardNumberLength = 12
+	_maxCardNumberLength = 19
+	_visibleCardDigits   = 4
+	_minCVVLength        = 3
+	_maxCVVLength        = 4
+	_minPINLength        = 4
+	_maxPINLength        = 12
+)
+
+// cardBrand describes a card brand by ranges of issuer identification numbers (IIN).
+type cardBrand struct {
+	name   string
+	ranges [][2]int
+}
+
+// _cardBrands is ordered list of known brands, more specific ranges go first.
+var _cardBrands = []cardBrand{
+	{"Mir", [][2]int{{2200, 2204}}},
+	{"Mastercard", [][2]int{{51, 55}, {2221, 2720}}},
+	{"Visa", [][2]int{{4, 4}}},
+	{"American Express", [][2]int{{34, 34}, {37, 37}}},
+	{"Diners Club", [][2]int{{300, 305}, {36, 36}, {38, 39}}},
+	{"JCB", [][2]int{{3528, 3589}}},
+	{"Discover", [][2]int{{6011, 6011}, {644, 649}, {65, 65}}},
+	{"UnionPay", [][2]int{{62, 62}}},
+	{"Maestro", [][2]int{{50, 50}, {56, 69}}},
+}
+
+// isDigits reports whether the string consists of decimal digits only.
+func isDigits(src string) bool {
+	for _, c := range src {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return src != ""
+}
+
+// NormalizeCardNumber removes spaces and dashes from the card number.
+func NormalizeCardNumber(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+// luhn checks the number using the Luhn algorithm.
+func luhn(number string) bool {
+	sum := 0
+	double := false
+
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// ValidateCardNumber checks length and check digit of the card number.
+func ValidateCardNumber(number string) error {
+	number = NormalizeCardNumber(number)
+
+	if !isDigits(number) ||
+		len(number) < _minCardNumberLength ||
+		len(number) > _maxCardNumberLength {
+		return fmt.Errorf("%w: should contain from %d to %d digits",
+			ErrBadCardNumber, _minCardNumberLength, _maxCardNumberLength)
+	}
+
+	if !luhn(number) {
+		return fmt.Errorf("%w: check digit mismatch", ErrBadCardNumber)
+	}
+
+	return nil
+}
+
+// CardBrand detects brand of the card by its number.
+// Returns empty string if brand is unknown.
+func CardBrand(number string) string {
+	number = NormalizeCardNumber(number)
+
+	for _, brand := range _cardBrands {
+		for _, r := range brand.ranges {
+			width := len(strconv.Itoa(r[0]))
+			if len(number) < width {
+				continue
+			}
+
+			prefix, err := strconv.Atoi(number[:width])
+			if err == nil && prefix >= r[0] && prefix <= r[1] {
+				return brand.name
+			}
+		}
+	}
+
+	return ""
+}
+
+// MaskCardNumber hides all but last four digits of the card number.
+func MaskCardNumber(number string) string {
+	number = NormalizeCardNumber(number)
+	if len(number) <= _visibleCardDigits {
+		return number
+	}
+
+	return strings.Repeat("*", len(number)-_visibleCardDigits) + number[len(number)-_visibleCardDigits:]
+}
+
+// ParseExpiration parses card expiration date in the MM/YY format.
+// Returns the first moment the card is not valid anymore, i.e. start of the next month.
+func ParseExpiration(src string) (time.Time, error) {
+	month, err := time.Parse(ExpirationLayout, strings.TrimSpace(src))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrBadExpiration, src)
+	}
+
+	return month.AddDate(0, 1, 0), nil
+}
+
+// ValidateCVV checks the card verification value.
+func ValidateCVV(cvv string) error {
+	if !isDigits(cvv) || len(cvv) < _minCVVLength || len(cvv) > _maxCVVLength {
+		return ErrBadCVV
+	}
+
+	return nil
+}
+
+// ValidatePIN checks the personal identification number.
+func ValidatePIN(pin string) error {
+	if !isDigits(pin) || len(pin) < _minPINLength || len(pin) > _maxPINLength {
+		return ErrBadPIN
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCardNumber(t *testing.T) {
+	tt := []struct {
+		name   string
+		number string
+	}{
+		{
+			name:   "Visa",
+			number: "4111 1111 1111 1111",
+		},
+		{
+			name:   "Mastercard",
+			number: "5555-5555-5555-4444",
+		},
+		{
+			name:   "American Express",
+			number: "378282246310005",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, entity.ValidateCardNumber(tc.number))
+		})
+	}
+}
+
+func TestCardBrand(t *testing.T) {
+	tt := []struct {
+		number   string
+		expected string
+	}{
+		{number: "4111111111111111", expected: "Visa"},
+		{number: "5555555555554444", expected: "Mastercard"},
+		{number: "2221000000000009", expected: "Mastercard"},
+		{number: "2200000000000004", expected: "Mir"},
+		{number: "378282246310005", expected: "American Express"},
+		{number: "6011111111111117", expected: "Discover"},
+		{number: "3530111333300000", expected: "JCB"},
+		{number: "30569309025904", expected: "Diners Club"},
+		{number: "6200000000000005", expected: "UnionPay"},
+		{number: "1234567890123452", expected: ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.number, func(t *testing.T) {
+			require.Equal(t, tc.expected, entity.CardBrand(tc.number))
+		})
+	}
+}
+
+func TestParseExpiration(t *testing.T) {
+	expires, err := entity.ParseExpiration("02/24")
+
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), expires)
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoCertificate  = errors.New("no PEM-encoded certificates found")
+	ErrBadCertificate = errors.New("invalid certificate")
+	ErrKeyMismatch    = errors.New("private key doesn't match the certificate")
+)
+
+// ParseCertificateChain parses PEM-encoded certificates, blocks of other types are skipped.
+func ParseCertificateChain(src string) ([]*x509.Certificate, error) {
+	rv := make([]*x509.Certificate, 0)
+	rest := []byte(src)
+
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w #%d: %s", ErrBadCertificate, len(rv)+1, err)
+		}
+
+		rv = append(rv, cert)
+	}
+
+	if len(rv) == 0 {
+		return nil, ErrNoCertificate
+	}
+
+	return rv, nil
+}
+
+// CertificateSANs returns all subject alternative names of the certificate.
+func CertificateSANs(cert *x509.Certificate) []string {
+	rv := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.EmailAddresses))
+	rv = append(rv, cert.DNSNames...)
+
+	for _, ip := range cert.IPAddresses {
+		rv = append(rv, ip.String())
+	}
+
+	rv = append(rv, cert.EmailAddresses...)
+
+	for _, uri := range cert.URIs {
+		rv = append(rv, uri.String())
+	}
+
+	return rv
+}
+
+// ValidateKeyPair checks that the private key matches the leaf certificate of the chain.
+func ValidateKeyPair(chain, key string) error {
+	if _, err := tls.X509KeyPair([]byte(chain), []byte(key)); err != nil {
+		return fmt.Errorf("%w: %s", ErrKeyMismatch, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package entity_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificate creates self-signed certificate and returns it with private key in PEM format.
+func newTestCertificate(t *testing.T, notAfter time.Time) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "keeper"},
+		DNSNames:     []string{"keeper", "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	rawKey, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rawKey})
+
+	return string(cert), string(keyPEM)
+}
+
+func TestPushCertificate(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert, key := newTestCertificate(t, notAfter)
+
+	kind, err := entity.KindOf(goph.DataKind_CERTIFICATE)
+	require.NoError(t, err)
+
+	data := &goph.Certificate{}
+	err = kind.Apply(data, []entity.Change{
+		{Attribute: "cert", Value: cert},
+		{Attribute: "key", Value: key},
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, kind.Validate(data))
+	require.Equal(t, "CN=keeper", data.GetSubject())
+	require.Equal(t, "CN=keeper", data.GetIssuer())
+	require.Equal(t, []string{"keeper", "localhost", "127.0.0.1"}, data.GetSans())
+	require.Equal(t, notAfter, data.GetNotAfter().AsTime())
+}
+
+func TestPushCertificateFailure(t *testing.T) {
+	cert, _ := newTestCertificate(t, time.Now().AddDate(1, 0, 0))
+	_, otherKey := newTestCertificate(t, time.Now().AddDate(1, 0, 0))
+
+	tt := []struct {
+		name     string
+		changes  []entity.Change
+		expected error
+	}{
+		{
+			name:     "Not a PEM file",
+			changes:  []entity.Change{{Attribute: "cert", Value: "xxx"}},
+			expected: entity.ErrNoCertificate,
+		},
+		{
+			name: "Key of another certificate",
+			changes: []entity.Change{
+				{Attribute: "cert", Value: cert},
+				{Attribute: "key", Value: otherKey},
+			},
+			expected: entity.ErrKeyMismatch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOf(goph.DataKind_CERTIFICATE)
+			require.NoError(t, err)
+
+			data := kind.New()
+			err = kind.Apply(data, tc.changes)
+
+			if err == nil {
+				err = kind.Validate(data)
+			}
+
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestCertificateWarnings(t *testing.T) {
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	cert, _ := newTestCertificate(t, now.AddDate(0, 0, 10))
+
+	kind, err := entity.KindOf(goph.DataKind_CERTIFICATE)
+	require.NoError(t, err)
+
+	data := kind.New()
+	require.NoError(t, kind.Apply(data, []entity.Change{{Attribute: "cert", Value: cert}}))
+
+	require.Equal(t, []string{"certificate expires in 10 days (2023-05-11)"}, kind.Warnings(data, now))
+}
@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExpiryWarningPeriod defines how long before expiration user is warned about it.
+const ExpiryWarningPeriod = 90 * _day
+
+var (
+	ErrBadDate       = errors.New("date should be in the YYYY-MM-DD format")
+	ErrDateInFuture  = errors.New("date is in the future")
+	ErrBadDatesOrder = errors.New("dates are in wrong order")
+	ErrBadDuration   = errors.New("duration should be in form of 30d, 2w or 12h")
+)
+
+const (
+	_day  = 24 * time.Hour
+	_week = 7 * _day
+)
+
+// ParseDuration parses duration extending time.ParseDuration with days (d) and weeks (w).
+func ParseDuration(src string) (time.Duration, error) {
+	units := map[string]time.Duration{"d": _day, "w": _week}
+
+	for suffix, unit := range units {
+		if !strings.HasSuffix(src, suffix) {
+			continue
+		}
+
+		count, err := strconv.Atoi(strings.TrimSuffix(src, suffix))
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("%w: %q", ErrBadDuration, src)
+		}
+
+		return time.Duration(count) * unit, nil
+	}
+
+	rv, err := time.ParseDuration(src)
+	if err != nil || rv < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrBadDuration, src)
+	}
+
+	return rv, nil
+}
+
+// ParseDate parses date entered by user, empty value results in zero time.
+func ParseDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s %q", ErrBadDate, name, value)
+	}
+
+	return date, nil
+}
+
+// ParseMoment parses moment in the past entered by user either as a date
+// in the YYYY-MM-DD format or as a duration back from now, e.g. 30d.
+// Empty value results in zero time.
+func ParseMoment(name, value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if ago, err := ParseDuration(value); err == nil {
+		return now.Add(-ago), nil
+	}
+
+	return ParseDate(name, value)
+}
+
+// ValidatePastDate checks that the date is valid and not in the future.
+func ValidatePastDate(name, value string, now time.Time) error {
+	date, err := ParseDate(name, value)
+	if err != nil {
+		return err
+	}
+
+	if date.After(now) {
+		return fmt.Errorf("%w: %s %q", ErrDateInFuture, name, value)
+	}
+
+	return nil
+}
+
+// ValidateDatesOrder checks that the earlier date precedes the later one if both are set.
+func ValidateDatesOrder(earlierName, earlier, laterName, later string) error {
+	from, err := ParseDate(earlierName, earlier)
+	if err != nil {
+		return err
+	}
+
+	to, err := ParseDate(laterName, later)
+	if err != nil {
+		return err
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return fmt.Errorf("%w: %s should precede %s", ErrBadDatesOrder, earlierName, laterName)
+	}
+
+	return nil
+}
+
+// ExpiryWarning returns warning if the expiry date has passed or is close.
+// Returns empty string if there is nothing to warn about.
+func ExpiryWarning(what string, expires, now time.Time) string {
+	if expires.IsZero() {
+		return ""
+	}
+
+	if !now.Before(expires) {
+		return fmt.Sprintf("%s expired on %s", what, expires.Format(DateLayout))
+	}
+
+	left := expires.Sub(now)
+	if left > ExpiryWarningPeriod {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"%s expires in %d days (%s)",
+		what,
+		int(left/_day),
+		expires.Format(DateLayout),
+	)
+}
+
+// formatExpiryDate adds expiration mark to the date if it has passed.
+func formatExpiryDate(value string, now time.Time) string {
+	expires, err := ParseDate("expiry date", value)
+	if err != nil || expires.IsZero() || now.Before(expires) {
+		return value
+	}
+
+	return value + " (expired)"
+}
@@ -0,0 +1,68 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	tt := []struct {
+		src      string
+		expected time.Duration
+	}{
+		{src: "30d", expected: 30 * 24 * time.Hour},
+		{src: "2w", expected: 14 * 24 * time.Hour},
+		{src: "12h", expected: 12 * time.Hour},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.src, func(t *testing.T) {
+			rv, err := entity.ParseDuration(tc.src)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rv)
+		})
+	}
+}
+
+func TestParseDurationFailure(t *testing.T) {
+	for _, src := range []string{"", "d", "-1d", "month"} {
+		t.Run(src, func(t *testing.T) {
+			_, err := entity.ParseDuration(src)
+
+			require.ErrorIs(t, err, entity.ErrBadDuration)
+		})
+	}
+}
+
+func TestParseMoment(t *testing.T) {
+	now := time.Date(2023, time.May, 10, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		src      string
+		expected time.Time
+	}{
+		{src: "", expected: time.Time{}},
+		{src: "30d", expected: now.AddDate(0, 0, -30)},
+		{src: "12h", expected: now.Add(-12 * time.Hour)},
+		{src: "2023-01-15", expected: time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.src, func(t *testing.T) {
+			moment, err := entity.ParseMoment("since", tc.src, now)
+
+			require.NoError(t, err)
+			require.True(t, tc.expected.Equal(moment))
+		})
+	}
+}
+
+func TestParseMomentFailure(t *testing.T) {
+	_, err := entity.ParseMoment("since", "yesterday", time.Now())
+
+	require.ErrorIs(t, err, entity.ErrBadDate)
+}
@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// FieldSeparator separates reference to a secret from name of its field, e.g. "prod-db:password".
+const FieldSeparator = ":"
+
+var (
+	ErrBadFieldRef = errors.New("bad reference to field of secret")
+	ErrBadEnvVar   = errors.New("bad environment variable binding")
+)
+
+var _envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// FieldRef refers to a field of a secret, e.g. "work/db/prod:password".
+// Empty Field means the primary value of the secret.
+type FieldRef struct {
+	Secret string
+	Field  string
+}
+
+// ParseFieldRef parses reference like "prod-db:password" or "prod-db".
+// Everything after the first separator is the field, so custom fields
+// may be referred with type, e.g. "prod-db:token:hidden".
+func ParseFieldRef(ref string) (FieldRef, error) {
+	secret, field, _ := strings.Cut(strings.TrimSpace(ref), FieldSeparator)
+
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return FieldRef{}, NewValidationError(fmt.Errorf("%w: %q", ErrBadFieldRef, ref))
+	}
+
+	return FieldRef{Secret: secret, Field: strings.TrimSpace(field)}, nil
+}
+
+func (r FieldRef) String() string {
+	if r.Field == "" {
+		return r.Secret
+	}
+
+	return r.Secret + FieldSeparator + r.Field
+}
+
+// EnvVar binds environment variable to a field of a secret, e.g. "DB_PASSWORD=prod-db:password".
+type EnvVar struct {
+	Name string
+	Ref  FieldRef
+}
+
+// ParseEnvVar parses binding of environment variable like "DB_PASSWORD=prod-db:password".
+func ParseEnvVar(binding string) (EnvVar, error) {
+	name, ref, ok := strings.Cut(binding, "=")
+	name = strings.TrimSpace(name)
+
+	if !ok || !_envName.MatchString(name) {
+		return EnvVar{}, NewValidationError(fmt.Errorf("%w: %q", ErrBadEnvVar, binding))
+	}
+
+	fieldRef, err := ParseFieldRef(ref)
+	if err != nil {
+		return EnvVar{}, err
+	}
+
+	return EnvVar{Name: name, Ref: fieldRef}, nil
+}
+
+// ParseEnvFile reads bindings of environment variables, one per line.
+// Empty lines and lines starting with # are skipped.
+func ParseEnvFile(r io.Reader) ([]EnvVar, error) {
+	rv := make([]EnvVar, 0)
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		v, err := ParseEnvVar(text)
+		if err != nil {
+			return nil, NewValidationError(fmt.Errorf("line %d: %w", line, err))
+		}
+
+		rv = append(rv, v)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rv, nil
+}
@@ -0,0 +1,109 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldRef(t *testing.T) {
+	tt := []struct {
+		name     string
+		ref      string
+		expected entity.FieldRef
+		err      error
+	}{
+		{
+			name:     "Field of secret",
+			ref:      "prod-db:password",
+			expected: entity.FieldRef{Secret: "prod-db", Field: "password"},
+		},
+		{
+			name:     "Primary value of secret by path",
+			ref:      "work/db/prod",
+			expected: entity.FieldRef{Secret: "work/db/prod"},
+		},
+		{
+			name:     "Custom field with type",
+			ref:      "prod-db:token:hidden",
+			expected: entity.FieldRef{Secret: "prod-db", Field: "token:hidden"},
+		},
+		{
+			name: "No secret",
+			ref:  ":password",
+			err:  entity.ErrBadFieldRef,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := entity.ParseFieldRef(tc.ref)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestParseEnvVar(t *testing.T) {
+	tt := []struct {
+		name     string
+		binding  string
+		expected entity.EnvVar
+		err      error
+	}{
+		{
+			name:    "Valid binding",
+			binding: "DB_PASSWORD=prod-db:password",
+			expected: entity.EnvVar{
+				Name: "DB_PASSWORD",
+				Ref:  entity.FieldRef{Secret: "prod-db", Field: "password"},
+			},
+		},
+		{
+			name:    "No reference",
+			binding: "DB_PASSWORD",
+			err:     entity.ErrBadEnvVar,
+		},
+		{
+			name:    "Bad variable name",
+			binding: "1DB=prod-db",
+			err:     entity.ErrBadEnvVar,
+		},
+		{
+			name:    "Empty reference",
+			binding: "DB_PASSWORD=",
+			err:     entity.ErrBadFieldRef,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := entity.ParseEnvVar(tc.binding)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, v)
+		})
+	}
+}
+
+func TestParseEnvFile(t *testing.T) {
+	file := "# Database\nDB_USER=prod-db:login\n\n  DB_PASSWORD = prod-db:password\n"
+
+	vars, err := entity.ParseEnvFile(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Equal(t, []entity.EnvVar{
+		{Name: "DB_USER", Ref: entity.FieldRef{Secret: "prod-db", Field: "login"}},
+		{Name: "DB_PASSWORD", Ref: entity.FieldRef{Secret: "prod-db", Field: "password"}},
+	}, vars)
+}
+
+func TestParseEnvFileWithBadLine(t *testing.T) {
+	_, err := entity.ParseEnvFile(strings.NewReader("DB_USER=prod-db:login\nDB_PASSWORD\n"))
+
+	require.ErrorIs(t, err, entity.ErrBadEnvVar)
+	require.ErrorContains(t, err, "line 2")
+}
@@ -6,11 +6,24 @@ import (
 	"strings"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 const _unknownErrorCode = 255
 
+// Exit codes of keepctl, errors returned by keeper are mapped by their gRPC codes.
+const (
+	ExitOK            = 0
+	ExitFailure       = 1
+	ExitBadRequest    = 2
+	ExitUnauthorized  = 3
+	ExitNotFound      = 4
+	ExitConflict      = 5
+	ExitUnavailable   = 6
+	ExitKeeperFailure = 7
+)
+
 // RequestError is custom error wrapper used incide keepctl to distinguish
 // internal errors from returned from keeper service.
 type RequestError struct {
@@ -63,14 +76,118 @@ func (e RequestError) Error() string {
 	return sb.String()
 }
 
-// Unwrap takes generic error and unwraps it to RequestError.
-// If the provided error is not RequestError, it is returned as is.
-func Unwrap(err error) error {
+// IsNotFound reports whether the error is keeper's response about missing data.
+func IsNotFound(err error) bool {
 	var rErr RequestError
 
+	return errors.As(err, &rErr) && rErr.code == uint32(codes.NotFound)
+}
+
+// ExitCode returns exit code of keepctl corresponding to the error.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var (
+		rErr RequestError
+		vErr ValidationError
+		sErr ExitStatusError
+	)
+
+	switch {
+	case errors.As(err, &sErr):
+		return sErr.Code
+
+	case errors.Is(err, ErrSecretNotFound):
+		return ExitNotFound
+
+	case errors.As(err, &rErr):
+		return rErr.exitCode()
+
+	case errors.As(err, &vErr):
+		return ExitBadRequest
+	}
+
+	return ExitFailure
+}
+
+// exitCode maps gRPC code of the error to exit code of keepctl.
+func (e RequestError) exitCode() int {
+	switch codes.Code(e.code) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return ExitBadRequest
+
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return ExitUnauthorized
+
+	case codes.NotFound:
+		return ExitNotFound
+
+	case codes.AlreadyExists, codes.Aborted:
+		return ExitConflict
+
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return ExitUnavailable
+
+	default:
+	}
+
+	if e.code == _unknownErrorCode {
+		return ExitFailure
+	}
+
+	return ExitKeeperFailure
+}
+
+// ExitStatusError reports exit code of a command started by keepctl, e.g. with run,
+// so that keepctl exits with the same code.
+type ExitStatusError struct {
+	Code int
+}
+
+// Error returns error text.
+// Required by Golang error interface.
+func (e ExitStatusError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ValidationError wraps errors caused by invalid data provided by user.
+type ValidationError struct {
+	err error
+}
+
+// NewValidationError wraps provided error into ValidationError.
+func NewValidationError(err error) error {
+	return ValidationError{err}
+}
+
+// Error returns error text.
+// Required by Golang error interface.
+func (e ValidationError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e ValidationError) Unwrap() error {
+	return e.err
+}
+
+// Unwrap takes generic error and unwraps it to RequestError or ValidationError.
+// If the provided error is neither of them, it is returned as is.
+func Unwrap(err error) error {
+	var (
+		rErr RequestError
+		vErr ValidationError
+	)
+
 	if errors.As(err, &rErr) {
 		return rErr
 	}
 
+	if errors.As(err, &vErr) {
+		return vErr
+	}
+
 	return err
 }
@@ -72,3 +72,112 @@ func TestUnwrap(t *testing.T) {
 		})
 	}
 }
+
+func TestIsNotFound(t *testing.T) {
+	tt := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "Keeper responded with not found",
+			err:      entity.NewRequestError(status.Error(codes.NotFound, "secret not found")),
+			expected: true,
+		},
+		{
+			name: "Keeper responded with other error",
+			err:  entity.NewRequestError(status.Error(codes.Internal, "something went wrong")),
+		},
+		{
+			name: "Not a keeper response",
+			err:  grpc.ErrServerStopped,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := fmt.Errorf("ErrorTest - TestIsNotFound - SomeError: %w", tc.err)
+
+			require.Equal(t, tc.expected, entity.IsNotFound(err))
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tt := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "No error",
+			expected: entity.ExitOK,
+		},
+		{
+			name:     "Generic error",
+			err:      grpc.ErrServerStopped,
+			expected: entity.ExitFailure,
+		},
+		{
+			name:     "Not a gRPC error wrapped into request error",
+			err:      entity.NewRequestError(grpc.ErrServerStopped),
+			expected: entity.ExitFailure,
+		},
+		{
+			name:     "Bad request",
+			err:      entity.NewRequestError(status.Error(codes.InvalidArgument, "bad name")),
+			expected: entity.ExitBadRequest,
+		},
+		{
+			name:     "Bad credentials",
+			err:      entity.NewRequestError(status.Error(codes.Unauthenticated, "bad password")),
+			expected: entity.ExitUnauthorized,
+		},
+		{
+			name:     "Secret not found by keeper",
+			err:      entity.NewRequestError(status.Error(codes.NotFound, "secret not found")),
+			expected: entity.ExitNotFound,
+		},
+		{
+			name:     "Secret not found by reference",
+			err:      entity.NewValidationError(entity.ErrSecretNotFound),
+			expected: entity.ExitNotFound,
+		},
+		{
+			name:     "Secret already exists",
+			err:      entity.NewRequestError(status.Error(codes.AlreadyExists, "secret exists")),
+			expected: entity.ExitConflict,
+		},
+		{
+			name:     "Keeper is unavailable",
+			err:      entity.NewRequestError(status.Error(codes.Unavailable, "connection refused")),
+			expected: entity.ExitUnavailable,
+		},
+		{
+			name:     "Keeper failure",
+			err:      entity.NewRequestError(status.Error(codes.Internal, "database is down")),
+			expected: entity.ExitKeeperFailure,
+		},
+		{
+			name:     "Invalid data provided by user",
+			err:      entity.NewValidationError(entity.ErrBadFolder),
+			expected: entity.ExitBadRequest,
+		},
+		{
+			name:     "Exit status of started command",
+			err:      entity.ExitStatusError{Code: 42},
+			expected: 42,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.err
+			if err != nil {
+				err = fmt.Errorf("ErrorTest - TestExitCode - SomeError: %w", tc.err)
+			}
+
+			require.Equal(t, tc.expected, entity.ExitCode(err))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/totp"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+// DateLayout is format of dates entered by user.
+const DateLayout = "2006-01-02"
+
+var (
+	ErrBadFieldFormat   = errors.New("field should be in form of name=value or name:type=value")
+	ErrUnknownFieldType = errors.New("unknown field type")
+	ErrBadFieldValue    = errors.New("invalid field value")
+	ErrDuplicatedField  = errors.New("field with such name already exists")
+	ErrFieldNotFound    = errors.New("field not found")
+	ErrFieldsRequired   = errors.New("at least one field is required")
+
+	errIncompleteURL = errors.New("scheme and host are required")
+)
+
+// FieldTypeName returns short name of the field type, e.g. "hidden".
+func FieldTypeName(kind goph.FieldType) string {
+	return strings.ToLower(strings.TrimPrefix(kind.String(), "FIELD_"))
+}
+
+// FieldTypeFromName converts short name of field type into goph.FieldType.
+func FieldTypeFromName(name string) (goph.FieldType, error) {
+	val, ok := goph.FieldType_value["FIELD_"+strings.ToUpper(name)]
+	if !ok {
+		return goph.FieldType_FIELD_TEXT, fmt.Errorf("%w: %s", ErrUnknownFieldType, name)
+	}
+
+	return goph.FieldType(val), nil
+}
+
+// ParseField parses field definition in form of "name=value" or "name:type=value".
+// If type is omitted, FIELD_TEXT is used.
+func ParseField(spec string) (*goph.Field, error) {
+	field, _, err := parseField(spec)
+
+	return field, err
+}
+
+// parseField parses field definition and reports whether type of the field was specified.
+func parseField(spec string) (*goph.Field, bool, error) {
+	key, value, found := strings.Cut(spec, "=")
+	if !found {
+		return nil, false, fmt.Errorf("%w: %q", ErrBadFieldFormat, spec)
+	}
+
+	field := &goph.Field{
+		Name:  strings.TrimSpace(key),
+		Type:  goph.FieldType_FIELD_TEXT,
+		Value: value,
+	}
+	typed := false
+
+	if idx := strings.LastIndex(key, ":"); idx >= 0 {
+		kind, err := FieldTypeFromName(key[idx+1:])
+		if err == nil {
+			field.Name = strings.TrimSpace(key[:idx])
+			field.Type = kind
+			typed = true
+		}
+	}
+
+	if field.Name == "" {
+		return nil, false, fmt.Errorf("%w: %q", ErrBadFieldFormat, spec)
+	}
+
+	return field, typed, nil
+}
+
+// ValidateField checks that value of the field matches its type.
+func ValidateField(field *goph.Field) error {
+	var err error
+
+	value := field.GetValue()
+
+	switch field.GetType() {
+	case goph.FieldType_FIELD_TEXT, goph.FieldType_FIELD_HIDDEN:
+		return nil
+
+	case goph.FieldType_FIELD_URL:
+		var u *url.URL
+
+		u, err = url.ParseRequestURI(value)
+		if err == nil && (u.Scheme == "" || u.Host == "") {
+			err = errIncompleteURL
+		}
+
+	case goph.FieldType_FIELD_EMAIL:
+		_, err = mail.ParseAddress(value)
+
+	case goph.FieldType_FIELD_NUMBER:
+		_, err = strconv.ParseFloat(value, 64)
+
+	case goph.FieldType_FIELD_DATE:
+		_, err = time.Parse(DateLayout, value)
+
+	case goph.FieldType_FIELD_TOTP:
+		_, err = totp.Parse(value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w %q (%s): %s", ErrBadFieldValue, field.GetName(), FieldTypeName(field.GetType()), err)
+	}
+
+	return nil
+}
+
+// ValidateFields checks values of the provided fields and uniqueness of their names.
+func ValidateFields(fields []*goph.Field) error {
+	names := make(map[string]struct{}, len(fields))
+
+	for _, field := range fields {
+		if _, ok := names[field.GetName()]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicatedField, field.GetName())
+		}
+
+		names[field.GetName()] = struct{}{}
+
+		if err := ValidateField(field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseField(t *testing.T) {
+	tt := []struct {
+		name     string
+		spec     string
+		expected *goph.Field
+	}{
+		{
+			name: "Parse field without type",
+			spec: "client_id=12345",
+			expected: &goph.Field{
+				Name:  "client_id",
+				Type:  goph.FieldType_FIELD_TEXT,
+				Value: "12345",
+			},
+		},
+		{
+			name: "Parse typed field",
+			spec: "portal:url=https://example.com/login?a=b",
+			expected: &goph.Field{
+				Name:  "portal",
+				Type:  goph.FieldType_FIELD_URL,
+				Value: "https://example.com/login?a=b",
+			},
+		},
+		{
+			name: "Parse field with colon in name",
+			spec: "host:port=localhost:8080",
+			expected: &goph.Field{
+				Name:  "host:port",
+				Type:  goph.FieldType_FIELD_TEXT,
+				Value: "localhost:8080",
+			},
+		},
+		{
+			name: "Parse field with empty value",
+			spec: "region=",
+			expected: &goph.Field{
+				Name: "region",
+				Type: goph.FieldType_FIELD_TEXT,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			field, err := entity.ParseField(tc.spec)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.GetName(), field.GetName())
+			require.Equal(t, tc.expected.GetType(), field.GetType())
+			require.Equal(t, tc.expected.GetValue(), field.GetValue())
+		})
+	}
+}
+
+func TestParseFieldFailure(t *testing.T) {
+	tt := []struct {
+		name string
+		spec string
+	}{
+		{
+			name: "Parse field without value",
+			spec: "client_id",
+		},
+		{
+			name: "Parse field without name",
+			spec: "=12345",
+		},
+		{
+			name: "Parse typed field without name",
+			spec: ":hidden=12345",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := entity.ParseField(tc.spec)
+
+			require.ErrorIs(t, err, entity.ErrBadFieldFormat)
+		})
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	tt := []struct {
+		name     string
+		fields   []*goph.Field
+		expected error
+	}{
+		{
+			name: "Valid fields of all types",
+			fields: []*goph.Field{
+				{Name: "a", Type: goph.FieldType_FIELD_TEXT, Value: "text"},
+				{Name: "b", Type: goph.FieldType_FIELD_HIDDEN, Value: "secret"},
+				{Name: "c", Type: goph.FieldType_FIELD_URL, Value: "https://example.com"},
+				{Name: "d", Type: goph.FieldType_FIELD_EMAIL, Value: "john@example.com"},
+				{Name: "e", Type: goph.FieldType_FIELD_NUMBER, Value: "-12.5"},
+				{Name: "f", Type: goph.FieldType_FIELD_DATE, Value: "2023-05-01"},
+				{Name: "g", Type: goph.FieldType_FIELD_TOTP, Value: "JBSWY3DPEHPK3PXP"},
+			},
+		},
+		{
+			name: "Duplicated field names",
+			fields: []*goph.Field{
+				{Name: "a", Value: "1"},
+				{Name: "a", Value: "2"},
+			},
+			expected: entity.ErrDuplicatedField,
+		},
+		{
+			name:     "Relative URL",
+			fields:   []*goph.Field{{Name: "a", Type: goph.FieldType_FIELD_URL, Value: "/login"}},
+			expected: entity.ErrBadFieldValue,
+		},
+		{
+			name:     "Bad email",
+			fields:   []*goph.Field{{Name: "a", Type: goph.FieldType_FIELD_EMAIL, Value: "john"}},
+			expected: entity.ErrBadFieldValue,
+		},
+		{
+			name:     "Bad number",
+			fields:   []*goph.Field{{Name: "a", Type: goph.FieldType_FIELD_NUMBER, Value: "1a"}},
+			expected: entity.ErrBadFieldValue,
+		},
+		{
+			name:     "Bad date",
+			fields:   []*goph.Field{{Name: "a", Type: goph.FieldType_FIELD_DATE, Value: "01.05.2023"}},
+			expected: entity.ErrBadFieldValue,
+		},
+		{
+			name:     "Bad TOTP secret",
+			fields:   []*goph.Field{{Name: "a", Type: goph.FieldType_FIELD_TOTP, Value: "123!"}},
+			expected: entity.ErrBadFieldValue,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := entity.ValidateFields(tc.fields)
+
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxSecretNameLength is maximum length of a secret name in bytes accepted by keeper.
+const MaxSecretNameLength = 256
+
+// NewSecret is a secret prepared to be stored, e.g. imported from other password manager.
+type NewSecret struct {
+	Name        string
+	Description string
+	Labels      *goph.Labels
+	Data        proto.Message
+	Attachments []NewAttachment
+	// Replaces is ID of the stored secret deleted in favor of the new one,
+	// the server does it in the same transaction.
+	Replaces string
+}
+
+// NewAttachment is a file to be attached to a new secret.
+type NewAttachment struct {
+	Filename string
+	MimeType string
+	Content  []byte
+}
+
+// Path returns full path to the new secret, e.g. "/work/aws".
+func (s *NewSecret) Path() string {
+	return SecretPath(&goph.Secret{Name: s.Name}, s.Labels)
+}
+
+// ImportAction tells what happens to an imported secret.
+type ImportAction int
+
+const (
+	// ImportCreate creates new secret.
+	ImportCreate ImportAction = iota
+	// ImportRename creates new secret with changed name, as the name is already taken.
+	ImportRename
+	// ImportSkipDuplicate skips the secret, as the same data is already stored.
+	ImportSkipDuplicate
+	// ImportSkipInvalid skips the secret, as its data is not valid.
+	ImportSkipInvalid
+	// ImportOverwrite replaces stored secret having the same name.
+	ImportOverwrite
+	// ImportSkipExisting skips the secret, as its name is already taken.
+	ImportSkipExisting
+)
+
+// String returns short description of the action.
+func (a ImportAction) String() string {
+	switch a {
+	case ImportCreate:
+		return "create"
+
+	case ImportRename:
+		return "rename"
+
+	case ImportSkipDuplicate:
+		return "skip duplicate"
+
+	case ImportSkipInvalid:
+		return "skip invalid"
+
+	case ImportOverwrite:
+		return "overwrite"
+
+	case ImportSkipExisting:
+		return "skip existing"
+
+	default:
+		return fmt.Sprintf("ImportAction(%d)", int(a))
+	}
+}
+
+// ImportItem is an imported secret with decision what to do with it.
+type ImportItem struct {
+	Secret NewSecret
+	Action ImportAction
+	// Reason explains the action, e.g. original name of renamed secret
+	// or path of the stored duplicate.
+	Reason string
+}
+
+// Created reports whether the secret is going to be stored.
+func (i *ImportItem) Created() bool {
+	return i.Action == ImportCreate || i.Action == ImportRename || i.Action == ImportOverwrite
+}
+
+// ConflictPolicy tells what to do with restored secret if its name is already taken.
+type ConflictPolicy int
+
+const (
+	// ConflictSkip keeps the stored secret.
+	ConflictSkip ConflictPolicy = iota
+	// ConflictOverwrite replaces the stored secret and files attached to it.
+	ConflictOverwrite
+	// ConflictRename stores the secret under new name, e.g. "github (2)".
+	ConflictRename
+)
+
+var _conflictPolicies = map[string]ConflictPolicy{
+	"skip":      ConflictSkip,
+	"overwrite": ConflictOverwrite,
+	"rename":    ConflictRename,
+}
+
+// ParseConflictPolicy converts name of the policy into ConflictPolicy.
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	rv, ok := _conflictPolicies[name]
+	if !ok {
+		return rv, NewValidationError(
+			fmt.Errorf("%w %q: should be one of skip, overwrite, rename", ErrBadAttribute, name),
+		)
+	}
+
+	return rv, nil
+}
+
+// PlanImport decides what to do with each imported secret.
+// Secrets having the same kind and data as already stored ones or previous imported ones
+// are skipped unless duplicates are allowed. Secrets with names already taken
+// within the folder are renamed, e.g. "github" becomes "github (2)".
+// Labels of existing secrets must be decrypted.
+func PlanImport(
+	secrets []NewSecret,
+	existing []SecretData,
+	allowDuplicates bool,
+) ([]ImportItem, error) {
+	stored := make([]*goph.Secret, 0, len(existing))
+	for _, s := range existing {
+		stored = append(stored, s.Secret)
+	}
+
+	taken, err := takenPaths(stored, len(secrets))
+	if err != nil {
+		return nil, fmt.Errorf("PlanImport - takenPaths: %w", err)
+	}
+
+	fingerprints, err := storedFingerprints(existing, len(secrets))
+	if err != nil {
+		return nil, fmt.Errorf("PlanImport - storedFingerprints: %w", err)
+	}
+
+	rv := make([]ImportItem, 0, len(secrets))
+
+	for _, secret := range secrets {
+		item := planImportItem(secret, taken, fingerprints, allowDuplicates)
+		rv = append(rv, item)
+	}
+
+	return rv, nil
+}
+
+func planImportItem(
+	secret NewSecret,
+	taken map[string]string,
+	fingerprints map[string]string,
+	allowDuplicates bool,
+) ImportItem {
+	item := ImportItem{Secret: secret, Action: ImportCreate}
+
+	if item.Secret.Labels == nil {
+		item.Secret.Labels = new(goph.Labels)
+	}
+
+	if err := validateNewSecret(&item.Secret); err != nil {
+		item.Action = ImportSkipInvalid
+		item.Reason = err.Error()
+
+		return item
+	}
+
+	fp, err := fingerprint(item.Secret.Data)
+	if err != nil {
+		item.Action = ImportSkipInvalid
+		item.Reason = err.Error()
+
+		return item
+	}
+
+	if path, ok := fingerprints[fp]; ok && !allowDuplicates {
+		item.Action = ImportSkipDuplicate
+		item.Reason = path
+
+		return item
+	}
+
+	renameTaken(&item, taken)
+	fingerprints[fp] = item.Secret.Path()
+
+	return item
+}
+
+// PlanRestore decides what to do with each secret restored from backup.
+// Secrets with names already taken within the folder are skipped, overwritten or renamed
+// according to the policy. Secrets of the backup never overwrite each other.
+// Labels of existing secrets must be decrypted.
+func PlanRestore(
+	secrets []NewSecret,
+	existing []*goph.Secret,
+	policy ConflictPolicy,
+) ([]ImportItem, error) {
+	taken, err := takenPaths(existing, len(secrets))
+	if err != nil {
+		return nil, fmt.Errorf("PlanRestore - takenPaths: %w", err)
+	}
+
+	rv := make([]ImportItem, 0, len(secrets))
+
+	for _, secret := range secrets {
+		item := ImportItem{Secret: secret, Action: ImportCreate}
+
+		if item.Secret.Labels == nil {
+			item.Secret.Labels = new(goph.Labels)
+		}
+
+		if err := validateNewSecret(&item.Secret); err != nil {
+			item.Action = ImportSkipInvalid
+			item.Reason = err.Error()
+
+			rv = append(rv, item)
+
+			continue
+		}
+
+		path := item.Secret.Path()
+		id, ok := taken[path]
+
+		switch {
+		case !ok:
+			taken[path] = ""
+
+		case policy == ConflictSkip:
+			item.Action = ImportSkipExisting
+			item.Reason = path
+
+		case policy == ConflictOverwrite && id != "":
+			item.Action = ImportOverwrite
+			item.Reason = id
+			item.Secret.Replaces = id
+			taken[path] = ""
+
+		default:
+			renameTaken(&item, taken)
+		}
+
+		rv = append(rv, item)
+	}
+
+	return rv, nil
+}
+
+// storedFingerprints maps fingerprints of stored secrets to their paths.
+func storedFingerprints(existing []SecretData, capacity int) (map[string]string, error) {
+	rv := make(map[string]string, len(existing)+capacity)
+
+	for _, stored := range existing {
+		labels, err := LabelsOf(stored.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("storedFingerprints - LabelsOf: %w", err)
+		}
+
+		if fp, err := fingerprint(stored.Data); err == nil {
+			rv[fp] = SecretPath(stored.Secret, labels)
+		}
+	}
+
+	return rv, nil
+}
+
+// takenPaths maps paths of stored secrets to their IDs.
+// Paths of secrets created during the import are mapped to empty IDs.
+func takenPaths(existing []*goph.Secret, capacity int) (map[string]string, error) {
+	rv := make(map[string]string, len(existing)+capacity)
+
+	for _, secret := range existing {
+		labels, err := LabelsOf(secret)
+		if err != nil {
+			return nil, fmt.Errorf("takenPaths - LabelsOf: %w", err)
+		}
+
+		rv[SecretPath(secret, labels)] = secret.GetId()
+	}
+
+	return rv, nil
+}
+
+// renameTaken appends number to name of the secret until its path is free
+// and marks the path taken.
+func renameTaken(item *ImportItem, taken map[string]string) {
+	original := item.Secret.Name
+
+	for n := 2; ; n++ {
+		if _, ok := taken[item.Secret.Path()]; !ok {
+			break
+		}
+
+		item.Action = ImportRename
+		item.Reason = original
+		item.Secret.Name = fmt.Sprintf("%s (%d)", original, n)
+	}
+
+	taken[item.Secret.Path()] = ""
+}
+
+// validateNewSecret normalizes labels of the secret and validates its data.
+func validateNewSecret(secret *NewSecret) error {
+	if secret.Name == "" || len(secret.Name) > MaxSecretNameLength {
+		return fmt.Errorf("%w %q: should contain from 1 to %d bytes",
+			ErrBadAttribute, "name", MaxSecretNameLength)
+	}
+
+	if err := NormalizeLabels(secret.Labels); err != nil {
+		return err
+	}
+
+	kind, err := KindOfMessage(secret.Data)
+	if err != nil {
+		return err
+	}
+
+	return kind.Validate(secret.Data)
+}
+
+// fingerprint identifies the data of a secret regardless of its name and labels.
+// Only data provided by user is taken into account, so that legacy layout and
+// bookkeeping of password changes don't hide duplicates.
+func fingerprint(data proto.Message) (string, error) {
+	stable := proto.Clone(data)
+
+	if kind, err := KindOfMessage(stable); err == nil {
+		kind.Upgrade(stable)
+	}
+
+	// NB (alkurbatov): Credentials pushed by keepctl always have moment of the password change,
+	// other password managers export it differently or don't export at all.
+	if creds, ok := stable.(*goph.Credentials); ok {
+		creds.PasswordChanged = nil
+		creds.PasswordHistory = nil
+	}
+
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(stable)
+	if err != nil {
+		return "", fmt.Errorf("fingerprint - proto.Marshal: %w", err)
+	}
+
+	return string(data.ProtoReflect().Descriptor().FullName()) + ":" + string(raw), nil
+}
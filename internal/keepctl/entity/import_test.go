@@ -0,0 +1,229 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newStoredSecret(t *testing.T, name, folder string, data proto.Message) entity.SecretData {
+	t.Helper()
+
+	labels, err := proto.Marshal(&goph.Labels{Folder: folder})
+	require.NoError(t, err)
+
+	return entity.SecretData{
+		Secret: &goph.Secret{Name: name, Labels: labels},
+		Data:   data,
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	existing := []entity.SecretData{
+		newStoredSecret(t, "github", "work", &goph.Credentials{Login: "alice", Password: "old"}),
+		newStoredSecret(t, "note", "", &goph.Text{Text: "hello"}),
+	}
+
+	secrets := []entity.NewSecret{
+		{
+			Name:   "github",
+			Labels: &goph.Labels{Folder: "/work/"},
+			Data:   &goph.Credentials{Login: "alice", Password: "new"},
+		},
+		{
+			Name: "copy of note",
+			Data: &goph.Text{Text: "hello"},
+		},
+		{
+			Name: "bank",
+			Data: &goph.Card{Number: "4111111111111112"},
+		},
+		{
+			Name:   "gitlab",
+			Labels: &goph.Labels{Folder: "work"},
+			Data:   &goph.Credentials{Login: "bob", Password: "secret"},
+		},
+		{
+			Name:   "gitlab",
+			Labels: &goph.Labels{Folder: "work"},
+			Data:   &goph.Credentials{Login: "bob", Password: "secret"},
+		},
+		{
+			Name:   "github",
+			Labels: &goph.Labels{Folder: "work"},
+			Data:   &goph.Credentials{Login: "carol", Password: "secret"},
+		},
+	}
+
+	items, err := entity.PlanImport(secrets, existing, false)
+	require.NoError(t, err)
+	require.Len(t, items, len(secrets))
+
+	expected := []struct {
+		path   string
+		action entity.ImportAction
+		reason string
+	}{
+		{path: "/work/github (2)", action: entity.ImportRename, reason: "github"},
+		{path: "/copy of note", action: entity.ImportSkipDuplicate, reason: "/note"},
+		{path: "/bank", action: entity.ImportSkipInvalid},
+		{path: "/work/gitlab", action: entity.ImportCreate},
+		{path: "/work/gitlab", action: entity.ImportSkipDuplicate, reason: "/work/gitlab"},
+		{path: "/work/github (3)", action: entity.ImportRename, reason: "github"},
+	}
+
+	for i, e := range expected {
+		require.Equal(t, e.path, items[i].Secret.Path(), "item %d", i)
+		require.Equal(t, e.action, items[i].Action, "item %d", i)
+
+		if e.reason != "" {
+			require.Equal(t, e.reason, items[i].Reason, "item %d", i)
+		}
+	}
+
+	require.NotEmpty(t, items[2].Reason)
+	require.True(t, items[0].Created())
+	require.False(t, items[1].Created())
+}
+
+func TestPlanImportWithDuplicatesAllowed(t *testing.T) {
+	existing := []entity.SecretData{
+		newStoredSecret(t, "note", "", &goph.Text{Text: "hello"}),
+	}
+
+	secrets := []entity.NewSecret{
+		{Name: "note", Data: &goph.Text{Text: "hello"}},
+	}
+
+	items, err := entity.PlanImport(secrets, existing, true)
+	require.NoError(t, err)
+	require.Equal(t, entity.ImportRename, items[0].Action)
+	require.Equal(t, "note (2)", items[0].Secret.Name)
+}
+
+func TestPlanImportSkipsPushedDuplicates(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+	require.NoError(t, err)
+
+	pushed := kind.New()
+	err = kind.Apply(pushed, []entity.Change{
+		{Attribute: "login", Value: "alice"},
+		{Attribute: "password", Value: "secret"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, pushed.(*goph.Credentials).GetPasswordChanged())
+
+	existing := []entity.SecretData{
+		newStoredSecret(t, "github", "work", pushed),
+		newStoredSecret(t, "bank", "", &goph.Card{
+			Number:     "4111111111111111",
+			Expiration: "12/30",
+			LegacyCvv:  42,
+		}),
+	}
+
+	secrets := []entity.NewSecret{
+		{
+			Name: "GitHub",
+			Data: &goph.Credentials{Login: "alice", Password: "secret"},
+		},
+		{
+			Name: "Bank",
+			Data: &goph.Card{Number: "4111111111111111", Expiration: "12/30", Cvv: "042"},
+		},
+	}
+
+	items, err := entity.PlanImport(secrets, existing, false)
+	require.NoError(t, err)
+	require.Equal(t, entity.ImportSkipDuplicate, items[0].Action)
+	require.Equal(t, "/work/github", items[0].Reason)
+	require.Equal(t, entity.ImportSkipDuplicate, items[1].Action)
+	require.Equal(t, "/bank", items[1].Reason)
+}
+
+func TestPlanRestore(t *testing.T) {
+	stored := newStoredSecret(t, "github", "work", &goph.Text{Text: "old"}).Secret
+	stored.Id = "2ec9ae8b-3b5a-4b8a-9f10-33d8f3a0b6a1"
+
+	secrets := []entity.NewSecret{
+		{
+			Name:   "github",
+			Labels: &goph.Labels{Folder: "work"},
+			Data:   &goph.Text{Text: "new"},
+		},
+		{
+			Name:   "github",
+			Labels: &goph.Labels{Folder: "work"},
+			Data:   &goph.Text{Text: "newer"},
+		},
+		{
+			Name: "note",
+			Data: &goph.Text{Text: "hello"},
+		},
+	}
+
+	tt := []struct {
+		name     string
+		policy   entity.ConflictPolicy
+		expected []entity.ImportAction
+		paths    []string
+	}{
+		{
+			name:   "Skip conflicts",
+			policy: entity.ConflictSkip,
+			expected: []entity.ImportAction{
+				entity.ImportSkipExisting,
+				entity.ImportSkipExisting,
+				entity.ImportCreate,
+			},
+			paths: []string{"/work/github", "/work/github", "/note"},
+		},
+		{
+			name:   "Overwrite conflicts",
+			policy: entity.ConflictOverwrite,
+			expected: []entity.ImportAction{
+				entity.ImportOverwrite,
+				entity.ImportRename,
+				entity.ImportCreate,
+			},
+			paths: []string{"/work/github", "/work/github (2)", "/note"},
+		},
+		{
+			name:     "Rename conflicts",
+			policy:   entity.ConflictRename,
+			expected: []entity.ImportAction{entity.ImportRename, entity.ImportRename, entity.ImportCreate},
+			paths:    []string{"/work/github (2)", "/work/github (3)", "/note"},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			items, err := entity.PlanRestore(secrets, []*goph.Secret{stored}, tc.policy)
+			require.NoError(t, err)
+			require.Len(t, items, len(secrets))
+
+			for i := range items {
+				require.Equal(t, tc.expected[i], items[i].Action, "item %d", i)
+				require.Equal(t, tc.paths[i], items[i].Secret.Path(), "item %d", i)
+			}
+
+			if tc.policy == entity.ConflictOverwrite {
+				require.Equal(t, stored.Id, items[0].Secret.Replaces)
+				require.True(t, items[0].Created())
+			}
+		})
+	}
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	policy, err := entity.ParseConflictPolicy("overwrite")
+	require.NoError(t, err)
+	require.Equal(t, entity.ConflictOverwrite, policy)
+
+	_, err = entity.ParseConflictPolicy("merge")
+	require.ErrorIs(t, err, entity.ErrBadAttribute)
+}
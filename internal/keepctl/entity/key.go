@@ -3,6 +3,7 @@ package entity
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -17,6 +18,9 @@ import (
 // See https://pkg.go.dev/crypto/cipher#example-NewGCM-Encrypt
 const _defaultNonceLength = 12
 
+// _digestDomain separates keyed digests from other uses of the key.
+const _digestDomain = "goph-keeper digest"
+
 // Key is user's encyption key.
 type Key struct {
 	sum [sha256.Size]byte
@@ -34,6 +38,19 @@ func (k Key) Hash() string {
 	return hex.EncodeToString(k.sum[:])
 }
 
+// Digest calculates keyed digest (HMAC-SHA256) of the provided data.
+// The digest is stable for the same key and data but reveals nothing
+// about the data to the parties without the key.
+func (k Key) Digest(data []byte) []byte {
+	subkey := hmac.New(sha256.New, k.sum[:])
+	subkey.Write([]byte(_digestDomain))
+
+	mac := hmac.New(sha256.New, subkey.Sum(nil))
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
+
 // Encrypt encrypts provided message with secret key.
 // Noop if data is empty.
 func (k Key) Encrypt(data []byte) ([]byte, error) {
@@ -1,6 +1,7 @@
 package entity_test
 
 import (
+	"crypto/sha256"
 	"testing"
 
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
@@ -56,3 +57,15 @@ func TestEncryptDecrypt(t *testing.T) {
 		})
 	}
 }
+
+func TestDigest(t *testing.T) {
+	sat := entity.NewKey(gophtest.Username, gophtest.Password)
+	other := entity.NewKey("a", "b")
+
+	digest := sat.Digest([]byte("work/aws"))
+
+	require.Len(t, digest, sha256.Size)
+	require.Equal(t, digest, sat.Digest([]byte("work/aws")))
+	require.NotEqual(t, digest, sat.Digest([]byte("work/gcp")))
+	require.NotEqual(t, digest, other.Digest([]byte("work/aws")))
+}
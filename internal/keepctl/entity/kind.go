@@ -0,0 +1,723 @@
+package entity
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	ErrUnknownKind      = errors.New("unknown secret kind")
+	ErrUnknownAttribute = errors.New("unknown attribute")
+	ErrBadAttribute     = errors.New("invalid attribute value")
+	ErrNoQRCode         = errors.New("no data to share via QR code")
+	ErrNoPrimary        = errors.New("no primary attribute")
+
+	errUnknownValue = errors.New("unknown value")
+)
+
+// AttributeType defines how value of an attribute is passed through commandline.
+type AttributeType int
+
+const (
+	// AttrString is a single string value.
+	AttrString AttributeType = iota
+	// AttrList is a list of values, corresponding flag can be repeated.
+	AttrList
+	// AttrBool is a boolean switch.
+	AttrBool
+)
+
+// Attribute describes single piece of data stored in a secret of particular kind.
+type Attribute struct {
+	// Name of the attribute, used as commandline flag and key of exported value.
+	Name string
+	// Field is name of the protobuf message field, defaults to Name with dashes replaced.
+	// Fields of nested messages are referred with dots, e.g. "address.city".
+	Field string
+	// Shorthand is optional one-letter commandline flag.
+	Shorthand string
+	// Usage is short description of the attribute.
+	Usage string
+	// Type defines how the value is passed through commandline.
+	Type AttributeType
+	// Column is header of the attribute in tabular output.
+	// Attributes without column are displayed below the table.
+	Column string
+	// Required attributes must be set on creation of a secret.
+	Required bool
+	// Sensitive attributes are masked on display unless revealed.
+	Sensitive bool
+	// EditOnly attributes make sense only for existing secrets.
+	EditOnly bool
+	// ReadOnly attributes are maintained automatically and can't be set from commandline.
+	ReadOnly bool
+	// File attributes take path to a file on commandline, the value is read from the file.
+	File bool
+	// Searchable attributes are matched by deep search, e.g. logins and URIs.
+	// Sensitive data must never be searchable.
+	Searchable bool
+	// Primary attribute is picked by default when single value of a secret is needed,
+	// e.g. password of credentials copied to clipboard.
+	Primary bool
+	// Generated attribute can be set to random password instead of value provided by user.
+	// At most one attribute of a kind can be generated.
+	Generated bool
+	// Password attributes hold passwords, e.g. checked against breached passwords.
+	Password bool
+	// Confidential attributes hold private data displayed as is, e.g. text of a note.
+	// Along with sensitive and password attributes they can be typed in a prompt
+	// instead of commandline, see Prompted.
+	Confidential bool
+	// FromFile attribute can be read from a file instead of commandline.
+	// At most one attribute of a kind can be read from file.
+	FromFile bool
+
+	get     func(msg proto.Message) string
+	set     func(msg proto.Message, value string) error
+	display func(msg proto.Message, reveal bool) string
+}
+
+// ListSeparator separates values of list attributes in exported data.
+const ListSeparator = "\n"
+
+// Table is variable-length part of secret data displayed separately.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Change is a request to set value of an attribute.
+type Change struct {
+	Attribute string
+	Value     string
+}
+
+// Kind describes a kind of secrets: data message, attributes and validation rules.
+// New kinds are added by registering them in init() of a kind_*.go file.
+type Kind struct {
+	// DataKind identifies the kind in the keeper API.
+	DataKind goph.DataKind
+	// Name is short name of the kind used in commands, e.g. "creds".
+	Name string
+	// Title is human-readable description of stored data, e.g. "bank card info".
+	Title string
+	// Attributes describe data of the kind in order of appearance.
+	Attributes []Attribute
+
+	// newMessage creates empty data message.
+	newMessage func() proto.Message
+	// upgrade optionally converts data stored by older clients to the current layout.
+	upgrade func(msg proto.Message)
+	// validate optionally checks consistency of the data.
+	validate func(msg proto.Message) error
+	// warnings optionally reports problems requiring attention, e.g. expired documents.
+	warnings func(msg proto.Message, now time.Time) []string
+	// formats optionally render the data in formats consumed by other tools, e.g. DSN.
+	formats map[string]func(msg proto.Message) (string, error)
+	// qrCode optionally renders content of QR code used to share the data,
+	// name of the secret is provided to label the content.
+	qrCode func(name string, msg proto.Message) (string, error)
+	// tables optionally render variable-length parts of the data.
+	tables func(msg proto.Message, reveal bool) []Table
+	// toValues optionally replaces attributes-based export of the data.
+	toValues func(msg proto.Message) map[string]string
+	// fromValues optionally replaces attributes-based import of the data.
+	fromValues func(msg proto.Message, values map[string]string) error
+	// sensitive optionally reports whether exported value is sensitive, used along with toValues.
+	sensitive func(key string) bool
+}
+
+var (
+	_kinds      = make(map[goph.DataKind]*Kind)
+	_kindsByMsg = make(map[protoreflect.FullName]*Kind)
+)
+
+// registerKind adds new kind of secrets to the registry.
+func registerKind(k *Kind) {
+	if _, ok := _kinds[k.DataKind]; ok {
+		panic("secret kind registered twice: " + k.Name)
+	}
+
+	_kinds[k.DataKind] = k
+	_kindsByMsg[k.New().ProtoReflect().Descriptor().FullName()] = k
+}
+
+// Kinds returns all registered kinds of secrets sorted by name.
+func Kinds() []*Kind {
+	rv := make([]*Kind, 0, len(_kinds))
+	for _, k := range _kinds {
+		rv = append(rv, k)
+	}
+
+	sort.Slice(rv, func(i, j int) bool { return rv[i].Name < rv[j].Name })
+
+	return rv
+}
+
+// KindOf returns description of the kind of secrets.
+func KindOf(kind goph.DataKind) (*Kind, error) {
+	k, ok := _kinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+
+	return k, nil
+}
+
+// KindOfMessage returns description of the kind the data message belongs to.
+func KindOfMessage(msg proto.Message) (*Kind, error) {
+	name := msg.ProtoReflect().Descriptor().FullName()
+
+	k, ok := _kindsByMsg[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKind, name)
+	}
+
+	return k, nil
+}
+
+// KindByName returns description of the kind by its short name.
+func KindByName(name string) (*Kind, error) {
+	for _, k := range _kinds {
+		if k.Name == name {
+			return k, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownKind, name)
+}
+
+// New creates empty data message of the kind.
+func (k *Kind) New() proto.Message {
+	return k.newMessage()
+}
+
+// Upgrade converts data stored by older clients to the current layout.
+func (k *Kind) Upgrade(msg proto.Message) {
+	if k.upgrade != nil {
+		k.upgrade(msg)
+	}
+}
+
+// Attribute returns description of the attribute.
+func (k *Kind) Attribute(name string) (*Attribute, error) {
+	for i := range k.Attributes {
+		if k.Attributes[i].Name == name {
+			return &k.Attributes[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownAttribute, name)
+}
+
+// PrimaryAttribute returns description of the attribute picked by default.
+func (k *Kind) PrimaryAttribute() (*Attribute, error) {
+	for i := range k.Attributes {
+		if k.Attributes[i].Primary {
+			return &k.Attributes[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w in %s", ErrNoPrimary, k.Name)
+}
+
+// Apply changes data message according to the requested changes.
+func (k *Kind) Apply(msg proto.Message, changes []Change) error {
+	for _, change := range changes {
+		attr, err := k.Attribute(change.Attribute)
+		if err != nil {
+			return NewValidationError(err)
+		}
+
+		if err := attr.Set(msg, change.Value); err != nil {
+			return NewValidationError(err)
+		}
+	}
+
+	return nil
+}
+
+// Passwords returns non-empty values of password attributes keyed by names of the attributes.
+func (k *Kind) Passwords(msg proto.Message) map[string]string {
+	rv := make(map[string]string)
+
+	for i := range k.Attributes {
+		attr := &k.Attributes[i]
+		if !attr.Password {
+			continue
+		}
+
+		if value := attr.Get(msg); value != "" {
+			rv[attr.Name] = value
+		}
+	}
+
+	return rv
+}
+
+// RawValue converts raw content, e.g. of a file, into value of the attribute accepted by Apply.
+// Content of bytes fields is hex-encoded.
+func (k *Kind) RawValue(name string, data []byte) (string, error) {
+	attr, err := k.Attribute(name)
+	if err != nil {
+		return "", err
+	}
+
+	if _, fd := attr.resolve(k.New(), false); fd != nil && fd.Kind() == protoreflect.BytesKind {
+		return hex.EncodeToString(data), nil
+	}
+
+	return string(data), nil
+}
+
+// HasPasswords reports whether the kind has password attributes.
+func (k *Kind) HasPasswords() bool {
+	for i := range k.Attributes {
+		if k.Attributes[i].Password {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks consistency of the data message.
+func (k *Kind) Validate(msg proto.Message) error {
+	if k.validate == nil {
+		return nil
+	}
+
+	if err := k.validate(msg); err != nil {
+		return NewValidationError(err)
+	}
+
+	return nil
+}
+
+// Warnings returns problems of the data requiring user's attention at the provided moment.
+func (k *Kind) Warnings(msg proto.Message, now time.Time) []string {
+	if k.warnings == nil {
+		return nil
+	}
+
+	return k.warnings(msg, now)
+}
+
+// Formats returns sorted names of formats the data can be rendered in.
+func (k *Kind) Formats() []string {
+	rv := make([]string, 0, len(k.formats))
+	for name := range k.formats {
+		rv = append(rv, name)
+	}
+
+	sort.Strings(rv)
+
+	return rv
+}
+
+// Format renders the data in the requested format.
+func (k *Kind) Format(name string, msg proto.Message) (string, error) {
+	render, ok := k.formats[name]
+	if !ok {
+		return "", fmt.Errorf(
+			"%w for %s: %q, expected one of: %s",
+			ErrUnsupportedFormat,
+			k.Name,
+			name,
+			strings.Join(k.Formats(), ", "),
+		)
+	}
+
+	return render(msg)
+}
+
+// QRCode returns content of QR code used to share the data with mobile devices.
+func (k *Kind) QRCode(name string, msg proto.Message) (string, error) {
+	if k.qrCode == nil {
+		return "", fmt.Errorf("%w in %s", ErrNoQRCode, k.Name)
+	}
+
+	return k.qrCode(name, msg)
+}
+
+// Tables renders variable-length parts of the data as separate tables.
+func (k *Kind) Tables(msg proto.Message, reveal bool) []Table {
+	if k.tables == nil {
+		return nil
+	}
+
+	return k.tables(msg, reveal)
+}
+
+// Export converts the data message into flat set of named values.
+func (k *Kind) Export(msg proto.Message) map[string]string {
+	if k.toValues != nil {
+		return k.toValues(msg)
+	}
+
+	rv := make(map[string]string, len(k.Attributes))
+
+	for i := range k.Attributes {
+		attr := &k.Attributes[i]
+		if attr.EditOnly {
+			continue
+		}
+
+		if val := attr.Get(msg); val != "" {
+			rv[attr.Name] = val
+		}
+	}
+
+	return rv
+}
+
+// Values converts the data message into named values for machine-readable output.
+// Keys are the same as in Export, sensitive values and passwords are masked unless revealed.
+func (k *Kind) Values(msg proto.Message, reveal bool) map[string]string {
+	values := k.Export(msg)
+	if reveal {
+		return values
+	}
+
+	for key, value := range values {
+		attr, err := k.Attribute(key)
+		password := err == nil && attr.Password
+
+		if (k.IsSensitive(key) || password) && value != "" {
+			values[key] = HiddenValue
+		}
+	}
+
+	return values
+}
+
+// Value returns single exported value of the data by its key, see Export.
+// Keys of custom fields may omit type, e.g. "token" instead of "token:hidden".
+func (k *Kind) Value(msg proto.Message, key string) (string, error) {
+	values := k.Export(msg)
+	if value, ok := values[key]; ok {
+		return value, nil
+	}
+
+	for name, value := range values {
+		if strings.HasPrefix(name, key+":") {
+			return value, nil
+		}
+	}
+
+	if attr, err := k.Attribute(key); err == nil && !attr.EditOnly {
+		return attr.Get(msg), nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnknownAttribute, key)
+}
+
+// IsSensitive reports whether exported value with the key is sensitive.
+func (k *Kind) IsSensitive(key string) bool {
+	if k.sensitive != nil {
+		return k.sensitive(key)
+	}
+
+	attr, err := k.Attribute(key)
+
+	return err == nil && attr.Sensitive
+}
+
+// Import creates data message from flat set of named values.
+// Values which don't match any attribute are ignored.
+func (k *Kind) Import(values map[string]string) (proto.Message, error) {
+	msg := k.New()
+
+	if k.fromValues != nil {
+		if err := k.fromValues(msg, values); err != nil {
+			return nil, NewValidationError(err)
+		}
+
+		return msg, nil
+	}
+
+	for i := range k.Attributes {
+		attr := &k.Attributes[i]
+		if attr.EditOnly {
+			continue
+		}
+
+		val, ok := values[attr.Name]
+		if !ok {
+			continue
+		}
+
+		items := []string{val}
+		if attr.Type == AttrList {
+			items = strings.Split(val, ListSeparator)
+		}
+
+		for _, item := range items {
+			if err := attr.Set(msg, item); err != nil {
+				return nil, NewValidationError(err)
+			}
+		}
+	}
+
+	return msg, nil
+}
+
+// dataOf casts data message to the type expected by hooks of particular kind.
+// Kinds only receive messages they created, so the mismatch results in zero value.
+func dataOf[T proto.Message](msg proto.Message) T {
+	rv, _ := msg.(T)
+
+	return rv
+}
+
+// fieldPath returns path to the protobuf field corresponding to the attribute.
+func (a *Attribute) fieldPath() []string {
+	if a.Field != "" {
+		return strings.Split(a.Field, ".")
+	}
+
+	return []string{strings.ReplaceAll(a.Name, "-", "_")}
+}
+
+// resolve finds the message containing the attribute field following the field path.
+// If create is false and some nested message is not set, nil message is returned.
+func (a *Attribute) resolve(
+	msg proto.Message,
+	create bool,
+) (protoreflect.Message, protoreflect.FieldDescriptor) {
+	m := msg.ProtoReflect()
+	path := a.fieldPath()
+
+	for i, name := range path {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, nil
+		}
+
+		if i == len(path)-1 {
+			return m, fd
+		}
+
+		if fd.Message() == nil || fd.IsList() {
+			return nil, nil
+		}
+
+		if !create && !m.Has(fd) {
+			return nil, fd
+		}
+
+		m = m.Mutable(fd).Message()
+	}
+
+	return nil, nil
+}
+
+// Get returns value of the attribute in the form accepted by Set.
+func (a *Attribute) Get(msg proto.Message) string {
+	if a.get != nil {
+		return a.get(msg)
+	}
+
+	m, fd := a.resolve(msg, false)
+	if m == nil {
+		return ""
+	}
+
+	if fd.IsList() {
+		list := m.Get(fd).List()
+		values := make([]string, 0, list.Len())
+
+		for i := 0; i < list.Len(); i++ {
+			values = append(values, formatValue(fd, list.Get(i)))
+		}
+
+		return strings.Join(values, ListSeparator)
+	}
+
+	return formatValue(fd, m.Get(fd))
+}
+
+// Set changes the attribute of the message, the value is parsed according to the field type.
+func (a *Attribute) Set(msg proto.Message, value string) error {
+	if a.set != nil {
+		return a.set(msg, value)
+	}
+
+	m, fd := a.resolve(msg, true)
+	if m == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownAttribute, a.Name)
+	}
+
+	val, err := parseValue(fd, value)
+	if err != nil {
+		return fmt.Errorf("%w %q: %s", ErrBadAttribute, a.Name, err)
+	}
+
+	if fd.IsList() {
+		m.Mutable(fd).List().Append(val)
+
+		return nil
+	}
+
+	m.Set(fd, val)
+
+	return nil
+}
+
+// Prompted reports whether the value should be kept out of commandline and shell history,
+// e.g. typed in a prompt with hidden echo.
+func (a *Attribute) Prompted() bool {
+	return a.Sensitive || a.Password || a.Confidential
+}
+
+// Display returns value of the attribute suitable for showing to user.
+// Sensitive values are masked unless revealed.
+func (a *Attribute) Display(msg proto.Message, reveal bool) string {
+	if a.display != nil {
+		return a.display(msg, reveal)
+	}
+
+	value := a.Get(msg)
+	if a.Sensitive && !reveal && value != "" {
+		return HiddenValue
+	}
+
+	return value
+}
+
+// formatValue converts protobuf value to human-readable string.
+func formatValue(fd protoreflect.FieldDescriptor, val protoreflect.Value) string {
+	switch fd.Kind() { //nolint:exhaustive //other kinds are not used in data messages
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(val.Bytes())
+
+	case protoreflect.EnumKind:
+		ev := fd.Enum().Values().ByNumber(val.Enum())
+		if ev == nil {
+			return strconv.Itoa(int(val.Enum()))
+		}
+
+		return enumValueName(fd.Enum(), ev)
+
+	case protoreflect.MessageKind:
+		return ""
+	}
+
+	return val.String()
+}
+
+// parseValue converts string to protobuf value according to the field type.
+func parseValue(fd protoreflect.FieldDescriptor, src string) (protoreflect.Value, error) {
+	switch fd.Kind() { //nolint:exhaustive //other kinds are not used in data messages
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(src), nil
+
+	case protoreflect.BytesKind:
+		data, err := hex.DecodeString(src)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return protoreflect.ValueOfBytes(data), nil
+
+	case protoreflect.BoolKind:
+		val, err := strconv.ParseBool(src)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return protoreflect.ValueOfBool(val), nil
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		val, err := strconv.ParseInt(src, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return protoreflect.ValueOfInt32(int32(val)), nil
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		val, err := strconv.ParseInt(src, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return protoreflect.ValueOfInt64(val), nil
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		val, err := strconv.ParseUint(src, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return protoreflect.ValueOfUint32(uint32(val)), nil
+
+	case protoreflect.EnumKind:
+		ev := fd.Enum().Values().ByName(
+			protoreflect.Name(enumPrefix(fd.Enum()) + strings.ToUpper(src)),
+		)
+		if ev == nil {
+			return protoreflect.Value{}, fmt.Errorf(
+				"%w %q, expected one of: %s",
+				errUnknownValue,
+				src,
+				strings.Join(EnumValues(fd.Enum()), ", "),
+			)
+		}
+
+		return protoreflect.ValueOfEnum(ev.Number()), nil
+	}
+
+	return protoreflect.Value{}, fmt.Errorf("%w: unsupported field type %s", ErrBadAttribute, fd.Kind())
+}
+
+// enumPrefix returns common prefix of the enum value names, e.g. "CARD_" for CardType.
+func enumPrefix(enum protoreflect.EnumDescriptor) string {
+	values := enum.Values()
+	if values.Len() == 0 {
+		return ""
+	}
+
+	first := string(values.Get(0).Name())
+
+	idx := strings.Index(first, "_")
+	if idx < 0 {
+		return ""
+	}
+
+	prefix := first[:idx+1]
+
+	for i := 1; i < values.Len(); i++ {
+		if !strings.HasPrefix(string(values.Get(i).Name()), prefix) {
+			return ""
+		}
+	}
+
+	return prefix
+}
+
+// enumValueName returns lowercase name of the enum value without common prefix.
+func enumValueName(enum protoreflect.EnumDescriptor, ev protoreflect.EnumValueDescriptor) string {
+	return strings.ToLower(strings.TrimPrefix(string(ev.Name()), enumPrefix(enum)))
+}
+
+// EnumValues returns lowercase names of the enum values, e.g. for usage messages.
+func EnumValues(enum protoreflect.EnumDescriptor) []string {
+	values := enum.Values()
+	rv := make([]string, 0, values.Len())
+
+	for i := 0; i < values.Len(); i++ {
+		rv = append(rv, enumValueName(enum, values.Get(i)))
+	}
+
+	return rv
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_BINARY,
+		Name:     "bin",
+		Title:    "arbitrary binary data",
+		Attributes: []Attribute{
+			{
+				Name:         "binary-data",
+				Field:        "binary",
+				Shorthand:    "b",
+				Usage:        "Binary data in hex format",
+				Required:     true,
+				Confidential: true,
+				FromFile:     true,
+				// NB (alkurbatov): The data is shown as is, hex form is used
+				// by machine-readable output only.
+				display: func(msg proto.Message, _ bool) string {
+					return string(dataOf[*goph.Binary](msg).GetBinary())
+				},
+			},
+		},
+		newMessage: func() proto.Message { return &goph.Binary{} },
+	})
+}
@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_CARD,
+		Name:     "card",
+		Title:    "bank card info",
+		Attributes: []Attribute{
+			{
+				Name:      "number",
+				Usage:     "Card number, spaces and dashes are ignored",
+				Column:    "Number",
+				Required:  true,
+				Sensitive: true,
+				Primary:   true,
+				set: func(msg proto.Message, value string) error {
+					dataOf[*goph.Card](msg).Number = NormalizeCardNumber(value)
+
+					return nil
+				},
+				display: func(msg proto.Message, reveal bool) string {
+					number := dataOf[*goph.Card](msg).GetNumber()
+					if reveal {
+						return number
+					}
+
+					return MaskCardNumber(number)
+				},
+			},
+			{
+				Name:       "brand",
+				Usage:      "Card brand detected by number",
+				Column:     "Brand",
+				ReadOnly:   true,
+				Searchable: true,
+				get:        noValue,
+				display: func(msg proto.Message, _ bool) string {
+					return CardBrand(dataOf[*goph.Card](msg).GetNumber())
+				},
+			},
+			{
+				Name:     "expiration",
+				Usage:    "Card expiration date in the MM/YY format",
+				Column:   "Expiration",
+				Required: true,
+				display: func(msg proto.Message, _ bool) string {
+					expiration := dataOf[*goph.Card](msg).GetExpiration()
+
+					expires, err := ParseExpiration(expiration)
+					if err == nil && !time.Now().Before(expires) {
+						return expiration + " (expired)"
+					}
+
+					return expiration
+				},
+			},
+			{
+				Name:       "holder",
+				Usage:      "Card holder name and surname",
+				Column:     "Holder",
+				Required:   true,
+				Searchable: true,
+			},
+			{
+				Name:      "cvv",
+				Usage:     "Card verification value",
+				Column:    "CVV",
+				Sensitive: true,
+			},
+			{
+				Name:      "pin",
+				Usage:     "Personal identification number",
+				Column:    "PIN",
+				Sensitive: true,
+			},
+			{
+				Name:       "bank",
+				Usage:      "Name of the issuing bank",
+				Column:     "Bank",
+				Searchable: true,
+			},
+			{
+				Name:   "type",
+				Usage:  "Type of the card: " + strings.Join(EnumValues(goph.CardType(0).Descriptor()), ", "),
+				Column: "Type",
+			},
+		},
+		newMessage: func() proto.Message { return &goph.Card{} },
+		upgrade: func(msg proto.Message) {
+			data := dataOf[*goph.Card](msg)
+			if data.GetCvv() == "" && data.GetLegacyCvv() != 0 {
+				data.Cvv = fmt.Sprintf("%03d", data.GetLegacyCvv())
+				data.LegacyCvv = 0
+			}
+		},
+		validate: validateCard,
+	})
+}
+
+func validateCard(msg proto.Message) error {
+	data := dataOf[*goph.Card](msg)
+
+	if err := ValidateCardNumber(data.GetNumber()); err != nil {
+		return err
+	}
+
+	if _, err := ParseExpiration(data.GetExpiration()); err != nil {
+		return err
+	}
+
+	if data.GetCvv() != "" {
+		if err := ValidateCVV(data.GetCvv()); err != nil {
+			return err
+		}
+	}
+
+	if data.GetPin() == "" {
+		return nil
+	}
+
+	return ValidatePIN(data.GetPin())
+}
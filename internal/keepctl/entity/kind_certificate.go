@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_CERTIFICATE,
+		Name:     "cert",
+		Title:    "X.509 certificate chain and private key",
+		Attributes: []Attribute{
+			{
+				Name:     "cert",
+				Field:    "chain",
+				Usage:    "Path to PEM file with certificate chain, leaf certificate first",
+				Required: true,
+				File:     true,
+				set:      setCertificateChain,
+				display:  func(proto.Message, bool) string { return "" },
+			},
+			{
+				Name:      "key",
+				Field:     "private_key",
+				Usage:     "Path to PEM file with private key of the leaf certificate",
+				Sensitive: true,
+				File:      true,
+				display: func(msg proto.Message, reveal bool) string {
+					if !reveal {
+						return ""
+					}
+
+					return dataOf[*goph.Certificate](msg).GetPrivateKey()
+				},
+			},
+			{
+				Name:       "subject",
+				Column:     "Subject",
+				ReadOnly:   true,
+				Searchable: true,
+				get:        noValue,
+				display: func(msg proto.Message, _ bool) string {
+					return dataOf[*goph.Certificate](msg).GetSubject()
+				},
+			},
+			{
+				Name:       "sans",
+				Column:     "SANs",
+				ReadOnly:   true,
+				Searchable: true,
+				get:        noValue,
+				display: func(msg proto.Message, _ bool) string {
+					return strings.Join(dataOf[*goph.Certificate](msg).GetSans(), ", ")
+				},
+			},
+			{
+				Name:       "issuer",
+				Column:     "Issuer",
+				ReadOnly:   true,
+				Searchable: true,
+				get:        noValue,
+				display: func(msg proto.Message, _ bool) string {
+					return dataOf[*goph.Certificate](msg).GetIssuer()
+				},
+			},
+			{
+				Name:     "not-after",
+				Column:   "Not after",
+				ReadOnly: true,
+				get:      noValue,
+				display: func(msg proto.Message, _ bool) string {
+					notAfter := dataOf[*goph.Certificate](msg).GetNotAfter()
+					if notAfter == nil {
+						return ""
+					}
+
+					rv := notAfter.AsTime().Format(DateLayout)
+					if !time.Now().Before(notAfter.AsTime()) {
+						rv += " (expired)"
+					}
+
+					return rv
+				},
+			},
+		},
+		newMessage: func() proto.Message { return &goph.Certificate{} },
+		validate: func(msg proto.Message) error {
+			data := dataOf[*goph.Certificate](msg)
+
+			if _, err := ParseCertificateChain(data.GetChain()); err != nil {
+				return err
+			}
+
+			if data.GetPrivateKey() == "" {
+				return nil
+			}
+
+			return ValidateKeyPair(data.GetChain(), data.GetPrivateKey())
+		},
+		warnings: func(msg proto.Message, now time.Time) []string {
+			notAfter := dataOf[*goph.Certificate](msg).GetNotAfter()
+			if notAfter == nil {
+				return nil
+			}
+
+			warning := ExpiryWarning("certificate", notAfter.AsTime(), now)
+			if warning == "" {
+				return nil
+			}
+
+			return []string{warning}
+		},
+		tables: certificateTables,
+	})
+}
+
+// setCertificateChain stores the chain and extracts details of the leaf certificate.
+func setCertificateChain(msg proto.Message, value string) error {
+	chain, err := ParseCertificateChain(value)
+	if err != nil {
+		return err
+	}
+
+	leaf := chain[0]
+	data := dataOf[*goph.Certificate](msg)
+
+	data.Chain = value
+	data.Subject = leaf.Subject.String()
+	data.Sans = CertificateSANs(leaf)
+	data.Issuer = leaf.Issuer.String()
+	data.NotBefore = timestamppb.New(leaf.NotBefore)
+	data.NotAfter = timestamppb.New(leaf.NotAfter)
+
+	return nil
+}
+
+// certificateTables renders certificates of the chain if there are intermediate ones.
+func certificateTables(msg proto.Message, _ bool) []Table {
+	chain, err := ParseCertificateChain(dataOf[*goph.Certificate](msg).GetChain())
+	if err != nil || len(chain) < 2 {
+		return nil
+	}
+
+	table := Table{Header: []string{"#", "Subject", "Issuer", "Not after"}}
+
+	for i, cert := range chain {
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(i + 1),
+			cert.Subject.String(),
+			cert.Issuer.String(),
+			cert.NotAfter.Format(DateLayout),
+		})
+	}
+
+	return []Table{table}
+}
@@ -0,0 +1,276 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/totp"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaxPasswordHistory is maximum number of previous passwords kept in credentials.
+const MaxPasswordHistory = 10
+
+// _displayTimeLayout is format of moments shown to user.
+const _displayTimeLayout = "2006-01-02 15:04"
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_CREDENTIALS,
+		Name:     "creds",
+		Title:    "credentials",
+		Attributes: []Attribute{
+			{
+				Name:       "login",
+				Shorthand:  "l",
+				Usage:      "Login or username",
+				Column:     "Login",
+				Required:   true,
+				Searchable: true,
+			},
+			{
+				Name:      "password",
+				Shorthand: "p",
+				Usage:     "Password, previous one is moved to the password history",
+				Column:    "Password",
+				Required:  true,
+				Primary:   true,
+				Generated: true,
+				Password:  true,
+				set:       setPassword,
+			},
+			{
+				Name: "uri",
+				Usage: "Website URI in form of uri or match=uri, " +
+					"supported match rules: domain (default), host, starts_with, exact, regex, never",
+				Type:       AttrList,
+				Searchable: true,
+				get:        getURIs,
+				set: func(msg proto.Message, value string) error {
+					upsertURI(dataOf[*goph.Credentials](msg), ParseURI(value))
+
+					return nil
+				},
+				display: func(proto.Message, bool) string { return "" },
+			},
+			{
+				Name:     "remove-uri",
+				Usage:    "Website URI to remove",
+				Type:     AttrList,
+				EditOnly: true,
+				get:      noValue,
+				set: func(msg proto.Message, value string) error {
+					return removeURI(dataOf[*goph.Credentials](msg), value)
+				},
+			},
+			{
+				Name:      "totp",
+				Usage:     "TOTP secret in base32 encoding or otpauth:// URI",
+				Column:    "TOTP",
+				Sensitive: true,
+				display: func(msg proto.Message, reveal bool) string {
+					secret := dataOf[*goph.Credentials](msg).GetTotp()
+					if secret == "" {
+						return ""
+					}
+
+					return FormatTOTP(secret, reveal)
+				},
+			},
+			{
+				Name:     "password-changed",
+				Usage:    "Moment the password was set",
+				Column:   "Password changed",
+				ReadOnly: true,
+				get: func(msg proto.Message) string {
+					changed := dataOf[*goph.Credentials](msg).GetPasswordChanged()
+					if changed == nil {
+						return ""
+					}
+
+					return changed.AsTime().Format(time.RFC3339)
+				},
+				set: func(msg proto.Message, value string) error {
+					changed, err := time.Parse(time.RFC3339, value)
+					if err != nil {
+						return fmt.Errorf("%w %q: %s", ErrBadAttribute, "password-changed", err)
+					}
+
+					dataOf[*goph.Credentials](msg).PasswordChanged = timestamppb.New(changed)
+
+					return nil
+				},
+				display: func(msg proto.Message, _ bool) string {
+					return formatTimestamp(dataOf[*goph.Credentials](msg).GetPasswordChanged())
+				},
+			},
+		},
+		newMessage: func() proto.Message { return &goph.Credentials{} },
+		validate:   validateCreds,
+		tables:     credsTables,
+		qrCode:     credsQRCode,
+	})
+}
+
+// formatTimestamp converts timestamp to human-readable local time.
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+
+	return ts.AsTime().Local().Format(_displayTimeLayout)
+}
+
+// setPassword changes password and moves the previous one to the password history.
+func setPassword(msg proto.Message, value string) error {
+	data := dataOf[*goph.Credentials](msg)
+	if data.GetPassword() == value {
+		return nil
+	}
+
+	now := timestamppb.Now()
+
+	if data.GetPassword() != "" {
+		entry := &goph.PasswordHistory{Password: data.GetPassword(), ChangedAt: now}
+		history := append([]*goph.PasswordHistory{entry}, data.GetPasswordHistory()...)
+
+		if len(history) > MaxPasswordHistory {
+			history = history[:MaxPasswordHistory]
+		}
+
+		data.PasswordHistory = history
+	}
+
+	data.Password = value
+	data.PasswordChanged = now
+
+	return nil
+}
+
+// getURIs returns stored URIs in form of match=uri.
+func getURIs(msg proto.Message) string {
+	uris := dataOf[*goph.Credentials](msg).GetUris()
+	values := make([]string, 0, len(uris))
+
+	for _, uri := range uris {
+		values = append(values, URIMatchName(uri.GetMatch())+"="+uri.GetUri())
+	}
+
+	return strings.Join(values, ListSeparator)
+}
+
+// findURI returns index of the URI or -1 if not found.
+func findURI(uris []*goph.Uri, value string) int {
+	for i, uri := range uris {
+		if uri.GetUri() == value {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// upsertURI replaces match rule of existing URI or appends new one.
+func upsertURI(data *goph.Credentials, uri *goph.Uri) {
+	idx := findURI(data.Uris, uri.GetUri())
+	if idx < 0 {
+		data.Uris = append(data.Uris, uri)
+
+		return
+	}
+
+	data.Uris[idx] = uri
+}
+
+// removeURI removes the URI from the credentials.
+func removeURI(data *goph.Credentials, value string) error {
+	idx := findURI(data.Uris, value)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrURINotFound, value)
+	}
+
+	data.Uris = append(data.Uris[:idx], data.Uris[idx+1:]...)
+
+	return nil
+}
+
+func validateCreds(msg proto.Message) error {
+	data := dataOf[*goph.Credentials](msg)
+
+	for _, uri := range data.GetUris() {
+		if err := ValidateURI(uri); err != nil {
+			return err
+		}
+	}
+
+	if data.GetTotp() == "" {
+		return nil
+	}
+
+	if _, err := totp.Parse(data.GetTotp()); err != nil {
+		return fmt.Errorf("%w %q: %s", ErrBadAttribute, "totp", err)
+	}
+
+	return nil
+}
+
+// credsQRCode renders otpauth:// URI to enroll the TOTP secret in authenticator app.
+func credsQRCode(name string, msg proto.Message) (string, error) {
+	data := dataOf[*goph.Credentials](msg)
+	if data.GetTotp() == "" {
+		return "", fmt.Errorf("%w: TOTP secret is not set", ErrNoQRCode)
+	}
+
+	key, err := totp.Parse(data.GetTotp())
+	if err != nil {
+		return "", err
+	}
+
+	if key.Issuer == "" {
+		key.Issuer = name
+	}
+
+	if key.Account == "" {
+		key.Account = data.GetLogin()
+	}
+
+	return key.URI(), nil
+}
+
+func credsTables(msg proto.Message, reveal bool) []Table {
+	data := dataOf[*goph.Credentials](msg)
+	rv := make([]Table, 0)
+
+	if len(data.GetUris()) > 0 {
+		uris := Table{Header: []string{"URI", "Match"}}
+
+		for _, uri := range data.GetUris() {
+			uris.Rows = append(uris.Rows, []string{uri.GetUri(), URIMatchName(uri.GetMatch())})
+		}
+
+		rv = append(rv, uris)
+	}
+
+	if len(data.GetPasswordHistory()) > 0 {
+		history := Table{Header: []string{"Previous password", "Replaced"}}
+
+		for _, entry := range data.GetPasswordHistory() {
+			password := entry.GetPassword()
+			if !reveal && password != "" {
+				password = HiddenValue
+			}
+
+			history.Rows = append(
+				history.Rows,
+				[]string{password, formatTimestamp(entry.GetChangedAt())},
+			)
+		}
+
+		rv = append(rv, history)
+	}
+
+	return rv
+}
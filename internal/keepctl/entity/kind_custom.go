@@ -0,0 +1,220 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/totp"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+// HiddenValue replaces sensitive values on display.
+const HiddenValue = "*****"
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_CUSTOM,
+		Name:     "custom",
+		Title:    "arbitrary list of typed fields (API keys, server entries etc)",
+		Attributes: []Attribute{
+			{
+				Name:      "field",
+				Shorthand: "f",
+				Usage: "Field in form of name=value or name:type=value, " +
+					"supported types: text, hidden, url, email, number, date, totp; " +
+					"type of existing field is kept if omitted",
+				Type: AttrList,
+				get:  noValue,
+				set: func(msg proto.Message, value string) error {
+					return upsertField(dataOf[*goph.Custom](msg), value, false)
+				},
+			},
+			{
+				Name:      "hidden-field",
+				Usage:     "Sensitive field in form of name=value, hidden on display",
+				Type:      AttrList,
+				Sensitive: true,
+				get:       noValue,
+				set: func(msg proto.Message, value string) error {
+					return upsertField(dataOf[*goph.Custom](msg), value, true)
+				},
+			},
+			{
+				Name:     "remove-field",
+				Usage:    "Name of the field to remove",
+				Type:     AttrList,
+				EditOnly: true,
+				get:      noValue,
+				set: func(msg proto.Message, value string) error {
+					return removeField(dataOf[*goph.Custom](msg), value)
+				},
+			},
+		},
+		newMessage: func() proto.Message { return &goph.Custom{} },
+		validate: func(msg proto.Message) error {
+			fields := dataOf[*goph.Custom](msg).GetFields()
+			if len(fields) == 0 {
+				return ErrFieldsRequired
+			}
+
+			return ValidateFields(fields)
+		},
+		tables:     customTables,
+		toValues:   customToValues,
+		fromValues: customFromValues,
+		sensitive:  customSensitive,
+	})
+}
+
+// noValue is used for attributes which are not displayed directly.
+func noValue(proto.Message) string {
+	return ""
+}
+
+// findField returns index of the field with provided name or -1 if not found.
+func findField(fields []*goph.Field, name string) int {
+	for i, field := range fields {
+		if field.GetName() == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// upsertField replaces existing field by name keeping its position or appends new one.
+// If type of the field is not specified, type of the existing field is preserved.
+func upsertField(data *goph.Custom, spec string, hidden bool) error {
+	field, typed, err := parseField(spec)
+	if err != nil {
+		return err
+	}
+
+	if hidden {
+		field.Type = goph.FieldType_FIELD_HIDDEN
+		typed = true
+	}
+
+	idx := findField(data.Fields, field.GetName())
+	if idx < 0 {
+		data.Fields = append(data.Fields, field)
+
+		return nil
+	}
+
+	if !typed {
+		field.Type = data.Fields[idx].GetType()
+	}
+
+	data.Fields[idx] = field
+
+	return nil
+}
+
+// removeField removes field with provided name.
+func removeField(data *goph.Custom, name string) error {
+	idx := findField(data.Fields, name)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+	}
+
+	data.Fields = append(data.Fields[:idx], data.Fields[idx+1:]...)
+
+	return nil
+}
+
+// FormatField converts value of custom field to human-readable form.
+func FormatField(field *goph.Field, reveal bool) string {
+	switch field.GetType() {
+	case goph.FieldType_FIELD_HIDDEN:
+		if !reveal {
+			return HiddenValue
+		}
+
+	case goph.FieldType_FIELD_TOTP:
+		return FormatTOTP(field.GetValue(), reveal)
+
+	default:
+	}
+
+	return field.GetValue()
+}
+
+func customTables(msg proto.Message, reveal bool) []Table {
+	fields := dataOf[*goph.Custom](msg).GetFields()
+	rows := make([][]string, 0, len(fields))
+
+	for _, field := range fields {
+		rows = append(rows, []string{
+			field.GetName(),
+			FieldTypeName(field.GetType()),
+			FormatField(field, reveal),
+		})
+	}
+
+	return []Table{{Header: []string{"Field", "Type", "Value"}, Rows: rows}}
+}
+
+// FormatTOTP shows current one-time code generated from the TOTP secret.
+// The secret itself is shown only if revealed.
+func FormatTOTP(secret string, reveal bool) string {
+	key, err := totp.Parse(secret)
+	if err != nil {
+		return err.Error()
+	}
+
+	now := time.Now()
+	code := fmt.Sprintf("%s (expires in %s)", key.Code(now), key.Remaining(now))
+
+	if reveal {
+		code += ", secret: " + secret
+	}
+
+	return code
+}
+
+// customToValues exports fields using "name:type" keys, type is omitted for text fields.
+func customToValues(msg proto.Message) map[string]string {
+	fields := dataOf[*goph.Custom](msg).GetFields()
+	rv := make(map[string]string, len(fields))
+
+	for _, field := range fields {
+		key := field.GetName()
+		if field.GetType() != goph.FieldType_FIELD_TEXT {
+			key += ":" + FieldTypeName(field.GetType())
+		}
+
+		rv[key] = field.GetValue()
+	}
+
+	return rv
+}
+
+// customSensitive reports whether exported field is hidden or TOTP secret.
+func customSensitive(key string) bool {
+	_, typeName, _ := strings.Cut(key, ":")
+
+	return typeName == FieldTypeName(goph.FieldType_FIELD_HIDDEN) ||
+		typeName == FieldTypeName(goph.FieldType_FIELD_TOTP)
+}
+
+// customFromValues imports fields in order of their names.
+func customFromValues(msg proto.Message, values map[string]string) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := upsertField(dataOf[*goph.Custom](msg), key+"="+values[key], false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
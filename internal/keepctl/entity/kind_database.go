@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/creds"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_DATABASE,
+		Name:     "db",
+		Title:    "database connection parameters",
+		Attributes: []Attribute{
+			{
+				Name: "engine",
+				Usage: "Database engine: " +
+					strings.Join(EnumValues(goph.DatabaseEngine(0).Descriptor()), ", "),
+				Column:     "Engine",
+				Searchable: true,
+			},
+			{
+				Name:       "host",
+				Usage:      "Host name or IP address of the database server",
+				Column:     "Host",
+				Required:   true,
+				Searchable: true,
+			},
+			{
+				Name:   "port",
+				Usage:  "Port of the database server, default port of the engine if omitted",
+				Column: "Port",
+				display: func(msg proto.Message, _ bool) string {
+					return databasePort(dataOf[*goph.Database](msg))
+				},
+			},
+			{
+				Name:       "database",
+				Usage:      "Name of the database",
+				Column:     "Database",
+				Searchable: true,
+			},
+			{
+				Name:       "user",
+				Usage:      "Name of the database user",
+				Column:     "User",
+				Searchable: true,
+			},
+			{
+				Name:      "password",
+				Usage:     "Password of the database user",
+				Column:    "Password",
+				Sensitive: true,
+				Primary:   true,
+				Password:  true,
+			},
+			{
+				Name:   "ssl-mode",
+				Usage:  "SSL mode, e.g. disable, require or verify-full",
+				Column: "SSL mode",
+			},
+			{
+				Name:  "option",
+				Field: "options",
+				Usage: "Additional connection option in form of key=value",
+				Type:  AttrList,
+				set: func(msg proto.Message, value string) error {
+					return upsertOption(dataOf[*goph.Database](msg), value)
+				},
+				display: func(proto.Message, bool) string { return "" },
+			},
+			{
+				Name:     "remove-option",
+				Usage:    "Key of the connection option to remove",
+				Type:     AttrList,
+				EditOnly: true,
+				get:      noValue,
+				set: func(msg proto.Message, value string) error {
+					return removeOption(dataOf[*goph.Database](msg), value)
+				},
+			},
+			{
+				Name:     "url",
+				Column:   "URL",
+				ReadOnly: true,
+				get:      noValue,
+				display: func(msg proto.Message, reveal bool) string {
+					connURL, err := DatabaseURL(dataOf[*goph.Database](msg))
+					if err != nil {
+						return err.Error()
+					}
+
+					if reveal {
+						return connURL
+					}
+
+					return creds.ConnURI(connURL).String()
+				},
+			},
+		},
+		newMessage: func() proto.Message { return &goph.Database{} },
+		validate: func(msg proto.Message) error {
+			_, err := databaseOptions(dataOf[*goph.Database](msg))
+
+			return err
+		},
+		formats: map[string]func(msg proto.Message) (string, error){
+			"dsn": func(msg proto.Message) (string, error) {
+				return DatabaseDSN(dataOf[*goph.Database](msg))
+			},
+			"url": func(msg proto.Message) (string, error) {
+				return DatabaseURL(dataOf[*goph.Database](msg))
+			},
+			"env": func(msg proto.Message) (string, error) {
+				return DatabaseEnv(dataOf[*goph.Database](msg))
+			},
+			"pgpass": func(msg proto.Message) (string, error) {
+				return DatabasePgpass(dataOf[*goph.Database](msg))
+			},
+		},
+	})
+}
+
+// findOption returns index of the option with provided key or -1 if not found.
+func findOption(options []string, key string) int {
+	for i, opt := range options {
+		if k, _, _ := strings.Cut(opt, "="); k == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// upsertOption replaces value of existing option or appends new one.
+func upsertOption(db *goph.Database, option string) error {
+	key, _, found := strings.Cut(option, "=")
+	if !found || key == "" {
+		return fmt.Errorf("%w: %q", ErrBadOption, option)
+	}
+
+	idx := findOption(db.Options, key)
+	if idx < 0 {
+		db.Options = append(db.Options, option)
+
+		return nil
+	}
+
+	db.Options[idx] = option
+
+	return nil
+}
+
+// removeOption removes option with provided key.
+func removeOption(db *goph.Database, key string) error {
+	idx := findOption(db.Options, key)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownAttribute, key)
+	}
+
+	db.Options = append(db.Options[:idx], db.Options[idx+1:]...)
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_DOCUMENT,
+		Name:     "document",
+		Title:    "identity document (passport, driver licence etc)",
+		Attributes: append(
+			[]Attribute{
+				{
+					Name: "type",
+					Usage: "Type of the document: " +
+						strings.Join(EnumValues(goph.DocumentType(0).Descriptor()), ", "),
+					Column:     "Type",
+					Searchable: true,
+				},
+				{
+					Name:     "number",
+					Usage:    "Number of the document",
+					Column:   "Number",
+					Required: true,
+					Primary:  true,
+				},
+				{
+					Name:       "full-name",
+					Usage:      "Full name of the document holder",
+					Column:     "Full name",
+					Searchable: true,
+				},
+				{
+					Name:   "birth-date",
+					Usage:  "Birth date of the holder in the YYYY-MM-DD format",
+					Column: "Birth date",
+				},
+				{
+					Name:   "issue-date",
+					Usage:  "Issue date in the YYYY-MM-DD format",
+					Column: "Issued",
+				},
+				{
+					Name:   "expiry-date",
+					Usage:  "Expiry date in the YYYY-MM-DD format",
+					Column: "Expires",
+					display: func(msg proto.Message, _ bool) string {
+						return formatExpiryDate(dataOf[*goph.Document](msg).GetExpiryDate(), time.Now())
+					},
+				},
+				{
+					Name:       "authority",
+					Usage:      "Name of the issuing authority",
+					Column:     "Authority",
+					Searchable: true,
+				},
+				{
+					Name:   "issuing-country",
+					Field:  "country",
+					Usage:  "Issuing country",
+					Column: "Country",
+				},
+			},
+			addressAttributes("Registration address")...,
+		),
+		newMessage: func() proto.Message { return &goph.Document{} },
+		validate:   validateDocument,
+		warnings: func(msg proto.Message, now time.Time) []string {
+			data := dataOf[*goph.Document](msg)
+
+			expires, err := ParseDate("expiry date", data.GetExpiryDate())
+			if err != nil {
+				return nil
+			}
+
+			warning := ExpiryWarning("document", expires, now)
+			if warning == "" {
+				return nil
+			}
+
+			return []string{warning}
+		},
+		tables: func(msg proto.Message, _ bool) []Table {
+			return addressTables(dataOf[*goph.Document](msg).GetAddress())
+		},
+	})
+}
+
+func validateDocument(msg proto.Message) error {
+	data := dataOf[*goph.Document](msg)
+	now := time.Now()
+
+	if err := ValidatePastDate("birth date", data.GetBirthDate(), now); err != nil {
+		return err
+	}
+
+	if err := ValidatePastDate("issue date", data.GetIssueDate(), now); err != nil {
+		return err
+	}
+
+	if err := ValidateDatesOrder(
+		"birth date", data.GetBirthDate(),
+		"issue date", data.GetIssueDate(),
+	); err != nil {
+		return err
+	}
+
+	return ValidateDatesOrder(
+		"issue date", data.GetIssueDate(),
+		"expiry date", data.GetExpiryDate(),
+	)
+}
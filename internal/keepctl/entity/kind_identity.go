@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_IDENTITY,
+		Name:     "identity",
+		Title:    "personal identity info",
+		Attributes: append(
+			[]Attribute{
+				{
+					Name:       "full-name",
+					Usage:      "Full name of the person",
+					Column:     "Full name",
+					Required:   true,
+					Searchable: true,
+				},
+				{
+					Name:   "birth-date",
+					Usage:  "Birth date in the YYYY-MM-DD format",
+					Column: "Birth date",
+				},
+				{
+					Name:       "email",
+					Usage:      "Email address",
+					Column:     "Email",
+					Searchable: true,
+					Primary:    true,
+				},
+				{
+					Name:       "phone",
+					Usage:      "Phone number",
+					Column:     "Phone",
+					Searchable: true,
+				},
+			},
+			addressAttributes("Home address")...,
+		),
+		newMessage: func() proto.Message { return &goph.Identity{} },
+		validate: func(msg proto.Message) error {
+			data := dataOf[*goph.Identity](msg)
+
+			if err := ValidatePastDate("birth date", data.GetBirthDate(), time.Now()); err != nil {
+				return err
+			}
+
+			if data.GetEmail() == "" {
+				return nil
+			}
+
+			if _, err := mail.ParseAddress(data.GetEmail()); err != nil {
+				return fmt.Errorf("%w %q: %s", ErrBadAttribute, "email", err)
+			}
+
+			return nil
+		},
+		tables: func(msg proto.Message, _ bool) []Table {
+			return addressTables(dataOf[*goph.Identity](msg).GetAddress())
+		},
+	})
+}
+
+// addressAttributes describes fields of the address nested into data message.
+func addressAttributes(usage string) []Attribute {
+	hidden := func(proto.Message, bool) string { return "" }
+
+	return []Attribute{
+		{
+			Name:    "street",
+			Field:   "address.street",
+			Usage:   usage + ": street, house and apartment",
+			display: hidden,
+		},
+		{
+			Name:    "city",
+			Field:   "address.city",
+			Usage:   usage + ": city or settlement",
+			display: hidden,
+		},
+		{
+			Name:    "region",
+			Field:   "address.region",
+			Usage:   usage + ": state, province or region",
+			display: hidden,
+		},
+		{
+			Name:    "postal-code",
+			Field:   "address.postal_code",
+			Usage:   usage + ": postal code",
+			display: hidden,
+		},
+		{
+			Name:    "country",
+			Field:   "address.country",
+			Usage:   usage + ": country",
+			display: hidden,
+		},
+	}
+}
+
+// addressTables renders address as a separate table if it is set.
+func addressTables(address *goph.Address) []Table {
+	if address == nil || proto.Equal(address, &goph.Address{}) {
+		return nil
+	}
+
+	return []Table{
+		{
+			Header: []string{"Street", "City", "Region", "Postal code", "Country"},
+			Rows: [][]string{
+				{
+					address.GetStreet(),
+					address.GetCity(),
+					address.GetRegion(),
+					address.GetPostalCode(),
+					address.GetCountry(),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_SEED_PHRASE,
+		Name:     "seed",
+		Title:    "crypto wallet seed phrase (BIP39)",
+		Attributes: []Attribute{
+			{
+				Name:      "phrase",
+				Usage:     "Space-separated mnemonic words of the seed phrase",
+				Required:  true,
+				Primary:   true,
+				Sensitive: true,
+				get: func(msg proto.Message) string {
+					return strings.Join(dataOf[*goph.SeedPhrase](msg).GetWords(), " ")
+				},
+				set: func(msg proto.Message, value string) error {
+					dataOf[*goph.SeedPhrase](msg).Words = SplitSeedPhrase(value)
+
+					return nil
+				},
+				display: func(proto.Message, bool) string { return "" },
+			},
+			{
+				Name:      "passphrase",
+				Usage:     "Optional BIP39 passphrase (25th word)",
+				Column:    "Passphrase",
+				Sensitive: true,
+			},
+			{
+				Name:   "derivation-path",
+				Usage:  "Note on the derivation path used by the wallet, e.g. m/84'/0'/0'",
+				Column: "Derivation path",
+			},
+		},
+		newMessage: func() proto.Message { return &goph.SeedPhrase{} },
+		validate: func(msg proto.Message) error {
+			return ValidateSeedPhrase(dataOf[*goph.SeedPhrase](msg).GetWords())
+		},
+		tables: seedTables,
+	})
+}
+
+// seedTables renders numbered words of the seed phrase, the words are masked unless revealed.
+func seedTables(msg proto.Message, reveal bool) []Table {
+	words := dataOf[*goph.SeedPhrase](msg).GetWords()
+	table := Table{Header: []string{"#", "Word"}}
+
+	for i, word := range words {
+		if !reveal {
+			word = HiddenValue
+		}
+
+		table.Rows = append(table.Rows, []string{strconv.Itoa(i + 1), word})
+	}
+
+	return []Table{table}
+}
@@ -0,0 +1,814 @@
+package entity_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestKindsRegistered(t *testing.T) {
+	for name := range goph.DataKind_value {
+		t.Run(name, func(t *testing.T) {
+			_, err := entity.KindOf(goph.DataKind(goph.DataKind_value[name]))
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUnknownKind(t *testing.T) {
+	_, err := entity.KindByName("unknown")
+
+	require.ErrorIs(t, err, entity.ErrUnknownKind)
+}
+
+func TestApplyChanges(t *testing.T) {
+	tt := []struct {
+		name     string
+		kind     goph.DataKind
+		data     proto.Message
+		changes  []entity.Change
+		expected proto.Message
+	}{
+		{
+			name:     "Change credentials",
+			kind:     goph.DataKind_CREDENTIALS,
+			data:     &goph.Credentials{Login: "root", Password: "1q2w3e"},
+			changes:  []entity.Change{{Attribute: "login", Value: "admin"}},
+			expected: &goph.Credentials{Login: "admin", Password: "1q2w3e"},
+		},
+		{
+			name: "Change bank card",
+			kind: goph.DataKind_CARD,
+			data: &goph.Card{Number: "4111111111111111", Cvv: "123"},
+			changes: []entity.Change{
+				{Attribute: "number", Value: "5555 5555 5555 4444"},
+				{Attribute: "cvv", Value: "012"},
+				{Attribute: "type", Value: "credit"},
+			},
+			expected: &goph.Card{
+				Number: "5555555555554444",
+				Cvv:    "012",
+				Type:   goph.CardType_CARD_CREDIT,
+			},
+		},
+		{
+			name:     "Change binary data",
+			kind:     goph.DataKind_BINARY,
+			data:     &goph.Binary{},
+			changes:  []entity.Change{{Attribute: "binary-data", Value: "0aff"}},
+			expected: &goph.Binary{Binary: []byte{0x0a, 0xff}},
+		},
+		{
+			name: "Change database options",
+			kind: goph.DataKind_DATABASE,
+			data: &goph.Database{Host: "db", Options: []string{"a=1", "b=2"}},
+			changes: []entity.Change{
+				{Attribute: "engine", Value: "mysql"},
+				{Attribute: "option", Value: "a=3"},
+				{Attribute: "remove-option", Value: "b"},
+			},
+			expected: &goph.Database{
+				Engine:  goph.DatabaseEngine_DB_MYSQL,
+				Host:    "db",
+				Options: []string{"a=3"},
+			},
+		},
+		{
+			name: "Change custom fields",
+			kind: goph.DataKind_CUSTOM,
+			data: &goph.Custom{
+				Fields: []*goph.Field{
+					{Name: "token", Type: goph.FieldType_FIELD_HIDDEN, Value: "xxx"},
+					{Name: "url", Type: goph.FieldType_FIELD_URL, Value: "https://example.com"},
+				},
+			},
+			changes: []entity.Change{
+				{Attribute: "remove-field", Value: "url"},
+				{Attribute: "field", Value: "token=yyy"},
+				{Attribute: "hidden-field", Value: "pin=1234"},
+			},
+			expected: &goph.Custom{
+				Fields: []*goph.Field{
+					{Name: "token", Type: goph.FieldType_FIELD_HIDDEN, Value: "yyy"},
+					{Name: "pin", Type: goph.FieldType_FIELD_HIDDEN, Value: "1234"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOf(tc.kind)
+			require.NoError(t, err)
+
+			err = kind.Apply(tc.data, tc.changes)
+
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected, tc.data))
+		})
+	}
+}
+
+func TestApplyChangesFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		kind     goph.DataKind
+		change   entity.Change
+		expected error
+	}{
+		{
+			name:     "Unknown attribute",
+			kind:     goph.DataKind_TEXT,
+			change:   entity.Change{Attribute: "login", Value: "root"},
+			expected: entity.ErrUnknownAttribute,
+		},
+		{
+			name:     "Unknown card type",
+			kind:     goph.DataKind_CARD,
+			change:   entity.Change{Attribute: "type", Value: "golden"},
+			expected: entity.ErrBadAttribute,
+		},
+		{
+			name:     "Remove missing URI",
+			kind:     goph.DataKind_CREDENTIALS,
+			change:   entity.Change{Attribute: "remove-uri", Value: "https://example.com"},
+			expected: entity.ErrURINotFound,
+		},
+		{
+			name:     "Remove missing field",
+			kind:     goph.DataKind_CUSTOM,
+			change:   entity.Change{Attribute: "remove-field", Value: "pin"},
+			expected: entity.ErrFieldNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOf(tc.kind)
+			require.NoError(t, err)
+
+			err = kind.Apply(kind.New(), []entity.Change{tc.change})
+
+			require.ErrorIs(t, err, tc.expected)
+
+			var verr entity.ValidationError
+			require.ErrorAs(t, err, &verr)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+	require.NoError(t, err)
+
+	data := &goph.Credentials{}
+
+	for i := 0; i <= entity.MaxPasswordHistory+1; i++ {
+		err = kind.Apply(data, []entity.Change{{Attribute: "password", Value: strconv.Itoa(i)}})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, strconv.Itoa(entity.MaxPasswordHistory+1), data.GetPassword())
+	require.NotNil(t, data.GetPasswordChanged())
+	require.Len(t, data.GetPasswordHistory(), entity.MaxPasswordHistory)
+	require.Equal(t, strconv.Itoa(entity.MaxPasswordHistory), data.GetPasswordHistory()[0].GetPassword())
+}
+
+func TestChangePasswordToTheSameValue(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+	require.NoError(t, err)
+
+	data := &goph.Credentials{Password: "1q2w3e"}
+
+	err = kind.Apply(data, []entity.Change{{Attribute: "password", Value: "1q2w3e"}})
+
+	require.NoError(t, err)
+	require.Empty(t, data.GetPasswordHistory())
+}
+
+func TestPasswordHistoryTable(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+	require.NoError(t, err)
+
+	data := &goph.Credentials{
+		Password: "new",
+		PasswordHistory: []*goph.PasswordHistory{
+			{Password: "old", ChangedAt: timestamppb.Now()},
+		},
+	}
+
+	tt := []struct {
+		name     string
+		reveal   bool
+		expected string
+	}{
+		{
+			name:     "Previous passwords are masked",
+			expected: entity.HiddenValue,
+		},
+		{
+			name:     "Previous passwords are revealed",
+			reveal:   true,
+			expected: "old",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tables := kind.Tables(data, tc.reveal)
+
+			require.Len(t, tables, 1)
+			require.Equal(t, tc.expected, tables[0].Rows[0][0])
+		})
+	}
+}
+
+func TestValidateCredsFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		data     *goph.Credentials
+		expected error
+	}{
+		{
+			name: "Relative URI",
+			data: &goph.Credentials{
+				Uris: []*goph.Uri{{Uri: "/login", Match: goph.UriMatch_MATCH_HOST}},
+			},
+			expected: entity.ErrBadURI,
+		},
+		{
+			name: "Bad regular expression",
+			data: &goph.Credentials{
+				Uris: []*goph.Uri{{Uri: "(", Match: goph.UriMatch_MATCH_REGEX}},
+			},
+			expected: entity.ErrBadURI,
+		},
+		{
+			name:     "Bad TOTP secret",
+			data:     &goph.Credentials{Totp: "123!"},
+			expected: entity.ErrBadAttribute,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+			require.NoError(t, err)
+
+			err = kind.Validate(tc.data)
+
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestValidateCardFailure(t *testing.T) {
+	valid := &goph.Card{Number: "4111111111111111", Expiration: "12/30", Cvv: "123"}
+
+	tt := []struct {
+		name     string
+		change   func(data *goph.Card)
+		expected error
+	}{
+		{
+			name:     "Wrong check digit",
+			change:   func(data *goph.Card) { data.Number = "4111111111111112" },
+			expected: entity.ErrBadCardNumber,
+		},
+		{
+			name:     "Too short number",
+			change:   func(data *goph.Card) { data.Number = "4111" },
+			expected: entity.ErrBadCardNumber,
+		},
+		{
+			name:     "Expiration with full year",
+			change:   func(data *goph.Card) { data.Expiration = "12/2030" },
+			expected: entity.ErrBadExpiration,
+		},
+		{
+			name:     "Expiration with bad month",
+			change:   func(data *goph.Card) { data.Expiration = "13/30" },
+			expected: entity.ErrBadExpiration,
+		},
+		{
+			name:     "Too long CVV",
+			change:   func(data *goph.Card) { data.Cvv = "12345" },
+			expected: entity.ErrBadCVV,
+		},
+		{
+			name:     "Not a number PIN",
+			change:   func(data *goph.Card) { data.Pin = "12ab" },
+			expected: entity.ErrBadPIN,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOf(goph.DataKind_CARD)
+			require.NoError(t, err)
+
+			data := proto.Clone(valid).(*goph.Card)
+			tc.change(data)
+
+			require.NoError(t, kind.Validate(valid))
+			require.ErrorIs(t, kind.Validate(data), tc.expected)
+		})
+	}
+}
+
+func TestValidateCardWithoutCVV(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CARD)
+	require.NoError(t, err)
+
+	data := &goph.Card{Number: "4111111111111111", Expiration: "12/30"}
+
+	require.NoError(t, kind.Validate(data))
+}
+
+func TestUpgradeLegacyCVV(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CARD)
+	require.NoError(t, err)
+
+	data := &goph.Card{LegacyCvv: 12}
+	kind.Upgrade(data)
+
+	require.Equal(t, "012", data.GetCvv())
+	require.Zero(t, data.GetLegacyCvv())
+}
+
+func TestDisplayCard(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CARD)
+	require.NoError(t, err)
+
+	data := &goph.Card{Number: "2200000000000004", Cvv: "123"}
+
+	tt := []struct {
+		name     string
+		attr     string
+		reveal   bool
+		expected string
+	}{
+		{
+			name:     "Masked number",
+			attr:     "number",
+			expected: "************0004",
+		},
+		{
+			name:     "Revealed number",
+			attr:     "number",
+			reveal:   true,
+			expected: "2200000000000004",
+		},
+		{
+			name:     "Brand",
+			attr:     "brand",
+			expected: "Mir",
+		},
+		{
+			name:     "Masked CVV",
+			attr:     "cvv",
+			expected: entity.HiddenValue,
+		},
+		{
+			name:     "Empty PIN",
+			attr:     "pin",
+			expected: "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			attr, err := kind.Attribute(tc.attr)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, attr.Display(data, tc.reveal))
+		})
+	}
+}
+
+func TestDisplayBinary(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_BINARY)
+	require.NoError(t, err)
+
+	attr, err := kind.Attribute("binary-data")
+	require.NoError(t, err)
+
+	data := &goph.Binary{Binary: []byte("raw data")}
+
+	require.Equal(t, "raw data", attr.Display(data, false))
+	require.Equal(t, "7261772064617461", attr.Get(data))
+}
+
+func TestApplyAddressChanges(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_DOCUMENT)
+	require.NoError(t, err)
+
+	data := &goph.Document{}
+
+	err = kind.Apply(data, []entity.Change{
+		{Attribute: "type", Value: "driver_licence"},
+		{Attribute: "issuing-country", Value: "UK"},
+		{Attribute: "city", Value: "London"},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, goph.DocumentType_DOCUMENT_DRIVER_LICENCE, data.GetType())
+	require.Equal(t, "UK", data.GetCountry())
+	require.Equal(t, "London", data.GetAddress().GetCity())
+}
+
+func TestValidateDocumentFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		data     *goph.Document
+		expected error
+	}{
+		{
+			name:     "Bad birth date",
+			data:     &goph.Document{BirthDate: "31.01.1980"},
+			expected: entity.ErrBadDate,
+		},
+		{
+			name:     "Issue date in future",
+			data:     &goph.Document{IssueDate: "2999-01-01"},
+			expected: entity.ErrDateInFuture,
+		},
+		{
+			name:     "Issued before birth",
+			data:     &goph.Document{BirthDate: "1980-01-31", IssueDate: "1979-01-01"},
+			expected: entity.ErrBadDatesOrder,
+		},
+		{
+			name:     "Expires before issue",
+			data:     &goph.Document{IssueDate: "2020-01-01", ExpiryDate: "2019-01-01"},
+			expected: entity.ErrBadDatesOrder,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOf(goph.DataKind_DOCUMENT)
+			require.NoError(t, err)
+
+			require.ErrorIs(t, kind.Validate(tc.data), tc.expected)
+		})
+	}
+}
+
+func TestDocumentWarnings(t *testing.T) {
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name       string
+		expiryDate string
+		expected   []string
+	}{
+		{
+			name:       "Valid document",
+			expiryDate: "2030-01-01",
+		},
+		{
+			name: "Unlimited document",
+		},
+		{
+			name:       "Document expires soon",
+			expiryDate: "2023-05-31",
+			expected:   []string{"document expires in 30 days (2023-05-31)"},
+		},
+		{
+			name:       "Expired document",
+			expiryDate: "2023-05-01",
+			expected:   []string{"document expired on 2023-05-01"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOf(goph.DataKind_DOCUMENT)
+			require.NoError(t, err)
+
+			warnings := kind.Warnings(&goph.Document{ExpiryDate: tc.expiryDate}, now)
+
+			require.Equal(t, tc.expected, warnings)
+		})
+	}
+}
+
+func TestValidateCustomWithoutFields(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CUSTOM)
+	require.NoError(t, err)
+
+	err = kind.Validate(kind.New())
+
+	require.ErrorIs(t, err, entity.ErrFieldsRequired)
+}
+
+func TestExportImport(t *testing.T) {
+	tt := []struct {
+		name string
+		data proto.Message
+	}{
+		{
+			name: "Credentials",
+			data: &goph.Credentials{
+				Login:    "root",
+				Password: "1q2w3e",
+				Uris: []*goph.Uri{
+					{Uri: "https://example.com", Match: goph.UriMatch_MATCH_DOMAIN},
+					{Uri: "^https://.*\\.example\\.com/", Match: goph.UriMatch_MATCH_REGEX},
+				},
+				Totp:            "JBSWY3DPEHPK3PXP",
+				PasswordChanged: timestamppb.New(time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Bank card",
+			data: &goph.Card{
+				Number:     "4111111111111111",
+				Expiration: "12/30",
+				Holder:     "John Doe",
+				Cvv:        "012",
+				Pin:        "1234",
+				Bank:       "ACME Bank",
+				Type:       goph.CardType_CARD_DEBIT,
+			},
+		},
+		{
+			name: "Identity with address",
+			data: &goph.Identity{
+				FullName:  "John Doe",
+				BirthDate: "1980-01-31",
+				Address:   &goph.Address{City: "London", PostalCode: "SW1A 1AA"},
+			},
+		},
+		{
+			name: "Custom fields",
+			data: &goph.Custom{
+				Fields: []*goph.Field{
+					{Name: "a", Type: goph.FieldType_FIELD_TEXT, Value: "1"},
+					{Name: "b", Type: goph.FieldType_FIELD_HIDDEN, Value: "2"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOfMessage(tc.data)
+			require.NoError(t, err)
+
+			data, err := kind.Import(kind.Export(tc.data))
+
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.data, data))
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	tt := []struct {
+		name     string
+		data     proto.Message
+		reveal   bool
+		expected map[string]string
+	}{
+		{
+			name: "Bank card",
+			data: &goph.Card{Number: "4111111111111111", Holder: "John Doe", Cvv: "012"},
+			expected: map[string]string{
+				"number": entity.HiddenValue,
+				"holder": "John Doe",
+				"cvv":    entity.HiddenValue,
+				"type":   "unknown",
+			},
+		},
+		{
+			name:   "Revealed bank card",
+			data:   &goph.Card{Number: "4111111111111111", Holder: "John Doe", Cvv: "012"},
+			reveal: true,
+			expected: map[string]string{
+				"number": "4111111111111111",
+				"holder": "John Doe",
+				"cvv":    "012",
+				"type":   "unknown",
+			},
+		},
+		{
+			name: "Credentials",
+			data: &goph.Credentials{Login: "alice", Password: "1q2w3e"},
+			expected: map[string]string{
+				"login":    "alice",
+				"password": entity.HiddenValue,
+			},
+		},
+		{
+			name:   "Revealed credentials",
+			data:   &goph.Credentials{Login: "alice", Password: "1q2w3e"},
+			reveal: true,
+			expected: map[string]string{
+				"login":    "alice",
+				"password": "1q2w3e",
+			},
+		},
+		{
+			name: "Seed phrase",
+			data: &goph.SeedPhrase{Words: []string{"legal", "winner"}},
+			expected: map[string]string{
+				"phrase": entity.HiddenValue,
+			},
+		},
+		{
+			name: "Custom fields",
+			data: &goph.Custom{
+				Fields: []*goph.Field{
+					{Name: "a", Type: goph.FieldType_FIELD_TEXT, Value: "1"},
+					{Name: "b", Type: goph.FieldType_FIELD_HIDDEN, Value: "2"},
+					{Name: "c", Type: goph.FieldType_FIELD_TOTP, Value: "JBSWY3DPEHPK3PXP"},
+				},
+			},
+			expected: map[string]string{
+				"a":        "1",
+				"b:hidden": entity.HiddenValue,
+				"c:totp":   entity.HiddenValue,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOfMessage(tc.data)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, kind.Values(tc.data, tc.reveal))
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	tt := []struct {
+		name     string
+		data     proto.Message
+		key      string
+		expected string
+		err      error
+	}{
+		{
+			name:     "Password of credentials",
+			data:     &goph.Credentials{Login: "root", Password: "1q2w3e"},
+			key:      "password",
+			expected: "1q2w3e",
+		},
+		{
+			name: "Empty attribute",
+			data: &goph.Credentials{Login: "root", Password: "1q2w3e"},
+			key:  "totp",
+		},
+		{
+			name: "Custom field without type",
+			data: &goph.Custom{
+				Fields: []*goph.Field{
+					{Name: "token", Type: goph.FieldType_FIELD_HIDDEN, Value: "s3cr3t"},
+				},
+			},
+			key:      "token",
+			expected: "s3cr3t",
+		},
+		{
+			name: "Unknown attribute",
+			data: &goph.Credentials{Login: "root", Password: "1q2w3e"},
+			key:  "pin",
+			err:  entity.ErrUnknownAttribute,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, err := entity.KindOfMessage(tc.data)
+			require.NoError(t, err)
+
+			value, err := kind.Value(tc.data, tc.key)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestCredsQRCode(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+	require.NoError(t, err)
+
+	rv, err := kind.QRCode("ACME", &goph.Credentials{Login: "john", Totp: "JBSWY3DPEHPK3PXP"})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"otpauth://totp/ACME:john?algorithm=SHA1&digits=6&issuer=ACME&period=30&secret=JBSWY3DPEHPK3PXP",
+		rv,
+	)
+
+	_, err = kind.QRCode("ACME", &goph.Credentials{Login: "john"})
+	require.ErrorIs(t, err, entity.ErrNoQRCode)
+}
+
+func TestNoQRCode(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_TEXT)
+	require.NoError(t, err)
+
+	_, err = kind.QRCode("notes", &goph.Text{Text: "hello"})
+	require.ErrorIs(t, err, entity.ErrNoQRCode)
+}
+
+func TestSearchableAttributesAreNotSensitive(t *testing.T) {
+	for _, kind := range entity.Kinds() {
+		for _, attr := range kind.Attributes {
+			require.False(t, attr.Searchable && attr.Sensitive, "%s %s", kind.Name, attr.Name)
+		}
+	}
+}
+
+func TestPrimaryAttribute(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+	require.NoError(t, err)
+
+	attr, err := kind.PrimaryAttribute()
+	require.NoError(t, err)
+	require.Equal(t, "password", attr.Name)
+
+	kind, err = entity.KindOf(goph.DataKind_CUSTOM)
+	require.NoError(t, err)
+
+	_, err = kind.PrimaryAttribute()
+	require.ErrorIs(t, err, entity.ErrNoPrimary)
+}
+
+func TestPasswords(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+	require.NoError(t, err)
+
+	require.True(t, kind.HasPasswords())
+	require.Equal(
+		t,
+		map[string]string{"password": "secret"},
+		kind.Passwords(&goph.Credentials{Login: "admin", Password: "secret"}),
+	)
+	require.Empty(t, kind.Passwords(&goph.Credentials{Login: "admin"}))
+
+	kind, err = entity.KindOf(goph.DataKind_TEXT)
+	require.NoError(t, err)
+
+	require.False(t, kind.HasPasswords())
+	require.Empty(t, kind.Passwords(&goph.Text{Text: "secret"}))
+}
+
+func TestRawValue(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_BINARY)
+	require.NoError(t, err)
+
+	value, err := kind.RawValue("binary-data", []byte{0xde, 0xad, 0xbe, 0xef})
+	require.NoError(t, err)
+	require.Equal(t, "deadbeef", value)
+
+	data := kind.New()
+	require.NoError(t, kind.Apply(data, []entity.Change{{Attribute: "binary-data", Value: value}}))
+	require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, data.(*goph.Binary).GetBinary())
+
+	kind, err = entity.KindOf(goph.DataKind_TEXT)
+	require.NoError(t, err)
+
+	value, err = kind.RawValue("text", []byte("line 1\nline 2\n"))
+	require.NoError(t, err)
+	require.Equal(t, "line 1\nline 2\n", value)
+
+	_, err = kind.RawValue("binary-data", nil)
+	require.ErrorIs(t, err, entity.ErrUnknownAttribute)
+}
+
+func TestPromptedAttributes(t *testing.T) {
+	for _, tc := range []struct {
+		kind     goph.DataKind
+		attr     string
+		prompted bool
+	}{
+		{goph.DataKind_CREDENTIALS, "password", true},
+		{goph.DataKind_CREDENTIALS, "login", false},
+		{goph.DataKind_CARD, "cvv", true},
+		{goph.DataKind_TEXT, "text", true},
+		{goph.DataKind_BINARY, "binary-data", true},
+		{goph.DataKind_SEED_PHRASE, "phrase", true},
+		{goph.DataKind_CUSTOM, "hidden-field", true},
+	} {
+		kind, err := entity.KindOf(tc.kind)
+		require.NoError(t, err)
+
+		attr, err := kind.Attribute(tc.attr)
+		require.NoError(t, err)
+		require.Equal(t, tc.prompted, attr.Prompted(), tc.attr)
+	}
+}
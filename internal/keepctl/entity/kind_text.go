@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_TEXT,
+		Name:     "text",
+		Title:    "arbitrary text",
+		Attributes: []Attribute{
+			{
+				Name:         "text",
+				Shorthand:    "t",
+				Usage:        "Text data",
+				Required:     true,
+				Primary:      true,
+				Confidential: true,
+				FromFile:     true,
+			},
+		},
+		newMessage: func() proto.Message { return &goph.Text{} },
+	})
+}
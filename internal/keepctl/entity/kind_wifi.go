@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	registerKind(&Kind{
+		DataKind: goph.DataKind_WIFI,
+		Name:     "wifi",
+		Title:    "Wi-Fi network",
+		Attributes: []Attribute{
+			{
+				Name:       "ssid",
+				Usage:      "Name of the network",
+				Column:     "SSID",
+				Required:   true,
+				Searchable: true,
+			},
+			{
+				Name: "security",
+				Usage: "Security type of the network: " +
+					strings.Join(EnumValues(goph.WifiSecurity(0).Descriptor()), ", "),
+				Column: "Security",
+			},
+			{
+				Name:      "password",
+				Shorthand: "p",
+				Usage:     "Password of the network",
+				Column:    "Password",
+				Sensitive: true,
+				Primary:   true,
+				Password:  true,
+			},
+			{
+				Name:   "hidden",
+				Usage:  "Network does not broadcast its name",
+				Type:   AttrBool,
+				Column: "Hidden",
+			},
+		},
+		newMessage: func() proto.Message { return &goph.Wifi{} },
+		validate: func(msg proto.Message) error {
+			data := dataOf[*goph.Wifi](msg)
+
+			return ValidateWifiPassword(data.GetSecurity(), data.GetPassword())
+		},
+		qrCode: func(_ string, msg proto.Message) (string, error) {
+			return WifiQRCode(dataOf[*goph.Wifi](msg)), nil
+		},
+	})
+}
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+// FolderSeparator separates parts of the folder path.
+const FolderSeparator = "/"
+
+var (
+	ErrBadFolder = errors.New("bad folder path")
+	ErrBadTag    = errors.New("bad tag")
+)
+
+// NormalizeFolder validates folder path and brings it to the canonical form,
+// e.g. "/work//aws/" is not allowed but " work/aws/ " becomes "work/aws".
+// Empty path and "/" denote the root folder.
+func NormalizeFolder(path string) (string, error) {
+	path = strings.Trim(strings.TrimSpace(path), FolderSeparator)
+	if path == "" {
+		return "", nil
+	}
+
+	parts := strings.Split(path, FolderSeparator)
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+
+		switch parts[i] {
+		case "", ".", "..":
+			return "", fmt.Errorf("%w: %q", ErrBadFolder, path)
+		}
+	}
+
+	return strings.Join(parts, FolderSeparator), nil
+}
+
+// NormalizeTags validates tags and returns sorted list of unique tags.
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(tags))
+	rv := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || strings.IndexFunc(tag, isTagDelimiter) != -1 {
+			return nil, fmt.Errorf("%w: %q", ErrBadTag, tag)
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		rv = append(rv, tag)
+	}
+
+	sort.Strings(rv)
+
+	return rv, nil
+}
+
+// isTagDelimiter reports whether the rune can't be part of a tag.
+func isTagDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == ','
+}
+
+// NormalizeLabels validates folder and tags of the labels in place.
+func NormalizeLabels(labels *goph.Labels) error {
+	folder, err := NormalizeFolder(labels.GetFolder())
+	if err != nil {
+		return err
+	}
+
+	tags, err := NormalizeTags(labels.GetTags())
+	if err != nil {
+		return err
+	}
+
+	labels.Folder = folder
+	labels.Tags = tags
+
+	return nil
+}
+
+// LabelsOf extracts decrypted labels of the secret.
+// Secrets without labels are placed into the root folder.
+func LabelsOf(secret *goph.Secret) (*goph.Labels, error) {
+	labels := new(goph.Labels)
+
+	if err := proto.Unmarshal(secret.GetLabels(), labels); err != nil {
+		return nil, fmt.Errorf("LabelsOf - proto.Unmarshal: %w", err)
+	}
+
+	return labels, nil
+}
+
+// InFolder reports whether the labels belong to the folder or one of its subfolders.
+// All labels belong to the root folder.
+func InFolder(labels *goph.Labels, folder string) bool {
+	if folder == "" {
+		return true
+	}
+
+	return labels.GetFolder() == folder ||
+		strings.HasPrefix(labels.GetFolder(), folder+FolderSeparator)
+}
+
+// HasTags reports whether the labels contain all the provided tags.
+func HasTags(labels *goph.Labels, tags ...string) bool {
+	for _, tag := range tags {
+		if !Contains(labels.GetTags(), tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Contains reports whether the list contains the value.
+func Contains[T comparable](list []T, value T) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
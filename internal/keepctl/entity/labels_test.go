@@ -0,0 +1,131 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNormalizeFolder(t *testing.T) {
+	tt := []struct {
+		name     string
+		path     string
+		expected string
+		err      error
+	}{
+		{
+			name: "Root folder",
+			path: "",
+		},
+		{
+			name: "Root folder with separator",
+			path: " / ",
+		},
+		{
+			name:     "Nested folder",
+			path:     "/work/aws/",
+			expected: "work/aws",
+		},
+		{
+			name:     "Folder with spaces",
+			path:     " home / bank accounts ",
+			expected: "home/bank accounts",
+		},
+		{
+			name: "Empty part",
+			path: "work//aws",
+			err:  entity.ErrBadFolder,
+		},
+		{
+			name: "Relative part",
+			path: "work/../aws",
+			err:  entity.ErrBadFolder,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			folder, err := entity.NormalizeFolder(tc.path)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, folder)
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tt := []struct {
+		name     string
+		tags     []string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "No tags",
+			expected: []string{},
+		},
+		{
+			name:     "Duplicated tags are sorted",
+			tags:     []string{"prod", " aws", "prod "},
+			expected: []string{"aws", "prod"},
+		},
+		{
+			name: "Empty tag",
+			tags: []string{"prod", " "},
+			err:  entity.ErrBadTag,
+		},
+		{
+			name: "Tag with delimiter",
+			tags: []string{"prod,aws"},
+			err:  entity.ErrBadTag,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tags, err := entity.NormalizeTags(tc.tags)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, tags)
+		})
+	}
+}
+
+func TestLabelsOf(t *testing.T) {
+	expected := &goph.Labels{Folder: "work/aws", Tags: []string{"prod"}}
+
+	raw, err := proto.Marshal(expected)
+	require.NoError(t, err)
+
+	labels, err := entity.LabelsOf(&goph.Secret{Labels: raw})
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, labels))
+
+	labels, err = entity.LabelsOf(&goph.Secret{})
+	require.NoError(t, err)
+	require.Empty(t, labels.GetFolder())
+	require.Empty(t, labels.GetTags())
+}
+
+func TestInFolder(t *testing.T) {
+	labels := &goph.Labels{Folder: "work/aws"}
+
+	require.True(t, entity.InFolder(labels, ""))
+	require.True(t, entity.InFolder(labels, "work"))
+	require.True(t, entity.InFolder(labels, "work/aws"))
+	require.False(t, entity.InFolder(labels, "work/aw"))
+	require.False(t, entity.InFolder(labels, "work/aws/prod"))
+	require.False(t, entity.InFolder(&goph.Labels{}, "work"))
+}
+
+func TestHasTags(t *testing.T) {
+	labels := &goph.Labels{Tags: []string{"aws", "prod"}}
+
+	require.True(t, entity.HasTags(labels))
+	require.True(t, entity.HasTags(labels, "prod"))
+	require.True(t, entity.HasTags(labels, "prod", "aws"))
+	require.False(t, entity.HasTags(labels, "prod", "dev"))
+}
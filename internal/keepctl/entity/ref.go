@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+// _minIDPrefix is length of the shortest ID prefix accepted as a reference to a secret.
+const _minIDPrefix = 4
+
+var (
+	ErrBadSecretPath   = errors.New("bad secret path")
+	ErrSecretNotFound  = errors.New("secret not found")
+	ErrAmbiguousSecret = errors.New("reference matches several secrets")
+)
+
+// IsSecretPath reports whether the reference is path to a secret, e.g. "work/aws".
+func IsSecretPath(ref string) bool {
+	return strings.Contains(ref, FolderSeparator)
+}
+
+// SplitSecretPath splits path to a secret into normalized folder and name of the secret.
+// Path with a single leading separator, e.g. "/aws", points to the root folder.
+func SplitSecretPath(path string) (folder, name string, err error) {
+	idx := strings.LastIndex(path, FolderSeparator)
+
+	name = strings.TrimSpace(path[idx+1:])
+	if name == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrBadSecretPath, path)
+	}
+
+	folder, err = NormalizeFolder(path[:idx])
+	if err != nil {
+		return "", "", err
+	}
+
+	return folder, name, nil
+}
+
+// SecretPath returns full path to the secret, e.g. "/work/aws".
+func SecretPath(secret *goph.Secret, labels *goph.Labels) string {
+	if labels.GetFolder() == "" {
+		return FolderSeparator + secret.GetName()
+	}
+
+	return FolderSeparator + labels.GetFolder() + FolderSeparator + secret.GetName()
+}
+
+// ResolveSecret finds the only secret matching the reference among the secrets.
+// The reference is matched against names, paths and IDs of the secrets,
+// prefix of an ID is enough if it is not shorter than 4 characters.
+// Labels of the secrets must be decrypted.
+// If several secrets match, the error lists their paths and IDs.
+func ResolveSecret(ref string, secrets []*goph.Secret) (*goph.Secret, error) {
+	var (
+		folder, name string
+		byPath       bool
+	)
+
+	if IsSecretPath(ref) {
+		var err error
+
+		folder, name, err = SplitSecretPath(ref)
+		byPath = err == nil
+	}
+
+	idPrefix := ""
+	if len(ref) >= _minIDPrefix {
+		idPrefix = strings.ToLower(ref)
+	}
+
+	candidates := make([]*goph.Secret, 0, 1)
+	paths := make([]string, 0, 1)
+
+	for _, secret := range secrets {
+		labels, err := LabelsOf(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		matched := secret.GetName() == ref ||
+			byPath && labels.GetFolder() == folder && secret.GetName() == name ||
+			idPrefix != "" && strings.HasPrefix(secret.GetId(), idPrefix)
+		if !matched {
+			continue
+		}
+
+		candidates = append(candidates, secret)
+		paths = append(paths, SecretPath(secret, labels))
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, NewValidationError(fmt.Errorf("%w: %q", ErrSecretNotFound, ref))
+
+	case 1:
+		return candidates[0], nil
+
+	default:
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%q, use path or ID instead:", ref))
+
+	for i, secret := range candidates {
+		sb.WriteString(fmt.Sprintf("\n\t%s (%s)", paths[i], secret.GetId()))
+	}
+
+	return nil, NewValidationError(fmt.Errorf("%w %s", ErrAmbiguousSecret, sb.String()))
+}
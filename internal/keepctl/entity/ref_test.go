@@ -0,0 +1,143 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+func newLabeledSecret(t *testing.T, id, name, folder string) *goph.Secret {
+	t.Helper()
+
+	secret := newStoredSecret(t, name, folder, nil).Secret
+	secret.Id = id
+
+	return secret
+}
+
+func TestSplitSecretPath(t *testing.T) {
+	tt := []struct {
+		name   string
+		path   string
+		folder string
+		secret string
+		err    error
+	}{
+		{
+			name:   "Secret in nested folder",
+			path:   "work/aws/prod",
+			folder: "work/aws",
+			secret: "prod",
+		},
+		{
+			name:   "Secret in root folder",
+			path:   "/prod",
+			secret: "prod",
+		},
+		{
+			name: "No name of secret",
+			path: "work/",
+			err:  entity.ErrBadSecretPath,
+		},
+		{
+			name: "Bad folder",
+			path: "work/../prod",
+			err:  entity.ErrBadFolder,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			folder, name, err := entity.SplitSecretPath(tc.path)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.folder, folder)
+			require.Equal(t, tc.secret, name)
+		})
+	}
+}
+
+func TestResolveSecret(t *testing.T) {
+	secrets := []*goph.Secret{
+		newLabeledSecret(t, "1f6c1c1e-0d7e-4f3a-9b8e-3c1d2a4b5c6d", "aws", "work"),
+		newLabeledSecret(t, "1f6c9a2b-5e4d-4c3b-8a7f-6e5d4c3b2a10", "aws", "home"),
+		newLabeledSecret(t, "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d", "github", ""),
+	}
+
+	tt := []struct {
+		name     string
+		ref      string
+		expected *goph.Secret
+		err      error
+	}{
+		{
+			name:     "Resolve by unique name",
+			ref:      "github",
+			expected: secrets[2],
+		},
+		{
+			name:     "Resolve by path",
+			ref:      "home/aws",
+			expected: secrets[1],
+		},
+		{
+			name:     "Resolve by path in root folder",
+			ref:      "/github",
+			expected: secrets[2],
+		},
+		{
+			name:     "Resolve by full ID",
+			ref:      secrets[0].GetId(),
+			expected: secrets[0],
+		},
+		{
+			name:     "Resolve by ID prefix",
+			ref:      "1F6C1",
+			expected: secrets[0],
+		},
+		{
+			name: "Ambiguous name",
+			ref:  "aws",
+			err:  entity.ErrAmbiguousSecret,
+		},
+		{
+			name: "Ambiguous ID prefix",
+			ref:  "1f6c",
+			err:  entity.ErrAmbiguousSecret,
+		},
+		{
+			name: "Too short ID prefix",
+			ref:  "7a8",
+			err:  entity.ErrSecretNotFound,
+		},
+		{
+			name: "Unknown path",
+			ref:  "work/github",
+			err:  entity.ErrSecretNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			secret, err := entity.ResolveSecret(tc.ref, secrets)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, secret)
+		})
+	}
+}
+
+func TestResolveSecretListsCandidates(t *testing.T) {
+	secrets := []*goph.Secret{
+		newLabeledSecret(t, "1f6c1c1e-0d7e-4f3a-9b8e-3c1d2a4b5c6d", "aws", "work"),
+		newLabeledSecret(t, "1f6c9a2b-5e4d-4c3b-8a7f-6e5d4c3b2a10", "aws", ""),
+	}
+
+	_, err := entity.ResolveSecret("aws", secrets)
+
+	require.ErrorIs(t, err, entity.ErrAmbiguousSecret)
+	require.ErrorContains(t, err, "/work/aws (1f6c1c1e-0d7e-4f3a-9b8e-3c1d2a4b5c6d)")
+	require.ErrorContains(t, err, "/aws (1f6c9a2b-5e4d-4c3b-8a7f-6e5d4c3b2a10)")
+}
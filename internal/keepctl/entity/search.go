@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/sahilm/fuzzy"
+	"google.golang.org/protobuf/proto"
+)
+
+// Weights of the search fields, matches in names are more relevant than in descriptions.
+const (
+	_nameWeight        = 30
+	_labelsWeight      = 20
+	_dataWeight        = 10
+	_descriptionWeight = 0
+
+	_exactMatchBonus     = 100
+	_substringMatchBonus = 50
+)
+
+// SearchQuery defines criteria of secrets search.
+type SearchQuery struct {
+	// Text is fuzzy matched against names, folders, tags and descriptions of secrets.
+	// Empty text matches all secrets satisfying the filters.
+	Text string
+	// Kinds limit the search to secrets of particular kinds.
+	Kinds []goph.DataKind
+	// Folder limits the search to the folder and its subfolders.
+	Folder string
+	// Tags which found secrets must have.
+	Tags []string
+	// ChangedAfter and ChangedBefore limit time of the last change of secrets if set.
+	ChangedAfter  time.Time
+	ChangedBefore time.Time
+	// Deep enables matching of searchable data attributes, e.g. logins and URIs.
+	// Requires download of full secrets.
+	Deep bool
+}
+
+// SearchField is a piece of secret's text the query is matched against.
+type SearchField struct {
+	Name   string
+	Value  string
+	Weight int
+}
+
+// SearchResult is a secret matching the query.
+type SearchResult struct {
+	Secret *goph.Secret
+	Labels *goph.Labels
+	// Field is name of the best matching field, empty if the query has no text.
+	Field string
+	// Score is rank of the result, higher is better.
+	Score int
+}
+
+// Filter reports whether the secret satisfies all filters of the query except the text.
+func (q SearchQuery) Filter(secret *goph.Secret, labels *goph.Labels) bool {
+	if len(q.Kinds) > 0 && !Contains(q.Kinds, secret.GetKind()) {
+		return false
+	}
+
+	if !InFolder(labels, q.Folder) || !HasTags(labels, q.Tags...) {
+		return false
+	}
+
+	changed := secret.GetUpdatedAt().AsTime()
+
+	if !q.ChangedAfter.IsZero() && changed.Before(q.ChangedAfter) {
+		return false
+	}
+
+	if !q.ChangedBefore.IsZero() && !changed.Before(q.ChangedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// Match matches text of the query against the fields.
+// Returns the best matching field and its score, ok is false if nothing matched.
+func (q SearchQuery) Match(fields []SearchField) (field string, score int, ok bool) {
+	if q.Text == "" {
+		return "", 0, true
+	}
+
+	values := make([]string, len(fields))
+	for i := range fields {
+		values[i] = fields[i].Value
+	}
+
+	text := strings.ToLower(q.Text)
+
+	for _, match := range fuzzy.FindNoSort(q.Text, values) {
+		candidate := fields[match.Index]
+		rank := match.Score + candidate.Weight
+
+		value := strings.ToLower(candidate.Value)
+		if value == text {
+			rank += _exactMatchBonus
+		} else if strings.Contains(value, text) {
+			rank += _substringMatchBonus
+		}
+
+		if !ok || rank > score {
+			field, score, ok = candidate.Name, rank, true
+		}
+	}
+
+	return field, score, ok
+}
+
+// SearchFields returns fields of the secret available without download of its data.
+// Labels and metadata of the secret must be decrypted.
+func SearchFields(secret *goph.Secret, labels *goph.Labels) []SearchField {
+	fields := []SearchField{
+		{Name: "name", Value: secret.GetName(), Weight: _nameWeight},
+	}
+
+	if labels.GetFolder() != "" {
+		fields = append(
+			fields,
+			SearchField{Name: "folder", Value: labels.GetFolder(), Weight: _labelsWeight},
+		)
+	}
+
+	for _, tag := range labels.GetTags() {
+		fields = append(fields, SearchField{Name: "tag", Value: tag, Weight: _labelsWeight})
+	}
+
+	if len(secret.GetMetadata()) > 0 {
+		fields = append(
+			fields,
+			SearchField{
+				Name:   "description",
+				Value:  string(secret.GetMetadata()),
+				Weight: _descriptionWeight,
+			},
+		)
+	}
+
+	return fields
+}
+
+// DataSearchFields returns searchable attributes of the secret data.
+// Values of list attributes are matched separately,
+// read-only attributes are matched as displayed, e.g. brand of a card.
+func (k *Kind) DataSearchFields(msg proto.Message) []SearchField {
+	fields := make([]SearchField, 0)
+
+	for i := range k.Attributes {
+		attr := &k.Attributes[i]
+		if !attr.Searchable || attr.Sensitive {
+			continue
+		}
+
+		text := attr.Get(msg)
+		if attr.ReadOnly {
+			text = attr.Display(msg, false)
+		}
+
+		for _, value := range strings.Split(text, ListSeparator) {
+			if value == "" {
+				continue
+			}
+
+			fields = append(fields, SearchField{Name: attr.Name, Value: value, Weight: _dataWeight})
+		}
+	}
+
+	return fields
+}
+
+// SortSearchResults orders results by score, results with equal score are ordered by name.
+func SortSearchResults(results []SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+
+		return results[i].Secret.GetName() < results[j].Secret.GetName()
+	})
+}
@@ -0,0 +1,180 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSearchQueryFilter(t *testing.T) {
+	changed := time.Date(2023, time.May, 10, 12, 0, 0, 0, time.UTC)
+	secret := &goph.Secret{
+		Name:      "aws",
+		Kind:      goph.DataKind_CREDENTIALS,
+		UpdatedAt: timestamppb.New(changed),
+	}
+	labels := &goph.Labels{Folder: "work/aws", Tags: []string{"prod"}}
+
+	tt := []struct {
+		name     string
+		query    entity.SearchQuery
+		expected bool
+	}{
+		{
+			name:     "Empty query",
+			expected: true,
+		},
+		{
+			name: "All filters satisfied",
+			query: entity.SearchQuery{
+				Kinds:         []goph.DataKind{goph.DataKind_CARD, goph.DataKind_CREDENTIALS},
+				Folder:        "work",
+				Tags:          []string{"prod"},
+				ChangedAfter:  changed.Add(-time.Hour),
+				ChangedBefore: changed.Add(time.Hour),
+			},
+			expected: true,
+		},
+		{
+			name:  "Other kind",
+			query: entity.SearchQuery{Kinds: []goph.DataKind{goph.DataKind_CARD}},
+		},
+		{
+			name:  "Other folder",
+			query: entity.SearchQuery{Folder: "home"},
+		},
+		{
+			name:  "Missing tag",
+			query: entity.SearchQuery{Tags: []string{"dev"}},
+		},
+		{
+			name:  "Changed too early",
+			query: entity.SearchQuery{ChangedAfter: changed.Add(time.Hour)},
+		},
+		{
+			name:  "Changed too late",
+			query: entity.SearchQuery{ChangedBefore: changed},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.query.Filter(secret, labels))
+		})
+	}
+}
+
+func TestSearchQueryMatch(t *testing.T) {
+	secret := &goph.Secret{Name: "Amazon Web Services", Metadata: []byte("root account of aws")}
+	fields := entity.SearchFields(secret, &goph.Labels{Folder: "work/cloud", Tags: []string{"aws"}})
+
+	tt := []struct {
+		name     string
+		text     string
+		expected string
+		ok       bool
+	}{
+		{
+			name: "Empty text matches everything",
+			ok:   true,
+		},
+		{
+			name:     "Exact match wins",
+			text:     "AWS",
+			expected: "tag",
+			ok:       true,
+		},
+		{
+			name:     "Fuzzy match of name",
+			text:     "amzws",
+			expected: "name",
+			ok:       true,
+		},
+		{
+			name:     "Match of folder",
+			text:     "cloud",
+			expected: "folder",
+			ok:       true,
+		},
+		{
+			name:     "Match of description",
+			text:     "root acc",
+			expected: "description",
+			ok:       true,
+		},
+		{
+			name: "No match",
+			text: "gcp",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			field, _, ok := entity.SearchQuery{Text: tc.text}.Match(fields)
+
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, field)
+		})
+	}
+}
+
+func TestDataSearchFields(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CREDENTIALS)
+	require.NoError(t, err)
+
+	data := &goph.Credentials{
+		Login:    "admin",
+		Password: "secret",
+		Uris:     []*goph.Uri{{Uri: "https://example.com"}, {Uri: "https://example.org"}},
+	}
+
+	fields := kind.DataSearchFields(data)
+
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		values = append(values, field.Value)
+	}
+
+	require.Contains(t, values, "admin")
+	require.Contains(t, values, "domain=https://example.com")
+	require.Contains(t, values, "domain=https://example.org")
+	require.NotContains(t, values, "secret")
+}
+
+func TestSearchCertificateBySAN(t *testing.T) {
+	kind, err := entity.KindOf(goph.DataKind_CERTIFICATE)
+	require.NoError(t, err)
+
+	data := &goph.Certificate{
+		Subject: "CN=example.com",
+		Issuer:  "CN=Example CA",
+		Sans:    []string{"example.com", "mail.example.org"},
+	}
+
+	query := entity.SearchQuery{Text: "mail.example.org"}
+
+	field, _, ok := query.Match(kind.DataSearchFields(data))
+	require.True(t, ok)
+	require.Equal(t, "sans", field)
+}
+
+func TestSortSearchResults(t *testing.T) {
+	results := []entity.SearchResult{
+		{Secret: &goph.Secret{Name: "b"}, Score: 10},
+		{Secret: &goph.Secret{Name: "c"}, Score: 20},
+		{Secret: &goph.Secret{Name: "a"}, Score: 10},
+	}
+
+	entity.SortSearchResults(results)
+
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.Secret.GetName())
+	}
+
+	require.Equal(t, []string{"c", "a", "b"}, names)
+}
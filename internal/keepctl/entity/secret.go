@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/proto"
+)
+
+// SecretData is a secret accompanied with its decrypted data.
+type SecretData struct {
+	Secret *goph.Secret
+	Data   proto.Message
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// SecretURIScheme starts references to fields of secrets, e.g. "goph://prod-db/password".
+const SecretURIScheme = "goph://"
+
+var ErrBadSecretURI = errors.New("bad secret reference")
+
+var _secretURI = regexp.MustCompile("goph://[^\\s\"'`<>{}()\\[\\],;]+")
+
+// FieldLookup returns value of the field of a secret.
+type FieldLookup func(ref FieldRef) (string, error)
+
+// ParseSecretURI parses reference like "goph://<name-or-id>/<field>".
+// The secret may be referred by path, e.g. "goph://work/db/prod/password",
+// the last segment is always the field. Reference without field,
+// e.g. "goph://prod-db", means the primary value of the secret.
+// Segments may be percent-encoded, e.g. "goph://main%20db/password".
+func ParseSecretURI(uri string) (FieldRef, error) {
+	rest, ok := strings.CutPrefix(uri, SecretURIScheme)
+	if !ok || rest == "" {
+		return FieldRef{}, NewValidationError(fmt.Errorf("%w: %q", ErrBadSecretURI, uri))
+	}
+
+	secret, field := rest, ""
+	if idx := strings.LastIndex(rest, "/"); idx >= 0 {
+		secret, field = rest[:idx], rest[idx+1:]
+	}
+
+	secret, err := url.PathUnescape(secret)
+	if err != nil || secret == "" {
+		return FieldRef{}, NewValidationError(fmt.Errorf("%w: %q", ErrBadSecretURI, uri))
+	}
+
+	field, err = url.PathUnescape(field)
+	if err != nil {
+		return FieldRef{}, NewValidationError(fmt.Errorf("%w: %q", ErrBadSecretURI, uri))
+	}
+
+	return FieldRef{Secret: secret, Field: field}, nil
+}
+
+// ReplaceSecretURIs substitutes values of the secrets for references
+// like "goph://prod-db/password" found in the text.
+// Fails on the first reference which can't be resolved.
+func ReplaceSecretURIs(text string, lookup FieldLookup) (string, error) {
+	var sb strings.Builder
+
+	last := 0
+
+	for _, loc := range _secretURI.FindAllStringIndex(text, -1) {
+		ref, err := ParseSecretURI(text[loc[0]:loc[1]])
+		if err != nil {
+			return "", err
+		}
+
+		value, err := lookup(ref)
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteString(text[last:loc[0]])
+		sb.WriteString(value)
+		last = loc[1]
+	}
+
+	sb.WriteString(text[last:])
+
+	return sb.String(), nil
+}
+
+// RenderTemplate executes Go template where values of secrets are accessed with the secret
+// function, e.g. {{ secret "goph://prod-db/password" }} or {{ secret "prod-db:password" }}.
+func RenderTemplate(name, text string, lookup FieldLookup) (string, error) {
+	funcs := template.FuncMap{
+		"secret": func(ref string) (string, error) {
+			fieldRef, err := parseAnyFieldRef(ref)
+			if err != nil {
+				return "", err
+			}
+
+			return lookup(fieldRef)
+		},
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", NewValidationError(err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// parseAnyFieldRef parses reference either as URI or in short form like "prod-db:password".
+func parseAnyFieldRef(ref string) (FieldRef, error) {
+	if strings.HasPrefix(ref, SecretURIScheme) {
+		return ParseSecretURI(ref)
+	}
+
+	return ParseFieldRef(ref)
+}
@@ -0,0 +1,146 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretURI(t *testing.T) {
+	tt := []struct {
+		name     string
+		uri      string
+		expected entity.FieldRef
+		err      error
+	}{
+		{
+			name:     "Field of secret",
+			uri:      "goph://prod-db/password",
+			expected: entity.FieldRef{Secret: "prod-db", Field: "password"},
+		},
+		{
+			name:     "Field of secret referred by path",
+			uri:      "goph://work/db/prod/login",
+			expected: entity.FieldRef{Secret: "work/db/prod", Field: "login"},
+		},
+		{
+			name:     "Primary value of secret",
+			uri:      "goph://prod-db",
+			expected: entity.FieldRef{Secret: "prod-db"},
+		},
+		{
+			name:     "Percent-encoded name",
+			uri:      "goph://main%20db/token:hidden",
+			expected: entity.FieldRef{Secret: "main db", Field: "token:hidden"},
+		},
+		{
+			name: "No secret",
+			uri:  "goph:///password",
+			err:  entity.ErrBadSecretURI,
+		},
+		{
+			name: "Wrong scheme",
+			uri:  "https://prod-db/password",
+			err:  entity.ErrBadSecretURI,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := entity.ParseSecretURI(tc.uri)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+// fakeLookup returns values of known fields and counts requests.
+func fakeLookup(calls *int) entity.FieldLookup {
+	values := map[entity.FieldRef]string{
+		{Secret: "prod-db", Field: "login"}:    "admin",
+		{Secret: "prod-db", Field: "password"}: "qwerty",
+	}
+
+	return func(ref entity.FieldRef) (string, error) {
+		*calls++
+
+		value, ok := values[ref]
+		if !ok {
+			return "", entity.ErrSecretNotFound
+		}
+
+		return value, nil
+	}
+}
+
+func TestReplaceSecretURIs(t *testing.T) {
+	calls := 0
+	text := "db:\n  user: goph://prod-db/login\n  password: \"goph://prod-db/password\"\n"
+
+	rv, err := entity.ReplaceSecretURIs(text, fakeLookup(&calls))
+
+	require.NoError(t, err)
+	require.Equal(t, "db:\n  user: admin\n  password: \"qwerty\"\n", rv)
+	require.Equal(t, 2, calls)
+}
+
+func TestReplaceSecretURIsFailsOnMissingSecret(t *testing.T) {
+	calls := 0
+
+	_, err := entity.ReplaceSecretURIs("token: goph://ci/token\n", fakeLookup(&calls))
+
+	require.ErrorIs(t, err, entity.ErrSecretNotFound)
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tt := []struct {
+		name     string
+		text     string
+		expected string
+		err      error
+	}{
+		{
+			name:     "URI reference",
+			text:     `password: {{ secret "goph://prod-db/password" }}`,
+			expected: "password: qwerty",
+		},
+		{
+			name:     "Short reference",
+			text:     `login: {{ secret "prod-db:login" | printf "%q" }}`,
+			expected: `login: "admin"`,
+		},
+		{
+			name: "Missing secret",
+			text: `token: {{ secret "ci:token" }}`,
+			err:  entity.ErrSecretNotFound,
+		},
+		{
+			name: "Bad reference",
+			text: `token: {{ secret ":token" }}`,
+			err:  entity.ErrBadFieldRef,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+
+			rv, err := entity.RenderTemplate("config", tc.text, fakeLookup(&calls))
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, rv)
+		})
+	}
+}
+
+func TestRenderTemplateWithSyntaxError(t *testing.T) {
+	calls := 0
+
+	_, err := entity.RenderTemplate("config", `{{ secret "prod-db" `, fakeLookup(&calls))
+
+	var vErr entity.ValidationError
+
+	require.ErrorAs(t, err, &vErr)
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+var (
+	ErrBadSeedLength   = errors.New("seed phrase should contain 12, 15, 18, 21 or 24 words")
+	ErrUnknownSeedWord = errors.New("word is not in the BIP39 English wordlist")
+	ErrBadSeedChecksum = errors.New("seed phrase checksum mismatch, check order and spelling of words")
+)
+
+const (
+	_minSeedWords   = 12
+	_maxSeedWords   = 24
+	_seedWordsRatio = 3
+)
+
+// SplitSeedPhrase converts seed phrase into list of lowercase words.
+func SplitSeedPhrase(phrase string) []string {
+	return strings.Fields(strings.ToLower(phrase))
+}
+
+// ValidateSeedPhrase checks words and checksum of the seed phrase against BIP39 English wordlist.
+func ValidateSeedPhrase(words []string) error {
+	if len(words) < _minSeedWords || len(words) > _maxSeedWords || len(words)%_seedWordsRatio != 0 {
+		return fmt.Errorf("%w, got %d", ErrBadSeedLength, len(words))
+	}
+
+	for i, word := range words {
+		if _, ok := bip39.GetWordIndex(word); !ok {
+			return fmt.Errorf("%w: #%d", ErrUnknownSeedWord, i+1)
+		}
+	}
+
+	if _, err := bip39.EntropyFromMnemonic(strings.Join(words, " ")); err != nil {
+		return ErrBadSeedChecksum
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from the reference BIP39 implementation.
+const (
+	_seed12 = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	_seed24 = "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo " +
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"
+)
+
+func TestValidateSeedPhrase(t *testing.T) {
+	tt := []struct {
+		name     string
+		phrase   string
+		expected error
+	}{
+		{
+			name:   "Valid 12 words phrase",
+			phrase: _seed12,
+		},
+		{
+			name:   "Valid 24 words phrase in upper case",
+			phrase: "  " + strings.ToUpper(_seed24) + "\n",
+		},
+		{
+			name:     "Too short phrase",
+			phrase:   "legal winner thank year",
+			expected: entity.ErrBadSeedLength,
+		},
+		{
+			name:     "Misspelled word",
+			phrase:   "legal winner thank year wave sausage worth usefull legal winner thank yellow",
+			expected: entity.ErrUnknownSeedWord,
+		},
+		{
+			name:     "Swapped words",
+			phrase:   "winner legal thank year wave sausage worth useful legal winner thank yellow",
+			expected: entity.ErrBadSeedChecksum,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := entity.ValidateSeedPhrase(entity.SplitSeedPhrase(tc.phrase))
+
+			require.ErrorIs(t, err, tc.expected)
+
+			if err != nil {
+				require.NotContains(t, err.Error(), "usefull")
+			}
+		})
+	}
+}
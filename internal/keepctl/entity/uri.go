@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+var (
+	ErrUnknownURIMatch = errors.New("unknown URI match rule")
+	ErrBadURI          = errors.New("invalid URI")
+	ErrURINotFound     = errors.New("URI not found")
+)
+
+// URIMatchName returns short name of the URI match rule, e.g. "host".
+func URIMatchName(match goph.UriMatch) string {
+	return strings.ToLower(strings.TrimPrefix(match.String(), "MATCH_"))
+}
+
+// URIMatchFromName converts short name of URI match rule into goph.UriMatch.
+func URIMatchFromName(name string) (goph.UriMatch, error) {
+	val, ok := goph.UriMatch_value["MATCH_"+strings.ToUpper(name)]
+	if !ok {
+		return goph.UriMatch_MATCH_DOMAIN, fmt.Errorf("%w: %s", ErrUnknownURIMatch, name)
+	}
+
+	return goph.UriMatch(val), nil
+}
+
+// ParseURI parses URI definition in form of "uri" or "match=uri".
+// If match rule is omitted, MATCH_DOMAIN is used.
+func ParseURI(spec string) *goph.Uri {
+	rv := &goph.Uri{Uri: spec, Match: goph.UriMatch_MATCH_DOMAIN}
+
+	name, value, found := strings.Cut(spec, "=")
+	if !found {
+		return rv
+	}
+
+	if match, err := URIMatchFromName(name); err == nil {
+		rv.Uri = value
+		rv.Match = match
+	}
+
+	return rv
+}
+
+// ValidateURI checks that the URI is consistent with its match rule.
+func ValidateURI(uri *goph.Uri) error {
+	var err error
+
+	switch uri.GetMatch() {
+	case goph.UriMatch_MATCH_REGEX:
+		_, err = regexp.Compile(uri.GetUri())
+
+	case goph.UriMatch_MATCH_NEVER:
+		if uri.GetUri() == "" {
+			err = errIncompleteURL
+		}
+
+	default:
+		var u *url.URL
+
+		u, err = url.Parse(uri.GetUri())
+		if err == nil && (u.Scheme == "" || u.Host == "") {
+			err = errIncompleteURL
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w %q (%s): %s", ErrBadURI, uri.GetUri(), URIMatchName(uri.GetMatch()), err)
+	}
+
+	return nil
+}
+
+// baseDomain returns last two labels of the host name, e.g. "example.com" for "www.example.com".
+// IP addresses are returned as is.
+func baseDomain(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) <= 2 {
+		return host
+	}
+
+	return strings.Join(labels[len(labels)-2:], ".")
+}
+
+// MatchURI reports whether the website address matches stored URI.
+func MatchURI(uri *goph.Uri, address string) bool {
+	switch uri.GetMatch() {
+	case goph.UriMatch_MATCH_STARTS_WITH:
+		return strings.HasPrefix(address, uri.GetUri())
+
+	case goph.UriMatch_MATCH_EXACT:
+		return address == uri.GetUri()
+
+	case goph.UriMatch_MATCH_REGEX:
+		re, err := regexp.Compile(uri.GetUri())
+
+		return err == nil && re.MatchString(address)
+
+	case goph.UriMatch_MATCH_NEVER:
+		return false
+
+	default:
+	}
+
+	stored, err := url.Parse(uri.GetUri())
+	if err != nil {
+		return false
+	}
+
+	actual, err := url.Parse(address)
+	if err != nil {
+		return false
+	}
+
+	if uri.GetMatch() == goph.UriMatch_MATCH_HOST {
+		return strings.EqualFold(stored.Host, actual.Host)
+	}
+
+	return strings.EqualFold(baseDomain(stored.Hostname()), baseDomain(actual.Hostname()))
+}
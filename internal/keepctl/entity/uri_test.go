@@ -0,0 +1,95 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURI(t *testing.T) {
+	tt := []struct {
+		name     string
+		spec     string
+		expected *goph.Uri
+	}{
+		{
+			name:     "Parse URI without match rule",
+			spec:     "https://example.com/login?a=b",
+			expected: &goph.Uri{Uri: "https://example.com/login?a=b", Match: goph.UriMatch_MATCH_DOMAIN},
+		},
+		{
+			name:     "Parse URI with match rule",
+			spec:     "starts_with=https://example.com/login?a=b",
+			expected: &goph.Uri{Uri: "https://example.com/login?a=b", Match: goph.UriMatch_MATCH_STARTS_WITH},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			uri := entity.ParseURI(tc.spec)
+
+			require.Equal(t, tc.expected.GetUri(), uri.GetUri())
+			require.Equal(t, tc.expected.GetMatch(), uri.GetMatch())
+		})
+	}
+}
+
+func TestMatchURI(t *testing.T) {
+	tt := []struct {
+		name     string
+		uri      *goph.Uri
+		address  string
+		expected bool
+	}{
+		{
+			name:     "Match subdomain by domain",
+			uri:      &goph.Uri{Uri: "https://example.com", Match: goph.UriMatch_MATCH_DOMAIN},
+			address:  "https://accounts.example.com/login",
+			expected: true,
+		},
+		{
+			name:     "Don't match another domain",
+			uri:      &goph.Uri{Uri: "https://example.com", Match: goph.UriMatch_MATCH_DOMAIN},
+			address:  "https://example.org",
+			expected: false,
+		},
+		{
+			name:     "Don't match another port by host",
+			uri:      &goph.Uri{Uri: "https://example.com:8443", Match: goph.UriMatch_MATCH_HOST},
+			address:  "https://example.com/login",
+			expected: false,
+		},
+		{
+			name:     "Match by prefix",
+			uri:      &goph.Uri{Uri: "https://example.com/app", Match: goph.UriMatch_MATCH_STARTS_WITH},
+			address:  "https://example.com/app/login",
+			expected: true,
+		},
+		{
+			name:     "Match exactly",
+			uri:      &goph.Uri{Uri: "https://example.com/login", Match: goph.UriMatch_MATCH_EXACT},
+			address:  "https://example.com/login",
+			expected: true,
+		},
+		{
+			name:     "Match by regular expression",
+			uri:      &goph.Uri{Uri: `^https://[a-z]+\.example\.com/`, Match: goph.UriMatch_MATCH_REGEX},
+			address:  "https://mail.example.com/inbox",
+			expected: true,
+		},
+		{
+			name:     "Never match",
+			uri:      &goph.Uri{Uri: "https://example.com", Match: goph.UriMatch_MATCH_NEVER},
+			address:  "https://example.com",
+			expected: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, entity.MatchURI(tc.uri, tc.address))
+		})
+	}
+}
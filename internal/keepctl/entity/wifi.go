@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+const (
+	_wpaMinPasswordLen = 8
+	_wpaMaxPasswordLen = 63
+	_wpaPSKLen         = 64
+	_wep64KeyLen       = 5
+	_wep128KeyLen      = 13
+)
+
+var ErrBadWifiPassword = errors.New("invalid Wi-Fi password")
+
+// _wifiAuthTypes contains authentication types of the WIFI: QR code format.
+var _wifiAuthTypes = map[goph.WifiSecurity]string{
+	goph.WifiSecurity_WIFI_WPA:  "WPA",
+	goph.WifiSecurity_WIFI_SAE:  "SAE",
+	goph.WifiSecurity_WIFI_WEP:  "WEP",
+	goph.WifiSecurity_WIFI_OPEN: "nopass",
+}
+
+// isHex reports whether the string contains hex digits only.
+func isHex(src string) bool {
+	_, err := hex.DecodeString(src)
+
+	return err == nil
+}
+
+// ValidateWifiPassword checks that the password is acceptable for the security type.
+func ValidateWifiPassword(security goph.WifiSecurity, password string) error {
+	switch security {
+	case goph.WifiSecurity_WIFI_OPEN:
+		if password != "" {
+			return fmt.Errorf("%w: open network has no password", ErrBadWifiPassword)
+		}
+
+	case goph.WifiSecurity_WIFI_WEP:
+		switch {
+		case len(password) == _wep64KeyLen || len(password) == _wep128KeyLen:
+		case (len(password) == hex.EncodedLen(_wep64KeyLen) ||
+			len(password) == hex.EncodedLen(_wep128KeyLen)) && isHex(password):
+		default:
+			return fmt.Errorf(
+				"%w: WEP key should contain 5 or 13 characters, 10 or 26 hex digits",
+				ErrBadWifiPassword,
+			)
+		}
+
+	case goph.WifiSecurity_WIFI_WPA, goph.WifiSecurity_WIFI_SAE:
+		if len(password) == _wpaPSKLen && isHex(password) {
+			return nil
+		}
+
+		if len(password) < _wpaMinPasswordLen || len(password) > _wpaMaxPasswordLen {
+			return fmt.Errorf(
+				"%w: passphrase should contain from %d to %d characters",
+				ErrBadWifiPassword,
+				_wpaMinPasswordLen,
+				_wpaMaxPasswordLen,
+			)
+		}
+	}
+
+	return nil
+}
+
+// escapeWifiValue escapes special characters of the WIFI: QR code format.
+func escapeWifiValue(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`;`, `\;`,
+		`,`, `\,`,
+		`:`, `\:`,
+		`"`, `\"`,
+	).Replace(value)
+}
+
+// WifiQRCode renders content of QR code used to join the network,
+// e.g. WIFI:T:WPA;S:guest;P:secret;;.
+func WifiQRCode(wifi *goph.Wifi) string {
+	var sb strings.Builder
+
+	sb.WriteString("WIFI:T:" + _wifiAuthTypes[wifi.GetSecurity()] + ";")
+	sb.WriteString("S:" + escapeWifiValue(wifi.GetSsid()) + ";")
+
+	if wifi.GetSecurity() != goph.WifiSecurity_WIFI_OPEN {
+		sb.WriteString("P:" + escapeWifiValue(wifi.GetPassword()) + ";")
+	}
+
+	if wifi.GetHidden() {
+		sb.WriteString("H:true;")
+	}
+
+	sb.WriteString(";")
+
+	return sb.String()
+}
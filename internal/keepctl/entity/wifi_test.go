@@ -0,0 +1,106 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWifiPassword(t *testing.T) {
+	tt := []struct {
+		name     string
+		security goph.WifiSecurity
+		password string
+		expected error
+	}{
+		{
+			name:     "WPA passphrase",
+			security: goph.WifiSecurity_WIFI_WPA,
+			password: "correct horse",
+		},
+		{
+			name:     "WPA3 raw key",
+			security: goph.WifiSecurity_WIFI_SAE,
+			password: strings.Repeat("0f", 32),
+		},
+		{
+			name:     "Short WPA passphrase",
+			security: goph.WifiSecurity_WIFI_WPA,
+			password: "1234567",
+			expected: entity.ErrBadWifiPassword,
+		},
+		{
+			name:     "Too long WPA passphrase",
+			security: goph.WifiSecurity_WIFI_WPA,
+			password: strings.Repeat("x", 64),
+			expected: entity.ErrBadWifiPassword,
+		},
+		{
+			name:     "WEP hex key",
+			security: goph.WifiSecurity_WIFI_WEP,
+			password: "0123456789",
+		},
+		{
+			name:     "Bad WEP key",
+			security: goph.WifiSecurity_WIFI_WEP,
+			password: "123456",
+			expected: entity.ErrBadWifiPassword,
+		},
+		{
+			name:     "Open network",
+			security: goph.WifiSecurity_WIFI_OPEN,
+		},
+		{
+			name:     "Open network with password",
+			security: goph.WifiSecurity_WIFI_OPEN,
+			password: "12345678",
+			expected: entity.ErrBadWifiPassword,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := entity.ValidateWifiPassword(tc.security, tc.password)
+
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestWifiQRCode(t *testing.T) {
+	tt := []struct {
+		name     string
+		wifi     *goph.Wifi
+		expected string
+	}{
+		{
+			name:     "WPA network",
+			wifi:     &goph.Wifi{Ssid: "guest", Password: "secret12"},
+			expected: "WIFI:T:WPA;S:guest;P:secret12;;",
+		},
+		{
+			name: "Hidden network with special characters",
+			wifi: &goph.Wifi{
+				Ssid:     `My "Home";Net`,
+				Security: goph.WifiSecurity_WIFI_SAE,
+				Password: `a:b,c\d`,
+				Hidden:   true,
+			},
+			expected: `WIFI:T:SAE;S:My \"Home\"\;Net;P:a\:b\,c\\d;H:true;;`,
+		},
+		{
+			name:     "Open network",
+			wifi:     &goph.Wifi{Ssid: "cafe", Security: goph.WifiSecurity_WIFI_OPEN},
+			expected: "WIFI:T:nopass;S:cafe;;",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, entity.WifiQRCode(tc.wifi))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Layout of the archive header, see package description.
+const (
+	_magic        = "GOPHBAK\n"
+	_saltLength   = 16
+	_nonceLength  = 12
+	_keyLength    = 32
+	_headerLength = len(_magic) + 1 + 4 + 4 + 1 + _saltLength + _nonceLength
+)
+
+// Upper limits of KDF parameters accepted from archives,
+// prevent exhausting of resources by malicious files.
+const (
+	_maxIterations = 100
+	_maxMemory     = 4 * 1024 * 1024
+)
+
+var (
+	ErrPassphraseRequired = errors.New("backup is encrypted, passphrase is required")
+	ErrInvalidPassphrase  = errors.New("invalid passphrase or corrupted backup")
+)
+
+// KDF sets parameters of Argon2id used to derive the encryption key from the passphrase.
+type KDF struct {
+	Iterations  uint32
+	Memory      uint32 // Memory in KiB.
+	Parallelism uint8
+}
+
+// DefaultKDF follows the second recommended option of RFC 9106.
+var DefaultKDF = KDF{
+	Iterations:  3,
+	Memory:      64 * 1024,
+	Parallelism: 4,
+}
+
+// Seal writes the backup as archive encrypted with the passphrase.
+// DefaultKDF is used if no KDF parameters provided.
+func Seal(w io.Writer, d *Document, passphrase string, kdf *KDF) error {
+	if kdf == nil {
+		kdf = &DefaultKDF
+	}
+
+	header := make([]byte, 0, _headerLength)
+	header = append(header, _magic...)
+	header = append(header, Version)
+	header = binary.BigEndian.AppendUint32(header, kdf.Iterations)
+	header = binary.BigEndian.AppendUint32(header, kdf.Memory)
+	header = append(header, kdf.Parallelism)
+
+	random := make([]byte, _saltLength+_nonceLength)
+	if _, err := io.ReadFull(rand.Reader, random); err != nil {
+		return fmt.Errorf("backup - Seal - io.ReadFull: %w", err)
+	}
+
+	header = append(header, random...)
+
+	var plain bytes.Buffer
+
+	zw := gzip.NewWriter(&plain)
+	if err := WriteJSON(zw, d); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("backup - Seal - zw.Close: %w", err)
+	}
+
+	aead, err := newAEAD(passphrase, header, kdf)
+	if err != nil {
+		return fmt.Errorf("backup - Seal - newAEAD: %w", err)
+	}
+
+	nonce := header[_headerLength-_nonceLength:]
+	sealed := aead.Seal(header, nonce, plain.Bytes(), header)
+
+	if _, err := w.Write(sealed); err != nil {
+		return fmt.Errorf("backup - Seal - w.Write: %w", err)
+	}
+
+	return nil
+}
+
+// Read reads the backup, either encrypted archive or plain JSON document.
+// The passphrase is used for archives only.
+func Read(r io.Reader, passphrase string) (*Document, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(len(_magic))
+	if err != nil || string(magic) != _magic {
+		return ReadJSON(br)
+	}
+
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	raw, err := io.ReadAll(br)
+	if err != nil {
+		return nil, fmt.Errorf("backup - Read - io.ReadAll: %w", err)
+	}
+
+	plain, err := open(raw, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(plain))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedBackup, err)
+	}
+
+	return ReadJSON(zr)
+}
+
+// open decrypts content of the archive.
+func open(raw []byte, passphrase string) ([]byte, error) {
+	if len(raw) < _headerLength {
+		return nil, fmt.Errorf("%w: truncated header", ErrMalformedBackup)
+	}
+
+	header := raw[:_headerLength]
+
+	offset := len(_magic)
+	if version := header[offset]; version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	offset++
+	kdf := KDF{
+		Iterations:  binary.BigEndian.Uint32(header[offset:]),
+		Memory:      binary.BigEndian.Uint32(header[offset+4:]),
+		Parallelism: header[offset+8],
+	}
+
+	if kdf.Iterations == 0 || kdf.Iterations > _maxIterations ||
+		kdf.Memory == 0 || kdf.Memory > _maxMemory || kdf.Parallelism == 0 {
+		return nil, fmt.Errorf("%w: bad KDF parameters", ErrMalformedBackup)
+	}
+
+	aead, err := newAEAD(passphrase, header, &kdf)
+	if err != nil {
+		return nil, fmt.Errorf("backup - open - newAEAD: %w", err)
+	}
+
+	nonce := header[_headerLength-_nonceLength:]
+
+	plain, err := aead.Open(nil, nonce, raw[_headerLength:], header)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	return plain, nil
+}
+
+// newAEAD derives key from the passphrase and salt stored in the header.
+func newAEAD(passphrase string, header []byte, kdf *KDF) (cipher.AEAD, error) {
+	salt := header[_headerLength-_nonceLength-_saltLength : _headerLength-_nonceLength]
+	key := argon2.IDKey([]byte(passphrase), salt, kdf.Iterations, kdf.Memory, kdf.Parallelism,
+		_keyLength)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("aes.NewCipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cipher.NewGCM: %w", err)
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,88 @@
+package backup_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/backup"
+	"github.com/stretchr/testify/require"
+)
+
+// testKDF keeps the tests fast.
+var testKDF = backup.KDF{Iterations: 1, Memory: 64, Parallelism: 1}
+
+func sealTestDocument(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, backup.Seal(&buf, newTestDocument(t), "passphrase", &testKDF))
+
+	return buf.Bytes()
+}
+
+func TestSealRead(t *testing.T) {
+	raw := sealTestDocument(t)
+	require.NotContains(t, string(raw), "s3cr3t")
+	require.Equal(t, "GOPHBAK\n", string(raw[:8]))
+
+	doc, err := backup.Read(bytes.NewReader(raw), "passphrase")
+	require.NoError(t, err)
+	require.Len(t, doc.Secrets, 2)
+	require.Equal(t, "github", doc.Secrets[0].Name)
+}
+
+func TestReadPlainJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, backup.WriteJSON(&buf, newTestDocument(t)))
+
+	doc, err := backup.Read(&buf, "")
+	require.NoError(t, err)
+	require.Len(t, doc.Secrets, 2)
+}
+
+func TestReadWithoutPassphrase(t *testing.T) {
+	_, err := backup.Read(bytes.NewReader(sealTestDocument(t)), "")
+
+	require.ErrorIs(t, err, backup.ErrPassphraseRequired)
+}
+
+func TestReadWithWrongPassphrase(t *testing.T) {
+	_, err := backup.Read(bytes.NewReader(sealTestDocument(t)), "wrong")
+
+	require.ErrorIs(t, err, backup.ErrInvalidPassphrase)
+}
+
+func TestReadTamperedArchive(t *testing.T) {
+	tt := []struct {
+		name   string
+		offset int
+		err    error
+	}{
+		{name: "Version", offset: 8, err: backup.ErrUnsupportedVersion},
+		{name: "KDF parameters", offset: 16, err: backup.ErrInvalidPassphrase},
+		{name: "Salt", offset: 20, err: backup.ErrInvalidPassphrase},
+		{name: "Ciphertext", offset: 50, err: backup.ErrInvalidPassphrase},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			raw := sealTestDocument(t)
+			raw[tc.offset] ^= 0x01
+
+			_, err := backup.Read(bytes.NewReader(raw), "passphrase")
+
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestReadTruncatedArchive(t *testing.T) {
+	raw := sealTestDocument(t)
+
+	_, err := backup.Read(bytes.NewReader(raw[:20]), "passphrase")
+
+	require.ErrorIs(t, err, backup.ErrMalformedBackup)
+}
@@ -0,0 +1,199 @@
+// Package backup reads and writes backups of user's vault.
+//
+// Backup is a JSON document listing all secrets with their decrypted data and attached files,
+// see Document. The document is either written as is, or sealed into archive encrypted
+// with a passphrase. The archive has the following layout, integers are big-endian:
+//
+//	offset  size  field
+//	0       8     magic "GOPHBAK\n"
+//	8       1     format version, currently 1
+//	9       4     Argon2id iterations
+//	13      4     Argon2id memory in KiB
+//	17      1     Argon2id parallelism
+//	18      16    salt
+//	34      12    nonce
+//	46      ...   AES-256-GCM ciphertext of the gzipped JSON document
+//
+// The encryption key is derived from the passphrase with Argon2id using the salt,
+// the header (bytes 0-45) is authenticated as additional data of AES-GCM.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Version is version of the backup format.
+const Version = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+	ErrMalformedBackup    = errors.New("malformed backup")
+)
+
+// Document is content of a backup.
+type Document struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Secrets   []Secret  `json:"secrets"`
+}
+
+// Secret is a stored secret with decrypted data.
+// Data is the data message in protobuf JSON form, the message type is defined by the kind.
+type Secret struct {
+	Name        string          `json:"name"`
+	Kind        string          `json:"kind"`
+	Description string          `json:"description"`
+	Folder      string          `json:"folder"`
+	Tags        []string        `json:"tags"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	Data        json.RawMessage `json:"data"`
+	Attachments []Attachment    `json:"attachments,omitempty"`
+}
+
+// Attachment is a file attached to a secret, the content is encoded with base64.
+type Attachment struct {
+	Filename string `json:"filename"`
+	MimeType string `json:"mime_type"`
+	Content  []byte `json:"content"`
+}
+
+// New creates empty backup document.
+func New(now time.Time) *Document {
+	return &Document{
+		Version:   Version,
+		CreatedAt: now.UTC(),
+		Secrets:   make([]Secret, 0),
+	}
+}
+
+// Add puts the secret with decrypted labels, metadata and data into the backup.
+func (d *Document) Add(
+	secret *goph.Secret,
+	labels *goph.Labels,
+	data proto.Message,
+	attachments []entity.Attachment,
+) error {
+	kind, err := entity.KindOf(secret.GetKind())
+	if err != nil {
+		return fmt.Errorf("backup - Add - entity.KindOf: %w", err)
+	}
+
+	raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("backup - Add - protojson.Marshal: %w", err)
+	}
+
+	tags := labels.GetTags()
+	if tags == nil {
+		tags = []string{}
+	}
+
+	s := Secret{
+		Name:        secret.GetName(),
+		Kind:        kind.Name,
+		Description: string(secret.GetMetadata()),
+		Folder:      entity.FolderSeparator + labels.GetFolder(),
+		Tags:        tags,
+		CreatedAt:   secret.GetCreatedAt().AsTime(),
+		UpdatedAt:   secret.GetUpdatedAt().AsTime(),
+		Data:        raw,
+	}
+
+	for _, attachment := range attachments {
+		s.Attachments = append(s.Attachments, Attachment{
+			Filename: attachment.Info.GetFilename(),
+			MimeType: attachment.Info.GetMimeType(),
+			Content:  attachment.Content,
+		})
+	}
+
+	d.Secrets = append(d.Secrets, s)
+
+	return nil
+}
+
+// NewSecrets converts the backup into secrets to be stored.
+func (d *Document) NewSecrets() ([]entity.NewSecret, error) {
+	rv := make([]entity.NewSecret, 0, len(d.Secrets))
+
+	for i := range d.Secrets {
+		secret, err := d.Secrets[i].newSecret()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrMalformedBackup, d.Secrets[i].Name, err)
+		}
+
+		rv = append(rv, secret)
+	}
+
+	return rv, nil
+}
+
+func (s *Secret) newSecret() (entity.NewSecret, error) {
+	kind, err := entity.KindByName(s.Kind)
+	if err != nil {
+		return entity.NewSecret{}, err
+	}
+
+	data := kind.New()
+	if err := protojson.Unmarshal(s.Data, data); err != nil {
+		return entity.NewSecret{}, err
+	}
+
+	rv := entity.NewSecret{
+		Name:        s.Name,
+		Description: s.Description,
+		Labels: &goph.Labels{
+			Folder: strings.TrimPrefix(s.Folder, entity.FolderSeparator),
+			Tags:   s.Tags,
+		},
+		Data: data,
+	}
+
+	for _, attachment := range s.Attachments {
+		rv.Attachments = append(rv.Attachments, entity.NewAttachment{
+			Filename: attachment.Filename,
+			MimeType: attachment.MimeType,
+			Content:  attachment.Content,
+		})
+	}
+
+	return rv, nil
+}
+
+// WriteJSON writes the backup as plain JSON document.
+func WriteJSON(w io.Writer, d *Document) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(d); err != nil {
+		return fmt.Errorf("backup - WriteJSON - enc.Encode: %w", err)
+	}
+
+	return nil
+}
+
+// ReadJSON reads plain JSON document.
+func ReadJSON(r io.Reader) (*Document, error) {
+	var rv Document
+
+	if err := json.NewDecoder(r).Decode(&rv); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedBackup, err)
+	}
+
+	if rv.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, rv.Version)
+	}
+
+	return &rv, nil
+}
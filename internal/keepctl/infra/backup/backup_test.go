@@ -0,0 +1,113 @@
+package backup_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/backup"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _now = time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDocument(t *testing.T) *backup.Document {
+	t.Helper()
+
+	doc := backup.New(_now)
+
+	secret := &goph.Secret{
+		Id:        "2ec9ae8b-3b5a-4b8a-9f10-33d8f3a0b6a1",
+		Name:      "github",
+		Kind:      goph.DataKind_CREDENTIALS,
+		Metadata:  []byte("work account"),
+		CreatedAt: timestamppb.New(_now),
+		UpdatedAt: timestamppb.New(_now),
+	}
+	labels := &goph.Labels{Folder: "work/dev", Tags: []string{"prod"}}
+	data := &goph.Credentials{
+		Login:    "alice",
+		Password: "s3cr3t",
+		Uris:     []*goph.Uri{{Uri: "https://github.com"}},
+	}
+	attachments := []entity.Attachment{
+		{
+			Info:    &goph.AttachmentInfo{Filename: "codes.txt", MimeType: "text/plain"},
+			Content: []byte("1234-5678"),
+		},
+	}
+
+	require.NoError(t, doc.Add(secret, labels, data, attachments))
+
+	note := &goph.Secret{Name: "note", Kind: goph.DataKind_TEXT}
+	require.NoError(t, doc.Add(note, &goph.Labels{}, &goph.Text{Text: "hello"}, nil))
+
+	return doc
+}
+
+func TestDocumentRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, backup.WriteJSON(&buf, newTestDocument(t)))
+
+	doc, err := backup.ReadJSON(&buf)
+	require.NoError(t, err)
+	require.Equal(t, backup.Version, doc.Version)
+	require.Equal(t, _now, doc.CreatedAt)
+
+	secrets, err := doc.NewSecrets()
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+
+	github := secrets[0]
+	require.Equal(t, "github", github.Name)
+	require.Equal(t, "work account", github.Description)
+	require.Equal(t, "/work/dev/github", github.Path())
+	require.Equal(t, []string{"prod"}, github.Labels.GetTags())
+	require.True(t, proto.Equal(&goph.Credentials{
+		Login:    "alice",
+		Password: "s3cr3t",
+		Uris:     []*goph.Uri{{Uri: "https://github.com"}},
+	}, github.Data))
+	require.Equal(t, []entity.NewAttachment{
+		{Filename: "codes.txt", MimeType: "text/plain", Content: []byte("1234-5678")},
+	}, github.Attachments)
+
+	require.Equal(t, "/note", secrets[1].Path())
+	require.True(t, proto.Equal(&goph.Text{Text: "hello"}, secrets[1].Data))
+}
+
+func TestReadJSONWithUnsupportedVersion(t *testing.T) {
+	_, err := backup.ReadJSON(strings.NewReader(`{"version": 2, "secrets": []}`))
+
+	require.ErrorIs(t, err, backup.ErrUnsupportedVersion)
+}
+
+func TestNewSecretsWithUnknownKind(t *testing.T) {
+	doc, err := backup.ReadJSON(strings.NewReader(
+		`{"version": 1, "secrets": [{"name": "x", "kind": "spaceship", "data": {}}]}`,
+	))
+	require.NoError(t, err)
+
+	_, err = doc.NewSecrets()
+	require.ErrorIs(t, err, backup.ErrMalformedBackup)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, backup.WriteCSV(&buf, newTestDocument(t)))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasPrefix(lines[0], "folder,name,kind,description,tags,"))
+	require.Contains(t, lines[0], "password")
+	require.True(t, strings.HasPrefix(lines[1], "/work/dev,github,creds,work account,prod,"))
+	require.Contains(t, lines[1], "s3cr3t")
+	require.True(t, strings.HasPrefix(lines[2], "/,note,text,,,"))
+}
@@ -0,0 +1,78 @@
+package backup
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+)
+
+// _csvColumns are common columns of CSV export, followed by values of secrets data.
+var _csvColumns = []string{"folder", "name", "kind", "description", "tags"}
+
+// WriteCSV writes the backup as CSV table, one secret per row.
+// Data of the secrets is exported as values of kind attributes,
+// see entity.Kind.Export, attached files are omitted.
+func WriteCSV(w io.Writer, d *Document) error {
+	rows := make([]map[string]string, 0, len(d.Secrets))
+	keys := make(map[string]struct{})
+
+	for i := range d.Secrets {
+		s := &d.Secrets[i]
+
+		secret, err := s.newSecret()
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrMalformedBackup, s.Name, err)
+		}
+
+		kind, err := entity.KindOfMessage(secret.Data)
+		if err != nil {
+			return fmt.Errorf("backup - WriteCSV - entity.KindOfMessage: %w", err)
+		}
+
+		values := kind.Export(secret.Data)
+		for key := range values {
+			keys[key] = struct{}{}
+		}
+
+		rows = append(rows, values)
+	}
+
+	dataColumns := make([]string, 0, len(keys))
+	for key := range keys {
+		dataColumns = append(dataColumns, key)
+	}
+
+	sort.Strings(dataColumns)
+
+	cw := csv.NewWriter(w)
+
+	header := append(append([]string{}, _csvColumns...), dataColumns...)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("backup - WriteCSV - cw.Write: %w", err)
+	}
+
+	for i := range d.Secrets {
+		s := &d.Secrets[i]
+		record := []string{s.Folder, s.Name, s.Kind, s.Description, strings.Join(s.Tags, ",")}
+
+		for _, key := range dataColumns {
+			record = append(record, rows[i][key])
+		}
+
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("backup - WriteCSV - cw.Write: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("backup - WriteCSV - cw.Flush: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/kdbx"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+// _kdbxTagSeparator separates tags of an entry.
+const _kdbxTagSeparator = ";"
+
+// WriteKDBX writes the backup as KeePass database (KDBX 4) protected with the password.
+// Folders become groups, credentials become regular entries, fields of custom secrets
+// become entry fields and attached files become entry attachments.
+// DefaultKDF of kdbx package is used if no KDF parameters provided.
+func WriteKDBX(w io.Writer, d *Document, name, password string, kdf *kdbx.KDF) error {
+	db := kdbx.New(name)
+	root := newKDBXGroup(&db.File.Root.Group)
+
+	for i := range d.Secrets {
+		s := &d.Secrets[i]
+
+		secret, err := s.newSecret()
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrMalformedBackup, s.Name, err)
+		}
+
+		entry := kdbxEntry(db, s, &secret)
+		group := root.subgroup(secret.Labels.GetFolder(), d.CreatedAt)
+		group.entries = append(group.entries, entry)
+	}
+
+	root.build()
+
+	if err := kdbx.Encode(w, db, password, kdf); err != nil {
+		return fmt.Errorf("backup - WriteKDBX - kdbx.Encode: %w", err)
+	}
+
+	return nil
+}
+
+// kdbxGroup collects entries of a group and its subgroups before building the database,
+// as groups of the database are kept by value.
+type kdbxGroup struct {
+	group    *kdbx.Group
+	entries  []kdbx.Entry
+	children []*kdbxGroup
+	byName   map[string]*kdbxGroup
+}
+
+func newKDBXGroup(group *kdbx.Group) *kdbxGroup {
+	return &kdbxGroup{group: group, byName: make(map[string]*kdbxGroup)}
+}
+
+// subgroup returns group of the folder creating missing groups on the path.
+func (g *kdbxGroup) subgroup(folder string, now time.Time) *kdbxGroup {
+	rv := g
+
+	for _, name := range strings.Split(folder, entity.FolderSeparator) {
+		if name == "" {
+			continue
+		}
+
+		child, ok := rv.byName[name]
+		if !ok {
+			child = newKDBXGroup(&kdbx.Group{
+				UUID:  kdbx.NewUUID(),
+				Name:  name,
+				Times: kdbx.NewTimes(now, now),
+			})
+			rv.byName[name] = child
+			rv.children = append(rv.children, child)
+		}
+
+		rv = child
+	}
+
+	return rv
+}
+
+// build puts collected entries and subgroups into the database group.
+func (g *kdbxGroup) build() {
+	g.group.Entries = append(g.group.Entries, g.entries...)
+
+	for _, child := range g.children {
+		child.build()
+		g.group.Groups = append(g.group.Groups, *child.group)
+	}
+}
+
+func kdbxEntry(db *kdbx.Database, s *Secret, secret *entity.NewSecret) kdbx.Entry {
+	entry := kdbx.Entry{
+		UUID:  kdbx.NewUUID(),
+		Tags:  strings.Join(s.Tags, _kdbxTagSeparator),
+		Times: kdbx.NewTimes(s.CreatedAt, s.UpdatedAt),
+	}
+
+	entry.Set(kdbx.TitleKey, s.Name, false)
+
+	notes := s.Description
+
+	switch data := secret.Data.(type) {
+	case *goph.Credentials:
+		kdbxCredentials(&entry, s, data)
+
+	case *goph.Custom:
+		for _, field := range data.GetFields() {
+			protected := field.GetType() == goph.FieldType_FIELD_HIDDEN ||
+				field.GetType() == goph.FieldType_FIELD_TOTP
+
+			entry.Set(kdbxFieldName(&entry, field.GetName()), field.GetValue(), protected)
+		}
+
+	case *goph.Text:
+		if notes == "" {
+			notes = data.GetText()
+
+			break
+		}
+
+		kdbxValues(&entry, secret)
+
+	default:
+		kdbxValues(&entry, secret)
+	}
+
+	if notes != "" {
+		entry.Set(kdbx.NotesKey, notes, false)
+	}
+
+	for _, attachment := range secret.Attachments {
+		entry.Binaries = append(entry.Binaries, db.AddBinary(attachment.Filename, attachment.Content))
+	}
+
+	return entry
+}
+
+func kdbxCredentials(entry *kdbx.Entry, s *Secret, data *goph.Credentials) {
+	entry.Set(kdbx.UserNameKey, data.GetLogin(), false)
+	entry.Set(kdbx.PasswordKey, data.GetPassword(), true)
+
+	for i, uri := range data.GetUris() {
+		key := kdbx.URLKey
+		if i > 0 {
+			key = kdbx.AltURLKey + "_" + strconv.Itoa(i)
+		}
+
+		entry.Set(key, uri.GetUri(), false)
+	}
+
+	if secret := data.GetTotp(); secret != "" {
+		// NB (alkurbatov): KeePassXC keeps otpauth:// URIs, KeePass keeps raw secrets.
+		if strings.HasPrefix(strings.ToLower(secret), "otpauth://") {
+			entry.Set(kdbx.OTPKey, secret, true)
+		} else {
+			entry.Set(kdbx.TOTPSecretKey, secret, true)
+		}
+	}
+
+	// NB (alkurbatov): KeePass has no moment the password was changed, the modification
+	// times of the entry and its history versions are used instead, see PasswordHistory.
+	history := data.GetPasswordHistory()
+
+	changed := data.GetPasswordChanged()
+	if changed == nil && len(history) > 0 {
+		changed = history[0].GetChangedAt()
+	}
+
+	if changed != nil {
+		modified := kdbx.FormatTime(changed.AsTime())
+		entry.Times.LastModificationTime = modified
+		entry.Times.LastAccessTime = modified
+	}
+
+	// NB (alkurbatov): Versions are kept oldest first, each password was set
+	// at the moment the previous one was replaced.
+	for i := len(history) - 1; i >= 0; i-- {
+		setAt := s.CreatedAt
+		if i+1 < len(history) {
+			setAt = history[i+1].GetChangedAt().AsTime()
+		}
+
+		version := kdbx.Entry{UUID: entry.UUID, Times: kdbx.NewTimes(setAt, setAt)}
+		version.Set(kdbx.TitleKey, s.Name, false)
+		version.Set(kdbx.UserNameKey, data.GetLogin(), false)
+		version.Set(kdbx.PasswordKey, history[i].GetPassword(), true)
+
+		entry.History = append(entry.History, version)
+	}
+}
+
+// kdbxValues stores data of kinds KeePass has no counterpart for
+// as fields named after the kind attributes.
+func kdbxValues(entry *kdbx.Entry, secret *entity.NewSecret) {
+	kind, err := entity.KindOfMessage(secret.Data)
+	if err != nil {
+		return
+	}
+
+	entry.Set(kdbx.KindKey, kind.Name, false)
+
+	values := kind.Export(secret.Data)
+
+	// NB (alkurbatov): Keep order of the attributes.
+	for i := range kind.Attributes {
+		key := kind.Attributes[i].Name
+		if value, ok := values[key]; ok {
+			entry.Set(kdbxFieldName(entry, key), value, kind.IsSensitive(key))
+		}
+	}
+}
+
+// kdbxFieldName makes name of the field unique and distinct from the standard fields.
+func kdbxFieldName(entry *kdbx.Entry, name string) string {
+	rv := name
+
+	for n := 2; ; n++ {
+		if !isKDBXReserved(rv) && !kdbxHasField(entry, rv) {
+			return rv
+		}
+
+		rv = fmt.Sprintf("%s (%d)", name, n)
+	}
+}
+
+func kdbxHasField(entry *kdbx.Entry, name string) bool {
+	for _, s := range entry.Strings {
+		if s.Key == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isKDBXReserved reports whether the field has special meaning.
+func isKDBXReserved(name string) bool {
+	switch name {
+	case kdbx.TitleKey, kdbx.UserNameKey, kdbx.PasswordKey, kdbx.URLKey, kdbx.NotesKey,
+		kdbx.OTPKey, kdbx.TOTPSecretKey, kdbx.KindKey:
+		return true
+
+	default:
+		return strings.HasPrefix(name, kdbx.AltURLKey)
+	}
+}
@@ -0,0 +1,144 @@
+package backup_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/backup"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/importer"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/kdbx"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testKDBXKDF = kdbx.KDF{
+	Variant:     kdbx.Argon2id,
+	Iterations:  2,
+	Memory:      64 * 1024,
+	Parallelism: 2,
+}
+
+func newKDBXDocument(t *testing.T) *backup.Document {
+	t.Helper()
+
+	doc := backup.New(_now)
+	changed := _now.Add(-24 * time.Hour)
+
+	add := func(name string, kind goph.DataKind, folder, description string, data proto.Message) {
+		secret := &goph.Secret{
+			Name:      name,
+			Kind:      kind,
+			Metadata:  []byte(description),
+			CreatedAt: timestamppb.New(_now.Add(-720 * time.Hour)),
+			UpdatedAt: timestamppb.New(_now),
+		}
+
+		require.NoError(t, doc.Add(secret, &goph.Labels{Folder: folder, Tags: []string{"team"}}, data, nil))
+	}
+
+	add("github", goph.DataKind_CREDENTIALS, "work/dev", "main account", &goph.Credentials{
+		Login:    "alice",
+		Password: "s3cr3t",
+		Uris: []*goph.Uri{
+			{Uri: "https://github.com"},
+			{Uri: "https://gist.github.com"},
+		},
+		Totp:            "JBSWY3DPEHPK3PXP",
+		PasswordChanged: timestamppb.New(changed),
+		PasswordHistory: []*goph.PasswordHistory{
+			{Password: "previous", ChangedAt: timestamppb.New(changed)},
+			{Password: "oldest", ChangedAt: timestamppb.New(changed.Add(-240 * time.Hour))},
+		},
+	})
+	add("server", goph.DataKind_CUSTOM, "work", "", &goph.Custom{
+		Fields: []*goph.Field{
+			{Name: "host", Type: goph.FieldType_FIELD_TEXT, Value: "10.0.0.1"},
+			{Name: "root password", Type: goph.FieldType_FIELD_HIDDEN, Value: "toor"},
+		},
+	})
+	add("visa", goph.DataKind_CARD, "", "salary", &goph.Card{
+		Number:     "4111111111111111",
+		Expiration: "12/30",
+		Holder:     "ALICE",
+		Cvv:        "123",
+	})
+	add("wifi", goph.DataKind_TEXT, "", "", &goph.Text{Text: "password: guest"})
+	add("motto", goph.DataKind_TEXT, "", "of the company", &goph.Text{Text: "Don't be evil"})
+
+	doc.Secrets[1].Attachments = []backup.Attachment{
+		{Filename: "id_rsa", MimeType: "text/plain", Content: []byte("key")},
+	}
+
+	return doc
+}
+
+func TestWriteKDBXRoundTrip(t *testing.T) {
+	doc := newKDBXDocument(t)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, backup.WriteKDBX(&buf, doc, "Vault", "password", &testKDBXKDF))
+
+	imported, err := importer.Parse(importer.FormatKDBX, &buf, "password")
+	require.NoError(t, err)
+
+	expected, err := doc.NewSecrets()
+	require.NoError(t, err)
+	require.Len(t, imported, len(expected))
+
+	byPath := make(map[string]int, len(imported))
+	for i := range imported {
+		byPath[imported[i].Path()] = i
+	}
+
+	for _, e := range expected {
+		idx, ok := byPath[e.Path()]
+		require.True(t, ok, e.Path())
+
+		actual := imported[idx]
+		require.Equal(t, e.Description, actual.Description, e.Path())
+		require.Equal(t, e.Labels.GetTags(), actual.Labels.GetTags(), e.Path())
+		require.True(t, proto.Equal(e.Data, actual.Data), "%s: %v != %v", e.Path(), e.Data, actual.Data)
+		require.Len(t, actual.Attachments, len(e.Attachments), e.Path())
+
+		for j := range e.Attachments {
+			require.Equal(t, e.Attachments[j].Filename, actual.Attachments[j].Filename)
+			require.Equal(t, e.Attachments[j].Content, actual.Attachments[j].Content)
+		}
+	}
+}
+
+func TestWriteKDBXKeepsStandardFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, backup.WriteKDBX(&buf, newKDBXDocument(t), "Vault", "password", &testKDBXKDF))
+
+	db, err := kdbx.Decode(&buf, "password")
+	require.NoError(t, err)
+	require.Equal(t, "Vault", db.File.Meta.DatabaseName)
+
+	root := db.File.Root.Group
+	require.Len(t, root.Groups, 1)
+	require.Equal(t, "work", root.Groups[0].Name)
+	require.Len(t, root.Groups[0].Groups, 1)
+	require.Equal(t, "dev", root.Groups[0].Groups[0].Name)
+
+	github := root.Groups[0].Groups[0].Entries[0]
+	require.Equal(t, "github", github.Get(kdbx.TitleKey))
+	require.Equal(t, "alice", github.Get(kdbx.UserNameKey))
+	require.Equal(t, "s3cr3t", github.Get(kdbx.PasswordKey))
+	require.True(t, github.IsProtected(kdbx.PasswordKey))
+	require.Equal(t, "https://github.com", github.Get(kdbx.URLKey))
+	require.Equal(t, "https://gist.github.com", github.Get(kdbx.AltURLKey+"_1"))
+	require.Equal(t, "main account", github.Get(kdbx.NotesKey))
+	require.Equal(t, []string{"team"}, github.TagList())
+	require.Len(t, github.History, 2)
+	require.Equal(t, "oldest", github.History[0].Get(kdbx.PasswordKey))
+
+	wifi := root.Entries[1]
+	require.Equal(t, "password: guest", wifi.Get(kdbx.NotesKey))
+	require.Empty(t, wifi.Get(kdbx.KindKey))
+}
@@ -0,0 +1,316 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Types of Bitwarden items.
+const (
+	_bitwardenLogin    = 1
+	_bitwardenNote     = 2
+	_bitwardenCard     = 3
+	_bitwardenIdentity = 4
+)
+
+// Types of Bitwarden custom fields.
+const (
+	_bitwardenFieldText   = 0
+	_bitwardenFieldHidden = 1
+	_bitwardenFieldBool   = 2
+)
+
+// bitwardenExport is unencrypted JSON export of Bitwarden vault.
+type bitwardenExport struct {
+	Encrypted bool `json:"encrypted"`
+	Folders   []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"folders"`
+	Items []bitwardenItem `json:"items"`
+}
+
+type bitwardenItem struct {
+	Type     int     `json:"type"`
+	FolderID *string `json:"folderId"`
+	Name     string  `json:"name"`
+	Notes    *string `json:"notes"`
+	Fields   []struct {
+		Name  string  `json:"name"`
+		Value *string `json:"value"`
+		Type  int     `json:"type"`
+	} `json:"fields"`
+	Login *struct {
+		URIs []struct {
+			Match *int32 `json:"match"`
+			URI   string `json:"uri"`
+		} `json:"uris"`
+		Username             *string    `json:"username"`
+		Password             *string    `json:"password"`
+		TOTP                 *string    `json:"totp"`
+		PasswordRevisionDate *time.Time `json:"passwordRevisionDate"`
+	} `json:"login"`
+	Card *struct {
+		CardholderName *string `json:"cardholderName"`
+		Brand          *string `json:"brand"`
+		Number         *string `json:"number"`
+		ExpMonth       *string `json:"expMonth"`
+		ExpYear        *string `json:"expYear"`
+		Code           *string `json:"code"`
+	} `json:"card"`
+	Identity        *bitwardenIdentity `json:"identity"`
+	PasswordHistory []struct {
+		LastUsedDate time.Time `json:"lastUsedDate"`
+		Password     string    `json:"password"`
+	} `json:"passwordHistory"`
+}
+
+type bitwardenIdentity struct {
+	FirstName      *string `json:"firstName"`
+	MiddleName     *string `json:"middleName"`
+	LastName       *string `json:"lastName"`
+	Address1       *string `json:"address1"`
+	Address2       *string `json:"address2"`
+	Address3       *string `json:"address3"`
+	City           *string `json:"city"`
+	State          *string `json:"state"`
+	PostalCode     *string `json:"postalCode"`
+	Country        *string `json:"country"`
+	Company        *string `json:"company"`
+	Email          *string `json:"email"`
+	Phone          *string `json:"phone"`
+	SSN            *string `json:"ssn"`
+	Username       *string `json:"username"`
+	PassportNumber *string `json:"passportNumber"`
+	LicenseNumber  *string `json:"licenseNumber"`
+}
+
+func parseBitwarden(r io.Reader) ([]entity.NewSecret, error) {
+	var export bitwardenExport
+
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedExport, err)
+	}
+
+	if export.Encrypted {
+		return nil, ErrEncryptedExport
+	}
+
+	folders := make(map[string]string, len(export.Folders))
+	for _, f := range export.Folders {
+		folders[f.ID] = f.Name
+	}
+
+	rv := make([]entity.NewSecret, 0, len(export.Items))
+
+	for i := range export.Items {
+		item := &export.Items[i]
+
+		e := &entry{
+			name:  item.Name,
+			notes: str(item.Notes),
+		}
+
+		if item.FolderID != nil {
+			e.folder = folders[*item.FolderID]
+		}
+
+		var (
+			secret entity.NewSecret
+			ok     bool
+		)
+
+		switch item.Type {
+		case _bitwardenLogin:
+			secret, ok = bitwardenLogin(e, item)
+
+		case _bitwardenNote:
+			bitwardenNotes(e, item)
+			secret, ok = e.secret()
+
+		case _bitwardenCard:
+			secret, ok = bitwardenCard(e, item)
+
+		case _bitwardenIdentity:
+			secret, ok = bitwardenIdentityOf(e, item)
+
+		default:
+			continue
+		}
+
+		if ok {
+			rv = append(rv, secret)
+		}
+	}
+
+	return rv, nil
+}
+
+func bitwardenLogin(e *entry, item *bitwardenItem) (entity.NewSecret, bool) {
+	if login := item.Login; login != nil {
+		e.username = str(login.Username)
+		e.password = str(login.Password)
+		e.totp = str(login.TOTP)
+
+		if login.PasswordRevisionDate != nil {
+			e.passwordChanged = *login.PasswordRevisionDate
+		}
+
+		for _, uri := range login.URIs {
+			match := goph.UriMatch_MATCH_DOMAIN
+
+			// NB (alkurbatov): Bitwarden match rules have the same values as ours.
+			if uri.Match != nil {
+				if _, ok := goph.UriMatch_name[*uri.Match]; ok {
+					match = goph.UriMatch(*uri.Match)
+				}
+			}
+
+			e.addURI(uri.URI, match)
+		}
+	}
+
+	for _, h := range item.PasswordHistory {
+		e.history = append(e.history, &goph.PasswordHistory{
+			Password:  h.Password,
+			ChangedAt: timestamppb.New(h.LastUsedDate),
+		})
+	}
+
+	for _, field := range item.Fields {
+		switch field.Type {
+		case _bitwardenFieldText, _bitwardenFieldBool:
+			e.addField(field.Name, goph.FieldType_FIELD_TEXT, str(field.Value))
+
+		case _bitwardenFieldHidden:
+			e.addField(field.Name, goph.FieldType_FIELD_HIDDEN, str(field.Value))
+		}
+	}
+
+	return e.secret()
+}
+
+// bitwardenNotes keeps custom fields of items having no place for them in the notes.
+func bitwardenNotes(e *entry, item *bitwardenItem) {
+	for _, field := range item.Fields {
+		if value := str(field.Value); value != "" {
+			e.notes = appendNote(e.notes, field.Name+": "+value)
+		}
+	}
+}
+
+func bitwardenCard(e *entry, item *bitwardenItem) (entity.NewSecret, bool) {
+	bitwardenNotes(e, item)
+
+	secret := e.base()
+
+	card := item.Card
+	if card == nil {
+		return secret, false
+	}
+
+	data := &goph.Card{
+		Number: entity.NormalizeCardNumber(str(card.Number)),
+		Holder: str(card.CardholderName),
+		Cvv:    str(card.Code),
+		Bank:   str(card.Brand),
+	}
+
+	month, year := str(card.ExpMonth), str(card.ExpYear)
+	if month != "" && year != "" {
+		if len(month) == 1 {
+			month = "0" + month
+		}
+
+		if len(year) > 2 {
+			year = year[len(year)-2:]
+		}
+
+		data.Expiration = month + "/" + year
+	}
+
+	secret.Data = data
+
+	return secret, true
+}
+
+func bitwardenIdentityOf(e *entry, item *bitwardenItem) (entity.NewSecret, bool) {
+	id := item.Identity
+	if id == nil {
+		return entity.NewSecret{}, false
+	}
+
+	// NB (alkurbatov): Identity keeps personal info only, the rest goes to the notes.
+	extra := []struct {
+		title string
+		value *string
+	}{
+		{"Company", id.Company},
+		{"Username", id.Username},
+		{"SSN", id.SSN},
+		{"Passport", id.PassportNumber},
+		{"License", id.LicenseNumber},
+	}
+
+	for _, v := range extra {
+		if value := str(v.value); value != "" {
+			e.notes = appendNote(e.notes, v.title+": "+value)
+		}
+	}
+
+	bitwardenNotes(e, item)
+
+	secret := e.base()
+
+	data := &goph.Identity{
+		FullName: joinNonEmpty(" ", str(id.FirstName), str(id.MiddleName), str(id.LastName)),
+		Email:    str(id.Email),
+		Phone:    str(id.Phone),
+	}
+
+	address := &goph.Address{
+		Street:     joinNonEmpty(", ", str(id.Address1), str(id.Address2), str(id.Address3)),
+		City:       str(id.City),
+		Region:     str(id.State),
+		PostalCode: str(id.PostalCode),
+		Country:    str(id.Country),
+	}
+
+	if address.Street != "" || address.City != "" || address.Region != "" ||
+		address.PostalCode != "" || address.Country != "" {
+		data.Address = address
+	}
+
+	secret.Data = data
+
+	return secret, true
+}
+
+// str dereferences optional string of JSON document.
+func str(v *string) string {
+	if v == nil {
+		return ""
+	}
+
+	return *v
+}
+
+// joinNonEmpty joins non-empty values with the separator.
+func joinNonEmpty(sep string, values ...string) string {
+	rv := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			rv = append(rv, v)
+		}
+	}
+
+	return strings.Join(rv, sep)
+}
@@ -0,0 +1,140 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/importer"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+const bitwardenExport = `{
+  "encrypted": false,
+  "folders": [{"id": "f1", "name": "Work/Mail"}],
+  "items": [
+    {
+      "type": 1,
+      "folderId": "f1",
+      "name": "Mail",
+      "notes": "corporate",
+      "login": {
+        "uris": [{"match": 1, "uri": "mail.example.com"}, {"match": null, "uri": "not a url"}],
+        "username": "alice",
+        "password": "s3cr3t",
+        "totp": "JBSWY3DPEHPK3PXP",
+        "passwordRevisionDate": "2023-03-01T10:00:00.000Z"
+      },
+      "passwordHistory": [{"lastUsedDate": "2023-03-01T10:00:00.000Z", "password": "old"}]
+    },
+    {
+      "type": 1,
+      "folderId": null,
+      "name": "Bank",
+      "fields": [{"name": "PIN", "value": "1234", "type": 1}],
+      "login": {"username": "bob", "password": "qwerty"}
+    },
+    {
+      "type": 2,
+      "name": "Wi-Fi",
+      "notes": "password: guest"
+    },
+    {
+      "type": 3,
+      "name": "Visa",
+      "card": {
+        "cardholderName": "ALICE",
+        "brand": "Visa",
+        "number": "4111 1111 1111 1111",
+        "expMonth": "3",
+        "expYear": "2030",
+        "code": "123"
+      }
+    },
+    {
+      "type": 4,
+      "name": "Me",
+      "identity": {
+        "firstName": "Alice",
+        "lastName": "Smith",
+        "email": "alice@example.com",
+        "city": "Moscow",
+        "company": "ACME"
+      }
+    },
+    {
+      "type": 5,
+      "name": "Unknown"
+    }
+  ]
+}`
+
+func TestParseBitwarden(t *testing.T) {
+	secrets, err := importer.Parse(importer.FormatBitwarden, strings.NewReader(bitwardenExport), "")
+	require.NoError(t, err)
+	require.Len(t, secrets, 5)
+
+	mail := secrets[0]
+	require.Equal(t, "/Work/Mail/Mail", mail.Path())
+	require.Equal(t, "corporate", mail.Description)
+
+	creds, ok := mail.Data.(*goph.Credentials)
+	require.True(t, ok)
+	require.Equal(t, "alice", creds.GetLogin())
+	require.Equal(t, "s3cr3t", creds.GetPassword())
+	require.Equal(t, "JBSWY3DPEHPK3PXP", creds.GetTotp())
+	require.Len(t, creds.GetUris(), 2)
+	require.Equal(t, "https://mail.example.com", creds.GetUris()[0].GetUri())
+	require.Equal(t, goph.UriMatch_MATCH_HOST, creds.GetUris()[0].GetMatch())
+	require.Equal(t, goph.UriMatch_MATCH_NEVER, creds.GetUris()[1].GetMatch())
+	require.Len(t, creds.GetPasswordHistory(), 1)
+	require.Equal(t, "old", creds.GetPasswordHistory()[0].GetPassword())
+	require.NotNil(t, creds.GetPasswordChanged())
+
+	bank, ok := secrets[1].Data.(*goph.Custom)
+	require.True(t, ok)
+	require.Equal(t, []string{"login", "password", "PIN"}, fieldNames(bank))
+	require.Equal(t, goph.FieldType_FIELD_HIDDEN, bank.GetFields()[2].GetType())
+
+	note, ok := secrets[2].Data.(*goph.Text)
+	require.True(t, ok)
+	require.Equal(t, "password: guest", note.GetText())
+	require.Empty(t, secrets[2].Description)
+
+	card, ok := secrets[3].Data.(*goph.Card)
+	require.True(t, ok)
+	require.Equal(t, "4111111111111111", card.GetNumber())
+	require.Equal(t, "03/30", card.GetExpiration())
+	require.Equal(t, "123", card.GetCvv())
+
+	id, ok := secrets[4].Data.(*goph.Identity)
+	require.True(t, ok)
+	require.Equal(t, "Alice Smith", id.GetFullName())
+	require.Equal(t, "Moscow", id.GetAddress().GetCity())
+	require.Equal(t, "Company: ACME", secrets[4].Description)
+}
+
+func TestParseBitwardenEncrypted(t *testing.T) {
+	_, err := importer.Parse(
+		importer.FormatBitwarden,
+		strings.NewReader(`{"encrypted": true, "items": []}`),
+		"",
+	)
+
+	require.ErrorIs(t, err, importer.ErrEncryptedExport)
+}
+
+func TestParseBitwardenMalformed(t *testing.T) {
+	_, err := importer.Parse(importer.FormatBitwarden, strings.NewReader(`[`), "")
+
+	require.ErrorIs(t, err, importer.ErrMalformedExport)
+}
+
+func fieldNames(custom *goph.Custom) []string {
+	rv := make([]string, 0, len(custom.GetFields()))
+	for _, field := range custom.GetFields() {
+		rv = append(rv, field.GetName())
+	}
+
+	return rv
+}
@@ -0,0 +1,172 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+)
+
+// Columns of CSV exports we understand.
+const (
+	_csvName = iota
+	_csvFolder
+	_csvUsername
+	_csvPassword
+	_csvURL
+	_csvTOTP
+	_csvNotes
+	_csvTags
+	_csvType
+	_csvColumns
+)
+
+// _csvAliases maps headers used by different password managers
+// (Bitwarden, LastPass, Chrome, Firefox, KeePassXC) to known columns.
+var _csvAliases = map[string]int{
+	"name":           _csvName,
+	"title":          _csvName,
+	"folder":         _csvFolder,
+	"grouping":       _csvFolder,
+	"group":          _csvFolder,
+	"username":       _csvUsername,
+	"login_username": _csvUsername,
+	"login":          _csvUsername,
+	"user name":      _csvUsername,
+	"user":           _csvUsername,
+	"password":       _csvPassword,
+	"login_password": _csvPassword,
+	"url":            _csvURL,
+	"login_uri":      _csvURL,
+	"uri":            _csvURL,
+	"website":        _csvURL,
+	"totp":           _csvTOTP,
+	"login_totp":     _csvTOTP,
+	"otpauth":        _csvTOTP,
+	"otp":            _csvTOTP,
+	"notes":          _csvNotes,
+	"note":           _csvNotes,
+	"extra":          _csvNotes,
+	"comments":       _csvNotes,
+	"tags":           _csvTags,
+	"type":           _csvType,
+}
+
+// _lastPassNoteURL marks secure notes in LastPass exports.
+const _lastPassNoteURL = "http://sn"
+
+// _bom is UTF-8 byte order mark.
+const _bom = "\ufeff"
+
+func parseCSV(r io.Reader) ([]entity.NewSecret, error) {
+	br := bufio.NewReader(r)
+
+	// NB (alkurbatov): Some password managers, e.g. Firefox, start the file with BOM.
+	if bom, err := br.Peek(len(_bom)); err == nil && string(bom) == _bom {
+		br.Discard(len(_bom)) //nolint:errcheck // never fails after successful peek
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedExport, err)
+	}
+
+	columns, err := csvColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make([]entity.NewSecret, 0)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrMalformedExport, err)
+		}
+
+		if secret, ok := csvSecret(columns, record); ok {
+			rv = append(rv, secret)
+		}
+	}
+
+	return rv, nil
+}
+
+// csvColumns returns indices of known columns in the record, -1 if there is no such column.
+func csvColumns(header []string) ([]int, error) {
+	rv := make([]int, _csvColumns)
+	for i := range rv {
+		rv[i] = -1
+	}
+
+	for i, title := range header {
+		title = strings.ToLower(strings.TrimSpace(title))
+
+		if column, ok := _csvAliases[title]; ok && rv[column] == -1 {
+			rv[column] = i
+		}
+	}
+
+	if rv[_csvName] == -1 && rv[_csvURL] == -1 {
+		return nil, fmt.Errorf("%w: no name or url column in header %q",
+			ErrMalformedExport, strings.Join(header, ","))
+	}
+
+	return rv, nil
+}
+
+func csvSecret(columns []int, record []string) (entity.NewSecret, bool) {
+	get := func(column int) string {
+		if idx := columns[column]; idx != -1 && idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+
+		return ""
+	}
+
+	e := &entry{
+		name:     get(_csvName),
+		folder:   get(_csvFolder),
+		notes:    get(_csvNotes),
+		username: get(_csvUsername),
+		password: get(_csvPassword),
+		totp:     get(_csvTOTP),
+	}
+
+	if tags := get(_csvTags); tags != "" {
+		e.tags = strings.Split(tags, ",")
+	}
+
+	rawURL := get(_csvURL)
+	if get(_csvType) == "note" || rawURL == _lastPassNoteURL {
+		e.username, e.password, e.totp = "", "", ""
+
+		return e.secret()
+	}
+
+	// NB (alkurbatov): Bitwarden joins several addresses with commas.
+	for _, raw := range strings.Split(rawURL, ",") {
+		e.addURI(raw, goph.UriMatch_MATCH_DOMAIN)
+	}
+
+	if e.name == "" && len(e.uris) > 0 {
+		if u, err := url.Parse(e.uris[0].GetUri()); err == nil {
+			e.name = u.Hostname()
+		}
+	}
+
+	return e.secret()
+}
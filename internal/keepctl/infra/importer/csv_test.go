@@ -0,0 +1,86 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/importer"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	tt := []struct {
+		name   string
+		data   string
+		path   string
+		login  string
+		uris   int
+		isNote bool
+	}{
+		{
+			name: "Bitwarden",
+			data: "folder,favorite,type,name,notes,fields,reprompt,login_uri,login_username," +
+				"login_password,login_totp\n" +
+				"Work,,login,Mail,,,0,\"mail.example.com,webmail.example.com\",alice,s3cr3t,\n",
+			path:  "/Work/Mail",
+			login: "alice",
+			uris:  2,
+		},
+		{
+			name:   "Bitwarden note",
+			data:   "folder,type,name,notes\n,note,Wi-Fi,password: guest\n",
+			path:   "/Wi-Fi",
+			isNote: true,
+		},
+		{
+			name:  "LastPass",
+			data:  "url,username,password,totp,extra,name,grouping,fav\nhttps://a.com,bob,pwd,,,A,Web,0\n",
+			path:  "/Web/A",
+			login: "bob",
+			uris:  1,
+		},
+		{
+			name:   "LastPass note",
+			data:   "url,username,password,totp,extra,name,grouping,fav\nhttp://sn,,,,text,Note,,0\n",
+			path:   "/Note",
+			isNote: true,
+		},
+		{
+			name: "Firefox",
+			data: "\ufeff\"url\",\"username\",\"password\"\n" +
+				"\"https://example.com:8080\",\"carol\",\"pwd\"\n",
+			path:  "/example.com",
+			login: "carol",
+			uris:  1,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			secrets, err := importer.Parse(importer.FormatCSV, strings.NewReader(tc.data), "")
+			require.NoError(t, err)
+			require.Len(t, secrets, 1)
+			require.Equal(t, tc.path, secrets[0].Path())
+
+			if tc.isNote {
+				_, ok := secrets[0].Data.(*goph.Text)
+				require.True(t, ok)
+
+				return
+			}
+
+			creds, ok := secrets[0].Data.(*goph.Credentials)
+			require.True(t, ok)
+			require.Equal(t, tc.login, creds.GetLogin())
+			require.Len(t, creds.GetUris(), tc.uris)
+		})
+	}
+}
+
+func TestParseCSVWithUnknownHeader(t *testing.T) {
+	_, err := importer.Parse(importer.FormatCSV, strings.NewReader("a,b,c\n1,2,3\n"), "")
+
+	require.ErrorIs(t, err, importer.ErrMalformedExport)
+}
@@ -0,0 +1,330 @@
+// Package importer converts data exported from other password managers
+// into secrets of the supported kinds.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/totp"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Supported formats of imported data.
+const (
+	FormatBitwarden  = "bitwarden-json"
+	FormatKeePassXML = "keepass-xml"
+	FormatKDBX       = "kdbx"
+	Format1PUX       = "1pux"
+	FormatCSV        = "csv"
+)
+
+var (
+	ErrUnknownFormat    = errors.New("unknown import format")
+	ErrMalformedExport  = errors.New("malformed export file")
+	ErrEncryptedExport  = errors.New("encrypted exports are not supported, export unencrypted data")
+	ErrPasswordRequired = errors.New("password of the database is required")
+)
+
+// _untitled is name of secrets imported without title.
+const _untitled = "Untitled"
+
+// Formats returns names of supported formats.
+func Formats() []string {
+	return []string{FormatBitwarden, FormatKeePassXML, FormatKDBX, Format1PUX, FormatCSV}
+}
+
+// Parse reads exported data of the format and converts it into new secrets.
+// The password is used to decrypt KeePass databases only.
+func Parse(format string, r io.Reader, password string) ([]entity.NewSecret, error) {
+	var (
+		rv  []entity.NewSecret
+		err error
+	)
+
+	switch format {
+	case FormatBitwarden:
+		rv, err = parseBitwarden(r)
+
+	case FormatKeePassXML:
+		rv, err = parseKeePassXML(r)
+
+	case FormatKDBX:
+		rv, err = parseKDBX(r, password)
+
+	case Format1PUX:
+		rv, err = parse1PUX(r)
+
+	case FormatCSV:
+		rv, err = parseCSV(r)
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("importer - Parse(%s): %w", format, err)
+	}
+
+	return rv, nil
+}
+
+// entry is intermediate form of an imported login shared by all formats.
+type entry struct {
+	name   string
+	folder string
+	notes  string
+	tags   []string
+
+	username        string
+	password        string
+	uris            []*goph.Uri
+	totp            string
+	passwordChanged time.Time
+	history         []*goph.PasswordHistory
+
+	// fields are custom fields of the entry, the entry becomes custom secret if there are any.
+	fields      []*goph.Field
+	attachments []entity.NewAttachment
+}
+
+// addURI adds website address to the entry, see importURI.
+func (e *entry) addURI(raw string, match goph.UriMatch) {
+	if uri := importURI(raw, match); uri != nil {
+		e.uris = append(e.uris, uri)
+	}
+}
+
+// addField adds custom field to the entry, empty values are ignored.
+// Values not valid for the type are stored as text.
+func (e *entry) addField(name string, kind goph.FieldType, value string) {
+	if value == "" {
+		return
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "field"
+	}
+
+	field := &goph.Field{Name: uniqueFieldName(e.fields, name), Type: kind, Value: value}
+	if entity.ValidateField(field) != nil {
+		field.Type = goph.FieldType_FIELD_TEXT
+		if kind == goph.FieldType_FIELD_TOTP {
+			field.Type = goph.FieldType_FIELD_HIDDEN
+		}
+	}
+
+	e.fields = append(e.fields, field)
+}
+
+// isEmpty reports whether the entry has no login data.
+func (e *entry) isEmpty() bool {
+	return e.username == "" && e.password == "" && len(e.uris) == 0 && e.totp == ""
+}
+
+// base returns new secret without data, used for kinds other than credentials.
+func (e *entry) base() entity.NewSecret {
+	return entity.NewSecret{
+		Name:        importName(e.name),
+		Description: e.notes,
+		Labels:      &goph.Labels{Folder: importFolder(e.folder), Tags: importTags(e.tags)},
+		Attachments: e.attachments,
+	}
+}
+
+// secret converts the entry into new secret.
+// Returns false if the entry has no data at all.
+func (e *entry) secret() (entity.NewSecret, bool) {
+	if e.totp != "" {
+		if _, err := totp.Parse(e.totp); err != nil {
+			// NB (alkurbatov): Unsupported one-time passwords, e.g. Steam guard codes,
+			// are still kept as hidden values.
+			e.addField("totp", goph.FieldType_FIELD_HIDDEN, e.totp)
+			e.totp = ""
+		}
+	}
+
+	rv := e.base()
+
+	switch {
+	case len(e.fields) > 0:
+		rv.Data = e.custom()
+
+	case !e.isEmpty():
+		rv.Data = e.credentials()
+
+	case e.notes != "":
+		rv.Description = ""
+		rv.Data = &goph.Text{Text: e.notes}
+
+	case len(e.attachments) > 0:
+		rv.Data = &goph.Text{Text: rv.Name}
+
+	default:
+		return rv, false
+	}
+
+	return rv, true
+}
+
+func (e *entry) credentials() *goph.Credentials {
+	rv := &goph.Credentials{
+		Login:    e.username,
+		Password: e.password,
+		Uris:     e.uris,
+		Totp:     e.totp,
+	}
+
+	if !e.passwordChanged.IsZero() {
+		rv.PasswordChanged = timestamppb.New(e.passwordChanged)
+	}
+
+	for _, h := range e.history {
+		if h.GetPassword() == "" || h.GetPassword() == e.password {
+			continue
+		}
+
+		if len(rv.PasswordHistory) == entity.MaxPasswordHistory {
+			break
+		}
+
+		rv.PasswordHistory = append(rv.PasswordHistory, h)
+	}
+
+	return rv
+}
+
+func (e *entry) custom() *goph.Custom {
+	extra := e.fields
+	e.fields = nil
+
+	e.addField("login", goph.FieldType_FIELD_TEXT, e.username)
+	e.addField("password", goph.FieldType_FIELD_HIDDEN, e.password)
+
+	for _, uri := range e.uris {
+		e.addField("url", goph.FieldType_FIELD_URL, uri.GetUri())
+	}
+
+	e.addField("totp", goph.FieldType_FIELD_TOTP, e.totp)
+
+	for _, field := range extra {
+		e.addField(field.GetName(), field.GetType(), field.GetValue())
+	}
+
+	return &goph.Custom{Fields: e.fields}
+}
+
+// uniqueFieldName appends number to the name if there is a field with the same name already.
+func uniqueFieldName(fields []*goph.Field, name string) string {
+	rv := name
+
+	for n := 2; ; n++ {
+		found := false
+
+		for _, field := range fields {
+			if field.GetName() == rv {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return rv
+		}
+
+		rv = fmt.Sprintf("%s (%d)", name, n)
+	}
+}
+
+// importURI converts website address into URI of credentials.
+// Addresses without scheme are treated as HTTPS ones, invalid addresses are stored
+// for reference only.
+func importURI(raw string, match goph.UriMatch) *goph.Uri {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+
+	uri := &goph.Uri{Uri: raw, Match: match}
+
+	if match != goph.UriMatch_MATCH_REGEX && match != goph.UriMatch_MATCH_NEVER &&
+		!strings.Contains(raw, "://") {
+		uri.Uri = "https://" + raw
+	}
+
+	if entity.ValidateURI(uri) != nil {
+		return &goph.Uri{Uri: raw, Match: goph.UriMatch_MATCH_NEVER}
+	}
+
+	return uri
+}
+
+// importName makes name of the secret acceptable by keeper.
+func importName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return _untitled
+	}
+
+	for len(name) > entity.MaxSecretNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return name
+}
+
+// importFolder makes folder path acceptable by keeper,
+// e.g. empty parts of the path are removed.
+func importFolder(path string) string {
+	parts := make([]string, 0)
+
+	for _, part := range strings.Split(path, entity.FolderSeparator) {
+		switch part = strings.TrimSpace(part); part {
+		case "":
+			continue
+
+		case ".", "..":
+			part = "_"
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, entity.FolderSeparator)
+}
+
+// importTags makes tags acceptable by keeper, e.g. spaces are replaced with dashes.
+func importTags(tags []string) []string {
+	rv := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.Join(strings.FieldsFunc(tag, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		}), "-")
+
+		if tag != "" {
+			rv = append(rv, tag)
+		}
+	}
+
+	return rv
+}
+
+// appendNote adds line to the notes.
+func appendNote(notes, line string) string {
+	if notes == "" {
+		return line
+	}
+
+	return notes + "\n" + line
+}
@@ -0,0 +1,24 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/importer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnknownFormat(t *testing.T) {
+	_, err := importer.Parse("lastpass-xml", strings.NewReader(""), "")
+
+	require.ErrorIs(t, err, importer.ErrUnknownFormat)
+}
+
+func TestFormats(t *testing.T) {
+	for _, format := range importer.Formats() {
+		_, err := importer.Parse(format, strings.NewReader(""), "")
+
+		require.Error(t, err)
+		require.NotErrorIs(t, err, importer.ErrUnknownFormat, format)
+	}
+}
@@ -0,0 +1,223 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/kdbx"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func parseKeePassXML(r io.Reader) ([]entity.NewSecret, error) {
+	db, err := kdbx.ParseXML(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedExport, err)
+	}
+
+	return keepassSecrets(db)
+}
+
+func parseKDBX(r io.Reader, password string) ([]entity.NewSecret, error) {
+	db, err := kdbx.Decode(r, password)
+	if err != nil {
+		if errors.Is(err, kdbx.ErrInvalidCredentials) && password == "" {
+			return nil, ErrPasswordRequired
+		}
+
+		return nil, err
+	}
+
+	return keepassSecrets(db)
+}
+
+func keepassSecrets(db *kdbx.Database) ([]entity.NewSecret, error) {
+	rv := make([]entity.NewSecret, 0)
+
+	recycleBin := ""
+	if !strings.EqualFold(db.File.Meta.RecycleBinEnabled, "False") {
+		recycleBin = db.File.Meta.RecycleBinUUID
+	}
+
+	var walk func(group *kdbx.Group, folder string) error
+
+	walk = func(group *kdbx.Group, folder string) error {
+		for i := range group.Entries {
+			secret, ok, err := keepassSecret(db, &group.Entries[i], folder)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				rv = append(rv, secret)
+			}
+		}
+
+		for i := range group.Groups {
+			sub := &group.Groups[i]
+			if recycleBin != "" && sub.UUID == recycleBin {
+				continue
+			}
+
+			if err := walk(sub, folder+entity.FolderSeparator+sub.Name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// NB (alkurbatov): The root group represents the database itself and doesn't become a folder.
+	if err := walk(&db.File.Root.Group, ""); err != nil {
+		return nil, err
+	}
+
+	return rv, nil
+}
+
+func keepassSecret(
+	db *kdbx.Database,
+	item *kdbx.Entry,
+	folder string,
+) (entity.NewSecret, bool, error) {
+	e := &entry{
+		name:     item.Get(kdbx.TitleKey),
+		folder:   folder,
+		notes:    item.Get(kdbx.NotesKey),
+		tags:     item.TagList(),
+		username: item.Get(kdbx.UserNameKey),
+		password: item.Get(kdbx.PasswordKey),
+		totp:     item.Get(kdbx.OTPKey),
+	}
+
+	if e.totp == "" {
+		e.totp = item.Get(kdbx.TOTPSecretKey)
+	}
+
+	e.addURI(item.Get(kdbx.URLKey), goph.UriMatch_MATCH_DOMAIN)
+	e.passwordChanged, e.history = keepassPasswordHistory(item)
+
+	for _, s := range item.Strings {
+		switch s.Key {
+		case kdbx.TitleKey, kdbx.UserNameKey, kdbx.PasswordKey, kdbx.URLKey, kdbx.NotesKey,
+			kdbx.OTPKey, kdbx.TOTPSecretKey, kdbx.KindKey:
+			continue
+		}
+
+		if strings.HasPrefix(s.Key, kdbx.AltURLKey) {
+			e.addURI(s.Value.Text, goph.UriMatch_MATCH_DOMAIN)
+
+			continue
+		}
+
+		kind := goph.FieldType_FIELD_TEXT
+		if item.IsProtected(s.Key) {
+			kind = goph.FieldType_FIELD_HIDDEN
+		}
+
+		e.addField(s.Key, kind, s.Value.Text)
+	}
+
+	for _, ref := range item.Binaries {
+		content, err := db.Binary(ref)
+		if err != nil {
+			return entity.NewSecret{}, false, err
+		}
+
+		e.attachments = append(e.attachments, entity.NewAttachment{
+			Filename: ref.Key,
+			Content:  content,
+		})
+	}
+
+	if secret, ok := keepassKindSecret(e, item); ok {
+		return secret, true, nil
+	}
+
+	secret, ok := e.secret()
+
+	return secret, ok, nil
+}
+
+// keepassKindSecret restores secret of the kind KeePass has no counterpart for,
+// e.g. card exported by keepctl. Returns false if the entry is not such secret.
+func keepassKindSecret(e *entry, item *kdbx.Entry) (entity.NewSecret, bool) {
+	kind, err := entity.KindByName(item.Get(kdbx.KindKey))
+	if err != nil {
+		return entity.NewSecret{}, false
+	}
+
+	values := make(map[string]string, len(item.Strings))
+	for _, s := range item.Strings {
+		values[s.Key] = s.Value.Text
+	}
+
+	data, err := kind.Import(values)
+	if err != nil || kind.Validate(data) != nil {
+		return entity.NewSecret{}, false
+	}
+
+	secret := e.base()
+	secret.Data = data
+
+	return secret, true
+}
+
+// keepassPasswordHistory extracts moment the current password was set
+// and previous passwords from history of the entry, most recent first.
+func keepassPasswordHistory(item *kdbx.Entry) (time.Time, []*goph.PasswordHistory) {
+	changed := keepassTime(item.Times.LastModificationTime)
+	current := item.Get(kdbx.PasswordKey)
+	rv := make([]*goph.PasswordHistory, 0)
+
+	// NB (alkurbatov): History is sorted from the oldest version to the newest one,
+	// a password was replaced at the moment the next version was saved.
+	replacedAt := changed
+	tracking := true
+
+	for i := len(item.History) - 1; i >= 0; i-- {
+		version := &item.History[i]
+		password := version.Get(kdbx.PasswordKey)
+		modified := keepassTime(version.Times.LastModificationTime)
+
+		if tracking && password == current {
+			changed = modified
+			replacedAt = modified
+
+			continue
+		}
+
+		tracking = false
+
+		if len(rv) == 0 || rv[len(rv)-1].GetPassword() != password {
+			h := &goph.PasswordHistory{Password: password}
+			if !replacedAt.IsZero() {
+				h.ChangedAt = timestamppb.New(replacedAt)
+			}
+
+			rv = append(rv, h)
+		}
+
+		replacedAt = modified
+	}
+
+	return changed, rv
+}
+
+// keepassTime parses timestamp of the entry, invalid timestamps are ignored.
+func keepassTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+
+	t, err := kdbx.ParseTime(value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
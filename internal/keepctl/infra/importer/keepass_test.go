@@ -0,0 +1,181 @@
+package importer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/importer"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/kdbx"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+var testKDF = kdbx.KDF{
+	Variant:     kdbx.Argon2id,
+	Iterations:  2,
+	Memory:      64 * 1024,
+	Parallelism: 2,
+}
+
+func newKeePassDatabase() *kdbx.Database {
+	db := kdbx.New("Vault")
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mail := kdbx.Entry{
+		UUID:  kdbx.NewUUID(),
+		Tags:  "work;mail box",
+		Times: kdbx.NewTimes(created, created.Add(72*time.Hour)),
+	}
+	mail.Set(kdbx.TitleKey, "Mail", false)
+	mail.Set(kdbx.UserNameKey, "alice", false)
+	mail.Set(kdbx.PasswordKey, "s3cr3t", true)
+	mail.Set(kdbx.URLKey, "https://mail.example.com", false)
+	mail.Set(kdbx.OTPKey, "otpauth://totp/mail?secret=JBSWY3DPEHPK3PXP", true)
+
+	v1 := kdbx.Entry{UUID: mail.UUID, Times: kdbx.NewTimes(created, created)}
+	v1.Set(kdbx.PasswordKey, "old", true)
+
+	v2 := kdbx.Entry{UUID: mail.UUID, Times: kdbx.NewTimes(created, created.Add(24*time.Hour))}
+	v2.Set(kdbx.PasswordKey, "s3cr3t", true)
+
+	mail.History = []kdbx.Entry{v1, v2}
+
+	server := kdbx.Entry{UUID: kdbx.NewUUID()}
+	server.Set(kdbx.TitleKey, "Server", false)
+	server.Set(kdbx.PasswordKey, "root", true)
+	server.Set("PIN", "1234", true)
+	server.Set("Host", "10.0.0.1", false)
+	server.Binaries = append(server.Binaries, db.AddBinary("id_rsa", []byte("key")))
+
+	deleted := kdbx.Entry{UUID: kdbx.NewUUID()}
+	deleted.Set(kdbx.TitleKey, "Deleted", false)
+	deleted.Set(kdbx.PasswordKey, "gone", true)
+
+	bin := kdbx.Group{UUID: kdbx.NewUUID(), Name: "Recycle Bin", Entries: []kdbx.Entry{deleted}}
+	db.File.Meta.RecycleBinEnabled = "True"
+	db.File.Meta.RecycleBinUUID = bin.UUID
+
+	root := &db.File.Root.Group
+	root.Entries = []kdbx.Entry{server}
+	root.Groups = []kdbx.Group{
+		{UUID: kdbx.NewUUID(), Name: "Internet", Entries: []kdbx.Entry{mail}},
+		bin,
+	}
+
+	return db
+}
+
+func TestParseKDBX(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := kdbx.Encode(&buf, newKeePassDatabase(), "password", &testKDF)
+	require.NoError(t, err)
+
+	secrets, err := importer.Parse(importer.FormatKDBX, &buf, "password")
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+
+	server := secrets[0]
+	require.Equal(t, "/Server", server.Path())
+
+	custom, ok := server.Data.(*goph.Custom)
+	require.True(t, ok)
+	require.Equal(t, []string{"password", "PIN", "Host"}, fieldNames(custom))
+	require.Equal(t, goph.FieldType_FIELD_HIDDEN, custom.GetFields()[1].GetType())
+	require.Equal(t, goph.FieldType_FIELD_TEXT, custom.GetFields()[2].GetType())
+	require.Len(t, server.Attachments, 1)
+	require.Equal(t, "id_rsa", server.Attachments[0].Filename)
+	require.Equal(t, []byte("key"), server.Attachments[0].Content)
+
+	mail := secrets[1]
+	require.Equal(t, "/Internet/Mail", mail.Path())
+	require.Equal(t, []string{"work", "mail-box"}, mail.Labels.GetTags())
+
+	creds, ok := mail.Data.(*goph.Credentials)
+	require.True(t, ok)
+	require.Equal(t, "alice", creds.GetLogin())
+	require.Equal(t, "s3cr3t", creds.GetPassword())
+	require.NotEmpty(t, creds.GetTotp())
+	require.Len(t, creds.GetUris(), 1)
+
+	// NB (alkurbatov): The password was set by the second version of the entry
+	// and replaced "old" one at the same moment.
+	changed := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, changed, creds.GetPasswordChanged().AsTime())
+	require.Len(t, creds.GetPasswordHistory(), 1)
+	require.Equal(t, "old", creds.GetPasswordHistory()[0].GetPassword())
+	require.Equal(t, changed, creds.GetPasswordHistory()[0].GetChangedAt().AsTime())
+}
+
+func TestParseKDBXWithoutPassword(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := kdbx.Encode(&buf, newKeePassDatabase(), "password", &testKDF)
+	require.NoError(t, err)
+
+	_, err = importer.Parse(importer.FormatKDBX, &buf, "")
+
+	require.ErrorIs(t, err, importer.ErrPasswordRequired)
+}
+
+func TestParseKDBXWithWrongPassword(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := kdbx.Encode(&buf, newKeePassDatabase(), "password", &testKDF)
+	require.NoError(t, err)
+
+	_, err = importer.Parse(importer.FormatKDBX, &buf, "wrong")
+
+	require.ErrorIs(t, err, kdbx.ErrInvalidCredentials)
+}
+
+const keepassXML = `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+  <Meta>
+    <DatabaseName>Vault</DatabaseName>
+    <Binaries>
+      <Binary ID="0">aGVsbG8=</Binary>
+    </Binaries>
+  </Meta>
+  <Root>
+    <Group>
+      <UUID>AAAAAAAAAAAAAAAAAAAAAA==</UUID>
+      <Name>Vault</Name>
+      <Group>
+        <UUID>AQAAAAAAAAAAAAAAAAAAAA==</UUID>
+        <Name>Notes</Name>
+        <Entry>
+          <UUID>AgAAAAAAAAAAAAAAAAAAAA==</UUID>
+          <String><Key>Title</Key><Value>Wi-Fi</Value></String>
+          <String><Key>Notes</Key><Value>password: guest</Value></String>
+        </Entry>
+        <Entry>
+          <UUID>AwAAAAAAAAAAAAAAAAAAAA==</UUID>
+          <String><Key>Title</Key><Value>Scan</Value></String>
+          <Binary><Key>passport.txt</Key><Value Ref="0"/></Binary>
+        </Entry>
+      </Group>
+    </Group>
+  </Root>
+</KeePassFile>`
+
+func TestParseKeePassXML(t *testing.T) {
+	secrets, err := importer.Parse(importer.FormatKeePassXML, strings.NewReader(keepassXML), "")
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+
+	require.Equal(t, "/Notes/Wi-Fi", secrets[0].Path())
+	require.Equal(t, &goph.Text{Text: "password: guest"}, secrets[0].Data)
+
+	require.Equal(t, "/Notes/Scan", secrets[1].Path())
+	require.Len(t, secrets[1].Attachments, 1)
+	require.Equal(t, []byte("hello"), secrets[1].Attachments[0].Content)
+}
+
+func TestParseKeePassXMLMalformed(t *testing.T) {
+	_, err := importer.Parse(importer.FormatKeePassXML, strings.NewReader("<html/>"), "")
+
+	require.ErrorIs(t, err, importer.ErrMalformedExport)
+}
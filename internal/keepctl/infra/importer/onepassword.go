@@ -0,0 +1,439 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Categories of 1Password items.
+const (
+	_onePasswordLogin    = "001"
+	_onePasswordCard     = "002"
+	_onePasswordNote     = "003"
+	_onePasswordIdentity = "004"
+	_onePasswordPassword = "005"
+	_onePasswordDocument = "006"
+)
+
+const (
+	_onePasswordData     = "export.data"
+	_onePasswordArchived = "archived"
+)
+
+// onePasswordExport is content of export.data file of 1PUX archive.
+type onePasswordExport struct {
+	Accounts []struct {
+		Vaults []struct {
+			Attrs struct {
+				Name string `json:"name"`
+			} `json:"attrs"`
+			Items []onePasswordItem `json:"items"`
+		} `json:"vaults"`
+	} `json:"accounts"`
+}
+
+type onePasswordItem struct {
+	State        string `json:"state"`
+	CategoryUUID string `json:"categoryUuid"`
+	Details      struct {
+		LoginFields []struct {
+			Value       string `json:"value"`
+			Name        string `json:"name"`
+			FieldType   string `json:"fieldType"`
+			Designation string `json:"designation"`
+		} `json:"loginFields"`
+		NotesPlain string `json:"notesPlain"`
+		Password   string `json:"password"`
+		Sections   []struct {
+			Title  string             `json:"title"`
+			Fields []onePasswordField `json:"fields"`
+		} `json:"sections"`
+		PasswordHistory []struct {
+			Value string `json:"value"`
+			Time  int64  `json:"time"`
+		} `json:"passwordHistory"`
+		DocumentAttributes *struct {
+			FileName   string `json:"fileName"`
+			DocumentID string `json:"documentId"`
+		} `json:"documentAttributes"`
+	} `json:"details"`
+	Overview struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+		URLs  []struct {
+			URL string `json:"url"`
+		} `json:"urls"`
+		Tags []string `json:"tags"`
+	} `json:"overview"`
+}
+
+// onePasswordField is field of an item section,
+// the value is an object with single key telling the type of the field.
+type onePasswordField struct {
+	Title string                     `json:"title"`
+	ID    string                     `json:"id"`
+	Value map[string]json.RawMessage `json:"value"`
+}
+
+// onePasswordValue is decoded value of a section field.
+type onePasswordValue struct {
+	kind    goph.FieldType
+	text    string
+	address *goph.Address
+}
+
+func parse1PUX(r io.Reader) ([]entity.NewSecret, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse1PUX - io.ReadAll: %w", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedExport, err)
+	}
+
+	data, err := readZipFile(archive, _onePasswordData)
+	if err != nil {
+		return nil, err
+	}
+
+	var export onePasswordExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedExport, err)
+	}
+
+	rv := make([]entity.NewSecret, 0)
+
+	for _, account := range export.Accounts {
+		for _, vault := range account.Vaults {
+			for i := range vault.Items {
+				secret, ok, err := onePasswordSecret(archive, vault.Attrs.Name, &vault.Items[i])
+				if err != nil {
+					return nil, err
+				}
+
+				if ok {
+					rv = append(rv, secret)
+				}
+			}
+		}
+	}
+
+	return rv, nil
+}
+
+func onePasswordSecret(
+	archive *zip.Reader,
+	vault string,
+	item *onePasswordItem,
+) (entity.NewSecret, bool, error) {
+	e := &entry{
+		name:   item.Overview.Title,
+		folder: vault,
+		notes:  item.Details.NotesPlain,
+		tags:   item.Overview.Tags,
+	}
+
+	if item.State == _onePasswordArchived {
+		e.tags = append(e.tags, _onePasswordArchived)
+	}
+
+	switch item.CategoryUUID {
+	case _onePasswordCard:
+		secret, ok := onePasswordCard(e, item)
+
+		return secret, ok, nil
+
+	case _onePasswordIdentity:
+		secret, ok := onePasswordIdentity(e, item)
+
+		return secret, ok, nil
+
+	case _onePasswordDocument:
+		if doc := item.Details.DocumentAttributes; doc != nil {
+			content, err := readZipFile(archive, "files/"+doc.DocumentID+"__"+doc.FileName)
+			if err != nil {
+				return entity.NewSecret{}, false, err
+			}
+
+			e.attachments = append(e.attachments, entity.NewAttachment{
+				Filename: doc.FileName,
+				Content:  content,
+			})
+		}
+	}
+
+	onePasswordLogin(e, item)
+
+	secret, ok := e.secret()
+
+	return secret, ok, nil
+}
+
+// onePasswordLogin fills login data and custom fields of the entry.
+// Used for logins, passwords and all other items we have no dedicated kind for.
+func onePasswordLogin(e *entry, item *onePasswordItem) {
+	e.password = item.Details.Password
+
+	for _, field := range item.Details.LoginFields {
+		switch {
+		case field.Designation == "username":
+			e.username = field.Value
+
+		case field.Designation == "password":
+			e.password = field.Value
+
+		case field.FieldType == "P":
+			e.addField(field.Name, goph.FieldType_FIELD_HIDDEN, field.Value)
+
+		default:
+			e.addField(field.Name, goph.FieldType_FIELD_TEXT, field.Value)
+		}
+	}
+
+	for _, uri := range item.Overview.URLs {
+		e.addURI(uri.URL, goph.UriMatch_MATCH_DOMAIN)
+	}
+
+	if len(item.Overview.URLs) == 0 {
+		e.addURI(item.Overview.URL, goph.UriMatch_MATCH_DOMAIN)
+	}
+
+	for _, h := range item.Details.PasswordHistory {
+		e.history = append(e.history, &goph.PasswordHistory{
+			Password:  h.Value,
+			ChangedAt: timestamppb.New(time.Unix(h.Time, 0)),
+		})
+	}
+
+	for _, section := range item.Details.Sections {
+		for _, field := range section.Fields {
+			value := field.value()
+
+			switch {
+			case value.address != nil:
+				e.addField(field.Title, goph.FieldType_FIELD_TEXT, formatAddress(value.address))
+
+			case value.kind == goph.FieldType_FIELD_TOTP && e.totp == "":
+				e.totp = value.text
+
+			default:
+				e.addField(field.Title, value.kind, value.text)
+			}
+		}
+	}
+}
+
+func onePasswordCard(e *entry, item *onePasswordItem) (entity.NewSecret, bool) {
+	data := new(goph.Card)
+
+	onePasswordSections(e, item, func(field *onePasswordField, value onePasswordValue) bool {
+		switch field.ID {
+		case "cardholder":
+			data.Holder = value.text
+
+		case "ccnum":
+			data.Number = entity.NormalizeCardNumber(value.text)
+
+		case "cvv":
+			data.Cvv = value.text
+
+		case "expiry":
+			// NB (alkurbatov): The expiration date is stored as YYYYMM.
+			if len(value.text) == len("YYYYMM") {
+				data.Expiration = value.text[4:] + "/" + value.text[2:4]
+			}
+
+		case "bank":
+			data.Bank = value.text
+
+		default:
+			return false
+		}
+
+		return true
+	})
+
+	secret := e.base()
+	secret.Data = data
+
+	return secret, data.Number != ""
+}
+
+func onePasswordIdentity(e *entry, item *onePasswordItem) (entity.NewSecret, bool) {
+	data := new(goph.Identity)
+
+	var first, initial, last string
+
+	onePasswordSections(e, item, func(field *onePasswordField, value onePasswordValue) bool {
+		switch {
+		case field.ID == "firstname":
+			first = value.text
+
+		case field.ID == "initial":
+			initial = value.text
+
+		case field.ID == "lastname":
+			last = value.text
+
+		case field.ID == "birthdate":
+			data.BirthDate = value.text
+
+		case field.ID == "email" && data.Email == "":
+			data.Email = value.text
+
+		case (field.ID == "defphone" || field.ID == "cellphone") && data.Phone == "":
+			data.Phone = value.text
+
+		case value.address != nil && data.Address == nil:
+			data.Address = value.address
+
+		default:
+			return false
+		}
+
+		return true
+	})
+
+	data.FullName = joinNonEmpty(" ", first, initial, last)
+
+	secret := e.base()
+	secret.Data = data
+
+	return secret, true
+}
+
+// onePasswordSections passes section fields to the handler,
+// fields not consumed by the handler are kept in the notes.
+func onePasswordSections(
+	e *entry,
+	item *onePasswordItem,
+	handle func(field *onePasswordField, value onePasswordValue) bool,
+) {
+	for _, section := range item.Details.Sections {
+		for i := range section.Fields {
+			field := &section.Fields[i]
+			value := field.value()
+
+			if value.text == "" && value.address == nil {
+				continue
+			}
+
+			if handle(field, value) {
+				continue
+			}
+
+			text := value.text
+			if value.address != nil {
+				text = formatAddress(value.address)
+			}
+
+			e.notes = appendNote(e.notes, field.Title+": "+text)
+		}
+	}
+}
+
+// value decodes value of the field, values of unknown types are kept as text.
+func (f *onePasswordField) value() onePasswordValue {
+	for key, raw := range f.Value {
+		rv := onePasswordValue{kind: goph.FieldType_FIELD_TEXT}
+
+		switch key {
+		case "concealed":
+			rv.kind = goph.FieldType_FIELD_HIDDEN
+
+		case "totp":
+			rv.kind = goph.FieldType_FIELD_TOTP
+
+		case "url":
+			rv.kind = goph.FieldType_FIELD_URL
+
+		case "email":
+			var email struct {
+				Address string `json:"email_address"`
+			}
+
+			if json.Unmarshal(raw, &email) == nil && email.Address != "" {
+				rv.text = email.Address
+
+				return rv
+			}
+
+		case "address":
+			var address struct {
+				Street  string `json:"street"`
+				City    string `json:"city"`
+				Country string `json:"country"`
+				Zip     string `json:"zip"`
+				State   string `json:"state"`
+			}
+
+			if json.Unmarshal(raw, &address) == nil {
+				rv.address = &goph.Address{
+					Street:     address.Street,
+					City:       address.City,
+					Region:     address.State,
+					PostalCode: address.Zip,
+					Country:    address.Country,
+				}
+
+				return rv
+			}
+
+		case "date":
+			var ts int64
+			if json.Unmarshal(raw, &ts) == nil && ts != 0 {
+				rv.text = time.Unix(ts, 0).UTC().Format("2006-01-02")
+
+				return rv
+			}
+		}
+
+		var text string
+		if json.Unmarshal(raw, &text) == nil {
+			rv.text = text
+
+			return rv
+		}
+
+		var number json.Number
+		if json.Unmarshal(raw, &number) == nil {
+			rv.text = number.String()
+
+			return rv
+		}
+	}
+
+	return onePasswordValue{kind: goph.FieldType_FIELD_TEXT}
+}
+
+// formatAddress converts address into single line of text.
+func formatAddress(address *goph.Address) string {
+	return joinNonEmpty(", ", address.Street, address.City, address.Region,
+		address.PostalCode, address.Country)
+}
+
+// readZipFile returns content of the file stored in the archive.
+func readZipFile(archive *zip.Reader, name string) ([]byte, error) {
+	f, err := archive.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedExport, err)
+	}
+	defer f.Close()
+
+	rv, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrMalformedExport, name, err)
+	}
+
+	return rv, nil
+}
@@ -0,0 +1,190 @@
+package importer_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/infra/importer"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	"github.com/stretchr/testify/require"
+)
+
+const onePasswordExport = `{
+  "accounts": [{
+    "vaults": [{
+      "attrs": {"name": "Personal"},
+      "items": [
+        {
+          "state": "active",
+          "categoryUuid": "001",
+          "details": {
+            "loginFields": [
+              {"value": "alice", "name": "email", "fieldType": "E", "designation": "username"},
+              {"value": "s3cr3t", "name": "password", "fieldType": "P", "designation": "password"}
+            ],
+            "notesPlain": "",
+            "sections": [{
+              "title": "",
+              "fields": [{
+                "title": "one-time password",
+                "id": "TOTP_1",
+                "value": {"totp": "JBSWY3DPEHPK3PXP"}
+              }]
+            }],
+            "passwordHistory": [{"value": "old", "time": 1672531200}]
+          },
+          "overview": {
+            "title": "Mail",
+            "url": "https://mail.example.com",
+            "urls": [{"label": "", "url": "https://mail.example.com"}],
+            "tags": ["work"]
+          }
+        },
+        {
+          "state": "archived",
+          "categoryUuid": "002",
+          "details": {
+            "notesPlain": "",
+            "sections": [{
+              "title": "",
+              "fields": [
+                {"title": "cardholder name", "id": "cardholder", "value": {"string": "ALICE"}},
+                {
+                  "title": "number",
+                  "id": "ccnum",
+                  "value": {"creditCardNumber": "4111111111111111"}
+                },
+                {"title": "verification number", "id": "cvv", "value": {"concealed": "123"}},
+                {"title": "expiry date", "id": "expiry", "value": {"monthYear": 203003}},
+                {"title": "PIN", "id": "pin", "value": {"concealed": "0000"}}
+              ]
+            }]
+          },
+          "overview": {"title": "Visa"}
+        },
+        {
+          "state": "active",
+          "categoryUuid": "004",
+          "details": {
+            "sections": [{
+              "title": "Identification",
+              "fields": [
+                {"title": "first name", "id": "firstname", "value": {"string": "Alice"}},
+                {"title": "last name", "id": "lastname", "value": {"string": "Smith"}},
+                {"title": "birth date", "id": "birthdate", "value": {"date": 631152000}},
+                {
+                  "title": "address",
+                  "id": "address",
+                  "value": {"address": {"street": "Main st. 1", "city": "Moscow", "country": "ru"}}
+                },
+                {"title": "email", "id": "email", "value": {"email": {"email_address": "a@b.c"}}}
+              ]
+            }]
+          },
+          "overview": {"title": "Me"}
+        },
+        {
+          "state": "active",
+          "categoryUuid": "006",
+          "details": {
+            "documentAttributes": {"fileName": "scan.pdf", "documentId": "doc1"}
+          },
+          "overview": {"title": "Passport"}
+        },
+        {
+          "state": "active",
+          "categoryUuid": "110",
+          "details": {
+            "sections": [{
+              "title": "",
+              "fields": [
+                {"title": "hostname", "id": "hostname", "value": {"string": "10.0.0.1"}},
+                {"title": "password", "id": "password", "value": {"concealed": "root"}}
+              ]
+            }]
+          },
+          "overview": {"title": "Server"}
+        }
+      ]
+    }]
+  }]
+}`
+
+func newOnePasswordArchive(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+
+	files := map[string]string{
+		"export.attributes":    `{"version": 3}`,
+		"export.data":          onePasswordExport,
+		"files/doc1__scan.pdf": "%PDF",
+	}
+
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	return &buf
+}
+
+func TestParse1PUX(t *testing.T) {
+	secrets, err := importer.Parse(importer.Format1PUX, newOnePasswordArchive(t), "")
+	require.NoError(t, err)
+	require.Len(t, secrets, 5)
+
+	mail := secrets[0]
+	require.Equal(t, "/Personal/Mail", mail.Path())
+	require.Equal(t, []string{"work"}, mail.Labels.GetTags())
+
+	creds, ok := mail.Data.(*goph.Credentials)
+	require.True(t, ok)
+	require.Equal(t, "alice", creds.GetLogin())
+	require.Equal(t, "s3cr3t", creds.GetPassword())
+	require.Equal(t, "JBSWY3DPEHPK3PXP", creds.GetTotp())
+	require.Len(t, creds.GetUris(), 1)
+	require.Len(t, creds.GetPasswordHistory(), 1)
+
+	visa := secrets[1]
+	require.Equal(t, []string{"archived"}, visa.Labels.GetTags())
+	require.Equal(t, "PIN: 0000", visa.Description)
+
+	card, ok := visa.Data.(*goph.Card)
+	require.True(t, ok)
+	require.Equal(t, "4111111111111111", card.GetNumber())
+	require.Equal(t, "03/30", card.GetExpiration())
+	require.Equal(t, "ALICE", card.GetHolder())
+	require.Equal(t, "123", card.GetCvv())
+
+	id, ok := secrets[2].Data.(*goph.Identity)
+	require.True(t, ok)
+	require.Equal(t, "Alice Smith", id.GetFullName())
+	require.Equal(t, "1990-01-01", id.GetBirthDate())
+	require.Equal(t, "a@b.c", id.GetEmail())
+	require.Equal(t, "Moscow", id.GetAddress().GetCity())
+
+	passport := secrets[3]
+	require.Len(t, passport.Attachments, 1)
+	require.Equal(t, "scan.pdf", passport.Attachments[0].Filename)
+	require.Equal(t, []byte("%PDF"), passport.Attachments[0].Content)
+
+	server, ok := secrets[4].Data.(*goph.Custom)
+	require.True(t, ok)
+	require.Equal(t, []string{"hostname", "password"}, fieldNames(server))
+	require.Equal(t, goph.FieldType_FIELD_HIDDEN, server.GetFields()[1].GetType())
+}
+
+func TestParse1PUXNotArchive(t *testing.T) {
+	_, err := importer.Parse(importer.Format1PUX, bytes.NewBufferString("{}"), "")
+
+	require.ErrorIs(t, err, importer.ErrMalformedExport)
+}
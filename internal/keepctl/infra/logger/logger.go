@@ -21,7 +21,7 @@ func New(debug bool) *Logger {
 	}
 
 	output := zerolog.ConsoleWriter{
-		Out:             os.Stdout,
+		Out:             os.Stderr,
 		FormatTimestamp: func(i interface{}) string { return "" },
 		FormatLevel:     func(i interface{}) string { return "" },
 	}
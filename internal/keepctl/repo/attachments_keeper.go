@@ -0,0 +1,122 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ Attachments = (*AttachmentsRepo)(nil)
+
+// AttachmentsRepo is facade to attachments stored in Keeper.
+type AttachmentsRepo struct {
+	client goph.AttachmentsClient
+}
+
+// NewAttachmentsRepo creates and initializes AttachmentsRepo object.
+func NewAttachmentsRepo(client goph.AttachmentsClient) *AttachmentsRepo {
+	return &AttachmentsRepo{client}
+}
+
+// Push sends new attachment of the secret to the server.
+func (r *AttachmentsRepo) Push(
+	ctx context.Context,
+	token string,
+	secretID uuid.UUID,
+	info, payload []byte,
+) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	md := metadata.New(map[string]string{"authorization": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	req := &goph.CreateAttachmentRequest{
+		SecretId: secretID.String(),
+		Metadata: info,
+		Data:     payload,
+	}
+
+	resp, err := r.client.Create(ctx, req)
+	if err != nil {
+		return id, fmt.Errorf(
+			"AttachmentsRepo - Push - r.client.Create: %w",
+			entity.NewRequestError(err),
+		)
+	}
+
+	id, err = uuid.FromString(resp.GetId())
+	if err != nil {
+		return id, fmt.Errorf("AttachmentsRepo - Push - uuid.FromString: %w", err)
+	}
+
+	return id, nil
+}
+
+// List returns list of the secret's attachments without data.
+func (r *AttachmentsRepo) List(
+	ctx context.Context,
+	token string,
+	secretID uuid.UUID,
+) ([]*goph.Attachment, error) {
+	md := metadata.New(map[string]string{"authorization": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	req := &goph.ListAttachmentsRequest{SecretId: secretID.String()}
+
+	resp, err := r.client.List(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"AttachmentsRepo - List - r.client.List: %w",
+			entity.NewRequestError(err),
+		)
+	}
+
+	return resp.GetAttachments(), nil
+}
+
+// Get downloads full attachment.
+func (r *AttachmentsRepo) Get(
+	ctx context.Context,
+	token string,
+	id uuid.UUID,
+) (*goph.Attachment, []byte, error) {
+	md := metadata.New(map[string]string{"authorization": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	req := &goph.GetAttachmentRequest{Id: id.String()}
+
+	resp, err := r.client.Get(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"AttachmentsRepo - Get - r.client.Get: %w",
+			entity.NewRequestError(err),
+		)
+	}
+
+	return resp.GetAttachment(), resp.GetData(), nil
+}
+
+// Delete removes attachment.
+func (r *AttachmentsRepo) Delete(
+	ctx context.Context,
+	token string,
+	id uuid.UUID,
+) error {
+	md := metadata.New(map[string]string{"authorization": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	req := &goph.DeleteAttachmentRequest{Id: id.String()}
+
+	if _, err := r.client.Delete(ctx, req); err != nil {
+		return fmt.Errorf(
+			"AttachmentsRepo - Delete - r.client.Delete: %w",
+			entity.NewRequestError(err),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ Attachments = (*AttachmentsRepoMock)(nil)
+
+type AttachmentsRepoMock struct {
+	mock.Mock
+}
+
+func (m *AttachmentsRepoMock) Push(
+	ctx context.Context,
+	token string,
+	secretID uuid.UUID,
+	info, payload []byte,
+) (uuid.UUID, error) {
+	args := m.Called(ctx, token, secretID, info, payload)
+
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *AttachmentsRepoMock) List(
+	ctx context.Context,
+	token string,
+	secretID uuid.UUID,
+) ([]*goph.Attachment, error) {
+	args := m.Called(ctx, token, secretID)
+
+	return args.Get(0).([]*goph.Attachment), args.Error(1)
+}
+
+func (m *AttachmentsRepoMock) Get(
+	ctx context.Context,
+	token string,
+	id uuid.UUID,
+) (*goph.Attachment, []byte, error) {
+	args := m.Called(ctx, token, id)
+
+	return args.Get(0).(*goph.Attachment), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *AttachmentsRepoMock) Delete(
+	ctx context.Context,
+	token string,
+	id uuid.UUID,
+) error {
+	args := m.Called(ctx, token, id)
+
+	return args.Error(0)
+}
@@ -0,0 +1,168 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/repo"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/gophtest"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func doCreateAttachment(
+	t *testing.T,
+	mockRV *goph.CreateAttachmentResponse,
+	mockErr error,
+) (uuid.UUID, error) {
+	t.Helper()
+
+	secretID := uuid.NewV4()
+	req := &goph.CreateAttachmentRequest{
+		SecretId: secretID.String(),
+		Metadata: []byte(gophtest.Metadata),
+		Data:     []byte(gophtest.TextData),
+	}
+
+	m := &goph.AttachmentsClientMock{}
+	m.On("Create", mock.Anything, req, mock.Anything).
+		Return(mockRV, mockErr)
+
+	sat := repo.NewAttachmentsRepo(m)
+	rv, err := sat.Push(
+		context.Background(),
+		gophtest.AccessToken,
+		secretID,
+		[]byte(gophtest.Metadata),
+		[]byte(gophtest.TextData),
+	)
+
+	m.AssertExpectations(t)
+
+	return rv, err
+}
+
+func TestCreateAttachment(t *testing.T) {
+	expected := uuid.NewV4()
+
+	id, err := doCreateAttachment(t, &goph.CreateAttachmentResponse{Id: expected.String()}, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, id)
+}
+
+func TestCreateAttachmentOnClientFailure(t *testing.T) {
+	_, err := doCreateAttachment(t, nil, gophtest.ErrUnexpected)
+
+	require.Error(t, err)
+}
+
+func TestListAttachments(t *testing.T) {
+	secretID := uuid.NewV4()
+	expected := []*goph.Attachment{
+		{
+			Id:       uuid.NewV4().String(),
+			SecretId: secretID.String(),
+			Metadata: []byte(gophtest.Metadata),
+		},
+	}
+
+	m := &goph.AttachmentsClientMock{}
+	m.On(
+		"List",
+		mock.Anything,
+		&goph.ListAttachmentsRequest{SecretId: secretID.String()},
+		mock.Anything,
+	).
+		Return(&goph.ListAttachmentsResponse{Attachments: expected}, nil)
+
+	sat := repo.NewAttachmentsRepo(m)
+	rv, err := sat.List(context.Background(), gophtest.AccessToken, secretID)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, rv)
+	m.AssertExpectations(t)
+}
+
+func TestListAttachmentsOnClientFailure(t *testing.T) {
+	m := &goph.AttachmentsClientMock{}
+	m.On("List", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, gophtest.ErrUnexpected)
+
+	sat := repo.NewAttachmentsRepo(m)
+	_, err := sat.List(context.Background(), gophtest.AccessToken, uuid.NewV4())
+
+	require.Error(t, err)
+	m.AssertExpectations(t)
+}
+
+func TestGetAttachment(t *testing.T) {
+	id := uuid.NewV4()
+	expected := &goph.Attachment{
+		Id:       id.String(),
+		SecretId: uuid.NewV4().String(),
+		Metadata: []byte(gophtest.Metadata),
+	}
+
+	m := &goph.AttachmentsClientMock{}
+	m.On("Get", mock.Anything, &goph.GetAttachmentRequest{Id: id.String()}, mock.Anything).
+		Return(&goph.GetAttachmentResponse{Attachment: expected, Data: []byte(gophtest.TextData)}, nil)
+
+	sat := repo.NewAttachmentsRepo(m)
+	attachment, data, err := sat.Get(context.Background(), gophtest.AccessToken, id)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, attachment)
+	require.Equal(t, []byte(gophtest.TextData), data)
+	m.AssertExpectations(t)
+}
+
+func TestGetAttachmentOnClientFailure(t *testing.T) {
+	m := &goph.AttachmentsClientMock{}
+	m.On("Get", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, gophtest.ErrUnexpected)
+
+	sat := repo.NewAttachmentsRepo(m)
+	_, _, err := sat.Get(context.Background(), gophtest.AccessToken, uuid.NewV4())
+
+	require.Error(t, err)
+	m.AssertExpectations(t)
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	tt := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "Delete attachment",
+		},
+		{
+			name: "Delete attachment fails on client failure",
+			err:  gophtest.ErrUnexpected,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			id := uuid.NewV4()
+
+			m := &goph.AttachmentsClientMock{}
+			m.On(
+				"Delete",
+				mock.Anything,
+				&goph.DeleteAttachmentRequest{Id: id.String()},
+				mock.Anything,
+			).
+				Return(&goph.DeleteAttachmentResponse{}, tc.err)
+
+			sat := repo.NewAttachmentsRepo(m)
+			err := sat.Delete(context.Background(), gophtest.AccessToken, id)
+
+			require.Equal(t, tc.err != nil, err != nil)
+			m.AssertExpectations(t)
+		})
+	}
+}
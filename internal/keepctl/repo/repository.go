@@ -8,6 +8,13 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+type Attachments interface {
+	Push(ctx context.Context, token string, secretID uuid.UUID, info, payload []byte) (uuid.UUID, error)
+	List(ctx context.Context, token string, secretID uuid.UUID) ([]*goph.Attachment, error)
+	Get(ctx context.Context, token string, id uuid.UUID) (*goph.Attachment, []byte, error)
+	Delete(ctx context.Context, token string, id uuid.UUID) error
+}
+
 type Auth interface {
 	Login(ctx context.Context, username, securityKey string) (string, error)
 }
@@ -17,12 +24,24 @@ type Secrets interface {
 		ctx context.Context,
 		token, name string,
 		kind goph.DataKind,
-		description, payload []byte,
+		description, payload, labels, folderDigest []byte,
 	) (uuid.UUID, error)
 
+	PushBatch(
+		ctx context.Context,
+		token string,
+		secrets []*goph.CreateSecretRequest,
+	) ([]uuid.UUID, error)
+
 	List(ctx context.Context, token string) ([]*goph.Secret, error)
 	Get(ctx context.Context, token string, id uuid.UUID) (*goph.Secret, []byte, error)
 
+	GetByName(
+		ctx context.Context,
+		token, name string,
+		folderDigest []byte,
+	) (*goph.Secret, []byte, error)
+
 	Update(
 		ctx context.Context,
 		token string,
@@ -30,7 +49,7 @@ type Secrets interface {
 		name string,
 		description []byte,
 		noDescription bool,
-		data []byte,
+		data, labels, folderDigest []byte,
 	) error
 
 	Delete(ctx context.Context, token string, id uuid.UUID) error
@@ -42,9 +61,10 @@ type Users interface {
 
 // Repositories is a collection of data repositories.
 type Repositories struct {
-	Auth    Auth
-	Secrets Secrets
-	Users   Users
+	Attachments Attachments
+	Auth        Auth
+	Secrets     Secrets
+	Users       Users
 }
 
 // New creates and initializes collection of data repositories.
@@ -52,8 +72,9 @@ func New(conn *grpcconn.Connection) *Repositories {
 	c := conn.Instance()
 
 	return &Repositories{
-		Auth:    NewAuthRepo(goph.NewAuthClient(c)),
-		Secrets: NewSecretsRepo(goph.NewSecretsClient(c)),
-		Users:   NewUsersRepo(goph.NewUsersClient(c)),
+		Attachments: NewAttachmentsRepo(goph.NewAttachmentsClient(c)),
+		Auth:        NewAuthRepo(goph.NewAuthClient(c)),
+		Secrets:     NewSecretsRepo(goph.NewSecretsClient(c)),
+		Users:       NewUsersRepo(goph.NewUsersClient(c)),
 	}
 }
@@ -28,7 +28,7 @@ func (r *SecretsRepo) Push(
 	ctx context.Context,
 	token, name string,
 	kind goph.DataKind,
-	description, payload []byte,
+	description, payload, labels, folderDigest []byte,
 ) (uuid.UUID, error) {
 	var id uuid.UUID
 
@@ -36,10 +36,12 @@ func (r *SecretsRepo) Push(
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
 	req := &goph.CreateSecretRequest{
-		Name:     name,
-		Metadata: description,
-		Kind:     kind,
-		Data:     payload,
+		Name:         name,
+		Metadata:     description,
+		Kind:         kind,
+		Data:         payload,
+		Labels:       labels,
+		FolderDigest: folderDigest,
 	}
 
 	resp, err := r.client.Create(ctx, req)
@@ -55,6 +57,38 @@ func (r *SecretsRepo) Push(
 	return id, nil
 }
 
+// PushBatch sends several new secrets to the server at once.
+// Either all secrets are created or none of them.
+func (r *SecretsRepo) PushBatch(
+	ctx context.Context,
+	token string,
+	secrets []*goph.CreateSecretRequest,
+) ([]uuid.UUID, error) {
+	md := metadata.New(map[string]string{"authorization": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := r.client.CreateBatch(ctx, &goph.CreateSecretsRequest{Secrets: secrets})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"SecretsRepo - PushBatch - r.client.CreateBatch: %w",
+			entity.NewRequestError(err),
+		)
+	}
+
+	rv := make([]uuid.UUID, 0, len(resp.GetIds()))
+
+	for _, raw := range resp.GetIds() {
+		id, err := uuid.FromString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("SecretsRepo - PushBatch - uuid.FromString: %w", err)
+		}
+
+		rv = append(rv, id)
+	}
+
+	return rv, nil
+}
+
 // List returns list of user's secrets without data.
 func (r *SecretsRepo) List(
 	ctx context.Context,
@@ -92,7 +126,31 @@ func (r *SecretsRepo) Get(
 	return resp.GetSecret(), resp.GetData(), nil
 }
 
+// GetByName downloads full user's secret by its name within the folder.
+// The folder is identified by its digest, nil digest stands for the root folder.
+func (r *SecretsRepo) GetByName(
+	ctx context.Context,
+	token, name string,
+	folderDigest []byte,
+) (*goph.Secret, []byte, error) {
+	md := metadata.New(map[string]string{"authorization": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	req := &goph.GetSecretByNameRequest{Name: name, FolderDigest: folderDigest}
+
+	resp, err := r.client.GetByName(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"SecretsRepo - GetByName - r.client.GetByName: %w",
+			entity.NewRequestError(err),
+		)
+	}
+
+	return resp.GetSecret(), resp.GetData(), nil
+}
+
 // Update changes parameters of stored secret.
+// Labels are changed only if not nil, empty labels move the secret to the root folder.
 func (r *SecretsRepo) Update(
 	ctx context.Context,
 	token string,
@@ -100,7 +158,7 @@ func (r *SecretsRepo) Update(
 	name string,
 	description []byte,
 	noDescription bool,
-	data []byte,
+	data, labels, folderDigest []byte,
 ) error {
 	md := metadata.New(map[string]string{"authorization": token})
 	ctx = metadata.NewOutgoingContext(ctx, md)
@@ -136,6 +194,15 @@ func (r *SecretsRepo) Update(
 		req.Data = data
 	}
 
+	if labels != nil {
+		if err := mask.Append(req, "labels"); err != nil {
+			return fmt.Errorf("SecretsRepo - Update - mask.Append: %w", err)
+		}
+
+		req.Labels = labels
+		req.FolderDigest = folderDigest
+	}
+
 	req.UpdateMask = mask
 
 	if _, err := r.client.Update(ctx, req); err != nil {
@@ -18,13 +18,23 @@ func (m *SecretsRepoMock) Push(
 	ctx context.Context,
 	token, name string,
 	kind goph.DataKind,
-	description, payload []byte,
+	description, payload, labels, folderDigest []byte,
 ) (uuid.UUID, error) {
-	args := m.Called(ctx, token, name, kind, description, payload)
+	args := m.Called(ctx, token, name, kind, description, payload, labels, folderDigest)
 
 	return args.Get(0).(uuid.UUID), args.Error(1)
 }
 
+func (m *SecretsRepoMock) PushBatch(
+	ctx context.Context,
+	token string,
+	secrets []*goph.CreateSecretRequest,
+) ([]uuid.UUID, error) {
+	args := m.Called(ctx, token, secrets)
+
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 func (m *SecretsRepoMock) List(
 	ctx context.Context,
 	token string,
@@ -44,6 +54,16 @@ func (m *SecretsRepoMock) Get(
 	return args.Get(0).(*goph.Secret), args.Get(1).([]byte), args.Error(2)
 }
 
+func (m *SecretsRepoMock) GetByName(
+	ctx context.Context,
+	token, name string,
+	folderDigest []byte,
+) (*goph.Secret, []byte, error) {
+	args := m.Called(ctx, token, name, folderDigest)
+
+	return args.Get(0).(*goph.Secret), args.Get(1).([]byte), args.Error(2)
+}
+
 func (m *SecretsRepoMock) Update(
 	ctx context.Context,
 	token string,
@@ -51,9 +71,19 @@ func (m *SecretsRepoMock) Update(
 	name string,
 	description []byte,
 	noDescription bool,
-	data []byte,
+	data, labels, folderDigest []byte,
 ) error {
-	args := m.Called(ctx, token, id, name, description, noDescription, data)
+	args := m.Called(
+		ctx,
+		token,
+		id,
+		name,
+		description,
+		noDescription,
+		data,
+		labels,
+		folderDigest,
+	)
 
 	return args.Error(0)
 }
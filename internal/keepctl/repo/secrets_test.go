@@ -22,10 +22,12 @@ func doCreateSecret(
 	t.Helper()
 
 	req := &goph.CreateSecretRequest{
-		Name:     gophtest.SecretName,
-		Metadata: []byte(gophtest.Metadata),
-		Kind:     goph.DataKind_TEXT,
-		Data:     []byte(gophtest.TextData),
+		Name:         gophtest.SecretName,
+		Metadata:     []byte(gophtest.Metadata),
+		Kind:         goph.DataKind_TEXT,
+		Data:         []byte(gophtest.TextData),
+		Labels:       []byte(gophtest.Labels),
+		FolderDigest: []byte(gophtest.FolderDigest),
 	}
 
 	m := &goph.SecretsClientMock{}
@@ -45,6 +47,8 @@ func doCreateSecret(
 		goph.DataKind_TEXT,
 		[]byte(gophtest.Metadata),
 		[]byte(gophtest.TextData),
+		[]byte(gophtest.Labels),
+		[]byte(gophtest.FolderDigest),
 	)
 
 	m.AssertExpectations(t)
@@ -52,6 +56,38 @@ func doCreateSecret(
 	return rv, err
 }
 
+func doCreateSecretsBatch(
+	t *testing.T,
+	mockRV *goph.CreateSecretsResponse,
+	mockErr error,
+) ([]uuid.UUID, error) {
+	t.Helper()
+
+	secrets := []*goph.CreateSecretRequest{
+		{
+			Name: gophtest.SecretName,
+			Kind: goph.DataKind_TEXT,
+			Data: []byte(gophtest.TextData),
+		},
+	}
+
+	m := &goph.SecretsClientMock{}
+	m.On(
+		"CreateBatch",
+		mock.Anything,
+		&goph.CreateSecretsRequest{Secrets: secrets},
+		mock.Anything,
+	).
+		Return(mockRV, mockErr)
+
+	sat := repo.NewSecretsRepo(m)
+	rv, err := sat.PushBatch(context.Background(), gophtest.AccessToken, secrets)
+
+	m.AssertExpectations(t)
+
+	return rv, err
+}
+
 func doListSecrets(
 	t *testing.T,
 	mockRV *goph.ListSecretsResponse,
@@ -105,12 +141,46 @@ func doGetSecret(
 	return secret, data, err
 }
 
+func doGetSecretByName(
+	t *testing.T,
+	mockRV *goph.GetSecretResponse,
+	mockErr error,
+) (*goph.Secret, []byte, error) {
+	t.Helper()
+
+	req := &goph.GetSecretByNameRequest{
+		Name:         gophtest.SecretName,
+		FolderDigest: []byte(gophtest.FolderDigest),
+	}
+
+	m := &goph.SecretsClientMock{}
+	m.On(
+		"GetByName",
+		mock.Anything,
+		req,
+		mock.Anything,
+	).
+		Return(mockRV, mockErr)
+
+	sat := repo.NewSecretsRepo(m)
+	secret, data, err := sat.GetByName(
+		context.Background(),
+		gophtest.AccessToken,
+		gophtest.SecretName,
+		[]byte(gophtest.FolderDigest),
+	)
+
+	m.AssertExpectations(t)
+
+	return secret, data, err
+}
+
 func doUpdateSecret(
 	t *testing.T,
 	name string,
 	description []byte,
 	noDescription bool,
-	data []byte,
+	data, labels, folderDigest []byte,
 	changed []string,
 	clientErr error,
 ) error {
@@ -118,10 +188,12 @@ func doUpdateSecret(
 
 	id := uuid.NewV4()
 	req := &goph.UpdateSecretRequest{
-		Id:       id.String(),
-		Name:     name,
-		Metadata: description,
-		Data:     data,
+		Id:           id.String(),
+		Name:         name,
+		Metadata:     description,
+		Data:         data,
+		Labels:       labels,
+		FolderDigest: folderDigest,
 	}
 
 	mask, err := fieldmaskpb.New(req, changed...)
@@ -147,6 +219,8 @@ func doUpdateSecret(
 		description,
 		noDescription,
 		data,
+		labels,
+		folderDigest,
 	)
 
 	m.AssertExpectations(t)
@@ -195,6 +269,24 @@ func TestCreateSecretOnClientFailure(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestCreateSecretsBatch(t *testing.T) {
+	expected := []uuid.UUID{uuid.NewV4()}
+	resp := &goph.CreateSecretsResponse{
+		Ids: []string{expected[0].String()},
+	}
+
+	ids, err := doCreateSecretsBatch(t, resp, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, ids)
+}
+
+func TestCreateSecretsBatchOnClientFailure(t *testing.T) {
+	_, err := doCreateSecretsBatch(t, nil, gophtest.ErrUnexpected)
+
+	require.Error(t, err)
+}
+
 func TestListSecrets(t *testing.T) {
 	tt := []struct {
 		name    string
@@ -264,6 +356,32 @@ func TestGetSecretOnClientFailure(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestGetSecretByName(t *testing.T) {
+	expSecret := &goph.Secret{
+		Id:   uuid.NewV4().String(),
+		Name: gophtest.SecretName,
+		Kind: goph.DataKind_TEXT,
+	}
+	expData := []byte(gophtest.TextData)
+
+	mockRV := &goph.GetSecretResponse{
+		Secret: expSecret,
+		Data:   expData,
+	}
+
+	secret, data, err := doGetSecretByName(t, mockRV, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, expSecret, secret)
+	require.Equal(t, expData, data)
+}
+
+func TestGetSecretByNameOnClientFailure(t *testing.T) {
+	_, _, err := doGetSecretByName(t, nil, gophtest.ErrUnexpected)
+
+	require.Error(t, err)
+}
+
 func TestUpdateSecret(t *testing.T) {
 	tt := []struct {
 		name          string
@@ -271,6 +389,8 @@ func TestUpdateSecret(t *testing.T) {
 		description   []byte
 		noDescription bool
 		data          []byte
+		labels        []byte
+		folderDigest  []byte
 		changed       []string
 	}{
 		{
@@ -300,6 +420,17 @@ func TestUpdateSecret(t *testing.T) {
 			data:    []byte(gophtest.TextData),
 			changed: []string{"data"},
 		},
+		{
+			name:         "Update secret's labels",
+			labels:       []byte(gophtest.Labels),
+			folderDigest: []byte(gophtest.FolderDigest),
+			changed:      []string{"labels"},
+		},
+		{
+			name:    "Move secret to the root folder",
+			labels:  []byte{},
+			changed: []string{"labels"},
+		},
 	}
 
 	for _, tc := range tt {
@@ -310,6 +441,8 @@ func TestUpdateSecret(t *testing.T) {
 				tc.description,
 				tc.noDescription,
 				tc.data,
+				tc.labels,
+				tc.folderDigest,
 				tc.changed,
 				nil,
 			)
@@ -320,7 +453,7 @@ func TestUpdateSecret(t *testing.T) {
 }
 
 func TestUpdateSecretOnClientFailure(t *testing.T) {
-	err := doUpdateSecret(t, "", nil, false, nil, nil, gophtest.ErrUnexpected)
+	err := doUpdateSecret(t, "", nil, false, nil, nil, nil, nil, gophtest.ErrUnexpected)
 
 	require.Error(t, err)
 }
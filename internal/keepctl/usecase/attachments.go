@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/repo"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ Attachments = (*AttachmentsUseCase)(nil)
+
+// AttachmentsUseCase contains business logic related to files attached to secrets.
+type AttachmentsUseCase struct {
+	key             entity.Key
+	attachmentsRepo repo.Attachments
+}
+
+// NewAttachmentsUseCase create and initializes new AttachmentsUseCase object.
+func NewAttachmentsUseCase(key entity.Key, attachments repo.Attachments) *AttachmentsUseCase {
+	return &AttachmentsUseCase{key, attachments}
+}
+
+// Attach encrypts the file and attaches it to the secret.
+// If MIME type is not provided, it is detected from the filename and content.
+func (uc *AttachmentsUseCase) Attach(
+	ctx context.Context,
+	token string,
+	secretID uuid.UUID,
+	filename, mimeType string,
+	content []byte,
+) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	filename = filepath.Base(filename)
+	if mimeType == "" {
+		mimeType = entity.DetectMIMEType(filename, content)
+	}
+
+	rawInfo, err := proto.Marshal(&goph.AttachmentInfo{
+		Filename: filename,
+		MimeType: mimeType,
+		Size:     uint64(len(content)),
+	})
+	if err != nil {
+		return id, fmt.Errorf("AttachmentsUseCase - Attach - proto.Marshal: %w", err)
+	}
+
+	encInfo, err := uc.key.Encrypt(rawInfo)
+	if err != nil {
+		return id, fmt.Errorf("AttachmentsUseCase - Attach - uc.key.Encrypt(info): %w", err)
+	}
+
+	encContent, err := uc.key.Encrypt(content)
+	if err != nil {
+		return id, fmt.Errorf("AttachmentsUseCase - Attach - uc.key.Encrypt(content): %w", err)
+	}
+
+	id, err = uc.attachmentsRepo.Push(ctx, token, secretID, encInfo, encContent)
+	if err != nil {
+		return id, fmt.Errorf("AttachmentsUseCase - Attach - uc.attachmentsRepo.Push: %w", err)
+	}
+
+	return id, nil
+}
+
+// decryptInfo decrypts description of the attached file.
+func (uc *AttachmentsUseCase) decryptInfo(
+	attachment *goph.Attachment,
+) (*goph.AttachmentInfo, error) {
+	rawInfo, err := uc.key.Decrypt(attachment.GetMetadata())
+	if err != nil {
+		return nil, fmt.Errorf("uc.key.Decrypt: %w", err)
+	}
+
+	info := &goph.AttachmentInfo{}
+	if err := proto.Unmarshal(rawInfo, info); err != nil {
+		return nil, fmt.Errorf("proto.Unmarshal: %w", err)
+	}
+
+	return info, nil
+}
+
+// List returns list of files attached to the secret without content.
+func (uc *AttachmentsUseCase) List(
+	ctx context.Context,
+	token string,
+	secretID uuid.UUID,
+) ([]entity.Attachment, error) {
+	attachments, err := uc.attachmentsRepo.List(ctx, token, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentsUseCase - List - uc.attachmentsRepo.List: %w", err)
+	}
+
+	rv := make([]entity.Attachment, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		info, err := uc.decryptInfo(attachment)
+		if err != nil {
+			return nil, fmt.Errorf("AttachmentsUseCase - List - uc.decryptInfo: %w", err)
+		}
+
+		rv = append(rv, entity.Attachment{
+			ID:       attachment.GetId(),
+			SecretID: attachment.GetSecretId(),
+			Info:     info,
+		})
+	}
+
+	return rv, nil
+}
+
+// Get downloads and decrypts attached file.
+func (uc *AttachmentsUseCase) Get(
+	ctx context.Context,
+	token string,
+	id uuid.UUID,
+) (*entity.Attachment, error) {
+	attachment, encContent, err := uc.attachmentsRepo.Get(ctx, token, id)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentsUseCase - Get - uc.attachmentsRepo.Get: %w", err)
+	}
+
+	info, err := uc.decryptInfo(attachment)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentsUseCase - Get - uc.decryptInfo: %w", err)
+	}
+
+	content, err := uc.key.Decrypt(encContent)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentsUseCase - Get - uc.key.Decrypt(content): %w", err)
+	}
+
+	return &entity.Attachment{
+		ID:       attachment.GetId(),
+		SecretID: attachment.GetSecretId(),
+		Info:     info,
+		Content:  content,
+	}, nil
+}
+
+// Detach removes the attached file.
+func (uc *AttachmentsUseCase) Detach(
+	ctx context.Context,
+	token string,
+	id uuid.UUID,
+) error {
+	if err := uc.attachmentsRepo.Delete(ctx, token, id); err != nil {
+		return fmt.Errorf("AttachmentsUseCase - Detach - uc.attachmentsRepo.Delete: %w", err)
+	}
+
+	return nil
+}
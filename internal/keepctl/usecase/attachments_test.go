@@ -0,0 +1,167 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/repo"
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/usecase"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/gophtest"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// pushAttachment attaches file and returns encrypted values sent to the server.
+func pushAttachment(
+	t *testing.T,
+	filename, mimeType string,
+	content []byte,
+) (encInfo, encContent []byte) {
+	t.Helper()
+
+	secretID := uuid.NewV4()
+	expected := uuid.NewV4()
+
+	m := &repo.AttachmentsRepoMock{}
+	m.On(
+		"Push",
+		mock.Anything,
+		gophtest.AccessToken,
+		secretID,
+		mock.AnythingOfType("[]uint8"),
+		mock.AnythingOfType("[]uint8"),
+	).
+		Run(func(args mock.Arguments) {
+			encInfo = args.Get(3).([]byte)
+			encContent = args.Get(4).([]byte)
+		}).
+		Return(expected, nil)
+
+	sat := usecase.NewAttachmentsUseCase(newTestKey(), m)
+	id, err := sat.Attach(
+		context.Background(),
+		gophtest.AccessToken,
+		secretID,
+		filename,
+		mimeType,
+		content,
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, id)
+	m.AssertExpectations(t)
+
+	return encInfo, encContent
+}
+
+func TestAttachAndGet(t *testing.T) {
+	tt := []struct {
+		name             string
+		filename         string
+		mimeType         string
+		content          []byte
+		expectedFilename string
+		expectedMIMEType string
+	}{
+		{
+			name:             "Attach file with explicit MIME type",
+			filename:         "contract.bin",
+			mimeType:         "application/pdf",
+			content:          []byte("%PDF-1.4"),
+			expectedFilename: "contract.bin",
+			expectedMIMEType: "application/pdf",
+		},
+		{
+			name:             "Attach file detecting MIME type by extension",
+			filename:         "/home/john/scans/contract.pdf",
+			content:          []byte("%PDF-1.4"),
+			expectedFilename: "contract.pdf",
+			expectedMIMEType: "application/pdf",
+		},
+		{
+			name:             "Attach file detecting MIME type by content",
+			filename:         "recovery",
+			content:          []byte("recovery codes"),
+			expectedFilename: "recovery",
+			expectedMIMEType: "text/plain; charset=utf-8",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			encInfo, encContent := pushAttachment(t, tc.filename, tc.mimeType, tc.content)
+			require.NotEqual(t, tc.content, encContent)
+
+			id := uuid.NewV4()
+			attachment := &goph.Attachment{
+				Id:       id.String(),
+				SecretId: uuid.NewV4().String(),
+				Metadata: encInfo,
+			}
+
+			m := &repo.AttachmentsRepoMock{}
+			m.On("Get", mock.Anything, gophtest.AccessToken, id).
+				Return(attachment, encContent, nil)
+
+			sat := usecase.NewAttachmentsUseCase(newTestKey(), m)
+			rv, err := sat.Get(context.Background(), gophtest.AccessToken, id)
+
+			require.NoError(t, err)
+			require.Equal(t, id.String(), rv.ID)
+			require.Equal(t, tc.expectedFilename, rv.Info.GetFilename())
+			require.Equal(t, tc.expectedMIMEType, rv.Info.GetMimeType())
+			require.Equal(t, uint64(len(tc.content)), rv.Info.GetSize())
+			require.Equal(t, tc.content, rv.Content)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAttachments(t *testing.T) {
+	encInfo, _ := pushAttachment(t, "scan.png", "", []byte{0x89, 'P', 'N', 'G'})
+	secretID := uuid.NewV4()
+
+	m := &repo.AttachmentsRepoMock{}
+	m.On("List", mock.Anything, gophtest.AccessToken, secretID).
+		Return([]*goph.Attachment{{Id: uuid.NewV4().String(), Metadata: encInfo}}, nil)
+
+	sat := usecase.NewAttachmentsUseCase(newTestKey(), m)
+	rv, err := sat.List(context.Background(), gophtest.AccessToken, secretID)
+
+	require.NoError(t, err)
+	require.Len(t, rv, 1)
+	require.Equal(t, "scan.png", rv[0].Info.GetFilename())
+	require.Equal(t, "image/png", rv[0].Info.GetMimeType())
+	require.Nil(t, rv[0].Content)
+	m.AssertExpectations(t)
+}
+
+func TestListAttachmentsOnRepoFailure(t *testing.T) {
+	secretID := uuid.NewV4()
+
+	m := &repo.AttachmentsRepoMock{}
+	m.On("List", mock.Anything, gophtest.AccessToken, secretID).
+		Return([]*goph.Attachment(nil), gophtest.ErrUnexpected)
+
+	sat := usecase.NewAttachmentsUseCase(newTestKey(), m)
+	_, err := sat.List(context.Background(), gophtest.AccessToken, secretID)
+
+	require.ErrorIs(t, err, gophtest.ErrUnexpected)
+	m.AssertExpectations(t)
+}
+
+func TestDetach(t *testing.T) {
+	id := uuid.NewV4()
+
+	m := &repo.AttachmentsRepoMock{}
+	m.On("Delete", mock.Anything, gophtest.AccessToken, id).
+		Return(nil)
+
+	sat := usecase.NewAttachmentsUseCase(newTestKey(), m)
+	err := sat.Detach(context.Background(), gophtest.AccessToken, id)
+
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+}
@@ -17,6 +17,12 @@ var _ Secrets = (*SecretsUseCase)(nil)
 
 var ErrKindMismatch = errors.New("secret kind doesn't match")
 
+// Limits of a single batch request, keeper rejects larger batches.
+const (
+	MaxBatchSize  = 100
+	MaxBatchBytes = 4 * 1024 * 1024
+)
+
 // SecretsUseCase contains business logic related to secrets management.
 type SecretsUseCase struct {
 	key         entity.Key
@@ -28,93 +34,153 @@ func NewSecretsUseCase(key entity.Key, secrets repo.Secrets) *SecretsUseCase {
 	return &SecretsUseCase{key, secrets}
 }
 
-// push is low level function sending generic secret creation message to keeper.
-func (uc *SecretsUseCase) push(
+// Push creates new secret with provided data.
+// Kind of the secret is defined by type of the data message.
+// The secret is placed into the root folder if no labels provided.
+func (uc *SecretsUseCase) Push(
 	ctx context.Context,
-	token string,
-	name string,
-	kind goph.DataKind,
-	description string,
+	token, name, description string,
+	labels *goph.Labels,
 	data proto.Message,
 ) (uuid.UUID, error) {
 	var id uuid.UUID
 
-	rawData, err := proto.Marshal(data)
+	req, err := uc.seal(name, description, labels, data)
 	if err != nil {
-		return id, fmt.Errorf("SecretsUseCase - push - proto.Marshal: %w", err)
+		return id, fmt.Errorf("SecretsUseCase - Push - uc.seal: %w", err)
 	}
 
-	encData, err := uc.key.Encrypt(rawData)
+	id, err = uc.secretsRepo.Push(
+		ctx,
+		token,
+		req.GetName(),
+		req.GetKind(),
+		req.GetMetadata(),
+		req.GetData(),
+		req.GetLabels(),
+		req.GetFolderDigest(),
+	)
 	if err != nil {
-		return id, fmt.Errorf("SecretsUseCase - push - uc.key.Encrypt(data): %w", err)
+		return id, fmt.Errorf("SecretsUseCase - Push - uc.secretsRepo.Push: %w", err)
 	}
 
-	encDescription, err := uc.key.Encrypt([]byte(description))
-	if err != nil {
-		return id, fmt.Errorf("SecretsUseCase - push - uc.key.Encrypt(description): %w", err)
+	return id, nil
+}
+
+// PushBatch creates several secrets at once, e.g. imported from other password manager.
+// All secrets are validated before sending anything. The secrets are sent in chunks
+// limited by number of secrets and size of a request, each chunk is created atomically
+// together with deletion of the replaced secrets and reported to the optional callback.
+// On failure IDs of already created secrets are returned along with the error.
+func (uc *SecretsUseCase) PushBatch(
+	ctx context.Context,
+	token string,
+	secrets []entity.NewSecret,
+	stored BatchStored,
+) ([]uuid.UUID, error) {
+	reqs := make([]*goph.CreateSecretRequest, 0, len(secrets))
+
+	for _, secret := range secrets {
+		req, err := uc.seal(secret.Name, secret.Description, secret.Labels, secret.Data)
+		if err != nil {
+			return nil, fmt.Errorf("SecretsUseCase - PushBatch - uc.seal(%s): %w", secret.Name, err)
+		}
+
+		req.Replaces = secret.Replaces
+		reqs = append(reqs, req)
 	}
 
-	id, err = uc.secretsRepo.Push(ctx, token, name, kind, encDescription, encData)
-	if err != nil {
-		return id, fmt.Errorf("SecretsUseCase - push - uc.secretsRepo.Push: %w", err)
+	rv := make([]uuid.UUID, 0, len(secrets))
+	start, chunkSize := 0, 0
+
+	flush := func(end int) error {
+		if start == end {
+			return nil
+		}
+
+		ids, err := uc.secretsRepo.PushBatch(ctx, token, reqs[start:end])
+		if err != nil {
+			return fmt.Errorf("SecretsUseCase - PushBatch - uc.secretsRepo.PushBatch: %w", err)
+		}
+
+		rv = append(rv, ids...)
+
+		if stored != nil {
+			if err := stored(secrets[start:end], ids); err != nil {
+				return err
+			}
+		}
+
+		start, chunkSize = end, 0
+
+		return nil
 	}
 
-	return id, nil
-}
+	for i, req := range reqs {
+		size := proto.Size(req)
+		if i-start == MaxBatchSize || (i > start && chunkSize+size > MaxBatchBytes) {
+			if err := flush(i); err != nil {
+				return rv, err
+			}
+		}
 
-// PushBinary creates new secret with arbitrary binary data.
-func (uc *SecretsUseCase) PushBinary(
-	ctx context.Context,
-	token, name, description string,
-	binary []byte,
-) (uuid.UUID, error) {
-	data := &goph.Binary{
-		Binary: binary,
+		chunkSize += size
+	}
+
+	if err := flush(len(reqs)); err != nil {
+		return rv, err
 	}
 
-	return uc.push(ctx, token, name, goph.DataKind_BINARY, description, data)
+	return rv, nil
 }
 
-// PushCard creates new secret containing bank card data.
-func (uc *SecretsUseCase) PushCard(
-	ctx context.Context,
-	token, name, description string,
-	number, expiration, holder string,
-	cvv int32,
-) (uuid.UUID, error) {
-	data := &goph.Card{
-		Number:     number,
-		Expiration: expiration,
-		Holder:     holder,
-		Cvv:        cvv,
+// seal validates and encrypts new secret.
+func (uc *SecretsUseCase) seal(
+	name, description string,
+	labels *goph.Labels,
+	data proto.Message,
+) (*goph.CreateSecretRequest, error) {
+	kind, err := entity.KindOfMessage(data)
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - seal - entity.KindOfMessage: %w", err)
 	}
 
-	return uc.push(ctx, token, name, goph.DataKind_CARD, description, data)
-}
+	if err = kind.Validate(data); err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - seal - kind.Validate: %w", err)
+	}
 
-// PushCreds creates new secret containing credentials.
-func (uc *SecretsUseCase) PushCreds(
-	ctx context.Context,
-	token, name, description, login, password string,
-) (uuid.UUID, error) {
-	data := &goph.Credentials{
-		Login:    login,
-		Password: password,
+	rawData, err := proto.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - seal - proto.Marshal: %w", err)
 	}
 
-	return uc.push(ctx, token, name, goph.DataKind_CREDENTIALS, description, data)
-}
+	encData, err := uc.key.Encrypt(rawData)
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - seal - uc.key.Encrypt(data): %w", err)
+	}
 
-// PushText creates new secret with arbitrary text.
-func (uc *SecretsUseCase) PushText(
-	ctx context.Context,
-	token, name, description, text string,
-) (uuid.UUID, error) {
-	data := &goph.Text{
-		Text: text,
+	encDescription, err := uc.key.Encrypt([]byte(description))
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - seal - uc.key.Encrypt(description): %w", err)
+	}
+
+	if labels == nil {
+		labels = new(goph.Labels)
 	}
 
-	return uc.push(ctx, token, name, goph.DataKind_TEXT, description, data)
+	encLabels, folderDigest, err := uc.sealLabels(labels)
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - seal - uc.sealLabels: %w", err)
+	}
+
+	return &goph.CreateSecretRequest{
+		Name:         name,
+		Kind:         kind.DataKind,
+		Metadata:     encDescription,
+		Data:         encData,
+		Labels:       encLabels,
+		FolderDigest: folderDigest,
+	}, nil
 }
 
 // List returns list of user's secrets.
@@ -128,13 +194,50 @@ func (uc *SecretsUseCase) List(ctx context.Context, token string) ([]*goph.Secre
 	for i, val := range data {
 		data[i].Metadata, err = uc.key.Decrypt(val.GetMetadata())
 		if err != nil {
-			return nil, fmt.Errorf("SecretsUseCase - List - uc.key.Decrypt: %w", err)
+			return nil, fmt.Errorf("SecretsUseCase - List - uc.key.Decrypt(metadata): %w", err)
+		}
+
+		data[i].Labels, err = uc.key.Decrypt(val.GetLabels())
+		if err != nil {
+			return nil, fmt.Errorf("SecretsUseCase - List - uc.key.Decrypt(labels): %w", err)
 		}
 	}
 
 	return data, nil
 }
 
+// sealLabels normalizes the labels in place and encrypts them.
+// Also calculates digest of the folder used by keeper to check uniqueness of names,
+// the digest is empty for the root folder.
+func (uc *SecretsUseCase) sealLabels(labels *goph.Labels) ([]byte, []byte, error) {
+	if err := entity.NormalizeLabels(labels); err != nil {
+		return nil, nil, fmt.Errorf("SecretsUseCase - sealLabels - entity.NormalizeLabels: %w", err)
+	}
+
+	rawLabels, err := proto.Marshal(labels)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SecretsUseCase - sealLabels - proto.Marshal: %w", err)
+	}
+
+	encLabels, err := uc.key.Encrypt(rawLabels)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SecretsUseCase - sealLabels - uc.key.Encrypt: %w", err)
+	}
+
+	// NB (alkurbatov): Empty labels are still sent to keeper
+	// in order to move the secret to the root folder.
+	if encLabels == nil {
+		encLabels = []byte{}
+	}
+
+	var folderDigest []byte
+	if labels.GetFolder() != "" {
+		folderDigest = uc.key.Digest([]byte(labels.GetFolder()))
+	}
+
+	return encLabels, folderDigest, nil
+}
+
 // update is low level function sending generic secret update message to keeper.
 func (uc *SecretsUseCase) update(
 	ctx context.Context,
@@ -172,6 +275,8 @@ func (uc *SecretsUseCase) update(
 		encDescription,
 		noDescription,
 		encData,
+		nil,
+		nil,
 	); err != nil {
 		return fmt.Errorf("SecretsUseCase - update - uc.secretsRepo.Update: %w", err)
 	}
@@ -179,182 +284,414 @@ func (uc *SecretsUseCase) update(
 	return nil
 }
 
-// EditBinary changes parameters of stored binary secret.
-func (uc *SecretsUseCase) EditBinary(
+// Edit changes parameters of stored secret.
+// If no changes of data are requested, only name and description are updated.
+func (uc *SecretsUseCase) Edit(
 	ctx context.Context,
 	token string,
 	id uuid.UUID,
 	name, description string,
 	noDescription bool,
-	binary []byte,
+	kind goph.DataKind,
+	changes []entity.Change,
 ) error {
-	if len(binary) == 0 {
+	if len(changes) == 0 {
 		return uc.update(ctx, token, id, name, description, noDescription, nil)
 	}
 
-	_, msg, err := uc.Get(ctx, token, id)
+	secret, data, err := uc.Get(ctx, token, id)
 	if err != nil {
-		return fmt.Errorf("SecretsUseCase - EditBinary - uc.Get: %w", err)
+		return fmt.Errorf("SecretsUseCase - Edit - uc.Get: %w", err)
 	}
 
-	data, ok := msg.(*goph.Binary)
-	if !ok {
-		return fmt.Errorf("SecretsUseCase - EditBinary - msg.(*goph.Binary): %w", ErrKindMismatch)
+	if secret.GetKind() != kind {
+		return fmt.Errorf("SecretsUseCase - Edit - secret.GetKind: %w", ErrKindMismatch)
 	}
 
-	data.Binary = binary
+	k, err := entity.KindOf(kind)
+	if err != nil {
+		return fmt.Errorf("SecretsUseCase - Edit - entity.KindOf: %w", err)
+	}
+
+	if err := k.Apply(data, changes); err != nil {
+		return fmt.Errorf("SecretsUseCase - Edit - k.Apply: %w", err)
+	}
+
+	if err := k.Validate(data); err != nil {
+		return fmt.Errorf("SecretsUseCase - Edit - k.Validate: %w", err)
+	}
 
 	return uc.update(ctx, token, id, name, description, noDescription, data)
 }
 
-// EditCard changes parameters of stored bank card.
-func (uc *SecretsUseCase) EditCard( //nolint:cyclop,gocyclo //needs simplification
+// Get retrieves full user's secret.
+// All sensitive parts are decrypted.
+func (uc *SecretsUseCase) Get(
 	ctx context.Context,
 	token string,
 	id uuid.UUID,
-	name, description string,
-	noDescription bool,
-	number, expiration, holder string,
-	cvv int32,
-) error {
-	if number == "" && expiration == "" && holder == "" && cvv == 0 {
-		return uc.update(ctx, token, id, name, description, noDescription, nil)
+) (*goph.Secret, proto.Message, error) {
+	secret, data, err := uc.secretsRepo.Get(ctx, token, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SecretsUseCase - Get - uc.secretsRepo.Get: %w", err)
 	}
 
-	_, msg, err := uc.Get(ctx, token, id)
+	msg, err := uc.open(secret, data)
 	if err != nil {
-		return fmt.Errorf("SecretsUseCase - EditCard - uc.Get: %w", err)
+		return nil, nil, fmt.Errorf("SecretsUseCase - Get - uc.open: %w", err)
 	}
 
-	data, ok := msg.(*goph.Card)
-	if !ok {
-		return fmt.Errorf("SecretsUseCase - EditCard - msg.(*goph.Card): %w", ErrKindMismatch)
+	return secret, msg, nil
+}
+
+// open decrypts the downloaded secret in place and unmarshals its data.
+func (uc *SecretsUseCase) open(secret *goph.Secret, data []byte) (proto.Message, error) {
+	var err error
+
+	secret.Metadata, err = uc.key.Decrypt(secret.GetMetadata())
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - open - uc.key.Decrypt(metadata): %w", err)
 	}
 
-	if number != "" {
-		data.Number = number
+	secret.Labels, err = uc.key.Decrypt(secret.GetLabels())
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - open - uc.key.Decrypt(labels): %w", err)
 	}
 
-	if expiration != "" {
-		data.Expiration = expiration
+	decryptedData, err := uc.key.Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - open - uc.key.Decrypt(data): %w", err)
 	}
 
-	if holder != "" {
-		data.Holder = holder
+	kind, err := entity.KindOf(secret.GetKind())
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - open - entity.KindOf: %w", err)
 	}
 
-	if cvv != 0 {
-		data.Cvv = cvv
+	msg := kind.New()
+
+	if err := proto.Unmarshal(decryptedData, msg); err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - open - proto.Unmarshal: %w", err)
 	}
 
-	return uc.update(ctx, token, id, name, description, noDescription, data)
+	kind.Upgrade(msg)
+
+	return msg, nil
 }
 
-// EditCreds changes parameters of stored credentials.
-func (uc *SecretsUseCase) EditCreds(
+// getByPath retrieves full user's secret by path like "work/aws".
+// Returns nil secret if keeper has no such secret or the path is malformed.
+func (uc *SecretsUseCase) getByPath(
 	ctx context.Context,
-	token string,
-	id uuid.UUID,
-	name, description string,
-	noDescription bool,
-	login, password string,
-) error {
-	if login == "" && password == "" {
-		return uc.update(ctx, token, id, name, description, noDescription, nil)
+	token, path string,
+) (*goph.Secret, proto.Message, error) {
+	folder, name, err := entity.SplitSecretPath(path)
+	if err != nil {
+		return nil, nil, nil //nolint:nilerr // malformed path may still be name of a secret
+	}
+
+	var folderDigest []byte
+	if folder != "" {
+		folderDigest = uc.key.Digest([]byte(folder))
 	}
 
-	_, msg, err := uc.Get(ctx, token, id)
+	secret, data, err := uc.secretsRepo.GetByName(ctx, token, name, folderDigest)
 	if err != nil {
-		return fmt.Errorf("SecretsUseCase - EditCreds - uc.Get: %w", err)
+		if entity.IsNotFound(err) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, fmt.Errorf("SecretsUseCase - getByPath - uc.secretsRepo.GetByName: %w", err)
 	}
 
-	data, ok := msg.(*goph.Credentials)
-	if !ok {
-		return fmt.Errorf("SecretsUseCase - EditCreds - msg.(*goph.Credentials): %w", ErrKindMismatch)
+	msg, err := uc.open(secret, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SecretsUseCase - getByPath - uc.open: %w", err)
 	}
 
-	if login != "" {
-		data.Login = login
+	return secret, msg, nil
+}
+
+// Resolve finds ID of user's secret by reference.
+// The reference is ID of the secret, unique prefix of the ID, unique name
+// or path to the secret like "work/aws".
+func (uc *SecretsUseCase) Resolve(ctx context.Context, token, ref string) (uuid.UUID, error) {
+	if entity.IsSecretPath(ref) {
+		secret, _, err := uc.getByPath(ctx, token, ref)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("SecretsUseCase - Resolve - uc.getByPath: %w", err)
+		}
+
+		if secret != nil {
+			id, err := uuid.FromString(secret.GetId())
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("SecretsUseCase - Resolve - uuid.FromString: %w", err)
+			}
+
+			return id, nil
+		}
 	}
 
-	if password != "" {
-		data.Password = password
+	id, err := uc.resolve(ctx, token, ref)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("SecretsUseCase - Resolve - uc.resolve: %w", err)
 	}
 
-	return uc.update(ctx, token, id, name, description, noDescription, data)
+	return id, nil
+}
+
+// resolve finds ID of user's secret by reference among all the secrets.
+func (uc *SecretsUseCase) resolve(ctx context.Context, token, ref string) (uuid.UUID, error) {
+	if id, err := uuid.FromString(ref); err == nil {
+		return id, nil
+	}
+
+	secrets, err := uc.List(ctx, token)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("SecretsUseCase - resolve - uc.List: %w", err)
+	}
+
+	secret, err := entity.ResolveSecret(ref, secrets)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("SecretsUseCase - resolve - entity.ResolveSecret: %w", err)
+	}
+
+	id, err := uuid.FromString(secret.GetId())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("SecretsUseCase - resolve - uuid.FromString: %w", err)
+	}
+
+	return id, nil
+}
+
+// Find retrieves full user's secret by reference, see Resolve for supported references.
+// Paths are looked up by keeper directly without listing of all secrets.
+// All sensitive parts are decrypted.
+func (uc *SecretsUseCase) Find(
+	ctx context.Context,
+	token, ref string,
+) (*goph.Secret, proto.Message, error) {
+	if entity.IsSecretPath(ref) {
+		secret, msg, err := uc.getByPath(ctx, token, ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("SecretsUseCase - Find - uc.getByPath: %w", err)
+		}
+
+		if secret != nil {
+			return secret, msg, nil
+		}
+
+		// NB (alkurbatov): Name of the secret may contain folder separator itself.
+	}
+
+	id, err := uc.resolve(ctx, token, ref)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SecretsUseCase - Find - uc.resolve: %w", err)
+	}
+
+	return uc.Get(ctx, token, id)
 }
 
-// EditText changes parameters of stored text secret.
-func (uc *SecretsUseCase) EditText(
+// relabel changes labels of stored secret with the provided function.
+func (uc *SecretsUseCase) relabel(
 	ctx context.Context,
 	token string,
 	id uuid.UUID,
-	name, description string,
-	noDescription bool,
-	text string,
+	change func(labels *goph.Labels),
 ) error {
-	if text == "" {
-		return uc.update(ctx, token, id, name, description, noDescription, nil)
+	secret, _, err := uc.secretsRepo.Get(ctx, token, id)
+	if err != nil {
+		return fmt.Errorf("SecretsUseCase - relabel - uc.secretsRepo.Get: %w", err)
 	}
 
-	_, msg, err := uc.Get(ctx, token, id)
+	secret.Labels, err = uc.key.Decrypt(secret.GetLabels())
 	if err != nil {
-		return fmt.Errorf("SecretsUseCase - EditText - uc.Get: %w", err)
+		return fmt.Errorf("SecretsUseCase - relabel - uc.key.Decrypt: %w", err)
 	}
 
-	data, ok := msg.(*goph.Text)
-	if !ok {
-		return fmt.Errorf("SecretsUseCase - EditText - msg.(*goph.Text): %w", ErrKindMismatch)
+	labels, err := entity.LabelsOf(secret)
+	if err != nil {
+		return fmt.Errorf("SecretsUseCase - relabel - entity.LabelsOf: %w", err)
 	}
 
-	data.Text = text
+	change(labels)
 
-	return uc.update(ctx, token, id, name, description, noDescription, data)
+	encLabels, folderDigest, err := uc.sealLabels(labels)
+	if err != nil {
+		return fmt.Errorf("SecretsUseCase - relabel - uc.sealLabels: %w", err)
+	}
+
+	if err := uc.secretsRepo.Update(
+		ctx,
+		token,
+		id,
+		"",
+		nil,
+		false,
+		nil,
+		encLabels,
+		folderDigest,
+	); err != nil {
+		return fmt.Errorf("SecretsUseCase - relabel - uc.secretsRepo.Update: %w", err)
+	}
+
+	return nil
 }
 
-// Get retrieves full user's secret.
-// All sensitive parts are decrypted.
-func (uc *SecretsUseCase) Get(
+// Move places stored secret into the folder, empty folder denotes the root one.
+func (uc *SecretsUseCase) Move(
 	ctx context.Context,
 	token string,
 	id uuid.UUID,
-) (*goph.Secret, proto.Message, error) {
-	secret, data, err := uc.secretsRepo.Get(ctx, token, id)
+	folder string,
+) error {
+	return uc.relabel(ctx, token, id, func(labels *goph.Labels) {
+		labels.Folder = folder
+	})
+}
+
+// Tag adds tags to stored secret.
+func (uc *SecretsUseCase) Tag(
+	ctx context.Context,
+	token string,
+	id uuid.UUID,
+	tags ...string,
+) error {
+	return uc.relabel(ctx, token, id, func(labels *goph.Labels) {
+		labels.Tags = append(labels.Tags, tags...)
+	})
+}
+
+// Untag removes tags from stored secret, unknown tags are ignored.
+func (uc *SecretsUseCase) Untag(
+	ctx context.Context,
+	token string,
+	id uuid.UUID,
+	tags ...string,
+) error {
+	return uc.relabel(ctx, token, id, func(labels *goph.Labels) {
+		kept := labels.Tags[:0]
+
+		for _, tag := range labels.GetTags() {
+			if !entity.Contains(tags, tag) {
+				kept = append(kept, tag)
+			}
+		}
+
+		labels.Tags = kept
+	})
+}
+
+// Fetch retrieves full user's secrets of the requested kinds, all secrets if no kinds provided.
+// All sensitive parts are decrypted.
+func (uc *SecretsUseCase) Fetch(
+	ctx context.Context,
+	token string,
+	kinds ...goph.DataKind,
+) ([]entity.SecretData, error) {
+	secrets, err := uc.secretsRepo.List(ctx, token)
 	if err != nil {
-		return nil, nil, fmt.Errorf("SecretsUseCase - Get - uc.secretsRepo.Get: %w", err)
+		return nil, fmt.Errorf("SecretsUseCase - Fetch - uc.secretsRepo.List: %w", err)
 	}
 
-	secret.Metadata, err = uc.key.Decrypt(secret.GetMetadata())
-	if err != nil {
-		return nil, nil, fmt.Errorf("SecretsUseCase - Get - uc.key.Decrypt(metadata): %w", err)
+	rv := make([]entity.SecretData, 0, len(secrets))
+
+	for _, secret := range secrets {
+		if len(kinds) > 0 && !entity.Contains(kinds, secret.GetKind()) {
+			continue
+		}
+
+		id, err := uuid.FromString(secret.GetId())
+		if err != nil {
+			return nil, fmt.Errorf("SecretsUseCase - Fetch - uuid.FromString: %w", err)
+		}
+
+		fullSecret, data, err := uc.Get(ctx, token, id)
+		if err != nil {
+			return nil, fmt.Errorf("SecretsUseCase - Fetch - uc.Get: %w", err)
+		}
+
+		rv = append(rv, entity.SecretData{Secret: fullSecret, Data: data})
 	}
 
-	decryptedData, err := uc.key.Decrypt(data)
+	return rv, nil
+}
+
+// Search looks for user's secrets matching the query, the results are ranked by relevance.
+// All sensitive parts of found secrets are decrypted except data.
+func (uc *SecretsUseCase) Search(
+	ctx context.Context,
+	token string,
+	query entity.SearchQuery,
+) ([]entity.SearchResult, error) {
+	secrets, err := uc.List(ctx, token)
 	if err != nil {
-		return nil, nil, fmt.Errorf("SecretsUseCase - Get - uc.key.Decrypt(data): %w", err)
+		return nil, fmt.Errorf("SecretsUseCase - Search - uc.List: %w", err)
 	}
 
-	var msg proto.Message
+	rv := make([]entity.SearchResult, 0)
+
+	for _, secret := range secrets {
+		labels, err := entity.LabelsOf(secret)
+		if err != nil {
+			return nil, fmt.Errorf("SecretsUseCase - Search - entity.LabelsOf: %w", err)
+		}
+
+		if !query.Filter(secret, labels) {
+			continue
+		}
 
-	switch secret.GetKind() {
-	case goph.DataKind_BINARY:
-		msg = &goph.Binary{}
+		fields := entity.SearchFields(secret, labels)
 
-	case goph.DataKind_CARD:
-		msg = &goph.Card{}
+		if query.Deep && query.Text != "" {
+			dataFields, err := uc.dataSearchFields(ctx, token, secret)
+			if err != nil {
+				return nil, fmt.Errorf("SecretsUseCase - Search - uc.dataSearchFields: %w", err)
+			}
 
-	case goph.DataKind_CREDENTIALS:
-		msg = &goph.Credentials{}
+			fields = append(fields, dataFields...)
+		}
 
-	case goph.DataKind_TEXT:
-		msg = &goph.Text{}
+		field, score, ok := query.Match(fields)
+		if !ok {
+			continue
+		}
+
+		rv = append(rv, entity.SearchResult{
+			Secret: secret,
+			Labels: labels,
+			Field:  field,
+			Score:  score,
+		})
 	}
 
-	if err := proto.Unmarshal(decryptedData, msg); err != nil {
-		return nil, nil, fmt.Errorf("SecretsUseCase - Get - proto.Unmarshal: %w", err)
+	entity.SortSearchResults(rv)
+
+	return rv, nil
+}
+
+// dataSearchFields downloads data of the secret and extracts searchable attributes.
+func (uc *SecretsUseCase) dataSearchFields(
+	ctx context.Context,
+	token string,
+	secret *goph.Secret,
+) ([]entity.SearchField, error) {
+	id, err := uuid.FromString(secret.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - dataSearchFields - uuid.FromString: %w", err)
 	}
 
-	return secret, msg, nil
+	_, data, err := uc.Get(ctx, token, id)
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - dataSearchFields - uc.Get: %w", err)
+	}
+
+	kind, err := entity.KindOf(secret.GetKind())
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - dataSearchFields - entity.KindOf: %w", err)
+	}
+
+	return kind.DataSearchFields(data), nil
 }
 
 // Delete removes user's secret.
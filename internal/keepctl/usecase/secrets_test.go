@@ -2,8 +2,10 @@ package usecase_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/alkurbatov/goph-keeper/internal/keepctl/entity"
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/repo"
 	"github.com/alkurbatov/goph-keeper/internal/keepctl/usecase"
 	"github.com/alkurbatov/goph-keeper/internal/libraries/gophtest"
@@ -12,6 +14,8 @@ import (
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -27,16 +31,19 @@ func doPushText(t *testing.T, mockRV uuid.UUID, mockErr error) (uuid.UUID, error
 		goph.DataKind_TEXT,
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
+		mock.AnythingOfType("[]uint8"),
+		newTestKey().Digest([]byte("work/aws")),
 	).
 		Return(mockRV, mockErr)
 
 	sat := usecase.NewSecretsUseCase(newTestKey(), m)
-	id, err := sat.PushText(
+	id, err := sat.Push(
 		context.Background(),
 		gophtest.AccessToken,
 		gophtest.SecretName,
 		gophtest.Metadata,
-		gophtest.TextData,
+		&goph.Labels{Folder: "/work/aws/", Tags: []string{"prod"}},
+		&goph.Text{Text: gophtest.TextData},
 	)
 
 	m.AssertExpectations(t)
@@ -118,18 +125,26 @@ func doUpdateTextSecret(
 		mock.AnythingOfType("[]uint8"),
 		noDescription,
 		mock.AnythingOfType("[]uint8"),
+		[]byte(nil),
+		[]byte(nil),
 	).
 		Return(repoErr)
 
+	var changes []entity.Change
+	if text != "" {
+		changes = append(changes, entity.Change{Attribute: "text", Value: text})
+	}
+
 	sat := usecase.NewSecretsUseCase(newTestKey(), m)
-	err := sat.EditText(
+	err := sat.Edit(
 		context.Background(),
 		gophtest.AccessToken,
 		id,
 		name,
 		description,
 		noDescription,
-		text,
+		goph.DataKind_TEXT,
+		changes,
 	)
 
 	m.AssertExpectations(t)
@@ -178,6 +193,204 @@ func TestPushSecretOnRepoFailure(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestPushInvalidSecret(t *testing.T) {
+	m := &repo.SecretsRepoMock{}
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	_, err := sat.Push(
+		context.Background(),
+		gophtest.AccessToken,
+		gophtest.SecretName,
+		gophtest.Metadata,
+		nil,
+		&goph.Custom{},
+	)
+
+	require.ErrorIs(t, err, entity.ErrFieldsRequired)
+	m.AssertNotCalled(t, "Push")
+}
+
+func TestPushSecretWithBadLabels(t *testing.T) {
+	m := &repo.SecretsRepoMock{}
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	_, err := sat.Push(
+		context.Background(),
+		gophtest.AccessToken,
+		gophtest.SecretName,
+		gophtest.Metadata,
+		&goph.Labels{Folder: "work/../aws"},
+		&goph.Text{Text: gophtest.TextData},
+	)
+
+	require.ErrorIs(t, err, entity.ErrBadFolder)
+	m.AssertNotCalled(t, "Push")
+}
+
+func TestPushBatch(t *testing.T) {
+	secrets := make([]entity.NewSecret, 0, usecase.MaxBatchSize+1)
+	for i := 0; i < usecase.MaxBatchSize+1; i++ {
+		secrets = append(secrets, entity.NewSecret{
+			Name:   fmt.Sprintf("%s-%d", gophtest.SecretName, i),
+			Labels: &goph.Labels{Folder: "work"},
+			Data:   &goph.Text{Text: gophtest.TextData},
+		})
+	}
+
+	chunkOf := func(size int) any {
+		return mock.MatchedBy(func(reqs []*goph.CreateSecretRequest) bool {
+			return len(reqs) == size &&
+				reqs[0].GetKind() == goph.DataKind_TEXT &&
+				string(reqs[0].GetFolderDigest()) == string(newTestKey().Digest([]byte("work")))
+		})
+	}
+
+	first := make([]uuid.UUID, usecase.MaxBatchSize)
+	for i := range first {
+		first[i] = uuid.NewV4()
+	}
+
+	last := []uuid.UUID{uuid.NewV4()}
+
+	m := &repo.SecretsRepoMock{}
+	m.On("PushBatch", mock.Anything, gophtest.AccessToken, chunkOf(usecase.MaxBatchSize)).
+		Return(first, nil).
+		Once()
+	m.On("PushBatch", mock.Anything, gophtest.AccessToken, chunkOf(1)).
+		Return(last, nil).
+		Once()
+
+	stored := make([][]uuid.UUID, 0, 2)
+	onStored := func(chunk []entity.NewSecret, ids []uuid.UUID) error {
+		require.Len(t, chunk, len(ids))
+
+		stored = append(stored, ids)
+
+		return nil
+	}
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	ids, err := sat.PushBatch(context.Background(), gophtest.AccessToken, secrets, onStored)
+
+	require.NoError(t, err)
+	require.Equal(t, append(first, last...), ids)
+	require.Equal(t, [][]uuid.UUID{first, last}, stored)
+	m.AssertExpectations(t)
+}
+
+func TestPushBatchOnPartialFailure(t *testing.T) {
+	secrets := make([]entity.NewSecret, 0, usecase.MaxBatchSize+1)
+	for i := 0; i < usecase.MaxBatchSize+1; i++ {
+		secrets = append(secrets, entity.NewSecret{
+			Name: fmt.Sprintf("%s-%d", gophtest.SecretName, i),
+			Data: &goph.Text{Text: gophtest.TextData},
+		})
+	}
+
+	chunkOf := func(size int) any {
+		return mock.MatchedBy(func(reqs []*goph.CreateSecretRequest) bool {
+			return len(reqs) == size
+		})
+	}
+
+	first := make([]uuid.UUID, usecase.MaxBatchSize)
+	for i := range first {
+		first[i] = uuid.NewV4()
+	}
+
+	m := &repo.SecretsRepoMock{}
+	m.On("PushBatch", mock.Anything, gophtest.AccessToken, chunkOf(usecase.MaxBatchSize)).
+		Return(first, nil).
+		Once()
+	m.On("PushBatch", mock.Anything, gophtest.AccessToken, chunkOf(1)).
+		Return([]uuid.UUID(nil), gophtest.ErrUnexpected).
+		Once()
+
+	var stored []uuid.UUID
+
+	onStored := func(_ []entity.NewSecret, ids []uuid.UUID) error {
+		stored = append(stored, ids...)
+
+		return nil
+	}
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	ids, err := sat.PushBatch(context.Background(), gophtest.AccessToken, secrets, onStored)
+
+	require.ErrorIs(t, err, gophtest.ErrUnexpected)
+	require.Equal(t, first, ids)
+	require.Equal(t, first, stored)
+	m.AssertExpectations(t)
+}
+
+func TestPushBatchSendsReplacedSecret(t *testing.T) {
+	replaced := uuid.NewV4().String()
+	expected := []uuid.UUID{uuid.NewV4()}
+
+	m := &repo.SecretsRepoMock{}
+	m.On(
+		"PushBatch",
+		mock.Anything,
+		gophtest.AccessToken,
+		mock.MatchedBy(func(reqs []*goph.CreateSecretRequest) bool {
+			return len(reqs) == 1 && reqs[0].GetReplaces() == replaced
+		}),
+	).
+		Return(expected, nil)
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	ids, err := sat.PushBatch(
+		context.Background(),
+		gophtest.AccessToken,
+		[]entity.NewSecret{
+			{
+				Name:     gophtest.SecretName,
+				Data:     &goph.Text{Text: gophtest.TextData},
+				Replaces: replaced,
+			},
+		},
+		nil,
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, ids)
+	m.AssertExpectations(t)
+}
+
+func TestPushBatchOnRepoFailure(t *testing.T) {
+	m := &repo.SecretsRepoMock{}
+	m.On("PushBatch", mock.Anything, gophtest.AccessToken, mock.Anything).
+		Return([]uuid.UUID(nil), gophtest.ErrUnexpected)
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	_, err := sat.PushBatch(
+		context.Background(),
+		gophtest.AccessToken,
+		[]entity.NewSecret{{Name: gophtest.SecretName, Data: &goph.Text{Text: gophtest.TextData}}},
+		nil,
+	)
+
+	require.ErrorIs(t, err, gophtest.ErrUnexpected)
+}
+
+func TestPushBatchWithInvalidSecret(t *testing.T) {
+	m := &repo.SecretsRepoMock{}
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	_, err := sat.PushBatch(
+		context.Background(),
+		gophtest.AccessToken,
+		[]entity.NewSecret{
+			{Name: gophtest.SecretName, Data: &goph.Text{Text: gophtest.TextData}},
+			{Name: gophtest.SecretName + "-invalid", Data: &goph.Custom{}},
+		},
+		nil,
+	)
+
+	require.ErrorIs(t, err, entity.ErrFieldsRequired)
+	m.AssertNotCalled(t, "PushBatch")
+}
+
 func TestListSecrets(t *testing.T) {
 	tt := []struct {
 		name    string
@@ -191,6 +404,7 @@ func TestListSecrets(t *testing.T) {
 					Name:     gophtest.SecretName,
 					Kind:     goph.DataKind_TEXT,
 					Metadata: []byte(gophtest.Metadata),
+					Labels:   []byte(gophtest.Labels),
 				},
 				{
 					Id:       gophtest.CreateUUID(t, "7728154c-9400-4f1b-a2a3-01deb83ece05").String(),
@@ -215,6 +429,9 @@ func TestListSecrets(t *testing.T) {
 				encrypted, err := key.Encrypt(secret.GetMetadata())
 				require.NoError(t, err)
 
+				encLabels, err := key.Encrypt(secret.GetLabels())
+				require.NoError(t, err)
+
 				mockRV = append(
 					mockRV,
 					&goph.Secret{
@@ -222,6 +439,7 @@ func TestListSecrets(t *testing.T) {
 						Name:     secret.Name,
 						Kind:     secret.Kind,
 						Metadata: encrypted,
+						Labels:   encLabels,
 					},
 				)
 			}
@@ -417,3 +635,566 @@ func TestDeleteSecretOnRepoFailure(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func doEditCustomSecret(
+	t *testing.T,
+	kind goph.DataKind,
+	stored []*goph.Field,
+	changes []entity.Change,
+) (*goph.Custom, error) {
+	t.Helper()
+
+	key := newTestKey()
+	id := uuid.NewV4()
+
+	rawData, err := proto.Marshal(&goph.Custom{Fields: stored})
+	require.NoError(t, err)
+
+	encData, err := key.Encrypt(rawData)
+	require.NoError(t, err)
+
+	secret := &goph.Secret{
+		Id:   id.String(),
+		Name: gophtest.SecretName,
+		Kind: goph.DataKind_CUSTOM,
+	}
+
+	rv := &goph.Custom{}
+
+	m := &repo.SecretsRepoMock{}
+	m.On("Get", mock.Anything, gophtest.AccessToken, id).
+		Return(secret, encData, nil)
+	m.On(
+		"Update",
+		mock.Anything,
+		gophtest.AccessToken,
+		id,
+		"",
+		mock.AnythingOfType("[]uint8"),
+		false,
+		mock.AnythingOfType("[]uint8"),
+		[]byte(nil),
+		[]byte(nil),
+	).
+		Run(func(args mock.Arguments) {
+			decrypted, err := key.Decrypt(args.Get(6).([]byte))
+			require.NoError(t, err)
+			require.NoError(t, proto.Unmarshal(decrypted, rv))
+		}).
+		Return(nil).
+		Maybe()
+
+	sat := usecase.NewSecretsUseCase(key, m)
+	err = sat.Edit(
+		context.Background(),
+		gophtest.AccessToken,
+		id,
+		"",
+		"",
+		false,
+		kind,
+		changes,
+	)
+
+	return rv, err
+}
+
+func TestEditCustomSecret(t *testing.T) {
+	stored := []*goph.Field{
+		{Name: "client_id", Type: goph.FieldType_FIELD_TEXT, Value: "id"},
+		{Name: "client_secret", Type: goph.FieldType_FIELD_HIDDEN, Value: "secret"},
+		{Name: "region", Type: goph.FieldType_FIELD_TEXT, Value: "eu-west-1"},
+	}
+
+	data, err := doEditCustomSecret(
+		t,
+		goph.DataKind_CUSTOM,
+		stored,
+		[]entity.Change{
+			{Attribute: "remove-field", Value: "region"},
+			{Attribute: "field", Value: "client_secret=new-secret"},
+			{Attribute: "field", Value: "portal:url=https://example.com"},
+		},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, data.GetFields(), 3)
+	require.Equal(t, "client_id", data.GetFields()[0].GetName())
+	require.Equal(t, "new-secret", data.GetFields()[1].GetValue())
+	require.Equal(t, goph.FieldType_FIELD_HIDDEN, data.GetFields()[1].GetType())
+	require.Equal(t, "portal", data.GetFields()[2].GetName())
+}
+
+func TestEditCustomSecretFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		kind     goph.DataKind
+		change   entity.Change
+		expected error
+	}{
+		{
+			name:     "Remove unknown field",
+			kind:     goph.DataKind_CUSTOM,
+			change:   entity.Change{Attribute: "remove-field", Value: "region"},
+			expected: entity.ErrFieldNotFound,
+		},
+		{
+			name:     "Set invalid value",
+			kind:     goph.DataKind_CUSTOM,
+			change:   entity.Change{Attribute: "field", Value: "portal:url=xxx"},
+			expected: entity.ErrBadFieldValue,
+		},
+		{
+			name:     "Edit secret of another kind",
+			kind:     goph.DataKind_TEXT,
+			change:   entity.Change{Attribute: "text", Value: gophtest.TextData},
+			expected: usecase.ErrKindMismatch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := doEditCustomSecret(
+				t,
+				tc.kind,
+				[]*goph.Field{{Name: "client_id", Value: "id"}},
+				[]entity.Change{tc.change},
+			)
+
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestFetchSecrets(t *testing.T) {
+	key := newTestKey()
+	textID := uuid.NewV4()
+	credsID := uuid.NewV4()
+
+	encMetadata, err := key.Encrypt([]byte(gophtest.Metadata))
+	require.NoError(t, err)
+
+	rawData, err := proto.Marshal(&goph.Credentials{Login: gophtest.Username})
+	require.NoError(t, err)
+
+	encData, err := key.Encrypt(rawData)
+	require.NoError(t, err)
+
+	secrets := []*goph.Secret{
+		{Id: textID.String(), Name: "text", Kind: goph.DataKind_TEXT, Metadata: encMetadata},
+		{Id: credsID.String(), Name: "creds", Kind: goph.DataKind_CREDENTIALS, Metadata: encMetadata},
+	}
+
+	m := &repo.SecretsRepoMock{}
+	m.On("List", mock.Anything, gophtest.AccessToken).
+		Return(secrets, nil)
+	m.On("Get", mock.Anything, gophtest.AccessToken, credsID).
+		Return(
+			&goph.Secret{
+				Id:       credsID.String(),
+				Name:     "creds",
+				Kind:     goph.DataKind_CREDENTIALS,
+				Metadata: encMetadata,
+			},
+			encData,
+			nil,
+		)
+
+	sat := usecase.NewSecretsUseCase(key, m)
+	rv, err := sat.Fetch(context.Background(), gophtest.AccessToken, goph.DataKind_CREDENTIALS)
+
+	require.NoError(t, err)
+	require.Len(t, rv, 1)
+	require.Equal(t, gophtest.Metadata, string(rv[0].Secret.GetMetadata()))
+	require.Equal(t, gophtest.Username, rv[0].Data.(*goph.Credentials).GetLogin())
+	m.AssertExpectations(t)
+}
+
+func TestFetchSecretsOnRepoFailure(t *testing.T) {
+	m := &repo.SecretsRepoMock{}
+	m.On("List", mock.Anything, gophtest.AccessToken).
+		Return([]*goph.Secret(nil), gophtest.ErrUnexpected)
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	_, err := sat.Fetch(context.Background(), gophtest.AccessToken)
+
+	require.Error(t, err)
+}
+
+func doRelabel(
+	t *testing.T,
+	stored *goph.Labels,
+	relabel func(sat *usecase.SecretsUseCase, id uuid.UUID) error,
+) (*goph.Labels, []byte, error) {
+	t.Helper()
+
+	key := newTestKey()
+	id := uuid.NewV4()
+
+	rawLabels, err := proto.Marshal(stored)
+	require.NoError(t, err)
+
+	encLabels, err := key.Encrypt(rawLabels)
+	require.NoError(t, err)
+
+	secret := &goph.Secret{
+		Id:     id.String(),
+		Name:   gophtest.SecretName,
+		Kind:   goph.DataKind_TEXT,
+		Labels: encLabels,
+	}
+
+	rv := &goph.Labels{}
+
+	var folderDigest []byte
+
+	m := &repo.SecretsRepoMock{}
+	m.On("Get", mock.Anything, gophtest.AccessToken, id).
+		Return(secret, []byte{}, nil)
+	m.On(
+		"Update",
+		mock.Anything,
+		gophtest.AccessToken,
+		id,
+		"",
+		[]byte(nil),
+		false,
+		[]byte(nil),
+		mock.AnythingOfType("[]uint8"),
+		mock.Anything,
+	).
+		Run(func(args mock.Arguments) {
+			decrypted, err := key.Decrypt(args.Get(7).([]byte))
+			require.NoError(t, err)
+			require.NoError(t, proto.Unmarshal(decrypted, rv))
+
+			folderDigest, _ = args.Get(8).([]byte)
+		}).
+		Return(nil).
+		Maybe()
+
+	err = relabel(usecase.NewSecretsUseCase(key, m), id)
+
+	return rv, folderDigest, err
+}
+
+func TestRelabelSecret(t *testing.T) {
+	tt := []struct {
+		name     string
+		stored   *goph.Labels
+		relabel  func(sat *usecase.SecretsUseCase, id uuid.UUID) error
+		expected *goph.Labels
+	}{
+		{
+			name:   "Move secret to a folder",
+			stored: &goph.Labels{Tags: []string{"prod"}},
+			relabel: func(sat *usecase.SecretsUseCase, id uuid.UUID) error {
+				return sat.Move(context.Background(), gophtest.AccessToken, id, "work/aws/")
+			},
+			expected: &goph.Labels{Folder: "work/aws", Tags: []string{"prod"}},
+		},
+		{
+			name:   "Move secret to the root folder",
+			stored: &goph.Labels{Folder: "work/aws"},
+			relabel: func(sat *usecase.SecretsUseCase, id uuid.UUID) error {
+				return sat.Move(context.Background(), gophtest.AccessToken, id, "")
+			},
+			expected: &goph.Labels{},
+		},
+		{
+			name:   "Tag secret",
+			stored: &goph.Labels{Folder: "work", Tags: []string{"prod"}},
+			relabel: func(sat *usecase.SecretsUseCase, id uuid.UUID) error {
+				return sat.Tag(context.Background(), gophtest.AccessToken, id, "aws", "prod")
+			},
+			expected: &goph.Labels{Folder: "work", Tags: []string{"aws", "prod"}},
+		},
+		{
+			name:   "Untag secret",
+			stored: &goph.Labels{Tags: []string{"aws", "prod"}},
+			relabel: func(sat *usecase.SecretsUseCase, id uuid.UUID) error {
+				return sat.Untag(context.Background(), gophtest.AccessToken, id, "prod", "dev")
+			},
+			expected: &goph.Labels{Tags: []string{"aws"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			labels, folderDigest, err := doRelabel(t, tc.stored, tc.relabel)
+
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected, labels))
+
+			if tc.expected.GetFolder() == "" {
+				require.Empty(t, folderDigest)
+			} else {
+				require.Equal(t, newTestKey().Digest([]byte(tc.expected.GetFolder())), folderDigest)
+			}
+		})
+	}
+}
+
+func TestRelabelSecretFailure(t *testing.T) {
+	_, _, err := doRelabel(
+		t,
+		&goph.Labels{},
+		func(sat *usecase.SecretsUseCase, id uuid.UUID) error {
+			return sat.Tag(context.Background(), gophtest.AccessToken, id, "bad tag")
+		},
+	)
+
+	require.ErrorIs(t, err, entity.ErrBadTag)
+}
+
+func TestSearchSecrets(t *testing.T) {
+	key := newTestKey()
+	textID := uuid.NewV4()
+	credsID := uuid.NewV4()
+
+	encMetadata, err := key.Encrypt([]byte(gophtest.Metadata))
+	require.NoError(t, err)
+
+	rawText, err := proto.Marshal(&goph.Text{Text: gophtest.TextData})
+	require.NoError(t, err)
+
+	encText, err := key.Encrypt(rawText)
+	require.NoError(t, err)
+
+	rawCreds, err := proto.Marshal(&goph.Credentials{Login: gophtest.Username})
+	require.NoError(t, err)
+
+	encCreds, err := key.Encrypt(rawCreds)
+	require.NoError(t, err)
+
+	// NB (alkurbatov): Secrets are decrypted in place, thus each call gets a new copy.
+	textSecret := func() *goph.Secret {
+		return &goph.Secret{
+			Id:       textID.String(),
+			Name:     "notes",
+			Kind:     goph.DataKind_TEXT,
+			Metadata: encMetadata,
+		}
+	}
+	credsSecret := func() *goph.Secret {
+		return &goph.Secret{
+			Id:       credsID.String(),
+			Name:     "mail",
+			Kind:     goph.DataKind_CREDENTIALS,
+			Metadata: encMetadata,
+		}
+	}
+
+	tt := []struct {
+		name     string
+		query    entity.SearchQuery
+		expected []string
+	}{
+		{
+			name:     "Search by name",
+			query:    entity.SearchQuery{Text: "nts"},
+			expected: []string{"notes"},
+		},
+		{
+			name:     "Search by description",
+			query:    entity.SearchQuery{Text: "extra"},
+			expected: []string{"mail", "notes"},
+		},
+		{
+			name:     "Search by login",
+			query:    entity.SearchQuery{Text: gophtest.Username, Deep: true},
+			expected: []string{"mail"},
+		},
+		{
+			name:     "Search by kind",
+			query:    entity.SearchQuery{Kinds: []goph.DataKind{goph.DataKind_CREDENTIALS}},
+			expected: []string{"mail"},
+		},
+		{
+			name:     "Nothing found",
+			query:    entity.SearchQuery{Text: gophtest.Username},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &repo.SecretsRepoMock{}
+			m.On("List", mock.Anything, gophtest.AccessToken).
+				Return([]*goph.Secret{textSecret(), credsSecret()}, nil)
+			m.On("Get", mock.Anything, gophtest.AccessToken, textID).
+				Return(textSecret(), encText, nil).
+				Maybe()
+			m.On("Get", mock.Anything, gophtest.AccessToken, credsID).
+				Return(credsSecret(), encCreds, nil).
+				Maybe()
+
+			sat := usecase.NewSecretsUseCase(key, m)
+			rv, err := sat.Search(context.Background(), gophtest.AccessToken, tc.query)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(rv))
+			for _, result := range rv {
+				names = append(names, result.Secret.GetName())
+			}
+
+			require.Equal(t, tc.expected, names)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchSecretsOnRepoFailure(t *testing.T) {
+	m := &repo.SecretsRepoMock{}
+	m.On("List", mock.Anything, gophtest.AccessToken).
+		Return([]*goph.Secret(nil), gophtest.ErrUnexpected)
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	_, err := sat.Search(context.Background(), gophtest.AccessToken, entity.SearchQuery{})
+
+	require.Error(t, err)
+}
+
+func newEncryptedSecret(
+	t *testing.T,
+	key entity.Key,
+	id uuid.UUID,
+	name, folder string,
+) *goph.Secret {
+	t.Helper()
+
+	rawLabels, err := proto.Marshal(&goph.Labels{Folder: folder})
+	require.NoError(t, err)
+
+	encLabels, err := key.Encrypt(rawLabels)
+	require.NoError(t, err)
+
+	return &goph.Secret{Id: id.String(), Name: name, Kind: goph.DataKind_TEXT, Labels: encLabels}
+}
+
+func TestResolveSecret(t *testing.T) {
+	key := newTestKey()
+	workID := gophtest.CreateUUID(t, "1f6c1c1e-0d7e-4f3a-9b8e-3c1d2a4b5c6d")
+	homeID := gophtest.CreateUUID(t, "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d")
+
+	tt := []struct {
+		name     string
+		ref      string
+		expected uuid.UUID
+		err      error
+	}{
+		{
+			name:     "Resolve by full ID without listing",
+			ref:      workID.String(),
+			expected: workID,
+		},
+		{
+			name:     "Resolve by ID prefix",
+			ref:      "7a8b9",
+			expected: homeID,
+		},
+		{
+			name:     "Resolve by path falling back to listing",
+			ref:      "home/aws",
+			expected: homeID,
+		},
+		{
+			name: "Resolve ambiguous name",
+			ref:  "aws",
+			err:  entity.ErrAmbiguousSecret,
+		},
+		{
+			name: "Resolve unknown name",
+			ref:  "github",
+			err:  entity.ErrSecretNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &repo.SecretsRepoMock{}
+			m.On("GetByName", mock.Anything, gophtest.AccessToken, "aws", key.Digest([]byte("home"))).
+				Return(
+					(*goph.Secret)(nil),
+					[]byte(nil),
+					entity.NewRequestError(status.Error(codes.NotFound, "secret not found")),
+				).
+				Maybe()
+			m.On("List", mock.Anything, gophtest.AccessToken).
+				Return(
+					[]*goph.Secret{
+						newEncryptedSecret(t, key, workID, "aws", "work"),
+						newEncryptedSecret(t, key, homeID, "aws", "home"),
+					},
+					nil,
+				).
+				Maybe()
+
+			sat := usecase.NewSecretsUseCase(key, m)
+			id, err := sat.Resolve(context.Background(), gophtest.AccessToken, tc.ref)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestFindSecretByPath(t *testing.T) {
+	key := newTestKey()
+	id := uuid.NewV4()
+
+	rawData, err := proto.Marshal(&goph.Text{Text: gophtest.TextData})
+	require.NoError(t, err)
+
+	encData, err := key.Encrypt(rawData)
+	require.NoError(t, err)
+
+	m := &repo.SecretsRepoMock{}
+	m.On("GetByName", mock.Anything, gophtest.AccessToken, "aws", []byte(nil)).
+		Return(newEncryptedSecret(t, key, id, "aws", ""), encData, nil)
+
+	sat := usecase.NewSecretsUseCase(key, m)
+	secret, data, err := sat.Find(context.Background(), gophtest.AccessToken, "/aws")
+
+	require.NoError(t, err)
+	require.Equal(t, id.String(), secret.GetId())
+	require.Equal(t, gophtest.TextData, data.(*goph.Text).GetText())
+	m.AssertExpectations(t)
+}
+
+func TestFindSecretByName(t *testing.T) {
+	key := newTestKey()
+	id := uuid.NewV4()
+
+	rawData, err := proto.Marshal(&goph.Text{Text: gophtest.TextData})
+	require.NoError(t, err)
+
+	encData, err := key.Encrypt(rawData)
+	require.NoError(t, err)
+
+	m := &repo.SecretsRepoMock{}
+	m.On("List", mock.Anything, gophtest.AccessToken).
+		Return([]*goph.Secret{newEncryptedSecret(t, key, id, "aws", "work")}, nil)
+	m.On("Get", mock.Anything, gophtest.AccessToken, id).
+		Return(newEncryptedSecret(t, key, id, "aws", "work"), encData, nil)
+
+	sat := usecase.NewSecretsUseCase(key, m)
+	secret, data, err := sat.Find(context.Background(), gophtest.AccessToken, "aws")
+
+	require.NoError(t, err)
+	require.Equal(t, id.String(), secret.GetId())
+	require.Equal(t, gophtest.TextData, data.(*goph.Text).GetText())
+	m.AssertExpectations(t)
+}
+
+func TestFindSecretOnRepoFailure(t *testing.T) {
+	m := &repo.SecretsRepoMock{}
+	m.On("GetByName", mock.Anything, gophtest.AccessToken, "aws", mock.Anything).
+		Return((*goph.Secret)(nil), []byte(nil), gophtest.ErrUnexpected)
+
+	sat := usecase.NewSecretsUseCase(newTestKey(), m)
+	_, _, err := sat.Find(context.Background(), gophtest.AccessToken, "work/aws")
+
+	require.Error(t, err)
+	m.AssertExpectations(t)
+}
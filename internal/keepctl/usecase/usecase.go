@@ -10,63 +10,63 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+type Attachments interface {
+	Attach(
+		ctx context.Context,
+		token string,
+		secretID uuid.UUID,
+		filename, mimeType string,
+		content []byte,
+	) (uuid.UUID, error)
+
+	List(ctx context.Context, token string, secretID uuid.UUID) ([]entity.Attachment, error)
+	Get(ctx context.Context, token string, id uuid.UUID) (*entity.Attachment, error)
+	Detach(ctx context.Context, token string, id uuid.UUID) error
+}
+
 type Auth interface {
 	Login(ctx context.Context, username string, key entity.Key) (string, error)
 }
 
-type Secrets interface { //nolint:interfacebloat //no plans to split it right now
-	PushBinary(ctx context.Context, token, name, description string, binary []byte) (uuid.UUID, error)
+// BatchStored is called by PushBatch for every stored chunk of secrets
+// with IDs of the secrets, e.g. to upload files attached to them.
+type BatchStored func(secrets []entity.NewSecret, ids []uuid.UUID) error
 
-	PushCard(
+type Secrets interface {
+	Push(
 		ctx context.Context,
 		token, name, description string,
-		number, expiration, holder string,
-		cvv int32,
+		labels *goph.Labels,
+		data proto.Message,
 	) (uuid.UUID, error)
 
-	PushCreds(ctx context.Context, token, name, description, login, password string) (uuid.UUID, error)
-	PushText(ctx context.Context, token, name, description, text string) (uuid.UUID, error)
-
-	List(ctx context.Context, token string) ([]*goph.Secret, error)
-	Get(ctx context.Context, token string, id uuid.UUID) (*goph.Secret, proto.Message, error)
-
-	EditBinary(
+	PushBatch(
 		ctx context.Context,
 		token string,
-		id uuid.UUID,
-		name, description string,
-		noDescription bool,
-		binary []byte,
-	) error
+		secrets []entity.NewSecret,
+		stored BatchStored,
+	) ([]uuid.UUID, error)
 
-	EditCard(
-		ctx context.Context,
-		token string,
-		id uuid.UUID,
-		name, description string,
-		noDescription bool,
-		number, expiration, holder string,
-		cvv int32,
-	) error
-
-	EditCreds(
-		ctx context.Context,
-		token string,
-		id uuid.UUID,
-		name, description string,
-		noDescription bool,
-		login, password string,
-	) error
+	List(ctx context.Context, token string) ([]*goph.Secret, error)
+	Get(ctx context.Context, token string, id uuid.UUID) (*goph.Secret, proto.Message, error)
+	Find(ctx context.Context, token, ref string) (*goph.Secret, proto.Message, error)
+	Resolve(ctx context.Context, token, ref string) (uuid.UUID, error)
+	Fetch(ctx context.Context, token string, kinds ...goph.DataKind) ([]entity.SecretData, error)
+	Search(ctx context.Context, token string, query entity.SearchQuery) ([]entity.SearchResult, error)
 
-	EditText(
+	Edit(
 		ctx context.Context,
 		token string,
 		id uuid.UUID,
 		name, description string,
 		noDescription bool,
-		text string,
+		kind goph.DataKind,
+		changes []entity.Change,
 	) error
 
+	Move(ctx context.Context, token string, id uuid.UUID, folder string) error
+	Tag(ctx context.Context, token string, id uuid.UUID, tags ...string) error
+	Untag(ctx context.Context, token string, id uuid.UUID, tags ...string) error
 	Delete(ctx context.Context, token string, id uuid.UUID) error
 }
 
@@ -76,16 +76,18 @@ type Users interface {
 
 // UseCases is a collection of business logic use cases.
 type UseCases struct {
-	Auth    Auth
-	Secrets Secrets
-	Users   Users
+	Attachments Attachments
+	Auth        Auth
+	Secrets     Secrets
+	Users       Users
 }
 
 // New creates and initializes collection of business logic use cases.
 func New(key entity.Key, repos *repo.Repositories) *UseCases {
 	return &UseCases{
-		Auth:    NewAuthUseCase(repos.Auth),
-		Secrets: NewSecretsUseCase(key, repos.Secrets),
-		Users:   NewUsersUseCase(repos.Users),
+		Attachments: NewAttachmentsUseCase(key, repos.Attachments),
+		Auth:        NewAuthUseCase(repos.Auth),
+		Secrets:     NewSecretsUseCase(key, repos.Secrets),
+		Users:       NewUsersUseCase(repos.Users),
 	}
 }
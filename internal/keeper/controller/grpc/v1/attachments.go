@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keeper/usecase"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AttachmentsServer provides implementation of the Attachments API.
+type AttachmentsServer struct {
+	goph.UnimplementedAttachmentsServer
+
+	attachmentsUseCase usecase.Attachments
+}
+
+// NewAttachmentsServer initializes and creates new AttachmentsServer.
+func NewAttachmentsServer(attachments usecase.Attachments) *AttachmentsServer {
+	return &AttachmentsServer{attachmentsUseCase: attachments}
+}
+
+// Create attaches new file to a secret of the user.
+func (s AttachmentsServer) Create(
+	ctx context.Context,
+	req *goph.CreateAttachmentRequest,
+) (*goph.CreateAttachmentResponse, error) {
+	owner := entity.UserFromContext(ctx)
+	if owner == nil {
+		return nil, status.Errorf(codes.Unauthenticated, entity.ErrInvalidCredentials.Error())
+	}
+
+	secretID, details := validateCreateAttachmentReq(req)
+	if details != nil {
+		st := composeBadRequestError(details)
+
+		return nil, st.Err()
+	}
+
+	id, err := s.attachmentsUseCase.Create(
+		ctx,
+		owner.ID,
+		secretID,
+		req.GetMetadata(),
+		req.GetData(),
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrSecretNotFound) {
+			return nil, status.Errorf(codes.NotFound, entity.ErrSecretNotFound.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &goph.CreateAttachmentResponse{Id: id.String()}, nil
+}
+
+// List retrieves list of the files attached to a secret.
+func (s AttachmentsServer) List(
+	ctx context.Context,
+	req *goph.ListAttachmentsRequest,
+) (*goph.ListAttachmentsResponse, error) {
+	owner := entity.UserFromContext(ctx)
+	if owner == nil {
+		return nil, status.Errorf(codes.Unauthenticated, entity.ErrInvalidCredentials.Error())
+	}
+
+	secretID, err := uuid.FromString(req.GetSecretId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	data, err := s.attachmentsUseCase.List(ctx, owner.ID, secretID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rv := make([]*goph.Attachment, 0, len(data))
+	for _, val := range data {
+		rv = append(rv, &goph.Attachment{
+			Id:       val.ID.String(),
+			SecretId: val.SecretID.String(),
+			Metadata: val.Metadata,
+		})
+	}
+
+	return &goph.ListAttachmentsResponse{Attachments: rv}, nil
+}
+
+// Get returns particular attachment with data.
+func (s AttachmentsServer) Get(
+	ctx context.Context,
+	req *goph.GetAttachmentRequest,
+) (*goph.GetAttachmentResponse, error) {
+	owner := entity.UserFromContext(ctx)
+	if owner == nil {
+		return nil, status.Errorf(codes.Unauthenticated, entity.ErrInvalidCredentials.Error())
+	}
+
+	id, err := uuid.FromString(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	attachment, err := s.attachmentsUseCase.Get(ctx, owner.ID, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrAttachmentNotFound) {
+			return nil, status.Errorf(codes.NotFound, entity.ErrAttachmentNotFound.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &goph.GetAttachmentResponse{
+		Attachment: &goph.Attachment{
+			Id:       attachment.ID.String(),
+			SecretId: attachment.SecretID.String(),
+			Metadata: attachment.Metadata,
+		},
+		Data: attachment.Data,
+	}, nil
+}
+
+// Delete removes particular attachment of the user.
+func (s AttachmentsServer) Delete(
+	ctx context.Context,
+	req *goph.DeleteAttachmentRequest,
+) (*goph.DeleteAttachmentResponse, error) {
+	owner := entity.UserFromContext(ctx)
+	if owner == nil {
+		return nil, status.Errorf(codes.Unauthenticated, entity.ErrInvalidCredentials.Error())
+	}
+
+	id, err := uuid.FromString(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.attachmentsUseCase.Delete(ctx, owner.ID, id); err != nil {
+		if errors.Is(err, entity.ErrAttachmentNotFound) {
+			return nil, status.Errorf(codes.NotFound, entity.ErrAttachmentNotFound.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &goph.DeleteAttachmentResponse{}, nil
+}
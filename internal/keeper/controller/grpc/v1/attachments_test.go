@@ -0,0 +1,320 @@
+package v1_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "github.com/alkurbatov/goph-keeper/internal/keeper/controller/grpc/v1"
+	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keeper/usecase"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/gophtest"
+	"github.com/alkurbatov/goph-keeper/pkg/goph"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func doCreateAttachment(
+	t *testing.T,
+	mockRV uuid.UUID,
+	mockErr error,
+) (*goph.CreateAttachmentResponse, error) {
+	t.Helper()
+
+	secretID := uuid.NewV4()
+
+	m := newUseCasesMock()
+	m.Attachments.(*usecase.AttachmentsUseCaseMock).On(
+		"Create",
+		mock.Anything,
+		mock.AnythingOfType("uuid.UUID"),
+		secretID,
+		[]byte(gophtest.Metadata),
+		[]byte(gophtest.TextData),
+	).
+		Return(mockRV, mockErr)
+
+	conn := createTestServerWithFakeAuth(t, m)
+	req := &goph.CreateAttachmentRequest{
+		SecretId: secretID.String(),
+		Metadata: []byte(gophtest.Metadata),
+		Data:     []byte(gophtest.TextData),
+	}
+
+	client := goph.NewAttachmentsClient(conn)
+	rv, err := client.Create(context.Background(), req)
+
+	m.Attachments.(*usecase.AttachmentsUseCaseMock).AssertExpectations(t)
+
+	return rv, err
+}
+
+func doGetAttachment(
+	t *testing.T,
+	mockRV *entity.Attachment,
+	mockErr error,
+) (*goph.GetAttachmentResponse, error) {
+	t.Helper()
+
+	m := newUseCasesMock()
+	m.Attachments.(*usecase.AttachmentsUseCaseMock).On(
+		"Get",
+		mock.Anything,
+		mock.AnythingOfType("uuid.UUID"),
+		mock.AnythingOfType("uuid.UUID"),
+	).
+		Return(mockRV, mockErr)
+
+	conn := createTestServerWithFakeAuth(t, m)
+	req := &goph.GetAttachmentRequest{Id: uuid.NewV4().String()}
+
+	client := goph.NewAttachmentsClient(conn)
+	rv, err := client.Get(context.Background(), req)
+
+	m.Attachments.(*usecase.AttachmentsUseCaseMock).AssertExpectations(t)
+
+	return rv, err
+}
+
+func doDeleteAttachment(t *testing.T, mockErr error) error {
+	t.Helper()
+
+	m := newUseCasesMock()
+	m.Attachments.(*usecase.AttachmentsUseCaseMock).On(
+		"Delete",
+		mock.Anything,
+		mock.AnythingOfType("uuid.UUID"),
+		mock.AnythingOfType("uuid.UUID"),
+	).
+		Return(mockErr)
+
+	conn := createTestServerWithFakeAuth(t, m)
+	req := &goph.DeleteAttachmentRequest{Id: uuid.NewV4().String()}
+
+	client := goph.NewAttachmentsClient(conn)
+	_, err := client.Delete(context.Background(), req)
+
+	m.Attachments.(*usecase.AttachmentsUseCaseMock).AssertExpectations(t)
+
+	return err
+}
+
+func TestCreateAttachment(t *testing.T) {
+	expected := uuid.NewV4()
+
+	resp, err := doCreateAttachment(t, expected, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), resp.GetId())
+}
+
+func TestCreateAttachmentWithBadRequest(t *testing.T) {
+	tt := []struct {
+		name     string
+		secretID string
+		metadata []byte
+		data     []byte
+	}{
+		{
+			name:     "Create attachment fails if secret ID is invalid",
+			secretID: "xxx",
+			metadata: []byte(gophtest.Metadata),
+			data:     []byte(gophtest.TextData),
+		},
+		{
+			name:     "Create attachment fails if metadata is too long",
+			secretID: uuid.NewV4().String(),
+			metadata: []byte(strings.Repeat("#", v1.DefaultMetadataLimit+1)),
+			data:     []byte(gophtest.TextData),
+		},
+		{
+			name:     "Create attachment fails if data is empty",
+			secretID: uuid.NewV4().String(),
+			metadata: []byte(gophtest.Metadata),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := createTestServerWithFakeAuth(t, newUseCasesMock())
+
+			req := &goph.CreateAttachmentRequest{
+				SecretId: tc.secretID,
+				Metadata: tc.metadata,
+				Data:     tc.data,
+			}
+
+			client := goph.NewAttachmentsClient(conn)
+			_, err := client.Create(context.Background(), req)
+
+			requireEqualCode(t, codes.InvalidArgument, err)
+		})
+	}
+}
+
+func TestCreateAttachmentOnUseCaseFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{
+			name:     "Create attachment fails if secret not found",
+			err:      entity.ErrSecretNotFound,
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Create attachment fails if use case fails unexpectedly",
+			err:      gophtest.ErrUnexpected,
+			expected: codes.Internal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := doCreateAttachment(t, uuid.UUID{}, tc.err)
+
+			requireEqualCode(t, tc.expected, err)
+		})
+	}
+}
+
+func TestListAttachments(t *testing.T) {
+	secretID := uuid.NewV4()
+	attachments := []entity.Attachment{
+		{ID: uuid.NewV4(), SecretID: secretID, Metadata: []byte(gophtest.Metadata)},
+	}
+
+	m := newUseCasesMock()
+	m.Attachments.(*usecase.AttachmentsUseCaseMock).On(
+		"List",
+		mock.Anything,
+		mock.AnythingOfType("uuid.UUID"),
+		secretID,
+	).
+		Return(attachments, nil)
+
+	conn := createTestServerWithFakeAuth(t, m)
+
+	client := goph.NewAttachmentsClient(conn)
+	resp, err := client.List(
+		context.Background(),
+		&goph.ListAttachmentsRequest{SecretId: secretID.String()},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetAttachments(), 1)
+	require.Equal(t, attachments[0].ID.String(), resp.GetAttachments()[0].GetId())
+	require.Equal(t, secretID.String(), resp.GetAttachments()[0].GetSecretId())
+	require.Equal(t, attachments[0].Metadata, resp.GetAttachments()[0].GetMetadata())
+	m.Attachments.(*usecase.AttachmentsUseCaseMock).AssertExpectations(t)
+}
+
+func TestListAttachmentsOnBadRequest(t *testing.T) {
+	conn := createTestServerWithFakeAuth(t, newUseCasesMock())
+
+	client := goph.NewAttachmentsClient(conn)
+	_, err := client.List(context.Background(), &goph.ListAttachmentsRequest{SecretId: "xxx"})
+
+	requireEqualCode(t, codes.InvalidArgument, err)
+}
+
+func TestAttachmentsFailIfNoUserInfo(t *testing.T) {
+	conn := createTestServer(t, newUseCasesMock())
+	client := goph.NewAttachmentsClient(conn)
+
+	_, err := client.Create(context.Background(), &goph.CreateAttachmentRequest{})
+	requireEqualCode(t, codes.Unauthenticated, err)
+
+	_, err = client.List(context.Background(), &goph.ListAttachmentsRequest{})
+	requireEqualCode(t, codes.Unauthenticated, err)
+
+	_, err = client.Get(context.Background(), &goph.GetAttachmentRequest{})
+	requireEqualCode(t, codes.Unauthenticated, err)
+
+	_, err = client.Delete(context.Background(), &goph.DeleteAttachmentRequest{})
+	requireEqualCode(t, codes.Unauthenticated, err)
+}
+
+func TestGetAttachment(t *testing.T) {
+	attachment := &entity.Attachment{
+		ID:       uuid.NewV4(),
+		SecretID: uuid.NewV4(),
+		Metadata: []byte(gophtest.Metadata),
+		Data:     []byte(gophtest.TextData),
+	}
+
+	resp, err := doGetAttachment(t, attachment, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, attachment.ID.String(), resp.GetAttachment().GetId())
+	require.Equal(t, attachment.SecretID.String(), resp.GetAttachment().GetSecretId())
+	require.Equal(t, attachment.Metadata, resp.GetAttachment().GetMetadata())
+	require.Equal(t, attachment.Data, resp.GetData())
+}
+
+func TestGetAttachmentOnUsecaseFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		ucErr    error
+		expected codes.Code
+	}{
+		{
+			name:     "Get attachment fails if attachment not found",
+			ucErr:    entity.ErrAttachmentNotFound,
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Get attachment fails on unexpected error",
+			ucErr:    gophtest.ErrUnexpected,
+			expected: codes.Internal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := doGetAttachment(t, nil, tc.ucErr)
+
+			requireEqualCode(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	tt := []struct {
+		name     string
+		ucErr    error
+		expected codes.Code
+	}{
+		{
+			name:     "Delete attachment",
+			expected: codes.OK,
+		},
+		{
+			name:     "Delete attachment fails if attachment not found",
+			ucErr:    entity.ErrAttachmentNotFound,
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Delete attachment fails on unexpected error",
+			ucErr:    gophtest.ErrUnexpected,
+			expected: codes.Internal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := doDeleteAttachment(t, tc.ucErr)
+
+			if tc.expected == codes.OK {
+				require.NoError(t, err)
+
+				return
+			}
+
+			requireEqualCode(t, tc.expected, err)
+		})
+	}
+}
@@ -30,9 +30,10 @@ func requireEqualCode(t *testing.T, expected codes.Code, err error) {
 
 func newUseCasesMock() usecase.UseCases {
 	return usecase.UseCases{
-		Auth:    &usecase.AuthUseCaseMock{},
-		Secrets: &usecase.SecretsUseCaseMock{},
-		Users:   &usecase.UsersUseCaseMock{},
+		Attachments: &usecase.AttachmentsUseCaseMock{},
+		Auth:        &usecase.AuthUseCaseMock{},
+		Secrets:     &usecase.SecretsUseCaseMock{},
+		Users:       &usecase.UsersUseCaseMock{},
 	}
 }
 
@@ -8,10 +8,14 @@ import (
 )
 
 // DefaultMaxMessageSize suggests limit for maximum length of gRPC message.
-const DefaultMaxMessageSize = DefaultDataLimit + DefaultMetadataLimit + 2*DefaultMaxSecretNameLength
+const DefaultMaxMessageSize = DefaultDataLimit + DefaultMetadataLimit + DefaultLabelsLimit +
+	DefaultMaxFolderDigestLength + 2*DefaultMaxSecretNameLength
 
 // RegisterRoutes injects new routes into the provided gRPC server.
 func RegisterRoutes(server *grpc.Server, useCases *usecase.UseCases) {
+	attachments := NewAttachmentsServer(useCases.Attachments)
+	goph.RegisterAttachmentsServer(server, attachments)
+
 	auth := NewAuthServer(useCases.Auth)
 	goph.RegisterAuthServer(server, auth)
 
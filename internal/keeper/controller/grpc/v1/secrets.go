@@ -10,6 +10,7 @@ import (
 	uuid "github.com/satori/go.uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // SecretsServer provides implementation of the Secrets API.
@@ -47,6 +48,8 @@ func (s SecretsServer) Create(
 		req.GetKind(),
 		req.GetMetadata(),
 		req.GetData(),
+		req.GetLabels(),
+		req.GetFolderDigest(),
 	)
 	if err != nil {
 		if errors.Is(err, entity.ErrSecretExists) {
@@ -59,6 +62,57 @@ func (s SecretsServer) Create(
 	return &goph.CreateSecretResponse{Id: id.String()}, nil
 }
 
+// CreateBatch creates several secrets for a user at once.
+// Either all secrets are created or none of them.
+func (s SecretsServer) CreateBatch(
+	ctx context.Context,
+	req *goph.CreateSecretsRequest,
+) (*goph.CreateSecretsResponse, error) {
+	owner := entity.UserFromContext(ctx)
+	if owner == nil {
+		return nil, status.Errorf(codes.Unauthenticated, entity.ErrInvalidCredentials.Error())
+	}
+
+	if details, ok := validateCreateSecretsReq(req); !ok {
+		st := composeBadRequestError(details)
+
+		return nil, st.Err()
+	}
+
+	secrets := make([]entity.Secret, 0, len(req.GetSecrets()))
+	for _, secret := range req.GetSecrets() {
+		secrets = append(secrets, entity.Secret{
+			Name:         secret.GetName(),
+			Kind:         secret.GetKind(),
+			Metadata:     secret.GetMetadata(),
+			Data:         secret.GetData(),
+			Labels:       secret.GetLabels(),
+			FolderDigest: secret.GetFolderDigest(),
+			Replaces:     uuid.FromStringOrNil(secret.GetReplaces()),
+		})
+	}
+
+	ids, err := s.secretsUseCase.CreateBatch(ctx, owner.ID, secrets)
+	if err != nil {
+		if errors.Is(err, entity.ErrSecretExists) {
+			return nil, status.Errorf(codes.AlreadyExists, entity.ErrSecretExists.Error())
+		}
+
+		if errors.Is(err, entity.ErrSecretNotFound) {
+			return nil, status.Errorf(codes.NotFound, entity.ErrSecretNotFound.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rv := make([]string, 0, len(ids))
+	for _, id := range ids {
+		rv = append(rv, id.String())
+	}
+
+	return &goph.CreateSecretsResponse{Ids: rv}, nil
+}
+
 // List retrieves list of the secrets stored a user.
 func (s SecretsServer) List(
 	ctx context.Context,
@@ -77,10 +131,13 @@ func (s SecretsServer) List(
 	rv := make([]*goph.Secret, 0, len(data))
 	for _, val := range data {
 		rv = append(rv, &goph.Secret{
-			Id:       val.ID.String(),
-			Name:     val.Name,
-			Kind:     val.Kind,
-			Metadata: val.Metadata,
+			Id:        val.ID.String(),
+			Name:      val.Name,
+			Kind:      val.Kind,
+			Metadata:  val.Metadata,
+			Labels:    val.Labels,
+			CreatedAt: timestamppb.New(val.CreatedAt),
+			UpdatedAt: timestamppb.New(val.UpdatedAt),
 		})
 	}
 
@@ -111,15 +168,51 @@ func (s SecretsServer) Get(
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
+	return newGetSecretResponse(secret), nil
+}
+
+// GetByName returns particular secret with data by name of the secret within a folder.
+func (s SecretsServer) GetByName(
+	ctx context.Context,
+	req *goph.GetSecretByNameRequest,
+) (*goph.GetSecretResponse, error) {
+	owner := entity.UserFromContext(ctx)
+	if owner == nil {
+		return nil, status.Errorf(codes.Unauthenticated, entity.ErrInvalidCredentials.Error())
+	}
+
+	if details, ok := validateGetSecretByNameReq(req); !ok {
+		st := composeBadRequestError(details)
+
+		return nil, st.Err()
+	}
+
+	secret, err := s.secretsUseCase.GetByName(ctx, owner.ID, req.GetName(), req.GetFolderDigest())
+	if err != nil {
+		if errors.Is(err, entity.ErrSecretNotFound) {
+			return nil, status.Errorf(codes.NotFound, entity.ErrSecretNotFound.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return newGetSecretResponse(secret), nil
+}
+
+// newGetSecretResponse converts the secret into response with data.
+func newGetSecretResponse(secret *entity.Secret) *goph.GetSecretResponse {
 	return &goph.GetSecretResponse{
 		Secret: &goph.Secret{
-			Id:       secret.ID.String(),
-			Name:     secret.Name,
-			Kind:     secret.Kind,
-			Metadata: secret.Metadata,
+			Id:        secret.ID.String(),
+			Name:      secret.Name,
+			Kind:      secret.Kind,
+			Metadata:  secret.Metadata,
+			Labels:    secret.Labels,
+			CreatedAt: timestamppb.New(secret.CreatedAt),
+			UpdatedAt: timestamppb.New(secret.UpdatedAt),
 		},
 		Data: secret.Data,
-	}, nil
+	}
 }
 
 // Update updates particular secret stored by a user.
@@ -151,6 +244,8 @@ func (s SecretsServer) Update(
 		req.GetName(),
 		req.GetMetadata(),
 		req.GetData(),
+		req.GetLabels(),
+		req.GetFolderDigest(),
 	); err != nil {
 		if errors.Is(err, entity.ErrSecretNotFound) {
 			return nil, status.Errorf(codes.NotFound, entity.ErrSecretNotFound.Error())
@@ -2,8 +2,10 @@ package v1_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	v1 "github.com/alkurbatov/goph-keeper/internal/keeper/controller/grpc/v1"
 	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
@@ -103,24 +105,28 @@ func TestCreateSecret(t *testing.T) {
 		secretName string
 		metadata   []byte
 		data       []byte
+		labels     []byte
 	}{
 		{
 			name:       "Create secret",
 			secretName: gophtest.SecretName,
 			metadata:   []byte(gophtest.Metadata),
 			data:       []byte(gophtest.TextData),
+			labels:     []byte(gophtest.Labels),
 		},
 		{
 			name:       "Create secret without metadata",
 			secretName: gophtest.Username,
 			metadata:   nil,
 			data:       []byte(gophtest.TextData),
+			labels:     []byte(gophtest.Labels),
 		},
 		{
 			name:       "Create secret of maximum size",
 			secretName: strings.Repeat("#", v1.DefaultMaxSecretNameLength),
 			metadata:   []byte(strings.Repeat("#", v1.DefaultMetadataLimit)),
 			data:       []byte(strings.Repeat("#", v1.DefaultDataLimit)),
+			labels:     []byte(strings.Repeat("#", v1.DefaultLabelsLimit)),
 		},
 	}
 
@@ -137,16 +143,20 @@ func TestCreateSecret(t *testing.T) {
 				goph.DataKind_BINARY,
 				tc.metadata,
 				tc.data,
+				tc.labels,
+				[]byte(gophtest.FolderDigest),
 			).
 				Return(expected, nil)
 
 			conn := createTestServerWithFakeAuth(t, m)
 
 			req := &goph.CreateSecretRequest{
-				Name:     tc.secretName,
-				Kind:     goph.DataKind_BINARY,
-				Metadata: tc.metadata,
-				Data:     tc.data,
+				Name:         tc.secretName,
+				Kind:         goph.DataKind_BINARY,
+				Metadata:     tc.metadata,
+				Data:         tc.data,
+				Labels:       tc.labels,
+				FolderDigest: []byte(gophtest.FolderDigest),
 			}
 
 			client := goph.NewSecretsClient(conn)
@@ -161,10 +171,13 @@ func TestCreateSecret(t *testing.T) {
 
 func TestCreateSecretWithBadRequest(t *testing.T) {
 	tt := []struct {
-		name       string
-		secretName string
-		metadata   []byte
-		data       []byte
+		name         string
+		secretName   string
+		kind         goph.DataKind
+		metadata     []byte
+		data         []byte
+		labels       []byte
+		folderDigest []byte
 	}{
 		{
 			name:       "Create secret fails if secret name is empty",
@@ -196,6 +209,27 @@ func TestCreateSecretWithBadRequest(t *testing.T) {
 			metadata:   []byte(gophtest.Metadata),
 			data:       []byte(strings.Repeat("#", v1.DefaultDataLimit+1)),
 		},
+		{
+			name:       "Create secret fails if kind is unknown",
+			secretName: gophtest.Username,
+			kind:       goph.DataKind(100),
+			metadata:   []byte(gophtest.Metadata),
+			data:       []byte(gophtest.TextData),
+		},
+		{
+			name:       "Create secret fails if labels are too long",
+			secretName: gophtest.Username,
+			metadata:   []byte(gophtest.Metadata),
+			data:       []byte(gophtest.TextData),
+			labels:     []byte(strings.Repeat("#", v1.DefaultLabelsLimit+1)),
+		},
+		{
+			name:         "Create secret fails if folder digest is too long",
+			secretName:   gophtest.Username,
+			metadata:     []byte(gophtest.Metadata),
+			data:         []byte(gophtest.TextData),
+			folderDigest: []byte(strings.Repeat("#", v1.DefaultMaxFolderDigestLength+1)),
+		},
 	}
 
 	for _, tc := range tt {
@@ -203,10 +237,12 @@ func TestCreateSecretWithBadRequest(t *testing.T) {
 			conn := createTestServerWithFakeAuth(t, newUseCasesMock())
 
 			req := &goph.CreateSecretRequest{
-				Name:     tc.secretName,
-				Kind:     goph.DataKind_BINARY,
-				Metadata: tc.metadata,
-				Data:     tc.data,
+				Name:         tc.secretName,
+				Kind:         tc.kind,
+				Metadata:     tc.metadata,
+				Data:         tc.data,
+				Labels:       tc.labels,
+				FolderDigest: tc.folderDigest,
 			}
 
 			client := goph.NewSecretsClient(conn)
@@ -255,6 +291,8 @@ func TestCreateServerOnUseCaseFailure(t *testing.T) {
 				goph.DataKind_BINARY,
 				[]byte(gophtest.Metadata),
 				[]byte(gophtest.TextData),
+				[]byte(nil),
+				[]byte(nil),
 			).
 				Return(uuid.UUID{}, tc.err)
 
@@ -276,6 +314,145 @@ func TestCreateServerOnUseCaseFailure(t *testing.T) {
 	}
 }
 
+func newCreateSecretsRequest(size int) *goph.CreateSecretsRequest {
+	req := &goph.CreateSecretsRequest{}
+
+	for i := 0; i < size; i++ {
+		req.Secrets = append(req.Secrets, &goph.CreateSecretRequest{
+			Name:     fmt.Sprintf("%s-%d", gophtest.SecretName, i),
+			Kind:     goph.DataKind_TEXT,
+			Metadata: []byte(gophtest.Metadata),
+			Data:     []byte(gophtest.TextData),
+		})
+	}
+
+	return req
+}
+
+func TestCreateSecretsBatch(t *testing.T) {
+	expected := []uuid.UUID{uuid.NewV4(), uuid.NewV4()}
+
+	m := newUseCasesMock()
+	m.Secrets.(*usecase.SecretsUseCaseMock).On(
+		"CreateBatch",
+		mock.Anything,
+		mock.AnythingOfType("uuid.UUID"),
+		mock.MatchedBy(func(secrets []entity.Secret) bool {
+			return len(secrets) == 2 && secrets[1].Name == gophtest.SecretName+"-1"
+		}),
+	).
+		Return(expected, nil)
+
+	conn := createTestServerWithFakeAuth(t, m)
+
+	client := goph.NewSecretsClient(conn)
+	resp, err := client.CreateBatch(context.Background(), newCreateSecretsRequest(2))
+
+	require.NoError(t, err)
+	require.Equal(t, []string{expected[0].String(), expected[1].String()}, resp.GetIds())
+	m.Secrets.(*usecase.SecretsUseCaseMock).AssertExpectations(t)
+}
+
+func TestCreateSecretsBatchWithBadRequest(t *testing.T) {
+	invalid := newCreateSecretsRequest(1)
+	invalid.Secrets[0].Data = nil
+
+	badReplaces := newCreateSecretsRequest(1)
+	badReplaces.Secrets[0].Replaces = "xxx"
+
+	tt := []struct {
+		name string
+		req  *goph.CreateSecretsRequest
+	}{
+		{
+			name: "Create batch fails if batch is empty",
+			req:  newCreateSecretsRequest(0),
+		},
+		{
+			name: "Create batch fails if batch is too large",
+			req:  newCreateSecretsRequest(v1.DefaultMaxBatchSize + 1),
+		},
+		{
+			name: "Create batch fails if a secret is invalid",
+			req:  invalid,
+		},
+		{
+			name: "Create batch fails if ID of replaced secret is invalid",
+			req:  badReplaces,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := createTestServerWithFakeAuth(t, newUseCasesMock())
+
+			client := goph.NewSecretsClient(conn)
+			_, err := client.CreateBatch(context.Background(), tc.req)
+
+			requireEqualCode(t, codes.InvalidArgument, err)
+		})
+	}
+}
+
+func TestCreateSecretsBatchFailsIfNoUserInfo(t *testing.T) {
+	conn := createTestServer(t, newUseCasesMock())
+
+	client := goph.NewSecretsClient(conn)
+	_, err := client.CreateBatch(context.Background(), newCreateSecretsRequest(1))
+
+	requireEqualCode(t, codes.Unauthenticated, err)
+}
+
+func TestCreateSecretsBatchOnUseCaseFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{
+			name:     "Create batch fails if a secret already exists",
+			err:      entity.ErrSecretExists,
+			expected: codes.AlreadyExists,
+		},
+		{
+			name:     "Create batch fails if replaced secret not found",
+			err:      entity.ErrSecretNotFound,
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Create batch fails if use case fails unexpectedly",
+			err:      gophtest.ErrUnexpected,
+			expected: codes.Internal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newUseCasesMock()
+			m.Secrets.(*usecase.SecretsUseCaseMock).On(
+				"CreateBatch",
+				mock.Anything,
+				mock.AnythingOfType("uuid.UUID"),
+				mock.AnythingOfType("[]entity.Secret"),
+			).
+				Return([]uuid.UUID(nil), tc.err)
+
+			conn := createTestServerWithFakeAuth(t, m)
+
+			client := goph.NewSecretsClient(conn)
+			_, err := client.CreateBatch(context.Background(), newCreateSecretsRequest(1))
+
+			requireEqualCode(t, tc.expected, err)
+			m.Secrets.(*usecase.SecretsUseCaseMock).AssertExpectations(t)
+		})
+	}
+}
+
+var (
+	_createdAt = time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC)
+	_updatedAt = time.Date(2023, time.April, 1, 10, 0, 0, 0, time.UTC)
+)
+
 func TestListSecrets(t *testing.T) {
 	tt := []struct {
 		name    string
@@ -285,9 +462,11 @@ func TestListSecrets(t *testing.T) {
 			name: "List secrets of a user",
 			secrets: []entity.Secret{
 				{
-					ID:   gophtest.CreateUUID(t, "7728154c-9400-4f1b-a2a3-01deb83ece05"),
-					Name: gophtest.SecretName,
-					Kind: goph.DataKind_BINARY,
+					ID:        gophtest.CreateUUID(t, "7728154c-9400-4f1b-a2a3-01deb83ece05"),
+					Name:      gophtest.SecretName,
+					Kind:      goph.DataKind_BINARY,
+					CreatedAt: _createdAt,
+					UpdatedAt: _updatedAt,
 				},
 				{
 					ID:       gophtest.CreateUUID(t, "df566e25-43a5-4c34-9123-3931fb809b45"),
@@ -336,11 +515,13 @@ func TestGetSecret(t *testing.T) {
 		{
 			name: "Get secret",
 			secret: &entity.Secret{
-				ID:       gophtest.CreateUUID(t, "df566e25-43a5-4c34-9123-3931fb809b45"),
-				Name:     gophtest.SecretName,
-				Kind:     goph.DataKind_TEXT,
-				Metadata: []byte(gophtest.Metadata),
-				Data:     []byte(gophtest.TextData),
+				ID:        gophtest.CreateUUID(t, "df566e25-43a5-4c34-9123-3931fb809b45"),
+				Name:      gophtest.SecretName,
+				Kind:      goph.DataKind_TEXT,
+				Metadata:  []byte(gophtest.Metadata),
+				Data:      []byte(gophtest.TextData),
+				CreatedAt: _createdAt,
+				UpdatedAt: _updatedAt,
 			},
 		},
 		{
@@ -456,6 +637,14 @@ func TestUpdateSecret(t *testing.T) {
 			},
 			changed: []string{"data"},
 		},
+		{
+			name: "Update secret's labels",
+			req: &goph.UpdateSecretRequest{
+				Labels:       []byte(gophtest.Labels),
+				FolderDigest: []byte(gophtest.FolderDigest),
+			},
+			changed: []string{"labels"},
+		},
 		{
 			name: "Update secret with maximum fields limits",
 			req: &goph.UpdateSecretRequest{
@@ -487,6 +676,8 @@ func TestUpdateSecret(t *testing.T) {
 				tc.req.Name,
 				tc.req.Metadata,
 				tc.req.Data,
+				tc.req.Labels,
+				tc.req.FolderDigest,
 			).
 				Return(nil)
 
@@ -563,6 +754,14 @@ func TestUpdateSecretOnBadRequest(t *testing.T) {
 			},
 			changed: []string{"data"},
 		},
+		{
+			name: "Update fails if too long folder digest provided",
+			req: &goph.UpdateSecretRequest{
+				Id:           uuid.NewV4().String(),
+				FolderDigest: []byte(strings.Repeat("#", v1.DefaultMaxFolderDigestLength+1)),
+			},
+			changed: []string{"labels"},
+		},
 	}
 
 	for _, tc := range tt {
@@ -628,6 +827,8 @@ func TestUpdateSecretOnUsecaseFailure(t *testing.T) {
 				gophtest.SecretName,
 				[]byte(nil),
 				[]byte(nil),
+				[]byte(nil),
+				[]byte(nil),
 			).
 				Return(tc.ucErr)
 
@@ -703,3 +904,122 @@ func TestDeleteSecretOnUsecaseFailure(t *testing.T) {
 		})
 	}
 }
+
+func doGetSecretByName(
+	t *testing.T,
+	mockRV *entity.Secret,
+	mockErr error,
+) (*goph.GetSecretResponse, error) {
+	t.Helper()
+
+	m := newUseCasesMock()
+	m.Secrets.(*usecase.SecretsUseCaseMock).On(
+		"GetByName",
+		mock.Anything,
+		mock.AnythingOfType("uuid.UUID"),
+		gophtest.SecretName,
+		[]byte(gophtest.FolderDigest),
+	).
+		Return(mockRV, mockErr)
+
+	conn := createTestServerWithFakeAuth(t, m)
+	req := &goph.GetSecretByNameRequest{
+		Name:         gophtest.SecretName,
+		FolderDigest: []byte(gophtest.FolderDigest),
+	}
+
+	client := goph.NewSecretsClient(conn)
+	rv, err := client.GetByName(context.Background(), req)
+
+	m.Secrets.(*usecase.SecretsUseCaseMock).AssertExpectations(t)
+
+	return rv, err
+}
+
+func TestGetSecretByName(t *testing.T) {
+	secret := &entity.Secret{
+		ID:        gophtest.CreateUUID(t, "df566e25-43a5-4c34-9123-3931fb809b45"),
+		Name:      gophtest.SecretName,
+		Kind:      goph.DataKind_TEXT,
+		Metadata:  []byte(gophtest.Metadata),
+		Labels:    []byte(gophtest.Labels),
+		Data:      []byte(gophtest.TextData),
+		CreatedAt: _createdAt,
+		UpdatedAt: _updatedAt,
+	}
+
+	resp, err := doGetSecretByName(t, secret, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, secret.ID.String(), resp.GetSecret().GetId())
+	require.Equal(t, secret.Name, resp.GetSecret().GetName())
+	require.Equal(t, secret.Labels, resp.GetSecret().GetLabels())
+	require.Equal(t, _updatedAt, resp.GetSecret().GetUpdatedAt().AsTime())
+	require.Equal(t, secret.Data, resp.GetData())
+}
+
+func TestGetSecretByNameOnBadRequest(t *testing.T) {
+	tt := []struct {
+		name string
+		req  *goph.GetSecretByNameRequest
+	}{
+		{
+			name: "Get secret by name fails if name is empty",
+			req:  &goph.GetSecretByNameRequest{},
+		},
+		{
+			name: "Get secret by name fails if folder digest is too long",
+			req: &goph.GetSecretByNameRequest{
+				Name:         gophtest.SecretName,
+				FolderDigest: []byte(strings.Repeat("#", v1.DefaultMaxFolderDigestLength+1)),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := createTestServerWithFakeAuth(t, newUseCasesMock())
+
+			client := goph.NewSecretsClient(conn)
+			_, err := client.GetByName(context.Background(), tc.req)
+
+			requireEqualCode(t, codes.InvalidArgument, err)
+		})
+	}
+}
+
+func TestGetSecretByNameFailsIfNoUserInfo(t *testing.T) {
+	conn := createTestServer(t, newUseCasesMock())
+
+	client := goph.NewSecretsClient(conn)
+	_, err := client.GetByName(context.Background(), &goph.GetSecretByNameRequest{})
+
+	requireEqualCode(t, codes.Unauthenticated, err)
+}
+
+func TestGetSecretByNameOnUsecaseFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		ucErr    error
+		expected codes.Code
+	}{
+		{
+			name:     "Get secret by name fails if secret not found",
+			ucErr:    entity.ErrSecretNotFound,
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Get secret by name fails on unexpected error",
+			ucErr:    gophtest.ErrUnexpected,
+			expected: codes.Internal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := doGetSecretByName(t, nil, tc.ucErr)
+
+			requireEqualCode(t, tc.expected, err)
+		})
+	}
+}
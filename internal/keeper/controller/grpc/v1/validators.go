@@ -16,7 +16,13 @@ const (
 
 	DefaultMetadataLimit = 2 * 1024 * 1024
 
+	DefaultLabelsLimit = 64 * 1024
+
+	DefaultMaxFolderDigestLength = 64
+
 	DefaultDataLimit = 4 * 1024 * 1024
+
+	DefaultMaxBatchSize = 100
 )
 
 // validateUsername validates provided username.
@@ -92,6 +98,24 @@ func validateMetadata(metadata []byte) (string, bool) {
 	return "", true
 }
 
+// validateLabels validates provided encrypted labels of a secret.
+func validateLabels(labels []byte) (string, bool) {
+	if len(labels) > DefaultLabelsLimit {
+		return fmt.Sprintf("should be <= %d bytes", DefaultLabelsLimit), false
+	}
+
+	return "", true
+}
+
+// validateFolderDigest validates provided digest of the secret's folder.
+func validateFolderDigest(digest []byte) (string, bool) {
+	if len(digest) > DefaultMaxFolderDigestLength {
+		return fmt.Sprintf("should be <= %d bytes", DefaultMaxFolderDigestLength), false
+	}
+
+	return "", true
+}
+
 // validateSecretData validates provided secret data.
 func validateSecretData(data []byte) (string, bool) {
 	if len(data) == 0 {
@@ -105,6 +129,15 @@ func validateSecretData(data []byte) (string, bool) {
 	return "", true
 }
 
+// validateKind validates provided kind of secret data.
+func validateKind(kind goph.DataKind) (string, bool) {
+	if _, ok := goph.DataKind_name[int32(kind)]; !ok {
+		return fmt.Sprintf("unknown kind %d", kind), false
+	}
+
+	return "", true
+}
+
 // validateCreateSecretReq validates goph.validateCreateSecretReq.
 func validateCreateSecretReq(
 	req *goph.CreateSecretRequest,
@@ -120,6 +153,15 @@ func validateCreateSecretReq(
 		br.FieldViolations = append(br.FieldViolations, v)
 	}
 
+	if reason, ok := validateKind(req.GetKind()); !ok {
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "kind",
+			Description: reason,
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
 	if reason, ok := validateMetadata(req.GetMetadata()); !ok {
 		v := &errdetails.BadRequest_FieldViolation{
 			Field:       "metadata",
@@ -138,6 +180,109 @@ func validateCreateSecretReq(
 		br.FieldViolations = append(br.FieldViolations, v)
 	}
 
+	if reason, ok := validateLabels(req.GetLabels()); !ok {
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "labels",
+			Description: reason,
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
+	if reason, ok := validateFolderDigest(req.GetFolderDigest()); !ok {
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "folder_digest",
+			Description: reason,
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
+	if len(br.FieldViolations) == 0 {
+		return nil, true
+	}
+
+	return br, false
+}
+
+// validateCreateSecretsReq validates goph.CreateSecretsRequest.
+func validateCreateSecretsReq(
+	req *goph.CreateSecretsRequest,
+) (*errdetails.BadRequest, bool) {
+	br := &errdetails.BadRequest{}
+
+	switch secrets := req.GetSecrets(); {
+	case len(secrets) == 0:
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "secrets",
+			Description: _missingField,
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+
+	case len(secrets) > DefaultMaxBatchSize:
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "secrets",
+			Description: fmt.Sprintf("should contain <= %d items", DefaultMaxBatchSize),
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
+	for i, secret := range req.GetSecrets() {
+		details, ok := validateCreateSecretReq(secret)
+		if !ok {
+			for _, v := range details.FieldViolations {
+				v.Field = fmt.Sprintf("secrets[%d].%s", i, v.Field)
+				br.FieldViolations = append(br.FieldViolations, v)
+			}
+		}
+
+		if secret.GetReplaces() == "" {
+			continue
+		}
+
+		if _, err := uuid.FromString(secret.GetReplaces()); err != nil {
+			v := &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("secrets[%d].replaces", i),
+				Description: err.Error(),
+			}
+
+			br.FieldViolations = append(br.FieldViolations, v)
+		}
+	}
+
+	if len(br.FieldViolations) == 0 {
+		return nil, true
+	}
+
+	return br, false
+}
+
+// validateGetSecretByNameReq validates goph.GetSecretByNameRequest.
+func validateGetSecretByNameReq(
+	req *goph.GetSecretByNameRequest,
+) (*errdetails.BadRequest, bool) {
+	br := &errdetails.BadRequest{}
+
+	if reason, ok := validateSecretName(req.GetName()); !ok {
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "name",
+			Description: reason,
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
+	if reason, ok := validateFolderDigest(req.GetFolderDigest()); !ok {
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "folder_digest",
+			Description: reason,
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
 	if len(br.FieldViolations) == 0 {
 		return nil, true
 	}
@@ -190,6 +335,11 @@ func validateUpdateSecretReq(
 
 		case "data":
 			reason, ok = validateSecretData(req.GetData())
+
+		case "labels":
+			if reason, ok = validateLabels(req.GetLabels()); ok {
+				reason, ok = validateFolderDigest(req.GetFolderDigest())
+			}
 		}
 
 		if !ok {
@@ -208,3 +358,44 @@ func validateUpdateSecretReq(
 
 	return id, br
 }
+
+// validateCreateAttachmentReq validates goph.CreateAttachmentRequest.
+func validateCreateAttachmentReq(
+	req *goph.CreateAttachmentRequest,
+) (uuid.UUID, *errdetails.BadRequest) {
+	br := &errdetails.BadRequest{}
+
+	secretID, err := uuid.FromString(req.GetSecretId())
+	if err != nil {
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "secret_id",
+			Description: err.Error(),
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
+	if reason, ok := validateMetadata(req.GetMetadata()); !ok {
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "metadata",
+			Description: reason,
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
+	if reason, ok := validateSecretData(req.GetData()); !ok {
+		v := &errdetails.BadRequest_FieldViolation{
+			Field:       "data",
+			Description: reason,
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+	}
+
+	if len(br.FieldViolations) == 0 {
+		return secretID, nil
+	}
+
+	return secretID, br
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"errors"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// Attachment represents encrypted file attached to a secret.
+type Attachment struct {
+	ID       uuid.UUID `db:"attachment_id"`
+	SecretID uuid.UUID `db:"secret_id"`
+	Metadata []byte
+	Data     []byte
+}
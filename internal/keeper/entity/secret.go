@@ -2,6 +2,7 @@ package entity
 
 import (
 	"errors"
+	"time"
 
 	"github.com/alkurbatov/goph-keeper/pkg/goph"
 	uuid "github.com/satori/go.uuid"
@@ -19,5 +20,16 @@ type Secret struct {
 	Name     string
 	Kind     goph.DataKind
 	Metadata []byte
+	Labels   []byte
 	Data     []byte
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	// FolderDigest is used to keep names of secrets unique within a folder.
+	FolderDigest []byte `db:"folder_digest"`
+
+	// Replaces is ID of the stored secret deleted when the secret is created,
+	// nil UUID if nothing is replaced.
+	Replaces uuid.UUID `db:"-"`
 }
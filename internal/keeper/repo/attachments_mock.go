@@ -0,0 +1,52 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ Attachments = (*AttachmentsRepoMock)(nil)
+
+type AttachmentsRepoMock struct {
+	mock.Mock
+}
+
+func (m *AttachmentsRepoMock) Create(
+	ctx context.Context,
+	owner, secretID uuid.UUID,
+	metadata, data []byte,
+) (uuid.UUID, error) {
+	args := m.Called(ctx, owner, secretID, metadata, data)
+
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *AttachmentsRepoMock) List(
+	ctx context.Context,
+	owner, secretID uuid.UUID,
+) ([]entity.Attachment, error) {
+	args := m.Called(ctx, owner, secretID)
+
+	return args.Get(0).([]entity.Attachment), args.Error(1)
+}
+
+func (m *AttachmentsRepoMock) Get(
+	ctx context.Context,
+	owner, id uuid.UUID,
+) (*entity.Attachment, error) {
+	args := m.Called(ctx, owner, id)
+
+	return args.Get(0).(*entity.Attachment), args.Error(1)
+}
+
+func (m *AttachmentsRepoMock) Delete(
+	ctx context.Context,
+	owner, id uuid.UUID,
+) error {
+	args := m.Called(ctx, owner, id)
+
+	return args.Error(0)
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keeper/infra/postgres"
+	uuid "github.com/satori/go.uuid"
+)
+
+var _ Attachments = (*AttachmentsRepo)(nil)
+
+// AttachmentsRepo is facade to attachments stored in Postgres.
+type AttachmentsRepo struct {
+	pg *postgres.Postgres
+}
+
+// NewAttachmentsRepo creates and initializes AttachmentsRepo object.
+func NewAttachmentsRepo(pg *postgres.Postgres) *AttachmentsRepo {
+	return &AttachmentsRepo{pg}
+}
+
+// Create stores new attachment of the secret in database.
+// The secret must belong to the owner.
+func (r *AttachmentsRepo) Create(
+	ctx context.Context,
+	owner, secretID uuid.UUID,
+	metadata, data []byte,
+) (id uuid.UUID, err error) {
+	fn := func(tx postgres.Transaction) error {
+		err := tx.QueryRow(
+			ctx,
+			`INSERT INTO
+           attachments (secret_id, owner_id, metadata, data)
+       SELECT
+           secret_id, owner_id, $3, $4
+       FROM
+           secrets
+       WHERE secret_id = $1 AND owner_id = $2
+       RETURNING attachment_id`,
+			secretID,
+			owner,
+			metadata,
+			data,
+		).Scan(&id)
+		if err != nil {
+			if postgres.IsEmptyResponse(err) {
+				return entity.ErrSecretNotFound
+			}
+
+			return fmt.Errorf("AttachmentsRepo - Create - tx.QueryRow.Scan: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := r.pg.RunAtomic(ctx, fn); err != nil {
+		return id, fmt.Errorf("AttachmentsRepo - Create - r.pg.RunAtomic: %w", err)
+	}
+
+	return id, nil
+}
+
+// List returns all attachments of the secret.
+// Data is not filled in this case to reduce load on service.
+func (r *AttachmentsRepo) List(
+	ctx context.Context,
+	owner, secretID uuid.UUID,
+) ([]entity.Attachment, error) {
+	rv := make([]entity.Attachment, 0)
+	if err := r.pg.Select(
+		ctx,
+		&rv,
+		`SELECT
+         attachment_id, secret_id, metadata
+     FROM
+         attachments
+     WHERE secret_id = $1 AND owner_id = $2`,
+		secretID,
+		owner,
+	); err != nil {
+		return nil, fmt.Errorf("AttachmentsRepo - List - r.Select: %w", err)
+	}
+
+	return rv, nil
+}
+
+// Get returns full attachment info and data.
+func (r *AttachmentsRepo) Get(
+	ctx context.Context,
+	owner, id uuid.UUID,
+) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+
+	err := r.pg.Pool.
+		QueryRow(
+			ctx,
+			`SELECT
+           attachment_id, secret_id, metadata, data
+       FROM
+           attachments
+       WHERE attachment_id = $1 AND owner_id = $2`,
+			id,
+			owner,
+		).
+		Scan(&attachment.ID, &attachment.SecretID, &attachment.Metadata, &attachment.Data)
+	if err != nil {
+		if postgres.IsEmptyResponse(err) {
+			return nil, entity.ErrAttachmentNotFound
+		}
+
+		return nil, fmt.Errorf("AttachmentsRepo - Get - r.pg.Pool.QueryRow.Scan: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+// Delete removes attachment from database.
+func (r *AttachmentsRepo) Delete(
+	ctx context.Context,
+	owner, id uuid.UUID,
+) error {
+	fn := func(tx postgres.Transaction) error {
+		tag, err := tx.Exec(
+			ctx,
+			`DELETE FROM
+           attachments
+       WHERE attachment_id = $1 AND owner_id = $2`,
+			id,
+			owner,
+		)
+		if err != nil {
+			return fmt.Errorf("AttachmentsRepo - Delete - tx.Exec: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return entity.ErrAttachmentNotFound
+		}
+
+		return nil
+	}
+
+	if err := r.pg.RunAtomic(ctx, fn); err != nil {
+		return fmt.Errorf("AttachmentsRepo - Delete - r.pg.RunAtomic: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keeper/infra/postgres"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/gophtest"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v2"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAttachment(t *testing.T) {
+	owner := uuid.NewV4()
+	secretID := uuid.NewV4()
+	expected := uuid.NewV4()
+
+	rows := pgxmock.NewRows([]string{"attachment_id"}).
+		AddRow(expected.String())
+
+	m := newPoolMock(t)
+	m.ExpectBeginTx(postgres.DefaultTxOptions)
+	m.ExpectQuery("INSERT INTO attachments").
+		WithArgs(secretID, owner, []byte(gophtest.Metadata), []byte(gophtest.TextData)).
+		WillReturnRows(rows)
+	m.ExpectCommit()
+
+	sat := newTestRepos(t, m).Attachments
+	id, err := sat.Create(
+		context.Background(),
+		owner,
+		secretID,
+		[]byte(gophtest.Metadata),
+		[]byte(gophtest.TextData),
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, id)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestCreateAttachmentOnDBFailure(t *testing.T) {
+	tt := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{
+			name:     "Create attachment fails if secret doesn't exist",
+			err:      pgx.ErrNoRows,
+			expected: entity.ErrSecretNotFound,
+		},
+		{
+			name:     "Create attachment fails on unexpected error",
+			err:      gophtest.ErrUnexpected,
+			expected: gophtest.ErrUnexpected,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := uuid.NewV4()
+			secretID := uuid.NewV4()
+
+			m := newPoolMock(t)
+			m.ExpectBeginTx(postgres.DefaultTxOptions)
+			m.ExpectQuery("INSERT").
+				WithArgs(secretID, owner, []byte(gophtest.Metadata), []byte(gophtest.TextData)).
+				WillReturnError(tc.err)
+			m.ExpectRollback()
+
+			sat := newTestRepos(t, m).Attachments
+			_, err := sat.Create(
+				context.Background(),
+				owner,
+				secretID,
+				[]byte(gophtest.Metadata),
+				[]byte(gophtest.TextData),
+			)
+
+			require.ErrorIs(t, err, tc.expected)
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListAttachments(t *testing.T) {
+	owner := uuid.NewV4()
+	secretID := uuid.NewV4()
+
+	rows := pgxmock.NewRows([]string{"attachment_id", "secret_id", "metadata"}).
+		AddRow(uuid.NewV4().String(), secretID.String(), []byte(gophtest.Metadata)).
+		AddRow(uuid.NewV4().String(), secretID.String(), []byte(gophtest.Metadata))
+
+	m := newPoolMock(t)
+	m.ExpectQuery("SELECT attachment_id, secret_id, metadata FROM attachments").
+		WithArgs(secretID, owner).
+		WillReturnRows(rows)
+
+	sat := newTestRepos(t, m).Attachments
+	attachments, err := sat.List(context.Background(), owner, secretID)
+
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	require.Equal(t, secretID, attachments[0].SecretID)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestListAttachmentsOnDBFailure(t *testing.T) {
+	owner := uuid.NewV4()
+	secretID := uuid.NewV4()
+
+	m := newPoolMock(t)
+	m.ExpectQuery("SELECT").
+		WithArgs(secretID, owner).
+		WillReturnError(gophtest.ErrUnexpected)
+
+	sat := newTestRepos(t, m).Attachments
+	_, err := sat.List(context.Background(), owner, secretID)
+
+	require.Error(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestGetAttachment(t *testing.T) {
+	owner := uuid.NewV4()
+
+	expected := &entity.Attachment{
+		ID:       uuid.NewV4(),
+		SecretID: uuid.NewV4(),
+		Metadata: []byte(gophtest.Metadata),
+		Data:     []byte(gophtest.TextData),
+	}
+
+	rows := pgxmock.NewRows([]string{"attachment_id", "secret_id", "metadata", "data"}).
+		AddRow(expected.ID.String(), expected.SecretID.String(), expected.Metadata, expected.Data)
+
+	m := newPoolMock(t)
+	m.ExpectQuery("SELECT attachment_id, secret_id, metadata, data FROM attachments").
+		WithArgs(expected.ID, owner).
+		WillReturnRows(rows)
+
+	sat := newTestRepos(t, m).Attachments
+	attachment, err := sat.Get(context.Background(), owner, expected.ID)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, attachment)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestGetUnexistingAttachment(t *testing.T) {
+	owner := uuid.NewV4()
+	id := uuid.NewV4()
+
+	m := newPoolMock(t)
+	m.ExpectQuery("SELECT").
+		WithArgs(id, owner).
+		WillReturnRows(pgxmock.NewRows([]string{"attachment_id", "secret_id", "metadata", "data"}))
+
+	sat := newTestRepos(t, m).Attachments
+	_, err := sat.Get(context.Background(), owner, id)
+
+	require.ErrorIs(t, err, entity.ErrAttachmentNotFound)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	tt := []struct {
+		name     string
+		affected int64
+		expected error
+	}{
+		{
+			name:     "Delete attachment",
+			affected: 1,
+		},
+		{
+			name:     "Delete unexisting attachment",
+			affected: 0,
+			expected: entity.ErrAttachmentNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := uuid.NewV4()
+			id := uuid.NewV4()
+
+			m := newPoolMock(t)
+			m.ExpectBeginTx(postgres.DefaultTxOptions)
+			m.ExpectExec("DELETE FROM attachments").
+				WithArgs(id, owner).
+				WillReturnResult(pgxmock.NewResult("DELETE", tc.affected))
+
+			if tc.expected == nil {
+				m.ExpectCommit()
+			} else {
+				m.ExpectRollback()
+			}
+
+			sat := newTestRepos(t, m).Attachments
+			err := sat.Delete(context.Background(), owner, id)
+
+			require.ErrorIs(t, err, tc.expected)
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
@@ -11,25 +11,38 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+type Attachments interface {
+	Create(ctx context.Context, owner, secretID uuid.UUID, metadata, data []byte) (uuid.UUID, error)
+	List(ctx context.Context, owner, secretID uuid.UUID) ([]entity.Attachment, error)
+	Get(ctx context.Context, owner, id uuid.UUID) (*entity.Attachment, error)
+	Delete(ctx context.Context, owner, id uuid.UUID) error
+}
+
 type Secrets interface {
 	Create(
 		ctx context.Context,
 		owner uuid.UUID,
 		name string,
 		kind goph.DataKind,
-		metadata, data []byte,
+		metadata, data, labels, folderDigest []byte,
 	) (uuid.UUID, error)
 
+	CreateBatch(ctx context.Context, owner uuid.UUID, secrets []entity.Secret) ([]uuid.UUID, error)
 	List(ctx context.Context, owner uuid.UUID) ([]entity.Secret, error)
 	Get(ctx context.Context, owner, id uuid.UUID) (*entity.Secret, error)
+	GetByName(
+		ctx context.Context,
+		owner uuid.UUID,
+		name string,
+		folderDigest []byte,
+	) (*entity.Secret, error)
 
 	Update(
 		ctx context.Context,
 		owner, id uuid.UUID,
 		changed []string,
 		name string,
-		metadata []byte,
-		data []byte,
+		metadata, data, labels, folderDigest []byte,
 	) error
 
 	Delete(ctx context.Context, owner, id uuid.UUID) error
@@ -42,14 +55,16 @@ type Users interface {
 
 // Repositories is a collection of data repositories.
 type Repositories struct {
-	Secrets Secrets
-	Users   Users
+	Attachments Attachments
+	Secrets     Secrets
+	Users       Users
 }
 
 // New creates and initializes collection of data repositories.
 func New(pg *postgres.Postgres) *Repositories {
 	return &Repositories{
-		Secrets: NewSecretsRepo(pg),
-		Users:   NewUsersRepo(pg),
+		Attachments: NewAttachmentsRepo(pg),
+		Secrets:     NewSecretsRepo(pg),
+		Users:       NewUsersRepo(pg),
 	}
 }
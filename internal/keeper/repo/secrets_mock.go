@@ -20,13 +20,23 @@ func (m *SecretsRepoMock) Create(
 	owner uuid.UUID,
 	name string,
 	kind goph.DataKind,
-	metadata, data []byte,
+	metadata, data, labels, folderDigest []byte,
 ) (uuid.UUID, error) {
-	args := m.Called(ctx, owner, name, kind, metadata, data)
+	args := m.Called(ctx, owner, name, kind, metadata, data, labels, folderDigest)
 
 	return args.Get(0).(uuid.UUID), args.Error(1)
 }
 
+func (m *SecretsRepoMock) CreateBatch(
+	ctx context.Context,
+	owner uuid.UUID,
+	secrets []entity.Secret,
+) ([]uuid.UUID, error) {
+	args := m.Called(ctx, owner, secrets)
+
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 func (m *SecretsRepoMock) List(
 	ctx context.Context,
 	owner uuid.UUID,
@@ -45,15 +55,25 @@ func (m *SecretsRepoMock) Get(
 	return args.Get(0).(*entity.Secret), args.Error(1)
 }
 
+func (m *SecretsRepoMock) GetByName(
+	ctx context.Context,
+	owner uuid.UUID,
+	name string,
+	folderDigest []byte,
+) (*entity.Secret, error) {
+	args := m.Called(ctx, owner, name, folderDigest)
+
+	return args.Get(0).(*entity.Secret), args.Error(1)
+}
+
 func (m *SecretsRepoMock) Update(
 	ctx context.Context,
 	owner, id uuid.UUID,
 	changed []string,
 	name string,
-	metadata []byte,
-	data []byte,
+	metadata, data, labels, folderDigest []byte,
 ) error {
-	args := m.Called(ctx, owner, id, changed, name, metadata, data)
+	args := m.Called(ctx, owner, id, changed, name, metadata, data, labels, folderDigest)
 
 	return args.Error(0)
 }
@@ -31,21 +31,25 @@ func (r *SecretsRepo) Create(
 	owner uuid.UUID,
 	name string,
 	kind goph.DataKind,
-	metadata, data []byte,
+	metadata, data, labels, folderDigest []byte,
 ) (id uuid.UUID, err error) {
+	folderDigest = rootIfEmpty(folderDigest)
+
 	fn := func(tx postgres.Transaction) error {
 		err := tx.QueryRow(
 			ctx,
 			`INSERT INTO
-           secrets (owner_id, name, kind, metadata, data)
+           secrets (owner_id, name, kind, metadata, data, labels, folder_digest)
        VALUES
-           ($1, $2, $3, $4, $5)
+           ($1, $2, $3, $4, $5, $6, $7)
        RETURNING secret_id`,
 			owner,
 			name,
 			kind,
 			metadata,
 			data,
+			labels,
+			folderDigest,
 		).Scan(&id)
 		if err != nil {
 			if postgres.IsEntityExists(err) {
@@ -65,6 +69,70 @@ func (r *SecretsRepo) Create(
 	return id, nil
 }
 
+// CreateBatch stores several secrets in single transaction, either all or none are created.
+// Replaced secrets are deleted in the same transaction, files attached to them are removed
+// by cascade.
+func (r *SecretsRepo) CreateBatch(
+	ctx context.Context,
+	owner uuid.UUID,
+	secrets []entity.Secret,
+) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(secrets))
+
+	fn := func(tx postgres.Transaction) error {
+		for i, secret := range secrets {
+			if !uuid.Equal(secret.Replaces, uuid.Nil) {
+				tag, err := tx.Exec(
+					ctx,
+					`DELETE FROM
+               secrets
+           WHERE secret_id = $1 AND owner_id = $2`,
+					secret.Replaces,
+					owner,
+				)
+				if err != nil {
+					return fmt.Errorf("SecretsRepo - CreateBatch - tx.Exec: %w", err)
+				}
+
+				if tag.RowsAffected() == 0 {
+					return fmt.Errorf("%w: %s", entity.ErrSecretNotFound, secret.Replaces)
+				}
+			}
+
+			err := tx.QueryRow(
+				ctx,
+				`INSERT INTO
+             secrets (owner_id, name, kind, metadata, data, labels, folder_digest)
+         VALUES
+             ($1, $2, $3, $4, $5, $6, $7)
+         RETURNING secret_id`,
+				owner,
+				secret.Name,
+				secret.Kind,
+				secret.Metadata,
+				secret.Data,
+				secret.Labels,
+				rootIfEmpty(secret.FolderDigest),
+			).Scan(&ids[i])
+			if err != nil {
+				if postgres.IsEntityExists(err) {
+					return fmt.Errorf("%w: %s", entity.ErrSecretExists, secret.Name)
+				}
+
+				return fmt.Errorf("SecretsRepo - CreateBatch - tx.QueryRow.Scan: %w", err)
+			}
+		}
+
+		return nil
+	}
+
+	if err := r.pg.RunAtomic(ctx, fn); err != nil {
+		return nil, fmt.Errorf("SecretsRepo - CreateBatch - r.pg.RunAtomic: %w", err)
+	}
+
+	return ids, nil
+}
+
 // List returns all secrets of the provided user.
 // Data is not filled in this case to reduce load on service.
 func (r *SecretsRepo) List(
@@ -76,7 +144,7 @@ func (r *SecretsRepo) List(
 		ctx,
 		&rv,
 		`SELECT
-         secret_id, name, kind, metadata
+         secret_id, name, kind, metadata, labels, created_at, updated_at
      FROM
          secrets
      WHERE owner_id = $1`,
@@ -99,14 +167,23 @@ func (r *SecretsRepo) Get(
 		QueryRow(
 			ctx,
 			`SELECT
-           secret_id, name, kind, metadata, data
+           secret_id, name, kind, metadata, labels, created_at, updated_at, data
        FROM
            secrets
        WHERE secret_id=$1 AND owner_id = $2`,
 			id,
 			owner,
 		).
-		Scan(&secret.ID, &secret.Name, &secret.Kind, &secret.Metadata, &secret.Data)
+		Scan(
+			&secret.ID,
+			&secret.Name,
+			&secret.Kind,
+			&secret.Metadata,
+			&secret.Labels,
+			&secret.CreatedAt,
+			&secret.UpdatedAt,
+			&secret.Data,
+		)
 	if err != nil {
 		if postgres.IsEmptyResponse(err) {
 			return nil, entity.ErrSecretNotFound
@@ -118,13 +195,55 @@ func (r *SecretsRepo) Get(
 	return &secret, nil
 }
 
+// GetByName returns full secret info and data by name of the secret within a folder.
+func (r *SecretsRepo) GetByName(
+	ctx context.Context,
+	owner uuid.UUID,
+	name string,
+	folderDigest []byte,
+) (*entity.Secret, error) {
+	var secret entity.Secret
+
+	err := r.pg.Pool.
+		QueryRow(
+			ctx,
+			`SELECT
+           secret_id, name, kind, metadata, labels, created_at, updated_at, data
+       FROM
+           secrets
+       WHERE owner_id = $1 AND folder_digest = $2 AND name = $3`,
+			owner,
+			rootIfEmpty(folderDigest),
+			name,
+		).
+		Scan(
+			&secret.ID,
+			&secret.Name,
+			&secret.Kind,
+			&secret.Metadata,
+			&secret.Labels,
+			&secret.CreatedAt,
+			&secret.UpdatedAt,
+			&secret.Data,
+		)
+	if err != nil {
+		if postgres.IsEmptyResponse(err) {
+			return nil, entity.ErrSecretNotFound
+		}
+
+		return nil, fmt.Errorf("SecretsRepo - GetByName - r.pg.Pool.QueryRow.Scan: %w", err)
+	}
+
+	return &secret, nil
+}
+
 // Update changes secret info and data.
 func (r *SecretsRepo) Update(
 	ctx context.Context,
 	owner, id uuid.UUID,
 	changed []string,
 	name string,
-	metadata, data []byte,
+	metadata, data, labels, folderDigest []byte,
 ) error {
 	fn := func(tx postgres.Transaction) error {
 		qb := newQueryBuilder("UPDATE secrets").Set()
@@ -139,6 +258,10 @@ func (r *SecretsRepo) Update(
 
 			case "data":
 				qb.Append("data", "=", data)
+
+			case "labels":
+				qb.Append("labels", "=", labels)
+				qb.Append("folder_digest", "=", rootIfEmpty(folderDigest))
 			}
 		}
 
@@ -205,3 +328,13 @@ func (r *SecretsRepo) Delete(
 
 	return nil
 }
+
+// rootIfEmpty replaces missing folder digest with the empty one, which denotes the root folder.
+// NB (alkurbatov): nil slice is sent as NULL and violates the NOT NULL constraint.
+func rootIfEmpty(folderDigest []byte) []byte {
+	if folderDigest == nil {
+		return []byte{}
+	}
+
+	return folderDigest
+}
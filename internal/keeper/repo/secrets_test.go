@@ -3,6 +3,7 @@ package repo_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
 	"github.com/alkurbatov/goph-keeper/internal/keeper/infra/postgres"
@@ -33,7 +34,7 @@ func doUpdateSecret(
 	owner, id uuid.UUID,
 	changed []string,
 	name string,
-	metadata, data []byte,
+	metadata, data, labels []byte,
 	m pgxmock.PgxPoolIface,
 ) error {
 	t.Helper()
@@ -47,6 +48,8 @@ func doUpdateSecret(
 		name,
 		metadata,
 		data,
+		labels,
+		[]byte(gophtest.FolderDigest),
 	)
 
 	require.NoError(t, m.ExpectationsWereMet())
@@ -81,6 +84,8 @@ func TestCreateSecret(t *testing.T) {
 			goph.DataKind_TEXT,
 			[]byte(gophtest.Metadata),
 			[]byte(gophtest.TextData),
+			[]byte(gophtest.Labels),
+			[]byte(gophtest.FolderDigest),
 		).
 		WillReturnRows(rows)
 	m.ExpectCommit()
@@ -93,6 +98,8 @@ func TestCreateSecret(t *testing.T) {
 		goph.DataKind_TEXT,
 		[]byte(gophtest.Metadata),
 		[]byte(gophtest.TextData),
+		[]byte(gophtest.Labels),
+		[]byte(gophtest.FolderDigest),
 	)
 
 	require.NoError(t, err)
@@ -131,6 +138,8 @@ func TestCreateSecretOnDBFailure(t *testing.T) {
 					goph.DataKind_TEXT,
 					[]byte(gophtest.Metadata),
 					[]byte(gophtest.TextData),
+					[]byte(gophtest.Labels),
+					[]byte(gophtest.FolderDigest),
 				).
 				WillReturnError(tc.err)
 			m.ExpectRollback()
@@ -143,6 +152,8 @@ func TestCreateSecretOnDBFailure(t *testing.T) {
 				goph.DataKind_TEXT,
 				[]byte(gophtest.Metadata),
 				[]byte(gophtest.TextData),
+				[]byte(gophtest.Labels),
+				[]byte(gophtest.FolderDigest),
 			)
 
 			require.ErrorIs(t, err, tc.expected)
@@ -151,6 +162,192 @@ func TestCreateSecretOnDBFailure(t *testing.T) {
 	}
 }
 
+func TestCreateSecretsBatch(t *testing.T) {
+	owner := uuid.NewV4()
+	expected := []uuid.UUID{uuid.NewV4(), uuid.NewV4()}
+	secrets := []entity.Secret{
+		{
+			Name:         gophtest.SecretName,
+			Kind:         goph.DataKind_TEXT,
+			Metadata:     []byte(gophtest.Metadata),
+			Data:         []byte(gophtest.TextData),
+			Labels:       []byte(gophtest.Labels),
+			FolderDigest: []byte(gophtest.FolderDigest),
+		},
+		{
+			Name: gophtest.Username,
+			Kind: goph.DataKind_BINARY,
+			Data: []byte(gophtest.TextData),
+		},
+	}
+
+	m := newPoolMock(t)
+	m.ExpectBeginTx(postgres.DefaultTxOptions)
+
+	for i, secret := range secrets {
+		digest := secret.FolderDigest
+		if len(digest) == 0 {
+			digest = []byte{}
+		}
+
+		m.ExpectQuery("INSERT INTO secrets").
+			WithArgs(
+				owner,
+				secret.Name,
+				secret.Kind,
+				secret.Metadata,
+				secret.Data,
+				secret.Labels,
+				digest,
+			).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(expected[i].String()))
+	}
+
+	m.ExpectCommit()
+
+	sat := newTestRepos(t, m).Secrets
+	ids, err := sat.CreateBatch(context.Background(), owner, secrets)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, ids)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestCreateSecretsBatchRollsBackOnConflict(t *testing.T) {
+	owner := uuid.NewV4()
+	secrets := []entity.Secret{
+		{
+			Name:         gophtest.SecretName,
+			Kind:         goph.DataKind_TEXT,
+			Data:         []byte(gophtest.TextData),
+			FolderDigest: []byte(gophtest.FolderDigest),
+		},
+	}
+
+	m := newPoolMock(t)
+	m.ExpectBeginTx(postgres.DefaultTxOptions)
+	m.ExpectQuery("INSERT INTO secrets").
+		WithArgs(
+			owner,
+			gophtest.SecretName,
+			goph.DataKind_TEXT,
+			[]byte(nil),
+			[]byte(gophtest.TextData),
+			[]byte(nil),
+			[]byte(gophtest.FolderDigest),
+		).
+		WillReturnError(errUniqueViolation)
+	m.ExpectRollback()
+
+	sat := newTestRepos(t, m).Secrets
+	_, err := sat.CreateBatch(context.Background(), owner, secrets)
+
+	require.ErrorIs(t, err, entity.ErrSecretExists)
+	require.ErrorContains(t, err, gophtest.SecretName)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestCreateSecretsBatchReplacesSecret(t *testing.T) {
+	owner := uuid.NewV4()
+	replaced := uuid.NewV4()
+	expected := uuid.NewV4()
+	secret := entity.Secret{
+		Name:         gophtest.SecretName,
+		Kind:         goph.DataKind_TEXT,
+		Data:         []byte(gophtest.TextData),
+		FolderDigest: []byte(gophtest.FolderDigest),
+		Replaces:     replaced,
+	}
+
+	m := newPoolMock(t)
+	m.ExpectBeginTx(postgres.DefaultTxOptions)
+	m.ExpectExec("DELETE FROM secrets").
+		WithArgs(replaced, owner).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	m.ExpectQuery("INSERT INTO secrets").
+		WithArgs(
+			owner,
+			gophtest.SecretName,
+			goph.DataKind_TEXT,
+			[]byte(nil),
+			[]byte(gophtest.TextData),
+			[]byte(nil),
+			[]byte(gophtest.FolderDigest),
+		).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(expected.String()))
+	m.ExpectCommit()
+
+	sat := newTestRepos(t, m).Secrets
+	ids, err := sat.CreateBatch(context.Background(), owner, []entity.Secret{secret})
+
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{expected}, ids)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestCreateSecretsBatchKeepsReplacedSecretOnFailure(t *testing.T) {
+	owner := uuid.NewV4()
+	replaced := uuid.NewV4()
+	secrets := []entity.Secret{
+		{
+			Name:     gophtest.SecretName,
+			Kind:     goph.DataKind_TEXT,
+			Data:     []byte(gophtest.TextData),
+			Replaces: replaced,
+		},
+	}
+
+	m := newPoolMock(t)
+	m.ExpectBeginTx(postgres.DefaultTxOptions)
+	m.ExpectExec("DELETE FROM secrets").
+		WithArgs(replaced, owner).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	m.ExpectQuery("INSERT INTO secrets").
+		WithArgs(
+			owner,
+			gophtest.SecretName,
+			goph.DataKind_TEXT,
+			[]byte(nil),
+			[]byte(gophtest.TextData),
+			[]byte(nil),
+			[]byte{},
+		).
+		WillReturnError(gophtest.ErrUnexpected)
+	m.ExpectRollback()
+
+	sat := newTestRepos(t, m).Secrets
+	_, err := sat.CreateBatch(context.Background(), owner, secrets)
+
+	require.ErrorIs(t, err, gophtest.ErrUnexpected)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestCreateSecretsBatchFailsIfReplacedSecretNotFound(t *testing.T) {
+	owner := uuid.NewV4()
+	replaced := uuid.NewV4()
+	secrets := []entity.Secret{
+		{
+			Name:     gophtest.SecretName,
+			Kind:     goph.DataKind_TEXT,
+			Data:     []byte(gophtest.TextData),
+			Replaces: replaced,
+		},
+	}
+
+	m := newPoolMock(t)
+	m.ExpectBeginTx(postgres.DefaultTxOptions)
+	m.ExpectExec("DELETE FROM secrets").
+		WithArgs(replaced, owner).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	m.ExpectRollback()
+
+	sat := newTestRepos(t, m).Secrets
+	_, err := sat.CreateBatch(context.Background(), owner, secrets)
+
+	require.ErrorIs(t, err, entity.ErrSecretNotFound)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
 func TestListSecrets(t *testing.T) {
 	tt := []struct {
 		name string
@@ -159,8 +356,24 @@ func TestListSecrets(t *testing.T) {
 		{
 			name: "List secrets of a user",
 			rows: [][]any{
-				{uuid.NewV4().String(), gophtest.SecretName, goph.DataKind_TEXT, []byte("xxx")},
-				{uuid.NewV4().String(), gophtest.SecretName + "ex", goph.DataKind_BINARY, []byte{}},
+				{
+					uuid.NewV4().String(),
+					gophtest.SecretName,
+					goph.DataKind_TEXT,
+					[]byte("xxx"),
+					[]byte(gophtest.Labels),
+					time.Now(),
+					time.Now(),
+				},
+				{
+					uuid.NewV4().String(),
+					gophtest.SecretName + "ex",
+					goph.DataKind_BINARY,
+					[]byte{},
+					[]byte(nil),
+					time.Now(),
+					time.Now(),
+				},
 			},
 		},
 		{
@@ -172,14 +385,22 @@ func TestListSecrets(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			owner := uuid.NewV4()
-			rows := pgxmock.NewRows([]string{"secret_id", "name", "kind", "metadata"})
+			rows := pgxmock.NewRows([]string{
+				"secret_id",
+				"name",
+				"kind",
+				"metadata",
+				"labels",
+				"created_at",
+				"updated_at",
+			})
 
 			for _, row := range tc.rows {
 				rows.AddRow(row...)
 			}
 
 			m := newPoolMock(t)
-			m.ExpectQuery("SELECT secret_id, name, kind, metadata FROM secrets").
+			m.ExpectQuery("SELECT secret_id, name, kind, metadata, labels, created_at, updated_at FROM secrets").
 				WithArgs(owner).
 				WillReturnRows(rows)
 
@@ -208,22 +429,47 @@ func TestListSecretsOnDBFailure(t *testing.T) {
 	require.NoError(t, m.ExpectationsWereMet())
 }
 
+var _getSecretColumns = []string{
+	"secret_id",
+	"name",
+	"kind",
+	"metadata",
+	"labels",
+	"created_at",
+	"updated_at",
+	"data",
+}
+
 func TestGetSecret(t *testing.T) {
 	owner := uuid.NewV4()
 
 	expected := &entity.Secret{
-		ID:       uuid.NewV4(),
-		Name:     gophtest.SecretName,
-		Kind:     goph.DataKind_TEXT,
-		Metadata: []byte(gophtest.Metadata),
-		Data:     []byte(gophtest.TextData),
+		ID:        uuid.NewV4(),
+		Name:      gophtest.SecretName,
+		Kind:      goph.DataKind_TEXT,
+		Metadata:  []byte(gophtest.Metadata),
+		Labels:    []byte(gophtest.Labels),
+		CreatedAt: time.Now().Add(-time.Hour),
+		UpdatedAt: time.Now(),
+		Data:      []byte(gophtest.TextData),
 	}
 
-	rows := pgxmock.NewRows([]string{"secret_id", "name", "kind", "metadata", "data"}).
-		AddRow(expected.ID.String(), expected.Name, expected.Kind, expected.Metadata, expected.Data)
+	rows := pgxmock.NewRows(_getSecretColumns).
+		AddRow(
+			expected.ID.String(),
+			expected.Name,
+			expected.Kind,
+			expected.Metadata,
+			expected.Labels,
+			expected.CreatedAt,
+			expected.UpdatedAt,
+			expected.Data,
+		)
 
 	m := newPoolMock(t)
-	m.ExpectQuery("SELECT secret_id, name, kind, metadata, data FROM secrets").
+	m.ExpectQuery(
+		"SELECT secret_id, name, kind, metadata, labels, created_at, updated_at, data FROM secrets",
+	).
 		WithArgs(expected.ID, owner).
 		WillReturnRows(rows)
 
@@ -234,7 +480,7 @@ func TestGetSecret(t *testing.T) {
 }
 
 func TestGetUnexistingSecret(t *testing.T) {
-	rows := pgxmock.NewRows([]string{"secret_id", "name", "kind", "metadata", "data"})
+	rows := pgxmock.NewRows(_getSecretColumns)
 
 	owner := uuid.NewV4()
 	id := uuid.NewV4()
@@ -263,6 +509,92 @@ func TestGetSecretOnDBFailure(t *testing.T) {
 	require.ErrorIs(t, err, gophtest.ErrUnexpected)
 }
 
+func TestGetSecretByName(t *testing.T) {
+	owner := uuid.NewV4()
+
+	expected := &entity.Secret{
+		ID:        uuid.NewV4(),
+		Name:      gophtest.SecretName,
+		Kind:      goph.DataKind_TEXT,
+		Metadata:  []byte(gophtest.Metadata),
+		Labels:    []byte(gophtest.Labels),
+		CreatedAt: time.Now().Add(-time.Hour),
+		UpdatedAt: time.Now(),
+		Data:      []byte(gophtest.TextData),
+	}
+
+	rows := pgxmock.NewRows(_getSecretColumns).
+		AddRow(
+			expected.ID.String(),
+			expected.Name,
+			expected.Kind,
+			expected.Metadata,
+			expected.Labels,
+			expected.CreatedAt,
+			expected.UpdatedAt,
+			expected.Data,
+		)
+
+	m := newPoolMock(t)
+	m.ExpectQuery(
+		"SELECT .* FROM secrets WHERE owner_id = \\$1 AND folder_digest = \\$2 AND name = \\$3",
+	).
+		WithArgs(owner, []byte(gophtest.FolderDigest), gophtest.SecretName).
+		WillReturnRows(rows)
+
+	sat := newTestRepos(t, m).Secrets
+	secret, err := sat.GetByName(
+		context.Background(),
+		owner,
+		gophtest.SecretName,
+		[]byte(gophtest.FolderDigest),
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, secret)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestGetSecretByNameFailure(t *testing.T) {
+	tt := []struct {
+		name  string
+		dbErr error
+		err   error
+	}{
+		{
+			name: "Get unexisting secret",
+			err:  entity.ErrSecretNotFound,
+		},
+		{
+			name:  "Get secret fails on DB failure",
+			dbErr: gophtest.ErrUnexpected,
+			err:   gophtest.ErrUnexpected,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := uuid.NewV4()
+
+			m := newPoolMock(t)
+			query := m.ExpectQuery("SELECT").
+				WithArgs(owner, []byte{}, gophtest.SecretName)
+
+			if tc.dbErr != nil {
+				query.WillReturnError(tc.dbErr)
+			} else {
+				query.WillReturnRows(pgxmock.NewRows(_getSecretColumns))
+			}
+
+			sat := newTestRepos(t, m).Secrets
+			_, err := sat.GetByName(context.Background(), owner, gophtest.SecretName, nil)
+
+			require.ErrorIs(t, err, tc.err)
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestUpdateSecret(t *testing.T) {
 	owner := uuid.NewV4()
 	id := uuid.NewV4()
@@ -278,6 +610,7 @@ func TestUpdateSecret(t *testing.T) {
 		changed    []string
 		metadata   []byte
 		data       []byte
+		labels     []byte
 		expected   expected
 	}{
 		{
@@ -324,6 +657,20 @@ func TestUpdateSecret(t *testing.T) {
 				args:  []any{[]byte(gophtest.TextData), id, owner},
 			},
 		},
+		{
+			name:    "Update labels",
+			changed: []string{"labels"},
+			labels:  []byte(gophtest.Labels),
+			expected: expected{
+				query: "UPDATE secrets SET labels = \\$1, folder_digest = \\$2",
+				args: []any{
+					[]byte(gophtest.Labels),
+					[]byte(gophtest.FolderDigest),
+					id,
+					owner,
+				},
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -343,6 +690,7 @@ func TestUpdateSecret(t *testing.T) {
 				tc.secretName,
 				tc.metadata,
 				tc.data,
+				tc.labels,
 				m,
 			)
 
@@ -370,6 +718,7 @@ func TestUpdateUnexistingSecret(t *testing.T) {
 		gophtest.SecretName,
 		nil,
 		nil,
+		nil,
 		m,
 	)
 
@@ -414,6 +763,7 @@ func TestUpdateSecretOnDBFailure(t *testing.T) {
 				gophtest.SecretName,
 				nil,
 				nil,
+				nil,
 				m,
 			)
 
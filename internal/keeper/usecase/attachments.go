@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keeper/repo"
+	uuid "github.com/satori/go.uuid"
+)
+
+var _ Attachments = (*AttachmentsUseCase)(nil)
+
+// AttachmentsUseCase contains business logic related to management of attached files.
+type AttachmentsUseCase struct {
+	attachmentsRepo repo.Attachments
+}
+
+// NewAttachmentsUseCase create and initializes new AttachmentsUseCase object.
+func NewAttachmentsUseCase(attachments repo.Attachments) *AttachmentsUseCase {
+	return &AttachmentsUseCase{attachments}
+}
+
+// Create attaches new file to the secret.
+func (uc *AttachmentsUseCase) Create(
+	ctx context.Context,
+	owner, secretID uuid.UUID,
+	metadata, data []byte,
+) (uuid.UUID, error) {
+	id, err := uc.attachmentsRepo.Create(ctx, owner, secretID, metadata, data)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf(
+			"AttachmentsUseCase - Create - uc.attachmentsRepo.Create: %w",
+			err,
+		)
+	}
+
+	return id, nil
+}
+
+// List returns list of the secret's attachments.
+func (uc *AttachmentsUseCase) List(
+	ctx context.Context,
+	owner, secretID uuid.UUID,
+) ([]entity.Attachment, error) {
+	attachments, err := uc.attachmentsRepo.List(ctx, owner, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentsUseCase - List - uc.attachmentsRepo.List: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Get retrieves full attachment info from database.
+func (uc *AttachmentsUseCase) Get(
+	ctx context.Context,
+	owner, id uuid.UUID,
+) (*entity.Attachment, error) {
+	attachment, err := uc.attachmentsRepo.Get(ctx, owner, id)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentsUseCase - Get - uc.attachmentsRepo.Get: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// Delete removes attachment owned by user.
+func (uc *AttachmentsUseCase) Delete(
+	ctx context.Context,
+	owner, id uuid.UUID,
+) error {
+	if err := uc.attachmentsRepo.Delete(ctx, owner, id); err != nil {
+		return fmt.Errorf("AttachmentsUseCase - Delete - uc.attachmentsRepo.Delete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/keeper/entity"
+	"github.com/alkurbatov/goph-keeper/internal/keeper/repo"
+	"github.com/alkurbatov/goph-keeper/internal/keeper/usecase"
+	"github.com/alkurbatov/goph-keeper/internal/libraries/gophtest"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAttachment(t *testing.T) {
+	tt := []struct {
+		name string
+		id   uuid.UUID
+		err  error
+	}{
+		{
+			name: "Create attachment",
+			id:   uuid.NewV4(),
+		},
+		{
+			name: "Create attachment fails if secret doesn't exist",
+			err:  entity.ErrSecretNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := uuid.NewV4()
+			secretID := uuid.NewV4()
+
+			m := &repo.AttachmentsRepoMock{}
+			m.On(
+				"Create",
+				mock.Anything,
+				owner,
+				secretID,
+				[]byte(gophtest.Metadata),
+				[]byte(gophtest.TextData),
+			).
+				Return(tc.id, tc.err)
+
+			sat := usecase.NewAttachmentsUseCase(m)
+			id, err := sat.Create(
+				context.Background(),
+				owner,
+				secretID,
+				[]byte(gophtest.Metadata),
+				[]byte(gophtest.TextData),
+			)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.id, id)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAttachments(t *testing.T) {
+	tt := []struct {
+		name        string
+		attachments []entity.Attachment
+		err         error
+	}{
+		{
+			name: "List attachments of a secret",
+			attachments: []entity.Attachment{
+				{ID: uuid.NewV4(), Metadata: []byte(gophtest.Metadata)},
+			},
+		},
+		{
+			name:        "List attachments fails if repo fails",
+			attachments: nil,
+			err:         gophtest.ErrUnexpected,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := uuid.NewV4()
+			secretID := uuid.NewV4()
+
+			m := &repo.AttachmentsRepoMock{}
+			m.On("List", mock.Anything, owner, secretID).
+				Return(tc.attachments, tc.err)
+
+			sat := usecase.NewAttachmentsUseCase(m)
+			rv, err := sat.List(context.Background(), owner, secretID)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.attachments, rv)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAttachment(t *testing.T) {
+	tt := []struct {
+		name       string
+		attachment *entity.Attachment
+		err        error
+	}{
+		{
+			name: "Get attachment",
+			attachment: &entity.Attachment{
+				ID:       uuid.NewV4(),
+				SecretID: uuid.NewV4(),
+				Metadata: []byte(gophtest.Metadata),
+				Data:     []byte(gophtest.TextData),
+			},
+		},
+		{
+			name: "Get attachment fails if attachment doesn't exist",
+			err:  entity.ErrAttachmentNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := uuid.NewV4()
+			id := uuid.NewV4()
+
+			m := &repo.AttachmentsRepoMock{}
+			m.On("Get", mock.Anything, owner, id).
+				Return(tc.attachment, tc.err)
+
+			sat := usecase.NewAttachmentsUseCase(m)
+			rv, err := sat.Get(context.Background(), owner, id)
+
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.attachment, rv)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	tt := []struct {
+		name     string
+		expected error
+	}{
+		{
+			name: "Delete attachment",
+		},
+		{
+			name:     "Delete attachment if attachment not found",
+			expected: entity.ErrAttachmentNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := uuid.NewV4()
+			id := uuid.NewV4()
+
+			m := &repo.AttachmentsRepoMock{}
+			m.On("Delete", mock.Anything, owner, id).
+				Return(tc.expected)
+
+			sat := usecase.NewAttachmentsUseCase(m)
+			err := sat.Delete(context.Background(), owner, id)
+
+			require.ErrorIs(t, err, tc.expected)
+			m.AssertExpectations(t)
+		})
+	}
+}
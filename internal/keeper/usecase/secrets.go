@@ -28,9 +28,9 @@ func (uc *SecretsUseCase) Create(
 	owner uuid.UUID,
 	name string,
 	kind goph.DataKind,
-	metadata, data []byte,
+	metadata, data, labels, folderDigest []byte,
 ) (uuid.UUID, error) {
-	id, err := uc.secretsRepo.Create(ctx, owner, name, kind, metadata, data)
+	id, err := uc.secretsRepo.Create(ctx, owner, name, kind, metadata, data, labels, folderDigest)
 	if err != nil {
 		return uuid.UUID{}, fmt.Errorf("SecretsUseCase - Create - uc.secretsRepo.Create: %w", err)
 	}
@@ -38,6 +38,20 @@ func (uc *SecretsUseCase) Create(
 	return id, nil
 }
 
+// CreateBatch creates several secrets at once.
+func (uc *SecretsUseCase) CreateBatch(
+	ctx context.Context,
+	owner uuid.UUID,
+	secrets []entity.Secret,
+) ([]uuid.UUID, error) {
+	ids, err := uc.secretsRepo.CreateBatch(ctx, owner, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - CreateBatch - uc.secretsRepo.CreateBatch: %w", err)
+	}
+
+	return ids, nil
+}
+
 // List returns list of user's secrets.
 func (uc *SecretsUseCase) List(
 	ctx context.Context,
@@ -64,16 +78,40 @@ func (uc *SecretsUseCase) Get(
 	return secret, nil
 }
 
+// GetByName retrieves full secret info from database by name of the secret within a folder.
+func (uc *SecretsUseCase) GetByName(
+	ctx context.Context,
+	owner uuid.UUID,
+	name string,
+	folderDigest []byte,
+) (*entity.Secret, error) {
+	secret, err := uc.secretsRepo.GetByName(ctx, owner, name, folderDigest)
+	if err != nil {
+		return nil, fmt.Errorf("SecretsUseCase - GetByName - uc.secretsRepo.GetByName: %w", err)
+	}
+
+	return secret, nil
+}
+
 // Update changes secret info and data.
 func (uc *SecretsUseCase) Update(
 	ctx context.Context,
 	owner, id uuid.UUID,
 	changed []string,
 	name string,
-	metadata []byte,
-	data []byte,
+	metadata, data, labels, folderDigest []byte,
 ) error {
-	if err := uc.secretsRepo.Update(ctx, owner, id, changed, name, metadata, data); err != nil {
+	if err := uc.secretsRepo.Update(
+		ctx,
+		owner,
+		id,
+		changed,
+		name,
+		metadata,
+		data,
+		labels,
+		folderDigest,
+	); err != nil {
 		return fmt.Errorf("SecretsUseCase - Update - uc.secretsRepo.Update: %w", err)
 	}
 
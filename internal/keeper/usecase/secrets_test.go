@@ -32,6 +32,8 @@ func doCreateSecret(
 		goph.DataKind_TEXT,
 		[]byte(gophtest.Metadata),
 		[]byte(gophtest.TextData),
+		[]byte(gophtest.Labels),
+		[]byte(gophtest.FolderDigest),
 	).
 		Return(repoSecretID, repoErr)
 
@@ -43,6 +45,8 @@ func doCreateSecret(
 		goph.DataKind_TEXT,
 		[]byte(gophtest.Metadata),
 		[]byte(gophtest.TextData),
+		[]byte(gophtest.Labels),
+		[]byte(gophtest.FolderDigest),
 	)
 
 	m.AssertExpectations(t)
@@ -92,12 +96,38 @@ func doGetSecret(t *testing.T, repoRV *entity.Secret, repoErr error) (*entity.Se
 	return secret, err
 }
 
+func doGetSecretByName(
+	t *testing.T,
+	repoRV *entity.Secret,
+	repoErr error,
+) (*entity.Secret, error) {
+	t.Helper()
+
+	owner := uuid.NewV4()
+
+	m := &repo.SecretsRepoMock{}
+	m.On("GetByName", mock.Anything, owner, gophtest.SecretName, []byte(gophtest.FolderDigest)).
+		Return(repoRV, repoErr)
+
+	sat := usecase.NewSecretsUseCase(m)
+	secret, err := sat.GetByName(
+		context.Background(),
+		owner,
+		gophtest.SecretName,
+		[]byte(gophtest.FolderDigest),
+	)
+
+	m.AssertExpectations(t)
+
+	return secret, err
+}
+
 func doUpdateSecret(t *testing.T, repoErr error) error {
 	t.Helper()
 
 	owner := uuid.NewV4()
 	id := uuid.NewV4()
-	changed := []string{"name", "metadata", "data"}
+	changed := []string{"name", "metadata", "data", "labels"}
 
 	m := &repo.SecretsRepoMock{}
 	m.On(
@@ -109,6 +139,8 @@ func doUpdateSecret(t *testing.T, repoErr error) error {
 		gophtest.SecretName,
 		[]byte(gophtest.Metadata),
 		[]byte(gophtest.TextData),
+		[]byte(gophtest.Labels),
+		[]byte(gophtest.FolderDigest),
 	).
 		Return(repoErr)
 
@@ -121,6 +153,8 @@ func doUpdateSecret(t *testing.T, repoErr error) error {
 		gophtest.SecretName,
 		[]byte(gophtest.Metadata),
 		[]byte(gophtest.TextData),
+		[]byte(gophtest.Labels),
+		[]byte(gophtest.FolderDigest),
 	)
 
 	m.AssertExpectations(t)
@@ -182,6 +216,45 @@ func TestCreateSecret(t *testing.T) {
 	}
 }
 
+func TestCreateSecretsBatch(t *testing.T) {
+	tt := []struct {
+		name    string
+		repoIDs []uuid.UUID
+		repoErr error
+	}{
+		{
+			name:    "Create batch of secrets",
+			repoIDs: []uuid.UUID{uuid.NewV4(), uuid.NewV4()},
+		},
+		{
+			name:    "Create batch fails if secret exists",
+			repoIDs: []uuid.UUID(nil),
+			repoErr: entity.ErrSecretExists,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := uuid.NewV4()
+			secrets := []entity.Secret{
+				{Name: gophtest.SecretName, Kind: goph.DataKind_TEXT, Data: []byte(gophtest.TextData)},
+				{Name: gophtest.Username, Kind: goph.DataKind_TEXT, Data: []byte(gophtest.TextData)},
+			}
+
+			m := &repo.SecretsRepoMock{}
+			m.On("CreateBatch", mock.Anything, owner, secrets).
+				Return(tc.repoIDs, tc.repoErr)
+
+			sat := usecase.NewSecretsUseCase(m)
+			ids, err := sat.CreateBatch(context.Background(), owner, secrets)
+
+			require.ErrorIs(t, err, tc.repoErr)
+			require.Equal(t, tc.repoIDs, ids)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
 func TestListSecrets(t *testing.T) {
 	type expected struct {
 		secrets []entity.Secret
@@ -326,3 +399,23 @@ func TestDeleteSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSecretByName(t *testing.T) {
+	expected := &entity.Secret{
+		ID:   uuid.NewV4(),
+		Name: gophtest.SecretName,
+		Kind: goph.DataKind_TEXT,
+		Data: []byte(gophtest.TextData),
+	}
+
+	secret, err := doGetSecretByName(t, expected, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, secret)
+}
+
+func TestGetSecretByNameFailure(t *testing.T) {
+	_, err := doGetSecretByName(t, (*entity.Secret)(nil), entity.ErrSecretNotFound)
+
+	require.ErrorIs(t, err, entity.ErrSecretNotFound)
+}
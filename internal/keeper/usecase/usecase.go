@@ -14,25 +14,38 @@ type Auth interface {
 	Login(ctx context.Context, username, securityKey string) (entity.AccessToken, error)
 }
 
+type Attachments interface {
+	Create(ctx context.Context, owner, secretID uuid.UUID, metadata, data []byte) (uuid.UUID, error)
+	List(ctx context.Context, owner, secretID uuid.UUID) ([]entity.Attachment, error)
+	Get(ctx context.Context, owner, id uuid.UUID) (*entity.Attachment, error)
+	Delete(ctx context.Context, owner, id uuid.UUID) error
+}
+
 type Secrets interface {
 	Create(
 		ctx context.Context,
 		owner uuid.UUID,
 		name string,
 		kind goph.DataKind,
-		metadata, data []byte,
+		metadata, data, labels, folderDigest []byte,
 	) (uuid.UUID, error)
 
+	CreateBatch(ctx context.Context, owner uuid.UUID, secrets []entity.Secret) ([]uuid.UUID, error)
 	List(ctx context.Context, owner uuid.UUID) ([]entity.Secret, error)
 	Get(ctx context.Context, owner, id uuid.UUID) (*entity.Secret, error)
+	GetByName(
+		ctx context.Context,
+		owner uuid.UUID,
+		name string,
+		folderDigest []byte,
+	) (*entity.Secret, error)
 
 	Update(
 		ctx context.Context,
 		owner, id uuid.UUID,
 		changed []string,
 		name string,
-		metadata []byte,
-		data []byte,
+		metadata, data, labels, folderDigest []byte,
 	) error
 
 	Delete(ctx context.Context, owner, id uuid.UUID) error
@@ -44,16 +57,18 @@ type Users interface {
 
 // UseCases is a collection of business logic use cases.
 type UseCases struct {
-	Auth    Auth
-	Secrets Secrets
-	Users   Users
+	Attachments Attachments
+	Auth        Auth
+	Secrets     Secrets
+	Users       Users
 }
 
 // New creates and initializes collection of business logic use cases.
 func New(cfg *config.Config, repos *repo.Repositories) *UseCases {
 	return &UseCases{
-		Auth:    NewAuthUseCase(cfg.Secret, repos.Users),
-		Secrets: NewSecretsUseCase(repos.Secrets),
-		Users:   NewUsersUseCase(cfg.Secret, repos.Users),
+		Attachments: NewAttachmentsUseCase(repos.Attachments),
+		Auth:        NewAuthUseCase(cfg.Secret, repos.Users),
+		Secrets:     NewSecretsUseCase(repos.Secrets),
+		Users:       NewUsersUseCase(cfg.Secret, repos.Users),
 	}
 }
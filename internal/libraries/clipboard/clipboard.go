@@ -0,0 +1,171 @@
+// Package clipboard puts text into the system clipboard.
+package clipboard
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+var ErrUnavailable = errors.New("no clipboard tool found")
+
+// Clipboard accesses the system clipboard with external tools, e.g. wl-copy or xclip.
+type Clipboard struct {
+	name  string
+	copy  []string
+	paste []string
+	clear []string
+}
+
+// tool describes commandline tools managing the clipboard.
+type tool struct {
+	Clipboard
+
+	// env is environment variable which must be set to use the tool, e.g. WAYLAND_DISPLAY.
+	env string
+	// goos limits the tool to the operating system.
+	goos string
+}
+
+// _tools are known clipboard tools in order of preference.
+var _tools = []tool{
+	{
+		Clipboard: Clipboard{
+			name:  "wl-clipboard",
+			copy:  []string{"wl-copy"},
+			paste: []string{"wl-paste", "--no-newline"},
+			clear: []string{"wl-copy", "--clear"},
+		},
+		env: "WAYLAND_DISPLAY",
+	},
+	{
+		Clipboard: Clipboard{
+			name:  "xclip",
+			copy:  []string{"xclip", "-selection", "clipboard"},
+			paste: []string{"xclip", "-selection", "clipboard", "-o"},
+		},
+		env: "DISPLAY",
+	},
+	{
+		Clipboard: Clipboard{
+			name:  "xsel",
+			copy:  []string{"xsel", "--clipboard", "--input"},
+			paste: []string{"xsel", "--clipboard", "--output"},
+			clear: []string{"xsel", "--clipboard", "--clear"},
+		},
+		env: "DISPLAY",
+	},
+	{
+		Clipboard: Clipboard{
+			name:  "pbcopy",
+			copy:  []string{"pbcopy"},
+			paste: []string{"pbpaste"},
+		},
+		goos: "darwin",
+	},
+}
+
+// Detect finds clipboard tool suitable for current session.
+// Returns ErrUnavailable if no tool is installed, e.g. in SSH session,
+// OSC 52 escape sequences are the only option in this case.
+func Detect() (*Clipboard, error) {
+	for i := range _tools {
+		t := &_tools[i]
+
+		if t.env != "" && os.Getenv(t.env) == "" {
+			continue
+		}
+
+		if t.goos != "" && t.goos != runtime.GOOS {
+			continue
+		}
+
+		if !installed(t.copy[0]) || !installed(t.paste[0]) {
+			continue
+		}
+
+		c := t.Clipboard
+
+		return &c, nil
+	}
+
+	return nil, ErrUnavailable
+}
+
+// installed reports whether the executable can be found in PATH.
+func installed(name string) bool {
+	_, err := exec.LookPath(name)
+
+	return err == nil
+}
+
+// Name returns name of the used tool.
+func (c *Clipboard) Name() string {
+	return c.name
+}
+
+// Write puts the text into the clipboard.
+func (c *Clipboard) Write(text string) error {
+	return run(c.copy, text)
+}
+
+// Read returns current content of the clipboard.
+func (c *Clipboard) Read() (string, error) {
+	var out bytes.Buffer
+
+	cmd := exec.Command(c.paste[0], c.paste[1:]...) //nolint:gosec // known tools only
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("clipboard - Read - %s: %w", c.paste[0], err)
+	}
+
+	return out.String(), nil
+}
+
+// ClearIfHolds empties the clipboard only if it still holds the text with the digest,
+// so that values copied by user afterwards are kept. Reports whether the clipboard was cleared.
+func (c *Clipboard) ClearIfHolds(digest string) (bool, error) {
+	content, err := c.Read()
+	if err != nil {
+		return false, err
+	}
+
+	if Digest(content) != digest && Digest(strings.TrimSuffix(content, "\n")) != digest {
+		return false, nil
+	}
+
+	if c.clear != nil {
+		return true, run(c.clear, "")
+	}
+
+	return true, c.Write("")
+}
+
+// Digest returns fingerprint of the text used to recognize clipboard content
+// without keeping the text itself.
+func Digest(text string) string {
+	sum := sha256.Sum256([]byte(text))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// run executes the tool feeding the input to it.
+// NB (alkurbatov): Output of the tool is discarded, because some tools (e.g. xclip) fork
+// and keep serving the clipboard, so reading their output would never finish.
+func run(args []string, input string) error {
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec // known tools only
+	cmd.Stdin = strings.NewReader(input)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("clipboard - run - %s: %w", args[0], err)
+	}
+
+	return nil
+}
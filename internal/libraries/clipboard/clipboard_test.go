@@ -0,0 +1,114 @@
+package clipboard_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/clipboard"
+	"github.com/stretchr/testify/require"
+)
+
+// installFakeWayland puts fake wl-copy and wl-paste keeping content in a file into PATH.
+func installFakeWayland(t *testing.T) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not supported")
+	}
+
+	dir := t.TempDir()
+	store := filepath.Join(dir, "content")
+
+	scripts := map[string]string{
+		"wl-copy": "#!/bin/sh\n" +
+			`if [ "$1" = "--clear" ]; then : > ` + store + "; else cat > " + store + "; fi\n",
+		"wl-paste": "#!/bin/sh\ncat " + store + "\n",
+	}
+
+	for name, body := range scripts {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o700))
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+}
+
+func TestDetectWayland(t *testing.T) {
+	installFakeWayland(t)
+
+	sat, err := clipboard.Detect()
+
+	require.NoError(t, err)
+	require.Equal(t, "wl-clipboard", sat.Name())
+}
+
+func TestDetectNoTools(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("pbcopy is always available")
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("DISPLAY", "")
+
+	_, err := clipboard.Detect()
+
+	require.ErrorIs(t, err, clipboard.ErrUnavailable)
+}
+
+func TestWriteRead(t *testing.T) {
+	installFakeWayland(t)
+
+	sat, err := clipboard.Detect()
+	require.NoError(t, err)
+
+	require.NoError(t, sat.Write("secret"))
+
+	content, err := sat.Read()
+	require.NoError(t, err)
+	require.Equal(t, "secret", content)
+}
+
+func TestClearIfHolds(t *testing.T) {
+	tt := []struct {
+		name     string
+		copied   string
+		current  string
+		expected string
+		cleared  bool
+	}{
+		{
+			name:    "Clipboard still holds copied value",
+			copied:  "secret",
+			current: "secret",
+			cleared: true,
+		},
+		{
+			name:     "User copied something else",
+			copied:   "secret",
+			current:  "something else",
+			expected: "something else",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			installFakeWayland(t)
+
+			sat, err := clipboard.Detect()
+			require.NoError(t, err)
+			require.NoError(t, sat.Write(tc.current))
+
+			cleared, err := sat.ClearIfHolds(clipboard.Digest(tc.copied))
+			require.NoError(t, err)
+			require.Equal(t, tc.cleared, cleared)
+
+			content, err := sat.Read()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, strings.TrimSpace(content))
+		})
+	}
+}
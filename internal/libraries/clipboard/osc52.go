@@ -0,0 +1,16 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// WriteOSC52 asks the terminal to put the text into the system clipboard
+// with the OSC 52 escape sequence. The sequence is supported by most modern
+// terminal emulators and works over SSH, but the terminal may silently ignore it.
+func WriteOSC52(w io.Writer, text string) error {
+	_, err := fmt.Fprintf(w, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+
+	return err
+}
@@ -0,0 +1,16 @@
+package clipboard_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/clipboard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOSC52(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, clipboard.WriteOSC52(&buf, "secret"))
+	require.Equal(t, "\x1b]52;c;c2VjcmV0\a", buf.String())
+}
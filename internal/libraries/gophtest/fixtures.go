@@ -16,6 +16,9 @@ const (
 	SecretName = "my-secret"
 	Metadata   = "encrypted extra data"
 	TextData   = "encrypted secret data"
+
+	Labels       = "encrypted folder and tags"
+	FolderDigest = "folder digest"
 )
 
 var ErrUnexpected = errors.New("runtime error")
@@ -0,0 +1,200 @@
+// Package hibp looks up passwords in local copy of the Have I Been Pwned
+// Pwned Passwords database, nothing is sent over network.
+//
+// Two layouts of the SHA-1 database are supported:
+//   - directory of range files named after the first 5 hex digits of hashes,
+//     e.g. 21BD1.txt, each line holds the rest 35 digits and the count, e.g.
+//     "0018A45C4D1DEF81644B54AB7F969B88D65:10", as produced by PwnedPasswordsDownloader;
+//   - single file of full hashes with counts, e.g. pwned-passwords-sha1-ordered-by-hash-v8.txt.
+//
+// Lines of each file must be ordered by hash, so that lookups use binary search.
+package hibp
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // HIBP database consists of SHA-1 hashes
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// _prefixLength is number of hex digits in names of range files.
+	_prefixLength = 5
+	// _maxLine is maximal length of a line in the database,
+	// lines consist of 40 hex digits, colon, count and line break.
+	_maxLine = 64
+)
+
+var (
+	ErrMissingRange = errors.New("range file is missing from the database")
+	ErrMalformedDB  = errors.New("malformed line in the database")
+	ErrBadHash      = errors.New("invalid SHA-1 hash")
+)
+
+// DB is local copy of the Pwned Passwords database.
+type DB struct {
+	path string
+	dir  bool
+}
+
+// Open checks presence of the database, the path can be a directory of range files
+// or a single ordered file.
+func Open(path string) (*DB, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("hibp - Open - os.Stat: %w", err)
+	}
+
+	return &DB{path: path, dir: info.IsDir()}, nil
+}
+
+// Lookup returns number of times the password appeared in data breaches, 0 if never.
+func (db *DB) Lookup(password string) (int, error) {
+	digest := sha1.Sum([]byte(password)) //nolint:gosec // HIBP database consists of SHA-1 hashes
+
+	return db.LookupHash(strings.ToUpper(hex.EncodeToString(digest[:])))
+}
+
+// LookupHash returns number of times the password with the SHA-1 hash in hex form
+// appeared in data breaches, 0 if never.
+func (db *DB) LookupHash(hash string) (int, error) {
+	hash = strings.ToUpper(hash)
+
+	if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha1.Size*2 {
+		return 0, fmt.Errorf("%w: %q", ErrBadHash, hash)
+	}
+
+	if !db.dir {
+		return lookupFile(db.path, hash)
+	}
+
+	prefix, suffix := hash[:_prefixLength], hash[_prefixLength:]
+
+	for _, name := range []string{prefix + ".txt", prefix} {
+		path := filepath.Join(db.path, name)
+		if _, err := os.Stat(path); err == nil {
+			return lookupFile(path, suffix)
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrMissingRange, prefix)
+}
+
+// lookupFile searches the ordered file for line starting with the key.
+func lookupFile(path, key string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("hibp - lookupFile - os.Open: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("hibp - lookupFile - f.Stat: %w", err)
+	}
+
+	return search(f, info.Size(), key)
+}
+
+// search looks for the key among lines of the ordered data with binary search
+// over byte offsets: each offset is mapped to the line starting at or after it.
+func search(r io.ReaderAt, size int64, key string) (int, error) {
+	lo, hi := int64(0), size
+
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		start, err := lineStart(r, size, mid)
+		if err != nil {
+			return 0, err
+		}
+
+		if start >= size {
+			hi = mid
+
+			continue
+		}
+
+		found, _, err := readLine(r, start)
+		if err != nil {
+			return 0, err
+		}
+
+		if found < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	start, err := lineStart(r, size, lo)
+	if err != nil || start >= size {
+		return 0, err
+	}
+
+	found, count, err := readLine(r, start)
+	if err != nil || found != key {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// lineStart returns offset of the line starting at or after the position.
+func lineStart(r io.ReaderAt, size, pos int64) (int64, error) {
+	if pos == 0 {
+		return 0, nil
+	}
+
+	buf := make([]byte, _maxLine)
+
+	n, err := r.ReadAt(buf, pos-1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("hibp - lineStart - r.ReadAt: %w", err)
+	}
+
+	idx := bytes.IndexByte(buf[:n], '\n')
+	if idx < 0 {
+		if pos-1+int64(n) >= size {
+			return size, nil
+		}
+
+		return 0, ErrMalformedDB
+	}
+
+	return pos + int64(idx), nil
+}
+
+// readLine reads hash and count of the line starting at the offset.
+// Lines with zero count are padding of range files and are treated as absent.
+func readLine(r io.ReaderAt, start int64) (string, int, error) {
+	buf := make([]byte, _maxLine)
+
+	n, err := r.ReadAt(buf, start)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", 0, fmt.Errorf("hibp - readLine - r.ReadAt: %w", err)
+	}
+
+	line := buf[:n]
+	if idx := bytes.IndexByte(line, '\n'); idx >= 0 {
+		line = line[:idx]
+	}
+
+	hash, rawCount, ok := strings.Cut(strings.TrimSpace(string(line)), ":")
+	if !ok {
+		return "", 0, fmt.Errorf("%w: %q", ErrMalformedDB, line)
+	}
+
+	count, err := strconv.Atoi(rawCount)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %q", ErrMalformedDB, line)
+	}
+
+	return strings.ToUpper(hash), count, nil
+}
@@ -0,0 +1,194 @@
+package hibp_test
+
+import (
+	"crypto/sha1" //nolint:gosec // HIBP database consists of SHA-1 hashes
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/hibp"
+	"github.com/stretchr/testify/require"
+)
+
+var _breached = map[string]int{
+	"password": 9545824,
+	"123456":   37359195,
+	"qwerty":   10556095,
+	"letmein":  1214124,
+}
+
+func sha1Hex(src string) string {
+	digest := sha1.Sum([]byte(src)) //nolint:gosec // HIBP database consists of SHA-1 hashes
+
+	return strings.ToUpper(hex.EncodeToString(digest[:]))
+}
+
+// newLines returns ordered lines of the database: hashes of breached passwords
+// and of n filler passwords.
+func newLines(n int) []string {
+	rv := make([]string, 0, len(_breached)+n)
+
+	for password, count := range _breached {
+		rv = append(rv, fmt.Sprintf("%s:%d", sha1Hex(password), count))
+	}
+
+	for i := 0; i < n; i++ {
+		rv = append(rv, fmt.Sprintf("%s:%d", sha1Hex(fmt.Sprintf("filler-%d", i)), i+1))
+	}
+
+	sort.Strings(rv)
+
+	return rv
+}
+
+func writeFile(t *testing.T, path string, lines []string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600))
+}
+
+func newSingleFileDB(t *testing.T, lines []string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	writeFile(t, path, lines)
+
+	return path
+}
+
+func newRangeDB(t *testing.T, lines []string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	ranges := make(map[string][]string)
+
+	for _, line := range lines {
+		ranges[line[:5]] = append(ranges[line[:5]], line[5:])
+	}
+
+	// NB (alkurbatov): Range files of checked passwords must exist.
+	for _, password := range []string{"correct horse battery staple", "Tr0ub4dor&3"} {
+		prefix := sha1Hex(password)[:5]
+		ranges[prefix] = append(ranges[prefix], "00000000000000000000000000000000000:0")
+		sort.Strings(ranges[prefix])
+	}
+
+	for prefix, suffixes := range ranges {
+		writeFile(t, filepath.Join(dir, prefix+".txt"), suffixes)
+	}
+
+	return dir
+}
+
+func TestLookup(t *testing.T) {
+	lines := newLines(500)
+
+	tt := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "Single ordered file",
+			path: newSingleFileDB(t, lines),
+		},
+		{
+			name: "Directory of range files",
+			path: newRangeDB(t, lines),
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := hibp.Open(tc.path)
+			require.NoError(t, err)
+
+			for password, expected := range _breached {
+				count, err := db.Lookup(password)
+
+				require.NoError(t, err)
+				require.Equal(t, expected, count, password)
+			}
+
+			for _, password := range []string{"correct horse battery staple", "Tr0ub4dor&3"} {
+				count, err := db.Lookup(password)
+
+				require.NoError(t, err)
+				require.Zero(t, count, password)
+			}
+		})
+	}
+}
+
+func TestLookupEveryLine(t *testing.T) {
+	lines := newLines(300)
+
+	db, err := hibp.Open(newSingleFileDB(t, lines))
+	require.NoError(t, err)
+
+	for _, line := range lines {
+		hash, _, _ := strings.Cut(line, ":")
+
+		count, err := db.LookupHash(strings.ToLower(hash))
+
+		require.NoError(t, err)
+		require.Positive(t, count, hash)
+	}
+
+	for _, hash := range []string{strings.Repeat("0", 40), strings.Repeat("F", 40)} {
+		count, err := db.LookupHash(hash)
+
+		require.NoError(t, err)
+		require.Zero(t, count)
+	}
+}
+
+func TestLookupPaddingIsIgnored(t *testing.T) {
+	hash := sha1Hex("correct horse battery staple")
+
+	db, err := hibp.Open(newSingleFileDB(t, []string{hash + ":0"}))
+	require.NoError(t, err)
+
+	count, err := db.LookupHash(hash)
+
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestLookupMissingRange(t *testing.T) {
+	db, err := hibp.Open(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = db.Lookup("password")
+
+	require.ErrorIs(t, err, hibp.ErrMissingRange)
+}
+
+func TestLookupMalformed(t *testing.T) {
+	db, err := hibp.Open(newSingleFileDB(t, []string{"not a hash"}))
+	require.NoError(t, err)
+
+	_, err = db.Lookup("password")
+
+	require.ErrorIs(t, err, hibp.ErrMalformedDB)
+}
+
+func TestLookupBadHash(t *testing.T) {
+	db, err := hibp.Open(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = db.LookupHash("XYZ")
+
+	require.ErrorIs(t, err, hibp.ErrBadHash)
+}
+
+func TestOpenMissing(t *testing.T) {
+	_, err := hibp.Open(filepath.Join(t.TempDir(), "missing"))
+
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
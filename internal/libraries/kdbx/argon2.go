@@ -0,0 +1,348 @@
+package kdbx
+
+import (
+	"encoding/binary"
+	"hash"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Argon2Variant is variant of Argon2 function as defined in RFC 9106.
+type Argon2Variant uint32
+
+// NB (alkurbatov): golang.org/x/crypto/argon2 doesn't provide Argon2d,
+// which is the default key derivation function of KeePassXC.
+const (
+	Argon2d Argon2Variant = iota
+	Argon2i
+	Argon2id
+)
+
+const (
+	_argon2Version    = 0x13
+	_argon2SyncPoints = 4
+	_argon2BlockWords = 128
+	_argon2BlockSize  = _argon2BlockWords * 8
+)
+
+type argon2Block [_argon2BlockWords]uint64
+
+// Argon2Key derives key of keyLen bytes from the password with the variant of Argon2.
+// Memory is set in KiB, secret and data are optional.
+func Argon2Key(
+	mode Argon2Variant,
+	password, salt, secret, data []byte,
+	iterations, memory uint32,
+	threads uint8,
+	keyLen uint32,
+) []byte {
+	lanes := uint32(threads)
+
+	h0 := argon2InitHash(mode, password, salt, secret, data, iterations, memory, lanes, keyLen)
+
+	memory = memory / (_argon2SyncPoints * lanes) * (_argon2SyncPoints * lanes)
+	if memory < 2*_argon2SyncPoints*lanes {
+		memory = 2 * _argon2SyncPoints * lanes
+	}
+
+	blocks := argon2InitBlocks(&h0, memory, lanes)
+	argon2Fill(blocks, mode, iterations, memory, lanes)
+
+	return argon2Finalize(blocks, memory, lanes, keyLen)
+}
+
+func argon2InitHash(
+	mode Argon2Variant,
+	password, salt, secret, data []byte,
+	iterations, memory, lanes, keyLen uint32,
+) [blake2b.Size + 8]byte {
+	var h0 [blake2b.Size + 8]byte
+
+	h, _ := blake2b.New512(nil)
+
+	for _, v := range []uint32{lanes, keyLen, memory, iterations, _argon2Version, uint32(mode)} {
+		writeUint32(h, v)
+	}
+
+	for _, v := range [][]byte{password, salt, secret, data} {
+		writeUint32(h, uint32(len(v)))
+		h.Write(v)
+	}
+
+	h.Sum(h0[:0])
+
+	return h0
+}
+
+func argon2InitBlocks(h0 *[blake2b.Size + 8]byte, memory, lanes uint32) []argon2Block {
+	var buf [_argon2BlockSize]byte
+
+	blocks := make([]argon2Block, memory)
+	laneLength := memory / lanes
+
+	for lane := uint32(0); lane < lanes; lane++ {
+		binary.LittleEndian.PutUint32(h0[blake2b.Size+4:], lane)
+
+		for i := uint32(0); i < 2; i++ {
+			binary.LittleEndian.PutUint32(h0[blake2b.Size:], i)
+			argon2Hash(buf[:], h0[:])
+
+			b := &blocks[lane*laneLength+i]
+			for j := range b {
+				b[j] = binary.LittleEndian.Uint64(buf[j*8:])
+			}
+		}
+	}
+
+	return blocks
+}
+
+func argon2Fill(blocks []argon2Block, mode Argon2Variant, iterations, memory, lanes uint32) {
+	laneLength := memory / lanes
+	segmentLength := laneLength / _argon2SyncPoints
+
+	fillSegment := func(pass, slice, lane uint32) {
+		var addresses, input, zero argon2Block
+
+		dataIndependent := mode == Argon2i ||
+			(mode == Argon2id && pass == 0 && slice < _argon2SyncPoints/2)
+
+		if dataIndependent {
+			input[0] = uint64(pass)
+			input[1] = uint64(lane)
+			input[2] = uint64(slice)
+			input[3] = uint64(memory)
+			input[4] = uint64(iterations)
+			input[5] = uint64(mode)
+		}
+
+		index := uint32(0)
+		if pass == 0 && slice == 0 {
+			// The first two blocks are already filled.
+			index = 2
+
+			if dataIndependent {
+				input[6]++
+				argon2Compress(&addresses, &input, &zero, false)
+				argon2Compress(&addresses, &addresses, &zero, false)
+			}
+		}
+
+		offset := lane*laneLength + slice*segmentLength + index
+
+		for ; index < segmentLength; index, offset = index+1, offset+1 {
+			prev := offset - 1
+			if index == 0 && slice == 0 {
+				prev += laneLength
+			}
+
+			var random uint64
+
+			if dataIndependent {
+				if index%_argon2BlockWords == 0 {
+					input[6]++
+					argon2Compress(&addresses, &input, &zero, false)
+					argon2Compress(&addresses, &addresses, &zero, false)
+				}
+
+				random = addresses[index%_argon2BlockWords]
+			} else {
+				random = blocks[prev][0]
+			}
+
+			ref := argon2RefIndex(random, laneLength, segmentLength, lanes, pass, slice, lane, index)
+			argon2Compress(&blocks[offset], &blocks[prev], &blocks[ref], true)
+		}
+	}
+
+	for pass := uint32(0); pass < iterations; pass++ {
+		for slice := uint32(0); slice < _argon2SyncPoints; slice++ {
+			var wg sync.WaitGroup
+
+			for lane := uint32(0); lane < lanes; lane++ {
+				wg.Add(1)
+
+				go func(lane uint32) {
+					defer wg.Done()
+					fillSegment(pass, slice, lane)
+				}(lane)
+			}
+
+			wg.Wait()
+		}
+	}
+}
+
+// argon2RefIndex maps pseudo-random value to index of the reference block.
+func argon2RefIndex(
+	random uint64,
+	laneLength, segmentLength, lanes, pass, slice, lane, index uint32,
+) uint32 {
+	refLane := uint32(random>>32) % lanes
+	if pass == 0 && slice == 0 {
+		refLane = lane
+	}
+
+	area := 3 * segmentLength
+	start := ((slice + 1) % _argon2SyncPoints) * segmentLength
+
+	if lane == refLane {
+		area += index
+	}
+
+	if pass == 0 {
+		area, start = slice*segmentLength, 0
+
+		if slice == 0 || lane == refLane {
+			area += index
+		}
+	}
+
+	if index == 0 || lane == refLane {
+		area--
+	}
+
+	x := random & 0xFFFFFFFF
+	x = (x * x) >> 32
+	x = (uint64(area) * x) >> 32
+
+	return refLane*laneLength + uint32((uint64(start)+uint64(area)-(x+1))%uint64(laneLength))
+}
+
+func argon2Finalize(blocks []argon2Block, memory, lanes, keyLen uint32) []byte {
+	laneLength := memory / lanes
+	last := &blocks[memory-1]
+
+	for lane := uint32(0); lane < lanes-1; lane++ {
+		for i, v := range blocks[lane*laneLength+laneLength-1] {
+			last[i] ^= v
+		}
+	}
+
+	var buf [_argon2BlockSize]byte
+	for i, v := range last {
+		binary.LittleEndian.PutUint64(buf[i*8:], v)
+	}
+
+	key := make([]byte, keyLen)
+	argon2Hash(key, buf[:])
+
+	return key
+}
+
+// argon2Compress computes compression function G over the blocks,
+// the result is either stored in out or XORed with it.
+func argon2Compress(out, x, y *argon2Block, xor bool) {
+	var r argon2Block
+
+	for i := range r {
+		r[i] = x[i] ^ y[i]
+	}
+
+	z := r
+
+	for i := 0; i < _argon2BlockWords; i += 16 {
+		blamkaRound(
+			&z[i], &z[i+1], &z[i+2], &z[i+3], &z[i+4], &z[i+5], &z[i+6], &z[i+7],
+			&z[i+8], &z[i+9], &z[i+10], &z[i+11], &z[i+12], &z[i+13], &z[i+14], &z[i+15],
+		)
+	}
+
+	for i := 0; i < 16; i += 2 {
+		blamkaRound(
+			&z[i], &z[i+1], &z[i+16], &z[i+17], &z[i+32], &z[i+33], &z[i+48], &z[i+49],
+			&z[i+64], &z[i+65], &z[i+80], &z[i+81], &z[i+96], &z[i+97], &z[i+112], &z[i+113],
+		)
+	}
+
+	for i := range out {
+		if xor {
+			out[i] ^= z[i] ^ r[i]
+		} else {
+			out[i] = z[i] ^ r[i]
+		}
+	}
+}
+
+// blamkaRound is round of BLAKE2b with multiplications added, see RFC 9106, 3.6.
+func blamkaRound(v0, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15 *uint64) {
+	blamkaMix(v0, v4, v8, v12)
+	blamkaMix(v1, v5, v9, v13)
+	blamkaMix(v2, v6, v10, v14)
+	blamkaMix(v3, v7, v11, v15)
+
+	blamkaMix(v0, v5, v10, v15)
+	blamkaMix(v1, v6, v11, v12)
+	blamkaMix(v2, v7, v8, v13)
+	blamkaMix(v3, v4, v9, v14)
+}
+
+func blamkaMix(a, b, c, d *uint64) {
+	fBlaMka := func(x, y uint64) uint64 {
+		return x + y + 2*uint64(uint32(x))*uint64(uint32(y))
+	}
+
+	rotr := func(x uint64, n uint) uint64 {
+		return x>>n | x<<(64-n)
+	}
+
+	*a = fBlaMka(*a, *b)
+	*d = rotr(*d^*a, 32)
+	*c = fBlaMka(*c, *d)
+	*b = rotr(*b^*c, 24)
+
+	*a = fBlaMka(*a, *b)
+	*d = rotr(*d^*a, 16)
+	*c = fBlaMka(*c, *d)
+	*b = rotr(*b^*c, 63)
+}
+
+// argon2Hash is variable-length hash function H' of Argon2, see RFC 9106, 3.3.
+func argon2Hash(out, in []byte) {
+	var (
+		h   hash.Hash
+		buf [blake2b.Size]byte
+	)
+
+	if len(out) <= blake2b.Size {
+		h, _ = blake2b.New(len(out), nil)
+		writeUint32(h, uint32(len(out)))
+		h.Write(in)
+		h.Sum(out[:0])
+
+		return
+	}
+
+	h, _ = blake2b.New512(nil)
+	writeUint32(h, uint32(len(out)))
+	h.Write(in)
+	h.Sum(buf[:0])
+
+	rest := out
+
+	for {
+		copy(rest, buf[:blake2b.Size/2])
+		rest = rest[blake2b.Size/2:]
+
+		if len(rest) <= blake2b.Size {
+			break
+		}
+
+		h.Reset()
+		h.Write(buf[:])
+		h.Sum(buf[:0])
+	}
+
+	// NB (alkurbatov): The last hash is as long as remaining part of output.
+	last, _ := blake2b.New(len(rest), nil)
+	last.Write(buf[:])
+	last.Sum(rest[:0])
+}
+
+func writeUint32(h hash.Hash, v uint32) {
+	var buf [4]byte
+
+	binary.LittleEndian.PutUint32(buf[:], v)
+	h.Write(buf[:])
+}
@@ -0,0 +1,92 @@
+package kdbx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/kdbx"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+)
+
+// Test vectors from RFC 9106, 5.
+func TestArgon2KeyRFCVectors(t *testing.T) {
+	password := bytes.Repeat([]byte{0x01}, 32)
+	salt := bytes.Repeat([]byte{0x02}, 16)
+	secret := bytes.Repeat([]byte{0x03}, 8)
+	data := bytes.Repeat([]byte{0x04}, 12)
+
+	tt := []struct {
+		name     string
+		mode     kdbx.Argon2Variant
+		expected string
+	}{
+		{
+			name:     "Argon2d",
+			mode:     kdbx.Argon2d,
+			expected: "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb",
+		},
+		{
+			name:     "Argon2i",
+			mode:     kdbx.Argon2i,
+			expected: "c814d9d1dc7f37aa13f0d77f2494bda1c8de6b016dd388d29952a4c4672b6ce8",
+		},
+		{
+			name:     "Argon2id",
+			mode:     kdbx.Argon2id,
+			expected: "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			key := kdbx.Argon2Key(tc.mode, password, salt, secret, data, 3, 32, 4, 32)
+
+			require.Equal(t, tc.expected, hex.EncodeToString(key))
+		})
+	}
+}
+
+func TestArgon2KeyMatchesReferenceImplementation(t *testing.T) {
+	password := []byte("correct horse battery staple")
+	salt := []byte("0123456789abcdef0123456789abcdef")
+
+	tt := []struct {
+		name     string
+		memory   uint32
+		threads  uint8
+		keyLen   uint32
+		expected func(memory uint32, threads uint8, keyLen uint32) []byte
+		mode     kdbx.Argon2Variant
+	}{
+		{
+			name:    "Argon2id with several lanes and long key",
+			memory:  256,
+			threads: 3,
+			keyLen:  100,
+			mode:    kdbx.Argon2id,
+			expected: func(memory uint32, threads uint8, keyLen uint32) []byte {
+				return argon2.IDKey(password, salt, 2, memory, threads, keyLen)
+			},
+		},
+		{
+			name:    "Argon2i with single lane",
+			memory:  64,
+			threads: 1,
+			keyLen:  32,
+			mode:    kdbx.Argon2i,
+			expected: func(memory uint32, threads uint8, keyLen uint32) []byte {
+				return argon2.Key(password, salt, 2, memory, threads, keyLen)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			key := kdbx.Argon2Key(tc.mode, password, salt, nil, nil, 2, tc.memory, tc.threads, tc.keyLen)
+
+			require.Equal(t, tc.expected(tc.memory, tc.threads, tc.keyLen), key)
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package kdbx
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+const _blockSize = 1024 * 1024
+
+// Upper limits of KDF parameters accepted from databases,
+// prevent exhausting of resources by malicious files.
+const (
+	_maxAESRounds        = 100 * 1000 * 1000
+	_maxArgon2Iterations = 1000
+	_maxArgon2Memory     = 1024 * 1024 * 1024
+)
+
+// Parameters of key derivation functions.
+const (
+	_kdfUUID        = "$UUID"
+	_kdfSalt        = "S"
+	_kdfRounds      = "R"
+	_kdfParallelism = "P"
+	_kdfMemory      = "M"
+	_kdfIterations  = "I"
+	_kdfVersion     = "V"
+	_kdfSecret      = "K"
+	_kdfData        = "A"
+)
+
+var (
+	_kdfAES = []byte{
+		0xc9, 0xd9, 0xf3, 0x9a, 0x62, 0x8a, 0x44, 0x60,
+		0xbf, 0x74, 0x0d, 0x08, 0xc1, 0x8a, 0x4f, 0xea,
+	}
+	_kdfArgon2d = []byte{
+		0xef, 0x63, 0x6d, 0xdf, 0x8c, 0x29, 0x44, 0x4b,
+		0x91, 0xf7, 0xa9, 0xa4, 0x03, 0xe3, 0x0a, 0x0c,
+	}
+	_kdfArgon2id = []byte{
+		0x9e, 0x29, 0x8b, 0x19, 0x56, 0xdb, 0x47, 0x73,
+		0xb2, 0x3d, 0xfc, 0x3e, 0xc6, 0xf0, 0xa1, 0xe6,
+	}
+)
+
+// Types of values in variant dictionary.
+const (
+	_variantVersion   = 0x0100
+	_variantEnd       = 0x00
+	_variantUInt32    = 0x04
+	_variantUInt64    = 0x05
+	_variantBool      = 0x08
+	_variantInt32     = 0x0C
+	_variantInt64     = 0x0D
+	_variantString    = 0x18
+	_variantByteArray = 0x42
+)
+
+// variantItem is single value of variant dictionary.
+type variantItem struct {
+	kind  byte
+	key   string
+	value []byte
+}
+
+// variantDict is ordered set of typed values used to store KDF parameters.
+type variantDict []variantItem
+
+func parseVariantDict(data []byte) (variantDict, error) {
+	r := bytes.NewReader(data)
+
+	var version uint16
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, ErrMalformedDictionary
+	}
+
+	if version&0xFF00 != _variantVersion {
+		return nil, fmt.Errorf("%w: version %#x", ErrMalformedDictionary, version)
+	}
+
+	rv := make(variantDict, 0)
+
+	for {
+		kind, err := r.ReadByte()
+		if err != nil {
+			return nil, ErrMalformedDictionary
+		}
+
+		if kind == _variantEnd {
+			return rv, nil
+		}
+
+		key, err := readSizedBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := readSizedBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		rv = append(rv, variantItem{kind: kind, key: string(key), value: value})
+	}
+}
+
+func readSizedBytes(r *bytes.Reader) ([]byte, error) {
+	var size int32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, ErrMalformedDictionary
+	}
+
+	if size < 0 || int(size) > r.Len() {
+		return nil, ErrMalformedDictionary
+	}
+
+	rv := make([]byte, size)
+	r.Read(rv)
+
+	return rv, nil
+}
+
+func (d variantDict) encode() []byte {
+	var buf bytes.Buffer
+
+	binary.Write(&buf, binary.LittleEndian, uint16(_variantVersion))
+
+	for _, item := range d {
+		buf.WriteByte(item.kind)
+		binary.Write(&buf, binary.LittleEndian, int32(len(item.key)))
+		buf.WriteString(item.key)
+		binary.Write(&buf, binary.LittleEndian, int32(len(item.value)))
+		buf.Write(item.value)
+	}
+
+	buf.WriteByte(_variantEnd)
+
+	return buf.Bytes()
+}
+
+func (d variantDict) bytes(key string) []byte {
+	for _, item := range d {
+		if item.key == key {
+			return item.value
+		}
+	}
+
+	return nil
+}
+
+func (d variantDict) uint(key string) (uint64, bool) {
+	value := d.bytes(key)
+
+	switch len(value) {
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(value)), true
+
+	case 8:
+		return binary.LittleEndian.Uint64(value), true
+
+	default:
+		return 0, false
+	}
+}
+
+func (d *variantDict) putBytes(key string, value []byte) {
+	*d = append(*d, variantItem{kind: _variantByteArray, key: key, value: value})
+}
+
+func (d *variantDict) putUint32(key string, value uint32) {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, value)
+
+	*d = append(*d, variantItem{kind: _variantUInt32, key: key, value: buf})
+}
+
+func (d *variantDict) putUint64(key string, value uint64) {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, value)
+
+	*d = append(*d, variantItem{kind: _variantUInt64, key: key, value: buf})
+}
+
+// kdfParams describe key derivation function used to transform the composite key.
+type kdfParams struct {
+	dict variantDict
+}
+
+func parseKdfParams(data []byte) (kdfParams, error) {
+	dict, err := parseVariantDict(data)
+	if err != nil {
+		return kdfParams{}, err
+	}
+
+	return kdfParams{dict}, nil
+}
+
+func newArgon2Params(kdf *KDF, salt []byte) (kdfParams, error) {
+	var dict variantDict
+
+	switch kdf.Variant {
+	case Argon2d:
+		dict.putBytes(_kdfUUID, _kdfArgon2d)
+
+	case Argon2id:
+		dict.putBytes(_kdfUUID, _kdfArgon2id)
+
+	default:
+		return kdfParams{}, fmt.Errorf("%w: Argon2 variant %d", ErrUnsupportedFeature, kdf.Variant)
+	}
+
+	dict.putBytes(_kdfSalt, salt)
+	dict.putUint32(_kdfParallelism, kdf.Parallelism)
+	dict.putUint64(_kdfMemory, kdf.Memory)
+	dict.putUint64(_kdfIterations, kdf.Iterations)
+	dict.putUint32(_kdfVersion, _argon2Version)
+
+	return kdfParams{dict}, nil
+}
+
+// transform derives master key from the composite key.
+func (p kdfParams) transform(key []byte) ([]byte, error) {
+	id := p.dict.bytes(_kdfUUID)
+
+	switch {
+	case bytes.Equal(id, _kdfAES):
+		return p.transformAES(key)
+
+	case bytes.Equal(id, _kdfArgon2d):
+		return p.transformArgon2(Argon2d, key)
+
+	case bytes.Equal(id, _kdfArgon2id):
+		return p.transformArgon2(Argon2id, key)
+
+	default:
+		return nil, fmt.Errorf("%w: KDF %x", ErrUnsupportedFeature, id)
+	}
+}
+
+func (p kdfParams) transformAES(key []byte) ([]byte, error) {
+	rounds, ok := p.dict.uint(_kdfRounds)
+	if !ok {
+		return nil, ErrMalformedDictionary
+	}
+
+	if rounds > _maxAESRounds {
+		return nil, fmt.Errorf("%w: AES-KDF rounds %d", ErrUnsupportedFeature, rounds)
+	}
+
+	block, err := aes.NewCipher(p.dict.bytes(_kdfSalt))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedDictionary, err)
+	}
+
+	rv := make([]byte, len(key))
+	copy(rv, key)
+
+	for i := uint64(0); i < rounds; i++ {
+		block.Encrypt(rv[:aes.BlockSize], rv[:aes.BlockSize])
+		block.Encrypt(rv[aes.BlockSize:], rv[aes.BlockSize:])
+	}
+
+	sum := sha256.Sum256(rv)
+
+	return sum[:], nil
+}
+
+func (p kdfParams) transformArgon2(variant Argon2Variant, key []byte) ([]byte, error) {
+	iterations, ok1 := p.dict.uint(_kdfIterations)
+	memory, ok2 := p.dict.uint(_kdfMemory)
+	parallelism, ok3 := p.dict.uint(_kdfParallelism)
+	version, ok4 := p.dict.uint(_kdfVersion)
+
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return nil, ErrMalformedDictionary
+	}
+
+	if version != _argon2Version {
+		return nil, fmt.Errorf("%w: Argon2 version %#x", ErrUnsupportedFeature, version)
+	}
+
+	if iterations == 0 || iterations > _maxArgon2Iterations ||
+		memory > _maxArgon2Memory ||
+		parallelism == 0 || parallelism > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: Argon2 parameters out of range", ErrUnsupportedFeature)
+	}
+
+	return Argon2Key(
+		variant,
+		key,
+		p.dict.bytes(_kdfSalt),
+		p.dict.bytes(_kdfSecret),
+		p.dict.bytes(_kdfData),
+		uint32(iterations),
+		uint32(memory/1024),
+		uint8(parallelism),
+		sha256.Size,
+	), nil
+}
+
+// compositeKey combines credentials protecting the database, only password is supported.
+func compositeKey(password string) []byte {
+	hashed := sha256.Sum256([]byte(password))
+	sum := sha256.Sum256(hashed[:])
+
+	return sum[:]
+}
+
+// cipherKey returns key used to encrypt the payload.
+func cipherKey(masterSeed, masterKey []byte) []byte {
+	h := sha256.New()
+	h.Write(masterSeed)
+	h.Write(masterKey)
+
+	return h.Sum(nil)
+}
+
+// blockHMACBaseKey returns key used to derive HMAC keys of blocks and the header.
+func blockHMACBaseKey(masterSeed, masterKey []byte) []byte {
+	h := sha512.New()
+	h.Write(masterSeed)
+	h.Write(masterKey)
+	h.Write([]byte{0x01})
+
+	return h.Sum(nil)
+}
+
+func blockHMACKey(baseKey []byte, index uint64) []byte {
+	var buf [8]byte
+
+	binary.LittleEndian.PutUint64(buf[:], index)
+
+	h := sha512.New()
+	h.Write(buf[:])
+	h.Write(baseKey)
+
+	return h.Sum(nil)
+}
+
+func blockHMAC(baseKey []byte, index uint64, data []byte) []byte {
+	var buf [12]byte
+
+	binary.LittleEndian.PutUint64(buf[:8], index)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(len(data)))
+
+	mac := hmac.New(sha256.New, blockHMACKey(baseKey, index))
+	mac.Write(buf[:])
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
+
+// readBlocks reads encrypted payload split into blocks authenticated with HMAC.
+func readBlocks(r io.Reader, baseKey []byte) ([]byte, error) {
+	var rv bytes.Buffer
+
+	for index := uint64(0); ; index++ {
+		var (
+			sum  [sha256.Size]byte
+			size int32
+		)
+
+		if _, err := io.ReadFull(r, sum[:]); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+		}
+
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil || size < 0 {
+			return nil, fmt.Errorf("%w: bad block size", ErrCorrupted)
+		}
+
+		data, err := io.ReadAll(io.LimitReader(r, int64(size)))
+		if err != nil || len(data) != int(size) {
+			return nil, fmt.Errorf("%w: truncated block", ErrCorrupted)
+		}
+
+		if !hmac.Equal(sum[:], blockHMAC(baseKey, index, data)) {
+			return nil, fmt.Errorf("%w: block %d was modified", ErrCorrupted, index)
+		}
+
+		if size == 0 {
+			return rv.Bytes(), nil
+		}
+
+		rv.Write(data)
+	}
+}
+
+// writeBlocks splits the encrypted payload into blocks authenticated with HMAC.
+func writeBlocks(w io.Writer, baseKey, data []byte) error {
+	for index := uint64(0); ; index++ {
+		size := len(data)
+		if size > _blockSize {
+			size = _blockSize
+		}
+
+		var buf bytes.Buffer
+
+		buf.Write(blockHMAC(baseKey, index, data[:size]))
+		binary.Write(&buf, binary.LittleEndian, int32(size))
+		buf.Write(data[:size])
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+
+		if size == 0 {
+			return nil
+		}
+
+		data = data[size:]
+	}
+}
@@ -0,0 +1,407 @@
+package kdbx
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/twofish"
+)
+
+const (
+	_signature1   = 0x9AA2D903
+	_signature2   = 0xB54BFB67
+	_versionMajor = 4
+	_version      = _versionMajor << 16
+)
+
+// Fields of outer header, see https://keepass.info/help/kb/kdbx_4.html.
+const (
+	_outerEndOfHeader   = 0
+	_outerCipherID      = 2
+	_outerCompression   = 3
+	_outerMasterSeed    = 4
+	_outerEncryptionIV  = 7
+	_outerKdfParameters = 11
+)
+
+// Fields of inner header.
+const (
+	_innerEndOfHeader = 0
+	_innerStreamID    = 1
+	_innerStreamKey   = 2
+	_innerBinary      = 3
+)
+
+const (
+	_innerStreamChaCha20  = 3
+	_binaryFlagProtected  = 0x01
+	_compressionGzip      = 1
+	_masterSeedLength     = 32
+	_aesIVLength          = 16
+	_kdfSaltLength        = 32
+	_streamKeyHashedSplit = 32
+	_chachaNonceLength    = 12
+)
+
+var (
+	_cipherAES256 = []byte{
+		0x31, 0xc1, 0xf2, 0xe6, 0xbf, 0x71, 0x43, 0x50,
+		0xbe, 0x58, 0x05, 0x21, 0x6a, 0xfc, 0x5a, 0xff,
+	}
+	_cipherChaCha20 = []byte{
+		0xd6, 0x03, 0x8a, 0x2b, 0x8b, 0x6f, 0x4c, 0xb5,
+		0xa5, 0x24, 0x33, 0x9a, 0x31, 0xdb, 0xb5, 0x9a,
+	}
+	_cipherTwofish = []byte{
+		0xad, 0x68, 0xf2, 0x9f, 0x57, 0x6f, 0x4b, 0xb9,
+		0xa3, 0x6a, 0xd4, 0x7a, 0xf9, 0x65, 0x34, 0x6c,
+	}
+)
+
+// outerHeader is unencrypted header of KDBX file.
+type outerHeader struct {
+	// raw keeps the header as is to verify its integrity.
+	raw []byte
+
+	cipherID   []byte
+	compressed bool
+	masterSeed []byte
+	iv         []byte
+	kdf        kdfParams
+}
+
+func newOuterHeader(kdf *KDF) (*outerHeader, error) {
+	h := &outerHeader{
+		cipherID:   _cipherAES256,
+		compressed: true,
+		masterSeed: make([]byte, _masterSeedLength),
+		iv:         make([]byte, _aesIVLength),
+	}
+
+	salt := make([]byte, _kdfSaltLength)
+
+	for _, v := range [][]byte{h.masterSeed, h.iv, salt} {
+		if _, err := rand.Read(v); err != nil {
+			return nil, err
+		}
+	}
+
+	params, err := newArgon2Params(kdf, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	h.kdf = params
+
+	return h, nil
+}
+
+func readOuterHeader(r io.Reader) (*outerHeader, error) {
+	var raw bytes.Buffer
+
+	tee := io.TeeReader(r, &raw)
+
+	var preamble [3]uint32
+	if err := binary.Read(tee, binary.LittleEndian, &preamble); err != nil {
+		return nil, ErrNotKeePass
+	}
+
+	if preamble[0] != _signature1 || preamble[1] != _signature2 {
+		return nil, ErrNotKeePass
+	}
+
+	if major := preamble[2] >> 16; major != _versionMajor {
+		return nil, fmt.Errorf("%w: got %d.%d", ErrUnsupportedVersion, major, preamble[2]&0xFFFF)
+	}
+
+	h := &outerHeader{}
+
+	for {
+		id, data, err := readField(tee)
+		if err != nil {
+			return nil, err
+		}
+
+		switch id {
+		case _outerEndOfHeader:
+			h.raw = raw.Bytes()
+
+			return h, h.validate()
+
+		case _outerCipherID:
+			h.cipherID = data
+
+		case _outerCompression:
+			h.compressed = len(data) == 4 && binary.LittleEndian.Uint32(data) == _compressionGzip
+
+		case _outerMasterSeed:
+			h.masterSeed = data
+
+		case _outerEncryptionIV:
+			h.iv = data
+
+		case _outerKdfParameters:
+			if h.kdf, err = parseKdfParams(data); err != nil {
+				return nil, err
+			}
+
+		default:
+		}
+	}
+}
+
+func (h *outerHeader) validate() error {
+	if len(h.masterSeed) != _masterSeedLength || h.kdf.dict == nil {
+		return fmt.Errorf("%w: incomplete header", ErrCorrupted)
+	}
+
+	if !bytes.Equal(h.cipherID, _cipherAES256) &&
+		!bytes.Equal(h.cipherID, _cipherChaCha20) &&
+		!bytes.Equal(h.cipherID, _cipherTwofish) {
+		return fmt.Errorf("%w: cipher %x", ErrUnsupportedFeature, h.cipherID)
+	}
+
+	return nil
+}
+
+// verify checks integrity of the header and the key derived from the password.
+func (h *outerHeader) verify(r io.Reader, hmacBaseKey []byte) error {
+	var sums [2][sha256.Size]byte
+
+	if _, err := io.ReadFull(r, sums[0][:]); err != nil {
+		return fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+
+	if _, err := io.ReadFull(r, sums[1][:]); err != nil {
+		return fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+
+	if sha256.Sum256(h.raw) != sums[0] {
+		return fmt.Errorf("%w: header checksum mismatch", ErrCorrupted)
+	}
+
+	if !hmac.Equal(h.mac(hmacBaseKey), sums[1][:]) {
+		return ErrInvalidCredentials
+	}
+
+	return nil
+}
+
+func (h *outerHeader) mac(hmacBaseKey []byte) []byte {
+	mac := hmac.New(sha256.New, blockHMACKey(hmacBaseKey, ^uint64(0)))
+	mac.Write(h.raw)
+
+	return mac.Sum(nil)
+}
+
+func (h *outerHeader) write(w io.Writer, hmacBaseKey []byte) error {
+	var raw bytes.Buffer
+
+	binary.Write(&raw, binary.LittleEndian, [3]uint32{_signature1, _signature2, _version})
+
+	compression := make([]byte, 4)
+	if h.compressed {
+		binary.LittleEndian.PutUint32(compression, _compressionGzip)
+	}
+
+	writeField(&raw, _outerCipherID, h.cipherID)
+	writeField(&raw, _outerCompression, compression)
+	writeField(&raw, _outerMasterSeed, h.masterSeed)
+	writeField(&raw, _outerEncryptionIV, h.iv)
+	writeField(&raw, _outerKdfParameters, h.kdf.dict.encode())
+	writeField(&raw, _outerEndOfHeader, []byte("\r\n\r\n"))
+
+	h.raw = raw.Bytes()
+	sum := sha256.Sum256(h.raw)
+
+	for _, v := range [][]byte{h.raw, sum[:], h.mac(hmacBaseKey)} {
+		if _, err := w.Write(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *outerHeader) decrypt(key, data []byte) ([]byte, error) {
+	if bytes.Equal(h.cipherID, _cipherChaCha20) {
+		stream, err := chacha20.NewUnauthenticatedCipher(key, h.iv)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+		}
+
+		rv := make([]byte, len(data))
+		stream.XORKeyStream(rv, data)
+
+		return rv, nil
+	}
+
+	block, err := h.blockCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 || len(data)%block.BlockSize() != 0 || len(h.iv) != block.BlockSize() {
+		return nil, fmt.Errorf("%w: bad length of encrypted data", ErrCorrupted)
+	}
+
+	rv := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, h.iv).CryptBlocks(rv, data)
+
+	// Remove PKCS#7 padding.
+	padding := int(rv[len(rv)-1])
+	if padding == 0 || padding > block.BlockSize() {
+		return nil, fmt.Errorf("%w: bad padding", ErrCorrupted)
+	}
+
+	return rv[:len(rv)-padding], nil
+}
+
+func (h *outerHeader) encrypt(key, data []byte) ([]byte, error) {
+	block, err := h.blockCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	padding := block.BlockSize() - len(data)%block.BlockSize()
+	rv := append(data, bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	cipher.NewCBCEncrypter(block, h.iv).CryptBlocks(rv, rv)
+
+	return rv, nil
+}
+
+func (h *outerHeader) blockCipher(key []byte) (cipher.Block, error) {
+	if bytes.Equal(h.cipherID, _cipherTwofish) {
+		return twofish.NewCipher(key)
+	}
+
+	return aes.NewCipher(key)
+}
+
+// innerHeader is header of the encrypted payload.
+type innerHeader struct {
+	streamID  uint32
+	streamKey []byte
+	binaries  []Binary
+}
+
+func readInnerHeader(r io.Reader) (*innerHeader, error) {
+	h := &innerHeader{binaries: make([]Binary, 0)}
+
+	for {
+		id, data, err := readField(r)
+		if err != nil {
+			return nil, err
+		}
+
+		switch id {
+		case _innerEndOfHeader:
+			return h, nil
+
+		case _innerStreamID:
+			if len(data) != 4 {
+				return nil, fmt.Errorf("%w: bad inner stream ID", ErrCorrupted)
+			}
+
+			h.streamID = binary.LittleEndian.Uint32(data)
+
+		case _innerStreamKey:
+			h.streamKey = data
+
+		case _innerBinary:
+			if len(data) == 0 {
+				return nil, fmt.Errorf("%w: empty binary", ErrCorrupted)
+			}
+
+			h.binaries = append(h.binaries, Binary{
+				Content:   data[1:],
+				Protected: data[0]&_binaryFlagProtected != 0,
+			})
+
+		default:
+		}
+	}
+}
+
+func (h *innerHeader) write(w io.Writer) error {
+	var buf bytes.Buffer
+
+	streamID := make([]byte, 4)
+	binary.LittleEndian.PutUint32(streamID, h.streamID)
+
+	writeField(&buf, _innerStreamID, streamID)
+	writeField(&buf, _innerStreamKey, h.streamKey)
+
+	for _, b := range h.binaries {
+		flags := byte(0)
+		if b.Protected {
+			flags = _binaryFlagProtected
+		}
+
+		writeField(&buf, _innerBinary, append([]byte{flags}, b.Content...))
+	}
+
+	writeField(&buf, _innerEndOfHeader, nil)
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// stream creates cipher protecting sensitive values inside XML.
+func (h *innerHeader) stream() (cipher.Stream, error) {
+	if h.streamID != _innerStreamChaCha20 {
+		return nil, fmt.Errorf("%w: inner stream %d", ErrUnsupportedFeature, h.streamID)
+	}
+
+	sum := sha512.Sum512(h.streamKey)
+
+	return chacha20.NewUnauthenticatedCipher(
+		sum[:_streamKeyHashedSplit],
+		sum[_streamKeyHashedSplit:_streamKeyHashedSplit+_chachaNonceLength],
+	)
+}
+
+// readField reads single header field: 1-byte ID, 4-byte size and data.
+func readField(r io.Reader) (byte, []byte, error) {
+	var (
+		id   byte
+		size uint32
+	)
+
+	if err := binary.Read(r, binary.LittleEndian, &id); err != nil {
+		return 0, nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return 0, nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+
+	// NB (alkurbatov): Size is not trusted to preallocate memory, the file may be corrupted.
+	data, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+
+	if len(data) != int(size) {
+		return 0, nil, fmt.Errorf("%w: truncated header", ErrCorrupted)
+	}
+
+	return id, data, nil
+}
+
+func writeField(buf *bytes.Buffer, id byte, data []byte) {
+	buf.WriteByte(id)
+	binary.Write(buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+}
@@ -0,0 +1,207 @@
+// Package kdbx reads and writes KeePass databases in KDBX 4 format
+// and KeePass XML exports.
+package kdbx
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrNotKeePass          = errors.New("not a KeePass database")
+	ErrUnsupportedVersion  = errors.New("unsupported KDBX version, only KDBX 4 is supported")
+	ErrUnsupportedFeature  = errors.New("unsupported KDBX feature")
+	ErrInvalidCredentials  = errors.New("invalid password or corrupted database")
+	ErrCorrupted           = errors.New("corrupted KeePass database")
+	ErrBadBinaryReference  = errors.New("reference to unknown binary")
+	ErrMalformedDictionary = errors.New("malformed variant dictionary")
+)
+
+// Database is decrypted content of KeePass database.
+type Database struct {
+	File File
+
+	// Binaries are attachments of entries referred by index, see BinaryRef.
+	Binaries []Binary
+}
+
+// Binary is content of an attachment.
+type Binary struct {
+	Content   []byte
+	Protected bool
+}
+
+// KDF sets parameters of Argon2 used to derive the encryption key from the password.
+type KDF struct {
+	Variant     Argon2Variant
+	Iterations  uint64
+	Memory      uint64 // Memory in bytes.
+	Parallelism uint32
+}
+
+// DefaultKDF is used to encode databases if no parameters provided.
+var DefaultKDF = KDF{
+	Variant:     Argon2d,
+	Iterations:  10,
+	Memory:      64 * 1024 * 1024,
+	Parallelism: 2,
+}
+
+// New creates empty database with the root group.
+func New(name string) *Database {
+	return &Database{
+		File: File{
+			Meta: Meta{
+				Generator:    "goph-keeper",
+				DatabaseName: name,
+			},
+			Root: Root{
+				Group: Group{UUID: NewUUID(), Name: name},
+			},
+		},
+		Binaries: make([]Binary, 0),
+	}
+}
+
+// Binary returns content of the attachment referred by the entry.
+func (db *Database) Binary(ref BinaryRef) ([]byte, error) {
+	if ref.Value.Ref < 0 || ref.Value.Ref >= len(db.Binaries) {
+		return nil, fmt.Errorf("%w: %d", ErrBadBinaryReference, ref.Value.Ref)
+	}
+
+	return db.Binaries[ref.Value.Ref].Content, nil
+}
+
+// AddBinary stores content of an attachment and returns reference to it.
+func (db *Database) AddBinary(name string, content []byte) BinaryRef {
+	db.Binaries = append(db.Binaries, Binary{Content: content})
+
+	ref := BinaryRef{Key: name}
+	ref.Value.Ref = len(db.Binaries) - 1
+
+	return ref
+}
+
+// Decode decrypts KDBX 4 database protected with the password.
+func Decode(r io.Reader, password string) (*Database, error) {
+	br := bufio.NewReader(r)
+
+	header, err := readOuterHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	masterKey, err := header.kdf.transform(compositeKey(password))
+	if err != nil {
+		return nil, err
+	}
+
+	hmacKey := blockHMACBaseKey(header.masterSeed, masterKey)
+	if err := header.verify(br, hmacKey); err != nil {
+		return nil, err
+	}
+
+	encrypted, err := readBlocks(br, hmacKey)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := header.decrypt(cipherKey(header.masterSeed, masterKey), encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	var content io.Reader = bytes.NewReader(payload)
+
+	if header.compressed {
+		gz, err := gzip.NewReader(content)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+		}
+		defer gz.Close()
+
+		content = gz
+	}
+
+	inner, err := readInnerHeader(content)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := inner.stream()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := unmarshalFile(content, stream.XORKeyStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Database{File: *file, Binaries: inner.binaries}, nil
+}
+
+// Encode encrypts the database with the password and writes it in KDBX 4 format.
+// DefaultKDF is used if kdf is nil.
+func Encode(w io.Writer, db *Database, password string, kdf *KDF) error {
+	if kdf == nil {
+		kdf = &DefaultKDF
+	}
+
+	header, err := newOuterHeader(kdf)
+	if err != nil {
+		return err
+	}
+
+	masterKey, err := header.kdf.transform(compositeKey(password))
+	if err != nil {
+		return err
+	}
+
+	inner := innerHeader{
+		streamID:  _innerStreamChaCha20,
+		streamKey: make([]byte, 64),
+		binaries:  db.Binaries,
+	}
+
+	if _, err := rand.Read(inner.streamKey); err != nil {
+		return err
+	}
+
+	stream, err := inner.stream()
+	if err != nil {
+		return err
+	}
+
+	var payload bytes.Buffer
+
+	gz := gzip.NewWriter(&payload)
+	if err := inner.write(gz); err != nil {
+		return err
+	}
+
+	if err := marshalFile(gz, &db.File, stream.XORKeyStream); err != nil {
+		return err
+	}
+
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	encrypted, err := header.encrypt(cipherKey(header.masterSeed, masterKey), payload.Bytes())
+	if err != nil {
+		return err
+	}
+
+	hmacKey := blockHMACBaseKey(header.masterSeed, masterKey)
+	if err := header.write(w, hmacKey); err != nil {
+		return err
+	}
+
+	return writeBlocks(w, hmacKey, encrypted)
+}
@@ -0,0 +1,200 @@
+package kdbx_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/kdbx"
+	"github.com/stretchr/testify/require"
+)
+
+// testKDF keeps the tests fast.
+var testKDF = kdbx.KDF{
+	Variant:     kdbx.Argon2id,
+	Iterations:  2,
+	Memory:      64 * 1024,
+	Parallelism: 2,
+}
+
+func newTestDatabase() *kdbx.Database {
+	db := kdbx.New("Test")
+
+	entry := kdbx.Entry{UUID: kdbx.NewUUID(), Tags: "work; mail"}
+	entry.Set(kdbx.TitleKey, "mail", false)
+	entry.Set(kdbx.UserNameKey, "alice", false)
+	entry.Set(kdbx.PasswordKey, "s3cr3t <&>", true)
+	entry.Set("PIN", "1234", true)
+	entry.Binaries = append(entry.Binaries, db.AddBinary("key.txt", []byte("content")))
+
+	old := kdbx.Entry{UUID: entry.UUID}
+	old.Set(kdbx.PasswordKey, "previous", true)
+	entry.History = append(entry.History, old)
+
+	db.File.Root.Group.Groups = append(db.File.Root.Group.Groups, kdbx.Group{
+		UUID:    kdbx.NewUUID(),
+		Name:    "Internet",
+		Entries: []kdbx.Entry{entry},
+	})
+
+	return db
+}
+
+func TestEncodeDecode(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := kdbx.Encode(&buf, newTestDatabase(), "password", &testKDF)
+	require.NoError(t, err)
+
+	require.NotContains(t, buf.String(), "s3cr3t")
+
+	db, err := kdbx.Decode(&buf, "password")
+	require.NoError(t, err)
+
+	require.Equal(t, "Test", db.File.Meta.DatabaseName)
+	require.Len(t, db.File.Root.Group.Groups, 1)
+
+	group := db.File.Root.Group.Groups[0]
+	require.Equal(t, "Internet", group.Name)
+	require.Len(t, group.Entries, 1)
+
+	entry := group.Entries[0]
+	require.Equal(t, "alice", entry.Get(kdbx.UserNameKey))
+	require.Equal(t, "s3cr3t <&>", entry.Get(kdbx.PasswordKey))
+	require.True(t, entry.IsProtected(kdbx.PasswordKey))
+	require.Equal(t, "1234", entry.Get("PIN"))
+	require.Equal(t, []string{"work", "mail"}, entry.TagList())
+	require.Len(t, entry.History, 1)
+	require.Equal(t, "previous", entry.History[0].Get(kdbx.PasswordKey))
+
+	require.Len(t, entry.Binaries, 1)
+	content, err := db.Binary(entry.Binaries[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte("content"), content)
+}
+
+func TestDecodeWithWrongPassword(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := kdbx.Encode(&buf, newTestDatabase(), "password", &testKDF)
+	require.NoError(t, err)
+
+	_, err = kdbx.Decode(&buf, "wrong")
+	require.ErrorIs(t, err, kdbx.ErrInvalidCredentials)
+}
+
+func TestDecodeTamperedDatabase(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := kdbx.Encode(&buf, newTestDatabase(), "password", &testKDF)
+	require.NoError(t, err)
+
+	data := buf.Bytes()
+	data[len(data)-64] ^= 0xFF
+
+	_, err = kdbx.Decode(bytes.NewReader(data), "password")
+	require.ErrorIs(t, err, kdbx.ErrCorrupted)
+}
+
+func TestDecodeWithExcessiveKDFParameters(t *testing.T) {
+	tt := []struct {
+		name string
+		key  string
+	}{
+		{
+			name: "Decode fails if Argon2 memory is too large",
+			key:  "M",
+		},
+		{
+			name: "Decode fails if Argon2 iterations are too many",
+			key:  "I",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := kdbx.Encode(&buf, newTestDatabase(), "password", &testKDF)
+			require.NoError(t, err)
+
+			// UInt64 item of the variant dictionary: type, key length, key, value length.
+			item := append([]byte{0x05, 0x01, 0x00, 0x00, 0x00}, tc.key...)
+			item = append(item, 0x08, 0x00, 0x00, 0x00)
+
+			data := buf.Bytes()
+			offset := bytes.Index(data, item)
+			require.Positive(t, offset)
+
+			binary.LittleEndian.PutUint64(data[offset+len(item):], 1<<40)
+
+			_, err = kdbx.Decode(bytes.NewReader(data), "password")
+			require.ErrorIs(t, err, kdbx.ErrUnsupportedFeature)
+		})
+	}
+}
+
+func TestDecodeNotKeePass(t *testing.T) {
+	_, err := kdbx.Decode(strings.NewReader("definitely not a database"), "password")
+	require.ErrorIs(t, err, kdbx.ErrNotKeePass)
+}
+
+func TestParseXML(t *testing.T) {
+	// Compressed binary contains gzipped "hello".
+	export := `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+	<Meta>
+		<Generator>KeePass</Generator>
+		<DatabaseName>Export</DatabaseName>
+		<Binaries>
+			<Binary ID="0" Compressed="True">H4sIAAAAAAAA/8pIzcnJBwQAAP//hqYQNgUAAAA=</Binary>
+		</Binaries>
+	</Meta>
+	<Root>
+		<Group>
+			<UUID>AAAAAAAAAAAAAAAAAAAAAA==</UUID>
+			<Name>Export</Name>
+			<Entry>
+				<UUID>AQAAAAAAAAAAAAAAAAAAAA==</UUID>
+				<Times>
+					<LastModificationTime>2023-03-01T10:00:00Z</LastModificationTime>
+				</Times>
+				<String><Key>Title</Key><Value>bank</Value></String>
+				<String><Key>Password</Key><Value ProtectInMemory="True">qwerty</Value></String>
+				<Binary><Key>hello.txt</Key><Value Ref="0" /></Binary>
+			</Entry>
+		</Group>
+	</Root>
+</KeePassFile>`
+
+	db, err := kdbx.ParseXML(strings.NewReader(export))
+	require.NoError(t, err)
+
+	entry := db.File.Root.Group.Entries[0]
+	require.Equal(t, "bank", entry.Get(kdbx.TitleKey))
+	require.Equal(t, "qwerty", entry.Get(kdbx.PasswordKey))
+	require.True(t, entry.IsProtected(kdbx.PasswordKey))
+
+	content, err := db.Binary(entry.Binaries[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), content)
+
+	modified, err := kdbx.ParseTime(entry.Times.LastModificationTime)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC), modified)
+}
+
+func TestParseXMLNotKeePass(t *testing.T) {
+	_, err := kdbx.ParseXML(strings.NewReader("{}"))
+	require.ErrorIs(t, err, kdbx.ErrNotKeePass)
+}
+
+func TestFormatTime(t *testing.T) {
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	parsed, err := kdbx.ParseTime(kdbx.FormatTime(now))
+	require.NoError(t, err)
+	require.Equal(t, now, parsed)
+}
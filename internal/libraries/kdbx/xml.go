@@ -0,0 +1,418 @@
+package kdbx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Standard fields of an entry.
+const (
+	TitleKey    = "Title"
+	UserNameKey = "UserName"
+	PasswordKey = "Password"
+	URLKey      = "URL"
+	NotesKey    = "Notes"
+
+	// OTPKey keeps TOTP settings as otpauth:// URI, used by KeePassXC.
+	OTPKey = "otp"
+	// TOTPSecretKey keeps base32-encoded TOTP secret, used by KeePass 2.47+.
+	TOTPSecretKey = "TimeOtp-Secret-Base32"
+	// AltURLKey prefixes additional website addresses of an entry, e.g. "KP2A_URL_1",
+	// introduced by Keepass2Android and supported by KeePassXC.
+	AltURLKey = "KP2A_URL"
+	// KindKey keeps kind of goph-keeper secrets having no counterpart in KeePass,
+	// values of such secrets are stored in fields named after attributes of the kind.
+	KindKey = "goph-kind"
+)
+
+const (
+	_true = "True"
+
+	// _epochOffset is number of seconds between 0001-01-01 and 1970-01-01,
+	// KDBX 4 stores time as seconds since 0001-01-01.
+	_epochOffset = 62135596800
+	_uuidLength  = 16
+)
+
+// File is XML document of KeePass database.
+type File struct {
+	XMLName xml.Name `xml:"KeePassFile"`
+	Meta    Meta     `xml:"Meta"`
+	Root    Root     `xml:"Root"`
+}
+
+// Meta is description of the database.
+type Meta struct {
+	Generator         string `xml:"Generator"`
+	DatabaseName      string `xml:"DatabaseName"`
+	RecycleBinEnabled string `xml:"RecycleBinEnabled,omitempty"`
+	RecycleBinUUID    string `xml:"RecycleBinUUID,omitempty"`
+
+	// Binaries are used by KeePass XML exports, KDBX 4 keeps them in inner header.
+	Binaries []MetaBinary `xml:"Binaries>Binary,omitempty"`
+}
+
+// MetaBinary is attachment stored in XML document.
+type MetaBinary struct {
+	ID         int    `xml:"ID,attr"`
+	Compressed string `xml:"Compressed,attr,omitempty"`
+	Content    string `xml:",chardata"`
+}
+
+// Root contains the root group.
+type Root struct {
+	Group Group `xml:"Group"`
+}
+
+// Group is folder containing entries and other groups.
+type Group struct {
+	UUID    string  `xml:"UUID"`
+	Name    string  `xml:"Name"`
+	Notes   string  `xml:"Notes,omitempty"`
+	IconID  int     `xml:"IconID"`
+	Times   Times   `xml:"Times"`
+	Entries []Entry `xml:"Entry"`
+	Groups  []Group `xml:"Group"`
+}
+
+// Entry is single record of the database.
+type Entry struct {
+	UUID     string      `xml:"UUID"`
+	IconID   int         `xml:"IconID"`
+	Tags     string      `xml:"Tags,omitempty"`
+	Times    Times       `xml:"Times"`
+	Strings  []String    `xml:"String"`
+	Binaries []BinaryRef `xml:"Binary"`
+
+	// History keeps previous versions of the entry, oldest first.
+	History []Entry `xml:"History>Entry,omitempty"`
+}
+
+// String is named text field of an entry.
+type String struct {
+	Key   string `xml:"Key"`
+	Value Value  `xml:"Value"`
+}
+
+// Value of a field. The text is kept decrypted,
+// Protected marks sensitive values encrypted in KDBX file.
+type Value struct {
+	Text            string `xml:",chardata"`
+	Protected       string `xml:"Protected,attr,omitempty"`
+	ProtectInMemory string `xml:"ProtectInMemory,attr,omitempty"`
+}
+
+// BinaryRef is attachment of an entry referring binary of the database.
+type BinaryRef struct {
+	Key   string `xml:"Key"`
+	Value struct {
+		Ref int `xml:"Ref,attr"`
+	} `xml:"Value"`
+}
+
+// Times keeps timestamps of an entry or a group.
+type Times struct {
+	CreationTime         string `xml:"CreationTime,omitempty"`
+	LastModificationTime string `xml:"LastModificationTime,omitempty"`
+	LastAccessTime       string `xml:"LastAccessTime,omitempty"`
+	ExpiryTime           string `xml:"ExpiryTime,omitempty"`
+	Expires              string `xml:"Expires,omitempty"`
+}
+
+// NewTimes returns timestamps of object created at the moment.
+func NewTimes(created, modified time.Time) Times {
+	return Times{
+		CreationTime:         FormatTime(created),
+		LastModificationTime: FormatTime(modified),
+		LastAccessTime:       FormatTime(modified),
+		ExpiryTime:           FormatTime(modified),
+		Expires:              "False",
+	}
+}
+
+// Get returns value of the field, empty string if there is no such field.
+func (e *Entry) Get(key string) string {
+	for _, s := range e.Strings {
+		if s.Key == key {
+			return s.Value.Text
+		}
+	}
+
+	return ""
+}
+
+// IsProtected reports whether value of the field is sensitive.
+func (e *Entry) IsProtected(key string) bool {
+	for _, s := range e.Strings {
+		if s.Key == key {
+			return isTrue(s.Value.Protected)
+		}
+	}
+
+	return false
+}
+
+// Set adds the field or replaces its value.
+func (e *Entry) Set(key, value string, protected bool) {
+	v := Value{Text: value}
+	if protected {
+		v.Protected = _true
+	}
+
+	for i := range e.Strings {
+		if e.Strings[i].Key == key {
+			e.Strings[i].Value = v
+
+			return
+		}
+	}
+
+	e.Strings = append(e.Strings, String{Key: key, Value: v})
+}
+
+// TagList returns tags of the entry, KeePass separates them with semicolons or commas.
+func (e *Entry) TagList() []string {
+	rv := make([]string, 0)
+
+	for _, tag := range strings.FieldsFunc(e.Tags, func(r rune) bool { return r == ';' || r == ',' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			rv = append(rv, tag)
+		}
+	}
+
+	return rv
+}
+
+// NewUUID generates random ID of an entry or a group.
+func NewUUID() string {
+	buf := make([]byte, _uuidLength)
+	rand.Read(buf) //nolint:errcheck // never fails
+
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+// ParseTime parses timestamp either in KDBX 4 binary form or in ISO 8601 form used by XML exports.
+func ParseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(raw) != 8 {
+		return time.Time{}, fmt.Errorf("%w: bad time %q", ErrCorrupted, value)
+	}
+
+	seconds := int64(binary.LittleEndian.Uint64(raw))
+
+	return time.Unix(seconds-_epochOffset, 0).UTC(), nil
+}
+
+// FormatTime formats timestamp in KDBX 4 binary form.
+func FormatTime(t time.Time) string {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(t.Unix()+_epochOffset))
+
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+// ParseXML reads KeePass XML export, all values are expected to be unencrypted.
+func ParseXML(r io.Reader) (*Database, error) {
+	file := new(File)
+	if err := xml.NewDecoder(r).Decode(file); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotKeePass, err)
+	}
+
+	db := &Database{File: *file, Binaries: make([]Binary, 0)}
+
+	for _, b := range file.Meta.Binaries {
+		content, err := decodeMetaBinary(b)
+		if err != nil {
+			return nil, err
+		}
+
+		for len(db.Binaries) <= b.ID {
+			db.Binaries = append(db.Binaries, Binary{})
+		}
+
+		db.Binaries[b.ID] = Binary{Content: content}
+	}
+
+	db.File.Meta.Binaries = nil
+	markProtected(&db.File.Root.Group)
+
+	return db, nil
+}
+
+func decodeMetaBinary(b MetaBinary) ([]byte, error) {
+	if b.ID < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrBadBinaryReference, b.ID)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b.Content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: binary %d: %s", ErrCorrupted, b.ID, err)
+	}
+
+	if !isTrue(b.Compressed) {
+		return content, nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: binary %d: %s", ErrCorrupted, b.ID, err)
+	}
+	defer gz.Close()
+
+	return io.ReadAll(gz)
+}
+
+// markProtected treats values protected in memory as sensitive, XML exports use this attribute.
+func markProtected(g *Group) {
+	mark := func(e *Entry) {
+		for i := range e.Strings {
+			if isTrue(e.Strings[i].Value.ProtectInMemory) {
+				e.Strings[i].Value.Protected = _true
+			}
+		}
+	}
+
+	for i := range g.Entries {
+		mark(&g.Entries[i])
+
+		for j := range g.Entries[i].History {
+			mark(&g.Entries[i].History[j])
+		}
+	}
+
+	for i := range g.Groups {
+		markProtected(&g.Groups[i])
+	}
+}
+
+// unmarshalFile decodes XML document decrypting protected values with the stream.
+func unmarshalFile(r io.Reader, xorKeyStream func(dst, src []byte)) (*File, error) {
+	var plain bytes.Buffer
+
+	err := transformProtected(r, &plain, func(value string) (string, error) {
+		raw, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrCorrupted, err)
+		}
+
+		xorKeyStream(raw, raw)
+
+		return string(raw), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	file := new(File)
+	if err := xml.Unmarshal(plain.Bytes(), file); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+
+	return file, nil
+}
+
+// marshalFile encodes XML document encrypting protected values with the stream.
+func marshalFile(w io.Writer, file *File, xorKeyStream func(dst, src []byte)) error {
+	plain, err := xml.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	return transformProtected(bytes.NewReader(plain), w, func(value string) (string, error) {
+		raw := []byte(value)
+		xorKeyStream(raw, raw)
+
+		return base64.StdEncoding.EncodeToString(raw), nil
+	})
+}
+
+// transformProtected copies XML document replacing text of protected values.
+// NB (alkurbatov): The values must be processed in order of appearance,
+// because they are encrypted with single stream cipher.
+func transformProtected(r io.Reader, w io.Writer, fn func(string) (string, error)) error {
+	dec := xml.NewDecoder(r)
+	enc := xml.NewEncoder(w)
+
+	var (
+		protected bool
+		text      strings.Builder
+	)
+
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return enc.Flush()
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrCorrupted, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			protected = t.Name.Local == "Value" && isProtected(t.Attr)
+			text.Reset()
+
+		case xml.CharData:
+			if protected {
+				text.Write(t)
+
+				continue
+			}
+
+		case xml.EndElement:
+			if protected {
+				value, err := fn(text.String())
+				if err != nil {
+					return err
+				}
+
+				if err := enc.EncodeToken(xml.CharData(value)); err != nil {
+					return err
+				}
+
+				protected = false
+			}
+
+		case xml.ProcInst:
+			// The header is written by caller.
+			continue
+
+		default:
+		}
+
+		if err := enc.EncodeToken(xml.CopyToken(token)); err != nil {
+			return err
+		}
+	}
+}
+
+func isProtected(attrs []xml.Attr) bool {
+	for _, attr := range attrs {
+		if attr.Name.Local == "Protected" && isTrue(attr.Value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTrue(value string) bool {
+	return strings.EqualFold(value, _true)
+}
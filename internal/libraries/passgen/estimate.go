@@ -0,0 +1,165 @@
+package passgen
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// _rawCommon is list of the most common passwords ordered by popularity,
+// taken from frequency lists of zxcvbn.
+//
+//go:embed common_passwords.txt
+var _rawCommon string
+
+var (
+	_common     map[string]int
+	_commonOnce sync.Once
+)
+
+// _otherChars is assumed number of characters outside of ASCII classes.
+const _otherChars = 100
+
+// _unleet reverts common substitutions of letters with digits and symbols.
+var _unleet = strings.NewReplacer(
+	"4", "a", "@", "a", "8", "b", "3", "e", "6", "g", "1", "i", "!", "i",
+	"0", "o", "5", "s", "$", "s", "7", "t", "2", "z",
+)
+
+// Estimate is approximate strength of a password chosen by human.
+type Estimate struct {
+	// Entropy is estimated number of bits required to guess the password.
+	Entropy float64
+	// Common is true if the password is a popular one, possibly with substituted letters
+	// or appended digits and symbols.
+	Common bool
+}
+
+// EstimateStrength estimates strength of the password.
+// Common passwords are rated by their popularity, other passwords by size of
+// used character classes, repeated characters and sequences like "abc" add one bit each.
+func EstimateStrength(password string) Estimate {
+	if password == "" {
+		return Estimate{}
+	}
+
+	if rank, suffix, ok := commonRank(password); ok {
+		return Estimate{
+			Entropy: math.Log2(float64(rank+1)) + bruteForce(suffix),
+			Common:  true,
+		}
+	}
+
+	return Estimate{Entropy: bruteForce(password)}
+}
+
+// commonRank returns position of the password in the list of common passwords
+// and the suffix appended to it.
+func commonRank(password string) (int, string, bool) {
+	common := commonPasswords()
+	lower := strings.ToLower(password)
+
+	base := strings.TrimRightFunc(lower, func(r rune) bool {
+		return unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	suffix := lower[len(base):]
+
+	for _, c := range []struct {
+		value  string
+		suffix string
+	}{
+		{lower, ""},
+		{_unleet.Replace(lower), ""},
+		{base, suffix},
+		{_unleet.Replace(base), suffix},
+	} {
+		if rank, ok := common[c.value]; ok && c.value != "" {
+			return rank, c.suffix, true
+		}
+	}
+
+	return 0, "", false
+}
+
+// bruteForce estimates entropy of the password guessed character by character.
+func bruteForce(password string) float64 {
+	var lower, upper, digits, symbols, other bool
+
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+
+		case r >= 'A' && r <= 'Z':
+			upper = true
+
+		case r >= '0' && r <= '9':
+			digits = true
+
+		case strings.ContainsRune(_symbols, r) || r == ' ':
+			symbols = true
+
+		default:
+			other = true
+		}
+	}
+
+	pool := 0
+
+	for _, c := range []struct {
+		used bool
+		size int
+	}{
+		{lower, len(_lower)},
+		{upper, len(_upper)},
+		{digits, len(_digits)},
+		{symbols, len(_symbols) + 1},
+		{other, _otherChars},
+	} {
+		if c.used {
+			pool += c.size
+		}
+	}
+
+	if pool == 0 {
+		return 0
+	}
+
+	bits := math.Log2(float64(pool))
+	rv := 0.0
+	prev := rune(-1)
+
+	for _, r := range password {
+		if d := r - prev; d >= -1 && d <= 1 {
+			rv++
+		} else {
+			rv += bits
+		}
+
+		prev = r
+	}
+
+	return rv
+}
+
+// commonPasswords maps the common passwords to their positions in the list.
+func commonPasswords() map[string]int {
+	_commonOnce.Do(func() {
+		lines := strings.Split(strings.TrimSpace(_rawCommon), "\n")
+		_common = make(map[string]int, len(lines))
+
+		for i, line := range lines {
+			_common[strings.TrimSpace(line)] = i
+		}
+	})
+
+	return _common
+}
+
+// IsWeak reports whether the password is common or its estimated entropy is below
+// the threshold.
+func (e Estimate) IsWeak(minEntropy float64) bool {
+	return e.Common || e.Entropy < minEntropy
+}
@@ -0,0 +1,95 @@
+package passgen_test
+
+import (
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/passgen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateStrength(t *testing.T) {
+	tt := []struct {
+		name     string
+		password string
+		common   bool
+		weak     bool
+	}{
+		{
+			name:     "Most popular password",
+			password: "password",
+			common:   true,
+			weak:     true,
+		},
+		{
+			name:     "Common password in different case",
+			password: "Qwerty",
+			common:   true,
+			weak:     true,
+		},
+		{
+			name:     "Common password with substituted letters",
+			password: "p@ssw0rd",
+			common:   true,
+			weak:     true,
+		},
+		{
+			name:     "Common password with appended digits",
+			password: "Dragon2023!",
+			common:   true,
+			weak:     true,
+		},
+		{
+			name:     "Short random password",
+			password: "x7Qa",
+			weak:     true,
+		},
+		{
+			name:     "Long repeated characters",
+			password: "zzzzzzzzzzzzzzzzzzzzzzzz",
+			weak:     true,
+		},
+		{
+			name:     "Long sequence",
+			password: "abcdefghijklmnopqrstuvwxyz",
+			weak:     true,
+		},
+		{
+			name:     "Random password",
+			password: "k#9Vq!mZ2@wLr8&T",
+		},
+		{
+			name:     "Diceware passphrase",
+			password: "turmoil-fantasy-ravioli-skiing-rover",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			rv := passgen.EstimateStrength(tc.password)
+
+			require.Equal(t, tc.common, rv.Common)
+			require.Equal(t, tc.weak, rv.IsWeak(50), rv.Entropy)
+		})
+	}
+}
+
+func TestEstimateStrengthOfEmptyPassword(t *testing.T) {
+	rv := passgen.EstimateStrength("")
+
+	require.Zero(t, rv.Entropy)
+	require.False(t, rv.Common)
+}
+
+func TestEstimateStrengthOfGeneratedPassword(t *testing.T) {
+	spec, err := passgen.Preset(passgen.DefaultPreset)
+	require.NoError(t, err)
+
+	password, err := passgen.Generate(spec)
+	require.NoError(t, err)
+
+	rv := passgen.EstimateStrength(password.Value)
+
+	require.False(t, rv.IsWeak(80), password.Value)
+}
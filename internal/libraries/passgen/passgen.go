@@ -0,0 +1,398 @@
+// Package passgen generates random passwords and passphrases and estimates strength
+// of passwords chosen by human.
+// All random values are taken from crypto/rand.
+package passgen
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Mode is a method of password generation.
+type Mode int
+
+const (
+	// ModeChars picks random characters of the requested classes.
+	ModeChars Mode = iota
+	// ModePronounceable alternates random consonants and vowels, e.g. "tobaremuki".
+	ModePronounceable
+	// ModeDiceware picks random words of the EFF large wordlist, e.g. "zoom-abacus-gravity".
+	ModeDiceware
+)
+
+// Class is a set of character classes used by ModeChars.
+type Class uint8
+
+const (
+	Lower Class = 1 << iota
+	Upper
+	Digits
+	Symbols
+
+	// AllClasses includes letters, digits and symbols.
+	AllClasses = Lower | Upper | Digits | Symbols
+)
+
+// Limits of generated passwords.
+const (
+	MinLength = 4
+	MaxLength = 256
+	MinWords  = 3
+	MaxWords  = 32
+)
+
+const (
+	_lower   = "abcdefghijklmnopqrstuvwxyz"
+	_upper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	_digits  = "0123456789"
+	_symbols = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+	// _ambiguous are characters easily confused with each other when read or typed by hand.
+	_ambiguous = "0Oo1Il|5S2Z8B`'\""
+
+	_consonants = "bcdfghjklmnprstvwxz"
+	_vowels     = "aeiou"
+)
+
+// Minimal entropy of passwords of each strength, in bits.
+const (
+	_fairEntropy   = 40
+	_goodEntropy   = 60
+	_strongEntropy = 80
+)
+
+var (
+	ErrBadLength     = errors.New("invalid password length")
+	ErrBadWords      = errors.New("invalid number of words")
+	ErrNoClasses     = errors.New("at least one character class is required")
+	ErrUnknownClass  = errors.New("unknown character class")
+	ErrUnknownMode   = errors.New("unknown generation mode")
+	ErrUnknownPreset = errors.New("unknown preset")
+)
+
+var _classNames = []struct {
+	class Class
+	name  string
+	chars string
+}{
+	{Lower, "lower", _lower},
+	{Upper, "upper", _upper},
+	{Digits, "digits", _digits},
+	{Symbols, "symbols", _symbols},
+}
+
+// Spec describes password to be generated.
+type Spec struct {
+	Mode Mode
+	// Length is number of characters, ignored by ModeDiceware.
+	Length int
+	// Words is number of words picked by ModeDiceware.
+	Words int
+	// Classes are character classes of ModeChars, each of them appears in the password
+	// at least once.
+	Classes Class
+	// ExcludeAmbiguous drops characters easily confused with each other, e.g. 0 and O.
+	ExcludeAmbiguous bool
+	// Separator is put between words of ModeDiceware.
+	Separator string
+	// Capitalize makes first letter of a pronounceable password or of each word uppercase.
+	Capitalize bool
+}
+
+// Password is a generated password with its strength.
+type Password struct {
+	Value string
+	// Entropy is number of bits required to guess the password knowing how it was generated.
+	Entropy float64
+}
+
+// ParseClasses converts list of character class names into Class,
+// e.g. "lower", "upper", "digits", "symbols".
+func ParseClasses(names []string) (Class, error) {
+	var rv Class
+
+	for _, name := range names {
+		found := false
+
+		for _, c := range _classNames {
+			if c.name == strings.ToLower(strings.TrimSpace(name)) {
+				rv |= c.class
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return 0, fmt.Errorf(
+				"%w %q: should be one of lower, upper, digits, symbols",
+				ErrUnknownClass,
+				name,
+			)
+		}
+	}
+
+	return rv, nil
+}
+
+// Validate checks that the password can be generated.
+func (s *Spec) Validate() error {
+	switch s.Mode {
+	case ModeChars, ModePronounceable:
+		if s.Length < MinLength || s.Length > MaxLength {
+			return fmt.Errorf(
+				"%w %d: should be from %d to %d",
+				ErrBadLength,
+				s.Length,
+				MinLength,
+				MaxLength,
+			)
+		}
+
+		if s.Mode == ModePronounceable {
+			return nil
+		}
+
+		if s.Classes&AllClasses == 0 {
+			return ErrNoClasses
+		}
+
+		if len(s.alphabets()) > s.Length {
+			return fmt.Errorf(
+				"%w %d: too short to include all character classes",
+				ErrBadLength,
+				s.Length,
+			)
+		}
+
+		return nil
+
+	case ModeDiceware:
+		if s.Words < MinWords || s.Words > MaxWords {
+			return fmt.Errorf(
+				"%w %d: should be from %d to %d",
+				ErrBadWords,
+				s.Words,
+				MinWords,
+				MaxWords,
+			)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownMode, int(s.Mode))
+	}
+}
+
+// Generate creates new random password according to the spec.
+func Generate(spec Spec) (Password, error) {
+	if err := spec.Validate(); err != nil {
+		return Password{}, err
+	}
+
+	switch spec.Mode {
+	case ModePronounceable:
+		return pronounceable(&spec)
+
+	case ModeDiceware:
+		return diceware(&spec)
+
+	default:
+		return chars(&spec)
+	}
+}
+
+// alphabets returns characters of each requested class.
+func (s *Spec) alphabets() []string {
+	rv := make([]string, 0, len(_classNames))
+
+	for _, c := range _classNames {
+		if s.Classes&c.class != 0 {
+			rv = append(rv, s.filter(c.chars))
+		}
+	}
+
+	return rv
+}
+
+// filter removes ambiguous characters from the alphabet if requested.
+func (s *Spec) filter(alphabet string) string {
+	if !s.ExcludeAmbiguous {
+		return alphabet
+	}
+
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(_ambiguous, r) {
+			return -1
+		}
+
+		return r
+	}, alphabet)
+}
+
+// chars picks random characters until the password contains all the requested classes.
+// Rejection keeps all acceptable passwords equally likely.
+func chars(spec *Spec) (Password, error) {
+	alphabets := spec.alphabets()
+	alphabet := strings.Join(alphabets, "")
+	buf := make([]byte, spec.Length)
+
+	for {
+		for i := range buf {
+			idx, err := randIndex(len(alphabet))
+			if err != nil {
+				return Password{}, err
+			}
+
+			buf[i] = alphabet[idx]
+		}
+
+		if containsAll(buf, alphabets) {
+			break
+		}
+	}
+
+	return Password{Value: string(buf), Entropy: charsEntropy(alphabets, spec.Length)}, nil
+}
+
+// containsAll reports whether the password contains characters of all the alphabets.
+func containsAll(password []byte, alphabets []string) bool {
+	for _, alphabet := range alphabets {
+		if !strings.ContainsAny(string(password), alphabet) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// charsEntropy calculates entropy of passwords containing all the alphabets
+// by counting such passwords with inclusion-exclusion principle.
+func charsEntropy(alphabets []string, length int) float64 {
+	total := 0
+	for _, alphabet := range alphabets {
+		total += len(alphabet)
+	}
+
+	count := new(big.Int)
+	exp := big.NewInt(int64(length))
+
+	for mask := 0; mask < 1<<len(alphabets); mask++ {
+		size, excluded := total, 0
+
+		for i, alphabet := range alphabets {
+			if mask&(1<<i) != 0 {
+				size -= len(alphabet)
+				excluded++
+			}
+		}
+
+		term := new(big.Int).Exp(big.NewInt(int64(size)), exp, nil)
+		if excluded%2 == 1 {
+			count.Sub(count, term)
+		} else {
+			count.Add(count, term)
+		}
+	}
+
+	return log2(count)
+}
+
+// pronounceable alternates consonants and vowels starting with random one of them.
+func pronounceable(spec *Spec) (Password, error) {
+	consonants, vowels := spec.filter(_consonants), spec.filter(_vowels)
+
+	start, err := randIndex(2)
+	if err != nil {
+		return Password{}, err
+	}
+
+	buf := make([]byte, spec.Length)
+	entropy := 1.0
+
+	for i := range buf {
+		alphabet := consonants
+		if (i+start)%2 == 1 {
+			alphabet = vowels
+		}
+
+		idx, err := randIndex(len(alphabet))
+		if err != nil {
+			return Password{}, err
+		}
+
+		buf[i] = alphabet[idx]
+		entropy += math.Log2(float64(len(alphabet)))
+	}
+
+	rv := string(buf)
+	if spec.Capitalize {
+		rv = strings.ToUpper(rv[:1]) + rv[1:]
+	}
+
+	return Password{Value: rv, Entropy: entropy}, nil
+}
+
+// diceware picks random words of the wordlist.
+func diceware(spec *Spec) (Password, error) {
+	words := wordlist()
+	picked := make([]string, spec.Words)
+
+	for i := range picked {
+		idx, err := randIndex(len(words))
+		if err != nil {
+			return Password{}, err
+		}
+
+		picked[i] = words[idx]
+		if spec.Capitalize {
+			picked[i] = strings.ToUpper(picked[i][:1]) + picked[i][1:]
+		}
+	}
+
+	return Password{
+		Value:   strings.Join(picked, spec.Separator),
+		Entropy: float64(spec.Words) * math.Log2(float64(len(words))),
+	}, nil
+}
+
+// randIndex returns uniformly distributed random number in [0, n).
+func randIndex(n int) (int, error) {
+	rv, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("passgen - randIndex - rand.Int: %w", err)
+	}
+
+	return int(rv.Int64()), nil
+}
+
+// log2 calculates binary logarithm of a big positive number.
+func log2(x *big.Int) float64 {
+	mant := new(big.Float)
+	exp := new(big.Float).SetInt(x).MantExp(mant)
+	m, _ := mant.Float64()
+
+	return float64(exp) + math.Log2(m)
+}
+
+// Strength rates the password entropy in human-readable form.
+func Strength(entropy float64) string {
+	switch {
+	case entropy < _fairEntropy:
+		return "weak"
+
+	case entropy < _goodEntropy:
+		return "fair"
+
+	case entropy < _strongEntropy:
+		return "good"
+
+	default:
+		return "strong"
+	}
+}
@@ -0,0 +1,225 @@
+package passgen_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/passgen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateChars(t *testing.T) {
+	tt := []struct {
+		name    string
+		spec    passgen.Spec
+		allowed string
+		entropy float64
+	}{
+		{
+			name:    "Digits only",
+			spec:    passgen.Spec{Length: 6, Classes: passgen.Digits},
+			allowed: "0123456789",
+			entropy: 6 * math.Log2(10),
+		},
+		{
+			name:    "Lowercase without ambiguous characters",
+			spec:    passgen.Spec{Length: 10, Classes: passgen.Lower, ExcludeAmbiguous: true},
+			allowed: "abcdefghijkmnpqrstuvwxyz",
+			entropy: 10 * math.Log2(24),
+		},
+		{
+			name:    "Letters and digits",
+			spec:    passgen.Spec{Length: 4, Classes: passgen.Lower | passgen.Digits},
+			allowed: "abcdefghijklmnopqrstuvwxyz0123456789",
+			// NB (alkurbatov): 36^4 - 10^4 - 26^4 passwords contain both letters and digits.
+			entropy: math.Log2(36*36*36*36 - 10*10*10*10 - 26*26*26*26),
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				rv, err := passgen.Generate(tc.spec)
+
+				require.NoError(t, err)
+				require.Len(t, rv.Value, tc.spec.Length)
+				require.InDelta(t, tc.entropy, rv.Entropy, 0.001)
+
+				for _, c := range rv.Value {
+					require.Contains(t, tc.allowed, string(c))
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCharsContainsAllClasses(t *testing.T) {
+	spec := passgen.Spec{Length: 4, Classes: passgen.AllClasses, ExcludeAmbiguous: true}
+
+	for i := 0; i < 100; i++ {
+		rv, err := passgen.Generate(spec)
+
+		require.NoError(t, err)
+		require.True(t, strings.ContainsAny(rv.Value, "abcdefghijkmnpqrstuvwxyz"), rv.Value)
+		require.True(t, strings.ContainsAny(rv.Value, "ACDEFGHJKLMNPQRTUVWXY"), rv.Value)
+		require.True(t, strings.ContainsAny(rv.Value, "34679"), rv.Value)
+		require.True(t, strings.ContainsAny(rv.Value, "!#$%&()*+,-./:;<=>?@[\\]^_{}~"), rv.Value)
+		require.NotContains(t, rv.Value, "0")
+		require.NotContains(t, rv.Value, "O")
+		require.NotContains(t, rv.Value, "l")
+	}
+}
+
+func TestGeneratePronounceable(t *testing.T) {
+	spec := passgen.Spec{Mode: passgen.ModePronounceable, Length: 12, Capitalize: true}
+
+	for i := 0; i < 50; i++ {
+		rv, err := passgen.Generate(spec)
+
+		require.NoError(t, err)
+		require.Len(t, rv.Value, 12)
+		require.Equal(t, strings.ToUpper(rv.Value[:1]), rv.Value[:1])
+		require.InDelta(t, 1+6*math.Log2(19)+6*math.Log2(5), rv.Entropy, 0.001)
+
+		value := strings.ToLower(rv.Value)
+		for j := 1; j < len(value); j++ {
+			require.NotEqual(
+				t,
+				strings.ContainsRune("aeiou", rune(value[j-1])),
+				strings.ContainsRune("aeiou", rune(value[j])),
+				rv.Value,
+			)
+		}
+	}
+}
+
+func TestGenerateDiceware(t *testing.T) {
+	words := make(map[string]bool)
+	for _, word := range passgen.Wordlist() {
+		words[word] = true
+	}
+
+	spec := passgen.Spec{Mode: passgen.ModeDiceware, Words: 5, Separator: " "}
+
+	rv, err := passgen.Generate(spec)
+
+	require.NoError(t, err)
+	require.InDelta(t, 5*math.Log2(7776), rv.Entropy, 0.001)
+
+	picked := strings.Split(rv.Value, " ")
+	require.Len(t, picked, 5)
+
+	for _, word := range picked {
+		require.True(t, words[word], word)
+	}
+}
+
+func TestGenerateDicewareCapitalized(t *testing.T) {
+	spec := passgen.Spec{Mode: passgen.ModeDiceware, Words: 4, Separator: ".", Capitalize: true}
+
+	rv, err := passgen.Generate(spec)
+
+	require.NoError(t, err)
+
+	for _, word := range strings.Split(rv.Value, ".") {
+		require.Equal(t, strings.ToUpper(word[:1]), word[:1])
+	}
+}
+
+func TestWordlist(t *testing.T) {
+	words := passgen.Wordlist()
+
+	require.Len(t, words, 7776)
+	require.Equal(t, "abacus", words[0])
+	require.Equal(t, "zoom", words[len(words)-1])
+
+	unique := make(map[string]bool, len(words))
+	for _, word := range words {
+		require.NotEmpty(t, word)
+		require.False(t, unique[word], word)
+
+		unique[word] = true
+	}
+}
+
+func TestGenerateInvalidSpec(t *testing.T) {
+	tt := []struct {
+		name     string
+		spec     passgen.Spec
+		expected error
+	}{
+		{
+			name:     "Too short",
+			spec:     passgen.Spec{Length: 3, Classes: passgen.AllClasses},
+			expected: passgen.ErrBadLength,
+		},
+		{
+			name:     "Too long",
+			spec:     passgen.Spec{Length: passgen.MaxLength + 1, Classes: passgen.Lower},
+			expected: passgen.ErrBadLength,
+		},
+		{
+			name:     "No classes",
+			spec:     passgen.Spec{Length: 10},
+			expected: passgen.ErrNoClasses,
+		},
+		{
+			name:     "Too few words",
+			spec:     passgen.Spec{Mode: passgen.ModeDiceware, Words: 2},
+			expected: passgen.ErrBadWords,
+		},
+		{
+			name:     "Unknown mode",
+			spec:     passgen.Spec{Mode: passgen.Mode(42), Length: 10},
+			expected: passgen.ErrUnknownMode,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := passgen.Generate(tc.spec)
+
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestParseClasses(t *testing.T) {
+	rv, err := passgen.ParseClasses([]string{"lower", " Digits "})
+
+	require.NoError(t, err)
+	require.Equal(t, passgen.Lower|passgen.Digits, rv)
+
+	_, err = passgen.ParseClasses([]string{"emoji"})
+
+	require.ErrorIs(t, err, passgen.ErrUnknownClass)
+}
+
+func TestPresets(t *testing.T) {
+	for _, name := range passgen.Presets() {
+		spec, err := passgen.Preset(name)
+		require.NoError(t, err)
+		require.NotEmpty(t, passgen.DescribePreset(name))
+
+		rv, err := passgen.Generate(spec)
+		require.NoError(t, err, name)
+		require.NotEmpty(t, rv.Value)
+		require.Positive(t, rv.Entropy)
+	}
+
+	_, err := passgen.Preset("unknown")
+
+	require.ErrorIs(t, err, passgen.ErrUnknownPreset)
+}
+
+func TestStrength(t *testing.T) {
+	require.Equal(t, "weak", passgen.Strength(20))
+	require.Equal(t, "fair", passgen.Strength(40))
+	require.Equal(t, "good", passgen.Strength(79.9))
+	require.Equal(t, "strong", passgen.Strength(128))
+}
@@ -0,0 +1,94 @@
+package passgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultPreset is name of the preset used if nothing else is requested.
+const DefaultPreset = "default"
+
+// _presets are commonly used specs ordered as shown to user.
+var _presets = []struct {
+	name        string
+	description string
+	spec        Spec
+}{
+	{
+		name:        DefaultPreset,
+		description: "20 characters: letters, digits and symbols",
+		spec:        Spec{Mode: ModeChars, Length: 20, Classes: AllClasses},
+	},
+	{
+		name:        "strong",
+		description: "32 characters: letters, digits and symbols",
+		spec:        Spec{Mode: ModeChars, Length: 32, Classes: AllClasses},
+	},
+	{
+		name:        "alnum",
+		description: "20 letters and digits, for services rejecting symbols",
+		spec:        Spec{Mode: ModeChars, Length: 20, Classes: Lower | Upper | Digits},
+	},
+	{
+		name:        "readable",
+		description: "16 characters without ambiguous ones like 0 and O",
+		spec: Spec{
+			Mode:             ModeChars,
+			Length:           16,
+			Classes:          AllClasses,
+			ExcludeAmbiguous: true,
+		},
+	},
+	{
+		name:        "pin",
+		description: "6 digits",
+		spec:        Spec{Mode: ModeChars, Length: 6, Classes: Digits},
+	},
+	{
+		name:        "pronounceable",
+		description: "16 alternating consonants and vowels",
+		spec:        Spec{Mode: ModePronounceable, Length: 16},
+	},
+	{
+		name:        "diceware",
+		description: "6 words of the EFF large wordlist separated by dashes",
+		spec:        Spec{Mode: ModeDiceware, Words: 6, Separator: "-"},
+	},
+}
+
+// Preset returns spec of the preset.
+func Preset(name string) (Spec, error) {
+	for _, p := range _presets {
+		if p.name == name {
+			return p.spec, nil
+		}
+	}
+
+	return Spec{}, fmt.Errorf(
+		"%w %q: should be one of %s",
+		ErrUnknownPreset,
+		name,
+		strings.Join(Presets(), ", "),
+	)
+}
+
+// Presets returns names of the supported presets.
+func Presets() []string {
+	rv := make([]string, 0, len(_presets))
+	for _, p := range _presets {
+		rv = append(rv, p.name)
+	}
+
+	return rv
+}
+
+// DescribePreset returns short human-readable description of the preset.
+func DescribePreset(name string) string {
+	for _, p := range _presets {
+		if p.name == name {
+			return p.description
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,44 @@
+package passgen
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// _rawWordlist is EFF large wordlist for diceware passphrases, each line consists of
+// dice rolls and the word separated by tab, see
+// https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases.
+//
+//go:embed eff_large_wordlist.txt
+var _rawWordlist string
+
+var (
+	_words     []string
+	_wordsOnce sync.Once
+)
+
+// wordlist returns words of the embedded wordlist.
+func wordlist() []string {
+	_wordsOnce.Do(func() {
+		lines := strings.Split(strings.TrimSpace(_rawWordlist), "\n")
+		_words = make([]string, 0, len(lines))
+
+		for _, line := range lines {
+			if _, word, ok := strings.Cut(line, "\t"); ok {
+				_words = append(_words, strings.TrimSpace(word))
+			}
+		}
+	})
+
+	return _words
+}
+
+// Wordlist returns copy of words used by ModeDiceware.
+func Wordlist() []string {
+	words := wordlist()
+	rv := make([]string, len(words))
+	copy(rv, words)
+
+	return rv
+}
@@ -0,0 +1,116 @@
+// Package redact hides secrets in streamed output.
+package redact
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Writer replaces secrets in the data written to the underlying writer.
+// Bytes which may start a secret are held until the next write proves otherwise,
+// so a secret split between writes is hidden as well. Flush must be called
+// at the end of the stream to write the held bytes.
+type Writer struct {
+	mu          sync.Mutex
+	w           io.Writer
+	secrets     [][]byte
+	replacement []byte
+	pending     []byte
+}
+
+// NewWriter creates Writer replacing the secrets with the replacement.
+// Empty secrets are ignored.
+func NewWriter(w io.Writer, secrets []string, replacement string) *Writer {
+	rw := &Writer{
+		w:           w,
+		secrets:     make([][]byte, 0, len(secrets)),
+		replacement: []byte(replacement),
+	}
+
+	for _, s := range secrets {
+		if s != "" {
+			rw.secrets = append(rw.secrets, []byte(s))
+		}
+	}
+
+	// NB (alkurbatov): Longer secrets go first to hide them completely
+	// if a shorter secret is a part of a longer one.
+	sort.SliceStable(rw.secrets, func(i, j int) bool {
+		return len(rw.secrets[i]) > len(rw.secrets[j])
+	})
+
+	return rw
+}
+
+// Write redacts the data and writes it to the underlying writer.
+// Required by io.Writer interface.
+func (rw *Writer) Write(p []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	rw.pending = append(rw.pending, p...)
+
+	if err := rw.flush(false); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the held bytes to the underlying writer.
+func (rw *Writer) Flush() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	return rw.flush(true)
+}
+
+func (rw *Writer) flush(final bool) error {
+	var out bytes.Buffer
+
+	i := 0
+
+scan:
+	for i < len(rw.pending) {
+		rest := rw.pending[i:]
+
+		if !final && rw.incomplete(rest) {
+			break
+		}
+
+		for _, s := range rw.secrets {
+			if bytes.HasPrefix(rest, s) {
+				out.Write(rw.replacement)
+				i += len(s)
+
+				continue scan
+			}
+		}
+
+		out.WriteByte(rest[0])
+		i++
+	}
+
+	rw.pending = append(rw.pending[:0], rw.pending[i:]...)
+
+	if out.Len() == 0 {
+		return nil
+	}
+
+	_, err := rw.w.Write(out.Bytes())
+
+	return err
+}
+
+// incomplete reports whether the data is a beginning of a secret.
+func (rw *Writer) incomplete(data []byte) bool {
+	for _, s := range rw.secrets {
+		if len(s) > len(data) && bytes.HasPrefix(s, data) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,76 @@
+package redact_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/redact"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	tt := []struct {
+		name     string
+		secrets  []string
+		chunks   []string
+		expected string
+	}{
+		{
+			name:     "Secret in single write",
+			secrets:  []string{"s3cr3t"},
+			chunks:   []string{"password is s3cr3t\n"},
+			expected: "password is ***\n",
+		},
+		{
+			name:     "Secret split between writes",
+			secrets:  []string{"s3cr3t"},
+			chunks:   []string{"password is s3", "cr", "3t!\n"},
+			expected: "password is ***!\n",
+		},
+		{
+			name:     "Beginning of secret at the end of stream",
+			secrets:  []string{"s3cr3t"},
+			chunks:   []string{"password is s3cr"},
+			expected: "password is s3cr",
+		},
+		{
+			name:     "Shorter secret is part of longer one",
+			secrets:  []string{"abc", "abcdef"},
+			chunks:   []string{"abc", "def abc"},
+			expected: "*** ***",
+		},
+		{
+			name:     "Several secrets",
+			secrets:  []string{"admin", "qwerty", ""},
+			chunks:   []string{"admin:qwerty@db"},
+			expected: "***:***@db",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w := redact.NewWriter(&buf, tc.secrets, "***")
+
+			for _, chunk := range tc.chunks {
+				n, err := w.Write([]byte(chunk))
+				require.NoError(t, err)
+				require.Equal(t, len(chunk), n)
+			}
+
+			require.NoError(t, w.Flush())
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestWriterHoldsBeginningOfSecret(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := redact.NewWriter(&buf, []string{"s3cr3t"}, "***")
+
+	_, err := w.Write([]byte("password is s3c"))
+	require.NoError(t, err)
+	require.Equal(t, "password is ", buf.String())
+}
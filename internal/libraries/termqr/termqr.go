@@ -0,0 +1,49 @@
+// Package termqr renders QR codes in terminal using Unicode block elements.
+package termqr
+
+import (
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Render encodes content into QR code and draws it with half-height blocks,
+// so that each line of text contains two rows of modules.
+// Light modules are drawn as filled blocks, which makes the code scannable
+// on terminals with dark background.
+func Render(content string) (string, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+
+	bitmap := code.Bitmap()
+	size := len(bitmap)
+
+	var sb strings.Builder
+
+	for y := 0; y < size; y += 2 {
+		for x := 0; x < size; x++ {
+			top := !bitmap[y][x]
+			bottom := y+1 < size && !bitmap[y+1][x]
+
+			switch {
+			case top && bottom:
+				sb.WriteRune('█')
+
+			case top:
+				sb.WriteRune('▀')
+
+			case bottom:
+				sb.WriteRune('▄')
+
+			default:
+				sb.WriteRune(' ')
+			}
+		}
+
+		sb.WriteRune('\n')
+	}
+
+	return sb.String(), nil
+}
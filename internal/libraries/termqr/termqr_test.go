@@ -0,0 +1,34 @@
+package termqr_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/termqr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	rv, err := termqr.Render("WIFI:T:WPA;S:guest;P:secret;;")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(rv, "\n"), "\n")
+	width := utf8.RuneCountInString(lines[0])
+
+	// Two rows of modules per line.
+	require.Equal(t, (width+1)/2, len(lines))
+
+	for _, line := range lines {
+		require.Equal(t, width, utf8.RuneCountInString(line))
+	}
+
+	// Quiet zone consists of light modules only.
+	require.Equal(t, strings.Repeat("█", width), lines[0])
+}
+
+func TestRenderTooLong(t *testing.T) {
+	_, err := termqr.Render(strings.Repeat("x", 4000))
+
+	require.Error(t, err)
+}
@@ -0,0 +1,223 @@
+// Package totp implements time-based one-time passwords as described in RFC 6238.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec //SHA1 is the default algorithm of RFC 6238
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	_defaultDigits = 6
+	_defaultPeriod = 30
+	_maxDigits     = 10
+)
+
+var (
+	ErrInvalidSecret        = errors.New("invalid TOTP secret")
+	ErrInvalidURI           = errors.New("invalid otpauth URI")
+	ErrUnsupportedAlgorithm = errors.New("unsupported TOTP algorithm")
+)
+
+// Key contains parameters required to generate one-time passwords.
+type Key struct {
+	Secret    []byte
+	Issuer    string
+	Account   string
+	Algorithm string
+	Digits    int
+	Period    int
+}
+
+// Parse creates new Key from the base32-encoded secret or otpauth:// URI.
+func Parse(src string) (*Key, error) {
+	src = strings.TrimSpace(src)
+
+	if strings.HasPrefix(strings.ToLower(src), "otpauth://") {
+		return parseURI(src)
+	}
+
+	secret, err := decodeSecret(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{
+		Secret:    secret,
+		Algorithm: "SHA1",
+		Digits:    _defaultDigits,
+		Period:    _defaultPeriod,
+	}, nil
+}
+
+// decodeSecret decodes base32 secret ignoring case, spaces, dashes and padding.
+func decodeSecret(src string) ([]byte, error) {
+	normalized := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '=' {
+			return -1
+		}
+
+		return r
+	}, strings.ToUpper(src))
+
+	if normalized == "" {
+		return nil, ErrInvalidSecret
+	}
+
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSecret, err)
+	}
+
+	return secret, nil
+}
+
+// parseURI parses key in the Key URI format used by authenticator apps.
+// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func parseURI(src string) (*Key, error) {
+	u, err := url.Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidURI, err)
+	}
+
+	if u.Host != "totp" {
+		return nil, fmt.Errorf("%w: unsupported type %q", ErrInvalidURI, u.Host)
+	}
+
+	q := u.Query()
+
+	secret, err := decodeSecret(q.Get("secret"))
+	if err != nil {
+		return nil, err
+	}
+
+	key := &Key{
+		Secret:    secret,
+		Issuer:    q.Get("issuer"),
+		Algorithm: "SHA1",
+		Digits:    _defaultDigits,
+		Period:    _defaultPeriod,
+	}
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, found := strings.Cut(label, ":"); found {
+		key.Account = strings.TrimSpace(account)
+
+		if key.Issuer == "" {
+			key.Issuer = issuer
+		}
+	} else {
+		key.Account = label
+	}
+
+	if alg := q.Get("algorithm"); alg != "" {
+		key.Algorithm = strings.ToUpper(alg)
+	}
+
+	if _, err := key.hasher(); err != nil {
+		return nil, err
+	}
+
+	if val := q.Get("digits"); val != "" {
+		key.Digits, err = strconv.Atoi(val)
+		if err != nil || key.Digits <= 0 || key.Digits > _maxDigits {
+			return nil, fmt.Errorf("%w: bad digits %q", ErrInvalidURI, val)
+		}
+	}
+
+	if val := q.Get("period"); val != "" {
+		key.Period, err = strconv.Atoi(val)
+		if err != nil || key.Period <= 0 {
+			return nil, fmt.Errorf("%w: bad period %q", ErrInvalidURI, val)
+		}
+	}
+
+	return key, nil
+}
+
+func (k *Key) hasher() (func() hash.Hash, error) {
+	switch k.Algorithm {
+	case "SHA1":
+		return sha1.New, nil
+
+	case "SHA256":
+		return sha256.New, nil
+
+	case "SHA512":
+		return sha512.New, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, k.Algorithm)
+}
+
+// Code generates one-time password valid at the provided moment.
+func (k *Key) Code(t time.Time) string {
+	hasher, err := k.hasher()
+	if err != nil {
+		hasher = sha1.New
+	}
+
+	var counter [8]byte
+
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix()/int64(k.Period)))
+
+	mac := hmac.New(hasher, k.Secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// NB (alkurbatov): Dynamic truncation, see RFC 4226, section 5.3.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint64(1)
+	for i := 0; i < k.Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", k.Digits, uint64(value)%mod)
+}
+
+// Remaining returns time left until the code generated at the provided moment expires.
+func (k *Key) Remaining(t time.Time) time.Duration {
+	period := int64(k.Period)
+
+	return time.Duration(period-t.Unix()%period) * time.Second
+}
+
+// URI renders the key in the Key URI format used by authenticator apps.
+func (k *Key) URI() string {
+	label := k.Account
+	if k.Issuer != "" {
+		label = k.Issuer + ":" + k.Account
+	}
+
+	q := make(url.Values)
+	q.Set("secret", base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(k.Secret))
+
+	if k.Issuer != "" {
+		q.Set("issuer", k.Issuer)
+	}
+
+	q.Set("algorithm", k.Algorithm)
+	q.Set("digits", strconv.Itoa(k.Digits))
+	q.Set("period", strconv.Itoa(k.Period))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + label,
+		RawQuery: q.Encode(),
+	}
+
+	return u.String()
+}
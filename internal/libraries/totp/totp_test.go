@@ -0,0 +1,138 @@
+package totp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alkurbatov/goph-keeper/internal/libraries/totp"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from RFC 6238, Appendix B.
+const (
+	_sha1Secret   = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	_sha256Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA"
+	_sha512Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" +
+		"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNA"
+)
+
+func TestCode(t *testing.T) {
+	tt := []struct {
+		name     string
+		src      string
+		moment   int64
+		expected string
+	}{
+		{
+			name:     "Raw base32 secret",
+			src:      _sha1Secret,
+			moment:   59,
+			expected: "287082",
+		},
+		{
+			name:     "Lowercase secret with spaces",
+			src:      "gezd gnbv gy3t qojq gezd gnbv gy3t qojq",
+			moment:   1111111109,
+			expected: "081804",
+		},
+		{
+			name:     "URI with SHA1 and 8 digits",
+			src:      "otpauth://totp/ACME:john@example.com?secret=" + _sha1Secret + "&digits=8",
+			moment:   1234567890,
+			expected: "89005924",
+		},
+		{
+			name: "URI with SHA256 and 8 digits",
+			src: "otpauth://totp/ACME:john@example.com?secret=" + _sha256Secret +
+				"&digits=8&algorithm=SHA256",
+			moment:   59,
+			expected: "46119246",
+		},
+		{
+			name: "URI with SHA512 and 8 digits",
+			src: "otpauth://totp/ACME:john@example.com?secret=" + _sha512Secret +
+				"&digits=8&algorithm=SHA512",
+			moment:   20000000000,
+			expected: "47863826",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sat, err := totp.Parse(tc.src)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, sat.Code(time.Unix(tc.moment, 0)))
+		})
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	sat, err := totp.Parse("otpauth://totp/ACME:john@example.com?secret=" + _sha1Secret + "&period=60")
+
+	require.NoError(t, err)
+	require.Equal(t, "ACME", sat.Issuer)
+	require.Equal(t, "john@example.com", sat.Account)
+	require.Equal(t, 60, sat.Period)
+	require.Equal(t, 20*time.Second, sat.Remaining(time.Unix(100, 0)))
+}
+
+func TestParseFailure(t *testing.T) {
+	tt := []struct {
+		name string
+		src  string
+	}{
+		{
+			name: "Empty secret",
+			src:  "",
+		},
+		{
+			name: "Not base32 secret",
+			src:  "not-a-secret!",
+		},
+		{
+			name: "HOTP URI",
+			src:  "otpauth://hotp/ACME?secret=" + _sha1Secret,
+		},
+		{
+			name: "URI with unsupported algorithm",
+			src:  "otpauth://totp/ACME?secret=" + _sha1Secret + "&algorithm=MD5",
+		},
+		{
+			name: "URI with bad digits",
+			src:  "otpauth://totp/ACME?secret=" + _sha1Secret + "&digits=x",
+		},
+		{
+			name: "URI with bad period",
+			src:  "otpauth://totp/ACME?secret=" + _sha1Secret + "&period=0",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := totp.Parse(tc.src)
+
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestURI(t *testing.T) {
+	src, err := totp.Parse(_sha1Secret)
+	require.NoError(t, err)
+
+	src.Issuer = "ACME Co"
+	src.Account = "john@example.com"
+
+	uri := src.URI()
+	require.Equal(
+		t,
+		"otpauth://totp/ACME%20Co:john@example.com"+
+			"?algorithm=SHA1&digits=6&issuer=ACME+Co&period=30&secret="+_sha1Secret,
+		uri,
+	)
+
+	sat, err := totp.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, src, sat)
+}
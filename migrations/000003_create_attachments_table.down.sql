@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS attachments;
+
+ALTER TABLE secrets DROP CONSTRAINT IF EXISTS secrets_secret_id_key;
@@ -0,0 +1,11 @@
+ALTER TABLE secrets ADD CONSTRAINT secrets_secret_id_key UNIQUE (secret_id);
+
+CREATE TABLE IF NOT EXISTS attachments (
+    attachment_id uuid DEFAULT gen_random_uuid () primary key,
+    secret_id     uuid not null REFERENCES secrets (secret_id) on delete cascade,
+    owner_id      uuid not null REFERENCES users (user_id) on delete cascade,
+    metadata      bytea not null,
+    data          bytea not null
+);
+
+CREATE INDEX IF NOT EXISTS attachments_secret_id_idx ON attachments (secret_id);
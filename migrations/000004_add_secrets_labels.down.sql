@@ -0,0 +1,22 @@
+ALTER TABLE secrets DROP CONSTRAINT IF EXISTS secrets_owner_folder_name_key;
+ALTER TABLE secrets DROP CONSTRAINT IF EXISTS secrets_pkey;
+
+-- Names are unique per owner without folders. The secret from the root folder
+-- (or the first one by ID) keeps the name, IDs are appended to names of the others.
+UPDATE secrets AS s
+SET name = left(s.name, 219) || ' ' || s.secret_id::text
+FROM (
+    SELECT
+        secret_id,
+        row_number() OVER (
+            PARTITION BY owner_id, name ORDER BY folder_digest, secret_id
+        ) AS n
+    FROM secrets
+) AS d
+WHERE s.secret_id = d.secret_id AND d.n > 1;
+
+ALTER TABLE secrets ADD PRIMARY KEY (name, owner_id);
+
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS folder_digest,
+    DROP COLUMN IF EXISTS labels;
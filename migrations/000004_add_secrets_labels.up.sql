@@ -0,0 +1,7 @@
+ALTER TABLE secrets
+    ADD COLUMN IF NOT EXISTS labels bytea,
+    ADD COLUMN IF NOT EXISTS folder_digest bytea not null DEFAULT ''::bytea;
+
+ALTER TABLE secrets DROP CONSTRAINT IF EXISTS secrets_pkey;
+ALTER TABLE secrets ADD PRIMARY KEY (secret_id);
+ALTER TABLE secrets ADD CONSTRAINT secrets_owner_folder_name_key UNIQUE (owner_id, folder_digest, name);
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS secrets_touch_updated_at ON secrets;
+DROP FUNCTION IF EXISTS touch_updated_at();
+
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,14 @@
+ALTER TABLE secrets
+    ADD COLUMN IF NOT EXISTS created_at timestamptz not null DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz not null DEFAULT now();
+
+CREATE OR REPLACE FUNCTION touch_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER secrets_touch_updated_at
+    BEFORE UPDATE ON secrets
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
@@ -0,0 +1,228 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: attachments.proto
+
+package goph
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Attachments_Create_FullMethodName = "/goph.keeper.v1.Attachments/Create"
+	Attachments_List_FullMethodName   = "/goph.keeper.v1.Attachments/List"
+	Attachments_Get_FullMethodName    = "/goph.keeper.v1.Attachments/Get"
+	Attachments_Delete_FullMethodName = "/goph.keeper.v1.Attachments/Delete"
+)
+
+// AttachmentsClient is the client API for Attachments service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AttachmentsClient interface {
+	// Attach new file to a secret.
+	Create(ctx context.Context, in *CreateAttachmentRequest, opts ...grpc.CallOption) (*CreateAttachmentResponse, error)
+	// List brief attachments of a secret without data.
+	List(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	// Get an attachment with data.
+	Get(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*GetAttachmentResponse, error)
+	// Remove an attachment.
+	Delete(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error)
+}
+
+type attachmentsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAttachmentsClient(cc grpc.ClientConnInterface) AttachmentsClient {
+	return &attachmentsClient{cc}
+}
+
+func (c *attachmentsClient) Create(ctx context.Context, in *CreateAttachmentRequest, opts ...grpc.CallOption) (*CreateAttachmentResponse, error) {
+	out := new(CreateAttachmentResponse)
+	err := c.cc.Invoke(ctx, Attachments_Create_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentsClient) List(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, Attachments_List_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentsClient) Get(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*GetAttachmentResponse, error) {
+	out := new(GetAttachmentResponse)
+	err := c.cc.Invoke(ctx, Attachments_Get_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentsClient) Delete(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error) {
+	out := new(DeleteAttachmentResponse)
+	err := c.cc.Invoke(ctx, Attachments_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AttachmentsServer is the server API for Attachments service.
+// All implementations must embed UnimplementedAttachmentsServer
+// for forward compatibility
+type AttachmentsServer interface {
+	// Attach new file to a secret.
+	Create(context.Context, *CreateAttachmentRequest) (*CreateAttachmentResponse, error)
+	// List brief attachments of a secret without data.
+	List(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	// Get an attachment with data.
+	Get(context.Context, *GetAttachmentRequest) (*GetAttachmentResponse, error)
+	// Remove an attachment.
+	Delete(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error)
+	mustEmbedUnimplementedAttachmentsServer()
+}
+
+// UnimplementedAttachmentsServer must be embedded to have forward compatible implementations.
+type UnimplementedAttachmentsServer struct {
+}
+
+func (UnimplementedAttachmentsServer) Create(context.Context, *CreateAttachmentRequest) (*CreateAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedAttachmentsServer) List(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedAttachmentsServer) Get(context.Context, *GetAttachmentRequest) (*GetAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedAttachmentsServer) Delete(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedAttachmentsServer) mustEmbedUnimplementedAttachmentsServer() {}
+
+// UnsafeAttachmentsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AttachmentsServer will
+// result in compilation errors.
+type UnsafeAttachmentsServer interface {
+	mustEmbedUnimplementedAttachmentsServer()
+}
+
+func RegisterAttachmentsServer(s grpc.ServiceRegistrar, srv AttachmentsServer) {
+	s.RegisterService(&Attachments_ServiceDesc, srv)
+}
+
+func _Attachments_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentsServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Attachments_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentsServer).Create(ctx, req.(*CreateAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Attachments_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentsServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Attachments_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentsServer).List(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Attachments_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentsServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Attachments_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentsServer).Get(ctx, req.(*GetAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Attachments_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentsServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Attachments_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentsServer).Delete(ctx, req.(*DeleteAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Attachments_ServiceDesc is the grpc.ServiceDesc for Attachments service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Attachments_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goph.keeper.v1.Attachments",
+	HandlerType: (*AttachmentsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _Attachments_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Attachments_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Attachments_Get_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Attachments_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "attachments.proto",
+}
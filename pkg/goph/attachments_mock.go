@@ -0,0 +1,70 @@
+package goph
+
+import (
+	context "context"
+
+	"github.com/stretchr/testify/mock"
+	grpc "google.golang.org/grpc"
+)
+
+var _ AttachmentsClient = (*AttachmentsClientMock)(nil)
+
+type AttachmentsClientMock struct {
+	mock.Mock
+}
+
+func (m *AttachmentsClientMock) Create(
+	ctx context.Context,
+	in *CreateAttachmentRequest,
+	opts ...grpc.CallOption,
+) (*CreateAttachmentResponse, error) {
+	args := m.Called(ctx, in, opts)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CreateAttachmentResponse), args.Error(1)
+}
+
+func (m *AttachmentsClientMock) List(
+	ctx context.Context,
+	in *ListAttachmentsRequest,
+	opts ...grpc.CallOption,
+) (*ListAttachmentsResponse, error) {
+	args := m.Called(ctx, in, opts)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ListAttachmentsResponse), args.Error(1)
+}
+
+func (m *AttachmentsClientMock) Get(
+	ctx context.Context,
+	in *GetAttachmentRequest,
+	opts ...grpc.CallOption,
+) (*GetAttachmentResponse, error) {
+	args := m.Called(ctx, in, opts)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetAttachmentResponse), args.Error(1)
+}
+
+func (m *AttachmentsClientMock) Delete(
+	ctx context.Context,
+	in *DeleteAttachmentRequest,
+	opts ...grpc.CallOption,
+) (*DeleteAttachmentResponse, error) {
+	args := m.Called(ctx, in, opts)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*DeleteAttachmentResponse), args.Error(1)
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -25,25 +26,46 @@ const (
 type DataKind int32
 
 const (
-	DataKind_BINARY      DataKind = 0 // Arbitrary binary data.
-	DataKind_TEXT        DataKind = 1 // Arbitrary text data.
-	DataKind_CREDENTIALS DataKind = 2 // Authentication credentials.
-	DataKind_CARD        DataKind = 3 // Bank card info.
+	DataKind_BINARY      DataKind = 0  // Arbitrary binary data.
+	DataKind_TEXT        DataKind = 1  // Arbitrary text data.
+	DataKind_CREDENTIALS DataKind = 2  // Authentication credentials.
+	DataKind_CARD        DataKind = 3  // Bank card info.
+	DataKind_CUSTOM      DataKind = 4  // Arbitrary list of typed fields.
+	DataKind_IDENTITY    DataKind = 5  // Personal identity info.
+	DataKind_DOCUMENT    DataKind = 6  // Identity document (passport, driver licence etc).
+	DataKind_SEED_PHRASE DataKind = 7  // Recovery seed phrase of a crypto wallet.
+	DataKind_CERTIFICATE DataKind = 8  // X.509 certificate chain and private key.
+	DataKind_DATABASE    DataKind = 9  // Database connection parameters.
+	DataKind_WIFI        DataKind = 10 // Wi-Fi network parameters.
 )
 
 // Enum value maps for DataKind.
 var (
 	DataKind_name = map[int32]string{
-		0: "BINARY",
-		1: "TEXT",
-		2: "CREDENTIALS",
-		3: "CARD",
+		0:  "BINARY",
+		1:  "TEXT",
+		2:  "CREDENTIALS",
+		3:  "CARD",
+		4:  "CUSTOM",
+		5:  "IDENTITY",
+		6:  "DOCUMENT",
+		7:  "SEED_PHRASE",
+		8:  "CERTIFICATE",
+		9:  "DATABASE",
+		10: "WIFI",
 	}
 	DataKind_value = map[string]int32{
 		"BINARY":      0,
 		"TEXT":        1,
 		"CREDENTIALS": 2,
 		"CARD":        3,
+		"CUSTOM":      4,
+		"IDENTITY":    5,
+		"DOCUMENT":    6,
+		"SEED_PHRASE": 7,
+		"CERTIFICATE": 8,
+		"DATABASE":    9,
+		"WIFI":        10,
 	}
 )
 
@@ -79,10 +101,13 @@ type Secret struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id       string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // ID of a secret in UUIDv4 form.
-	Name     string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                               // Name of a secret.
-	Kind     DataKind `protobuf:"varint,3,opt,name=kind,proto3,enum=goph.keeper.v1.DataKind" json:"kind,omitempty"` // Type of stored data.
-	Metadata []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`                       // Arbitrary encrypted description (activation codes, bank names etc).
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // ID of a secret in UUIDv4 form.
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                               // Name of a secret.
+	Kind      DataKind               `protobuf:"varint,3,opt,name=kind,proto3,enum=goph.keeper.v1.DataKind" json:"kind,omitempty"` // Type of stored data.
+	Metadata  []byte                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`                       // Arbitrary encrypted description (activation codes, bank names etc).
+	Labels    []byte                 `protobuf:"bytes,5,opt,name=labels,proto3" json:"labels,omitempty"`                           // Encrypted folder and tags, see Labels in data.proto.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`    // Time of the secret creation.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`    // Time of the last change of the secret.
 }
 
 func (x *Secret) Reset() {
@@ -145,6 +170,27 @@ func (x *Secret) GetMetadata() []byte {
 	return nil
 }
 
+func (x *Secret) GetLabels() []byte {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Secret) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Secret) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 type CreateSecretRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -154,6 +200,13 @@ type CreateSecretRequest struct {
 	Metadata []byte   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`                       // Arbitrary description data encrypted by client.
 	Kind     DataKind `protobuf:"varint,3,opt,name=kind,proto3,enum=goph.keeper.v1.DataKind" json:"kind,omitempty"` // Type of stored data.
 	Data     []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                               // Actual secret data encrypted by client, see data.proto.
+	Labels   []byte   `protobuf:"bytes,5,opt,name=labels,proto3" json:"labels,omitempty"`                           // Folder and tags encrypted by client, see Labels in data.proto.
+	// Keyed digest of the folder path computed by client, empty for the root folder.
+	// Names of secrets are unique within the folder.
+	FolderDigest []byte `protobuf:"bytes,6,opt,name=folder_digest,json=folderDigest,proto3" json:"folder_digest,omitempty"`
+	// ID of a stored secret in UUIDv4 form deleted together with its files when the new
+	// secret is created, e.g. on restore with overwrite. Used by CreateBatch only.
+	Replaces string `protobuf:"bytes,7,opt,name=replaces,proto3" json:"replaces,omitempty"`
 }
 
 func (x *CreateSecretRequest) Reset() {
@@ -216,6 +269,27 @@ func (x *CreateSecretRequest) GetData() []byte {
 	return nil
 }
 
+func (x *CreateSecretRequest) GetLabels() []byte {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateSecretRequest) GetFolderDigest() []byte {
+	if x != nil {
+		return x.FolderDigest
+	}
+	return nil
+}
+
+func (x *CreateSecretRequest) GetReplaces() string {
+	if x != nil {
+		return x.Replaces
+	}
+	return ""
+}
+
 type CreateSecretResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -263,6 +337,100 @@ func (x *CreateSecretResponse) GetId() string {
 	return ""
 }
 
+type CreateSecretsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secrets []*CreateSecretRequest `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"` // Secrets to store, either all or none are created.
+}
+
+func (x *CreateSecretsRequest) Reset() {
+	*x = CreateSecretsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_secrets_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretsRequest) ProtoMessage() {}
+
+func (x *CreateSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_secrets_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretsRequest.ProtoReflect.Descriptor instead.
+func (*CreateSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_secrets_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSecretsRequest) GetSecrets() []*CreateSecretRequest {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+type CreateSecretsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // IDs of the secrets in UUIDv4 form, in order of the request.
+}
+
+func (x *CreateSecretsResponse) Reset() {
+	*x = CreateSecretsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_secrets_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretsResponse) ProtoMessage() {}
+
+func (x *CreateSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_secrets_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretsResponse.ProtoReflect.Descriptor instead.
+func (*CreateSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_secrets_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateSecretsResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 type ListSecretsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -272,7 +440,7 @@ type ListSecretsRequest struct {
 func (x *ListSecretsRequest) Reset() {
 	*x = ListSecretsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_secrets_proto_msgTypes[3]
+		mi := &file_secrets_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -285,7 +453,7 @@ func (x *ListSecretsRequest) String() string {
 func (*ListSecretsRequest) ProtoMessage() {}
 
 func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_secrets_proto_msgTypes[3]
+	mi := &file_secrets_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,7 +466,7 @@ func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSecretsRequest.ProtoReflect.Descriptor instead.
 func (*ListSecretsRequest) Descriptor() ([]byte, []int) {
-	return file_secrets_proto_rawDescGZIP(), []int{3}
+	return file_secrets_proto_rawDescGZIP(), []int{5}
 }
 
 type ListSecretsResponse struct {
@@ -312,7 +480,7 @@ type ListSecretsResponse struct {
 func (x *ListSecretsResponse) Reset() {
 	*x = ListSecretsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_secrets_proto_msgTypes[4]
+		mi := &file_secrets_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -325,7 +493,7 @@ func (x *ListSecretsResponse) String() string {
 func (*ListSecretsResponse) ProtoMessage() {}
 
 func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_secrets_proto_msgTypes[4]
+	mi := &file_secrets_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,7 +506,7 @@ func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSecretsResponse.ProtoReflect.Descriptor instead.
 func (*ListSecretsResponse) Descriptor() ([]byte, []int) {
-	return file_secrets_proto_rawDescGZIP(), []int{4}
+	return file_secrets_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListSecretsResponse) GetSecrets() []*Secret {
@@ -359,7 +527,7 @@ type GetSecretRequest struct {
 func (x *GetSecretRequest) Reset() {
 	*x = GetSecretRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_secrets_proto_msgTypes[5]
+		mi := &file_secrets_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -372,7 +540,7 @@ func (x *GetSecretRequest) String() string {
 func (*GetSecretRequest) ProtoMessage() {}
 
 func (x *GetSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_secrets_proto_msgTypes[5]
+	mi := &file_secrets_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -385,7 +553,7 @@ func (x *GetSecretRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSecretRequest.ProtoReflect.Descriptor instead.
 func (*GetSecretRequest) Descriptor() ([]byte, []int) {
-	return file_secrets_proto_rawDescGZIP(), []int{5}
+	return file_secrets_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetSecretRequest) GetId() string {
@@ -407,7 +575,7 @@ type GetSecretResponse struct {
 func (x *GetSecretResponse) Reset() {
 	*x = GetSecretResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_secrets_proto_msgTypes[6]
+		mi := &file_secrets_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -420,7 +588,7 @@ func (x *GetSecretResponse) String() string {
 func (*GetSecretResponse) ProtoMessage() {}
 
 func (x *GetSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_secrets_proto_msgTypes[6]
+	mi := &file_secrets_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +601,7 @@ func (x *GetSecretResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSecretResponse.ProtoReflect.Descriptor instead.
 func (*GetSecretResponse) Descriptor() ([]byte, []int) {
-	return file_secrets_proto_rawDescGZIP(), []int{6}
+	return file_secrets_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetSecretResponse) GetSecret() *Secret {
@@ -450,22 +618,79 @@ func (x *GetSecretResponse) GetData() []byte {
 	return nil
 }
 
+type GetSecretByNameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // Name of a secret.
+	FolderDigest []byte `protobuf:"bytes,2,opt,name=folder_digest,json=folderDigest,proto3" json:"folder_digest,omitempty"` // Keyed digest of the folder path, empty for the root folder.
+}
+
+func (x *GetSecretByNameRequest) Reset() {
+	*x = GetSecretByNameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_secrets_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSecretByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretByNameRequest) ProtoMessage() {}
+
+func (x *GetSecretByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_secrets_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretByNameRequest) Descriptor() ([]byte, []int) {
+	return file_secrets_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSecretByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetSecretByNameRequest) GetFolderDigest() []byte {
+	if x != nil {
+		return x.FolderDigest
+	}
+	return nil
+}
+
 type UpdateSecretRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // ID of a secret in UUIDv4 form.
-	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // Specifies what values should be changed.
-	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                               // Name of a secret.
-	Metadata   []byte                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`                       // Arbitrary description data encrypted by client.
-	Data       []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`                               // Actual secret data encrypted by client, see data.proto.
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                         // ID of a secret in UUIDv4 form.
+	UpdateMask   *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`       // Specifies what values should be changed.
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                     // Name of a secret.
+	Metadata     []byte                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`                             // Arbitrary description data encrypted by client.
+	Data         []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`                                     // Actual secret data encrypted by client, see data.proto.
+	Labels       []byte                 `protobuf:"bytes,6,opt,name=labels,proto3" json:"labels,omitempty"`                                 // Folder and tags encrypted by client, see Labels in data.proto.
+	FolderDigest []byte                 `protobuf:"bytes,7,opt,name=folder_digest,json=folderDigest,proto3" json:"folder_digest,omitempty"` // Keyed digest of the folder path, changed together with labels.
 }
 
 func (x *UpdateSecretRequest) Reset() {
 	*x = UpdateSecretRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_secrets_proto_msgTypes[7]
+		mi := &file_secrets_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -478,7 +703,7 @@ func (x *UpdateSecretRequest) String() string {
 func (*UpdateSecretRequest) ProtoMessage() {}
 
 func (x *UpdateSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_secrets_proto_msgTypes[7]
+	mi := &file_secrets_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,7 +716,7 @@ func (x *UpdateSecretRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSecretRequest.ProtoReflect.Descriptor instead.
 func (*UpdateSecretRequest) Descriptor() ([]byte, []int) {
-	return file_secrets_proto_rawDescGZIP(), []int{7}
+	return file_secrets_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateSecretRequest) GetId() string {
@@ -529,6 +754,20 @@ func (x *UpdateSecretRequest) GetData() []byte {
 	return nil
 }
 
+func (x *UpdateSecretRequest) GetLabels() []byte {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateSecretRequest) GetFolderDigest() []byte {
+	if x != nil {
+		return x.FolderDigest
+	}
+	return nil
+}
+
 type UpdateSecretResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -538,7 +777,7 @@ type UpdateSecretResponse struct {
 func (x *UpdateSecretResponse) Reset() {
 	*x = UpdateSecretResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_secrets_proto_msgTypes[8]
+		mi := &file_secrets_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -551,7 +790,7 @@ func (x *UpdateSecretResponse) String() string {
 func (*UpdateSecretResponse) ProtoMessage() {}
 
 func (x *UpdateSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_secrets_proto_msgTypes[8]
+	mi := &file_secrets_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +803,7 @@ func (x *UpdateSecretResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSecretResponse.ProtoReflect.Descriptor instead.
 func (*UpdateSecretResponse) Descriptor() ([]byte, []int) {
-	return file_secrets_proto_rawDescGZIP(), []int{8}
+	return file_secrets_proto_rawDescGZIP(), []int{11}
 }
 
 type DeleteSecretRequest struct {
@@ -578,7 +817,7 @@ type DeleteSecretRequest struct {
 func (x *DeleteSecretRequest) Reset() {
 	*x = DeleteSecretRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_secrets_proto_msgTypes[9]
+		mi := &file_secrets_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -591,7 +830,7 @@ func (x *DeleteSecretRequest) String() string {
 func (*DeleteSecretRequest) ProtoMessage() {}
 
 func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_secrets_proto_msgTypes[9]
+	mi := &file_secrets_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,7 +843,7 @@ func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSecretRequest.ProtoReflect.Descriptor instead.
 func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
-	return file_secrets_proto_rawDescGZIP(), []int{9}
+	return file_secrets_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteSecretRequest) GetId() string {
@@ -623,7 +862,7 @@ type DeleteSecretResponse struct {
 func (x *DeleteSecretResponse) Reset() {
 	*x = DeleteSecretResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_secrets_proto_msgTypes[10]
+		mi := &file_secrets_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -636,7 +875,7 @@ func (x *DeleteSecretResponse) String() string {
 func (*DeleteSecretResponse) ProtoMessage() {}
 
 func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_secrets_proto_msgTypes[10]
+	mi := &file_secrets_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,7 +888,7 @@ func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSecretResponse.ProtoReflect.Descriptor instead.
 func (*DeleteSecretResponse) Descriptor() ([]byte, []int) {
-	return file_secrets_proto_rawDescGZIP(), []int{10}
+	return file_secrets_proto_rawDescGZIP(), []int{13}
 }
 
 var File_secrets_proto protoreflect.FileDescriptor
@@ -659,14 +898,25 @@ var file_secrets_proto_rawDesc = []byte{
 	0x0e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a,
 	0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x76, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x2c, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e,
-	0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44,
-	0x61, 0x74, 0x61, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1a, 0x0a,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x87, 0x01, 0x0a, 0x13, 0x43, 0x72,
+	0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x84, 0x02, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x2c, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x18, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39,
+	0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xe0, 0x01, 0x0a, 0x13, 0x43, 0x72,
 	0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
@@ -675,72 +925,112 @@ var file_secrets_proto_rawDesc = []byte{
 	0x18, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31,
 	0x2e, 0x44, 0x61, 0x74, 0x61, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
 	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x26, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x4c,
-	0x69, 0x73, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x47, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x70, 0x68,
-	0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x52, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x22, 0x22, 0x0a, 0x10, 0x47, 0x65,
-	0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x57,
-	0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xa6, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b,
-	0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x22, 0x16, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0x16, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x3b, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x4b,
-	0x69, 0x6e, 0x64, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x00, 0x12,
-	0x08, 0x0a, 0x04, 0x54, 0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x52, 0x45,
-	0x44, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x53, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x41,
-	0x52, 0x44, 0x10, 0x03, 0x32, 0xa5, 0x03, 0x0a, 0x07, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73,
-	0x12, 0x53, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x67, 0x6f, 0x70,
-	0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x24, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x22, 0x2e,
-	0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x23, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x20, 0x2e,
-	0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
-	0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x21, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x53, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x67,
-	0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x24, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x12, 0x23, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65,
-	0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65,
-	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a, 0x26,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x6b, 0x75, 0x72,
-	0x62, 0x61, 0x74, 0x6f, 0x76, 0x2f, 0x67, 0x6f, 0x70, 0x68, 0x2d, 0x6b, 0x65, 0x65, 0x70, 0x65,
-	0x72, 0x2f, 0x67, 0x6f, 0x70, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x66,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0c, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x22, 0x26, 0x0a, 0x14,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x55, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x07,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x22, 0x29, 0x0a, 0x15, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x47, 0x0a, 0x13,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x07, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x22, 0x22, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x57, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e,
+	0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x51, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x42,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0xe3, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3b, 0x0a,
+	0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x66,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x25, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2a, 0x9d, 0x01, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x4b, 0x69, 0x6e, 0x64, 0x12,
+	0x0a, 0x0a, 0x06, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x54,
+	0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x52, 0x45, 0x44, 0x45, 0x4e, 0x54,
+	0x49, 0x41, 0x4c, 0x53, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x41, 0x52, 0x44, 0x10, 0x03,
+	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08,
+	0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x4f,
+	0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x45, 0x45, 0x44,
+	0x5f, 0x50, 0x48, 0x52, 0x41, 0x53, 0x45, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x45, 0x52,
+	0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x45, 0x10, 0x08, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x41,
+	0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x10, 0x09, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x49, 0x46, 0x49,
+	0x10, 0x0a, 0x32, 0xd9, 0x04, 0x0a, 0x07, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x53,
+	0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e,
+	0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
+	0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x24, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e,
+	0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4f, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x22, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b,
+	0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x67, 0x6f,
+	0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4a, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x20, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b,
+	0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x6f, 0x70, 0x68,
+	0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x2e, 0x67, 0x6f, 0x70, 0x68,
+	0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x23,
+	0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x06, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e, 0x6b, 0x65, 0x65, 0x70, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x67, 0x6f, 0x70, 0x68, 0x2e,
+	0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28,
+	0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x6b,
+	0x75, 0x72, 0x62, 0x61, 0x74, 0x6f, 0x76, 0x2f, 0x67, 0x6f, 0x70, 0x68, 0x2d, 0x6b, 0x65, 0x65,
+	0x70, 0x65, 0x72, 0x2f, 0x67, 0x6f, 0x70, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -756,43 +1046,54 @@ func file_secrets_proto_rawDescGZIP() []byte {
 }
 
 var file_secrets_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_secrets_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_secrets_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_secrets_proto_goTypes = []interface{}{
-	(DataKind)(0),                 // 0: goph.keeper.v1.DataKind
-	(*Secret)(nil),                // 1: goph.keeper.v1.Secret
-	(*CreateSecretRequest)(nil),   // 2: goph.keeper.v1.CreateSecretRequest
-	(*CreateSecretResponse)(nil),  // 3: goph.keeper.v1.CreateSecretResponse
-	(*ListSecretsRequest)(nil),    // 4: goph.keeper.v1.ListSecretsRequest
-	(*ListSecretsResponse)(nil),   // 5: goph.keeper.v1.ListSecretsResponse
-	(*GetSecretRequest)(nil),      // 6: goph.keeper.v1.GetSecretRequest
-	(*GetSecretResponse)(nil),     // 7: goph.keeper.v1.GetSecretResponse
-	(*UpdateSecretRequest)(nil),   // 8: goph.keeper.v1.UpdateSecretRequest
-	(*UpdateSecretResponse)(nil),  // 9: goph.keeper.v1.UpdateSecretResponse
-	(*DeleteSecretRequest)(nil),   // 10: goph.keeper.v1.DeleteSecretRequest
-	(*DeleteSecretResponse)(nil),  // 11: goph.keeper.v1.DeleteSecretResponse
-	(*fieldmaskpb.FieldMask)(nil), // 12: google.protobuf.FieldMask
+	(DataKind)(0),                  // 0: goph.keeper.v1.DataKind
+	(*Secret)(nil),                 // 1: goph.keeper.v1.Secret
+	(*CreateSecretRequest)(nil),    // 2: goph.keeper.v1.CreateSecretRequest
+	(*CreateSecretResponse)(nil),   // 3: goph.keeper.v1.CreateSecretResponse
+	(*CreateSecretsRequest)(nil),   // 4: goph.keeper.v1.CreateSecretsRequest
+	(*CreateSecretsResponse)(nil),  // 5: goph.keeper.v1.CreateSecretsResponse
+	(*ListSecretsRequest)(nil),     // 6: goph.keeper.v1.ListSecretsRequest
+	(*ListSecretsResponse)(nil),    // 7: goph.keeper.v1.ListSecretsResponse
+	(*GetSecretRequest)(nil),       // 8: goph.keeper.v1.GetSecretRequest
+	(*GetSecretResponse)(nil),      // 9: goph.keeper.v1.GetSecretResponse
+	(*GetSecretByNameRequest)(nil), // 10: goph.keeper.v1.GetSecretByNameRequest
+	(*UpdateSecretRequest)(nil),    // 11: goph.keeper.v1.UpdateSecretRequest
+	(*UpdateSecretResponse)(nil),   // 12: goph.keeper.v1.UpdateSecretResponse
+	(*DeleteSecretRequest)(nil),    // 13: goph.keeper.v1.DeleteSecretRequest
+	(*DeleteSecretResponse)(nil),   // 14: goph.keeper.v1.DeleteSecretResponse
+	(*timestamppb.Timestamp)(nil),  // 15: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),  // 16: google.protobuf.FieldMask
 }
 var file_secrets_proto_depIdxs = []int32{
 	0,  // 0: goph.keeper.v1.Secret.kind:type_name -> goph.keeper.v1.DataKind
-	0,  // 1: goph.keeper.v1.CreateSecretRequest.kind:type_name -> goph.keeper.v1.DataKind
-	1,  // 2: goph.keeper.v1.ListSecretsResponse.secrets:type_name -> goph.keeper.v1.Secret
-	1,  // 3: goph.keeper.v1.GetSecretResponse.secret:type_name -> goph.keeper.v1.Secret
-	12, // 4: goph.keeper.v1.UpdateSecretRequest.update_mask:type_name -> google.protobuf.FieldMask
-	2,  // 5: goph.keeper.v1.Secrets.Create:input_type -> goph.keeper.v1.CreateSecretRequest
-	4,  // 6: goph.keeper.v1.Secrets.List:input_type -> goph.keeper.v1.ListSecretsRequest
-	6,  // 7: goph.keeper.v1.Secrets.Get:input_type -> goph.keeper.v1.GetSecretRequest
-	8,  // 8: goph.keeper.v1.Secrets.Update:input_type -> goph.keeper.v1.UpdateSecretRequest
-	10, // 9: goph.keeper.v1.Secrets.Delete:input_type -> goph.keeper.v1.DeleteSecretRequest
-	3,  // 10: goph.keeper.v1.Secrets.Create:output_type -> goph.keeper.v1.CreateSecretResponse
-	5,  // 11: goph.keeper.v1.Secrets.List:output_type -> goph.keeper.v1.ListSecretsResponse
-	7,  // 12: goph.keeper.v1.Secrets.Get:output_type -> goph.keeper.v1.GetSecretResponse
-	9,  // 13: goph.keeper.v1.Secrets.Update:output_type -> goph.keeper.v1.UpdateSecretResponse
-	11, // 14: goph.keeper.v1.Secrets.Delete:output_type -> goph.keeper.v1.DeleteSecretResponse
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	15, // 1: goph.keeper.v1.Secret.created_at:type_name -> google.protobuf.Timestamp
+	15, // 2: goph.keeper.v1.Secret.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: goph.keeper.v1.CreateSecretRequest.kind:type_name -> goph.keeper.v1.DataKind
+	2,  // 4: goph.keeper.v1.CreateSecretsRequest.secrets:type_name -> goph.keeper.v1.CreateSecretRequest
+	1,  // 5: goph.keeper.v1.ListSecretsResponse.secrets:type_name -> goph.keeper.v1.Secret
+	1,  // 6: goph.keeper.v1.GetSecretResponse.secret:type_name -> goph.keeper.v1.Secret
+	16, // 7: goph.keeper.v1.UpdateSecretRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 8: goph.keeper.v1.Secrets.Create:input_type -> goph.keeper.v1.CreateSecretRequest
+	4,  // 9: goph.keeper.v1.Secrets.CreateBatch:input_type -> goph.keeper.v1.CreateSecretsRequest
+	6,  // 10: goph.keeper.v1.Secrets.List:input_type -> goph.keeper.v1.ListSecretsRequest
+	8,  // 11: goph.keeper.v1.Secrets.Get:input_type -> goph.keeper.v1.GetSecretRequest
+	10, // 12: goph.keeper.v1.Secrets.GetByName:input_type -> goph.keeper.v1.GetSecretByNameRequest
+	11, // 13: goph.keeper.v1.Secrets.Update:input_type -> goph.keeper.v1.UpdateSecretRequest
+	13, // 14: goph.keeper.v1.Secrets.Delete:input_type -> goph.keeper.v1.DeleteSecretRequest
+	3,  // 15: goph.keeper.v1.Secrets.Create:output_type -> goph.keeper.v1.CreateSecretResponse
+	5,  // 16: goph.keeper.v1.Secrets.CreateBatch:output_type -> goph.keeper.v1.CreateSecretsResponse
+	7,  // 17: goph.keeper.v1.Secrets.List:output_type -> goph.keeper.v1.ListSecretsResponse
+	9,  // 18: goph.keeper.v1.Secrets.Get:output_type -> goph.keeper.v1.GetSecretResponse
+	9,  // 19: goph.keeper.v1.Secrets.GetByName:output_type -> goph.keeper.v1.GetSecretResponse
+	12, // 20: goph.keeper.v1.Secrets.Update:output_type -> goph.keeper.v1.UpdateSecretResponse
+	14, // 21: goph.keeper.v1.Secrets.Delete:output_type -> goph.keeper.v1.DeleteSecretResponse
+	15, // [15:22] is the sub-list for method output_type
+	8,  // [8:15] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_secrets_proto_init() }
@@ -838,7 +1139,7 @@ func file_secrets_proto_init() {
 			}
 		}
 		file_secrets_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListSecretsRequest); i {
+			switch v := v.(*CreateSecretsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -850,7 +1151,7 @@ func file_secrets_proto_init() {
 			}
 		}
 		file_secrets_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListSecretsResponse); i {
+			switch v := v.(*CreateSecretsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -862,7 +1163,7 @@ func file_secrets_proto_init() {
 			}
 		}
 		file_secrets_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSecretRequest); i {
+			switch v := v.(*ListSecretsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -874,7 +1175,7 @@ func file_secrets_proto_init() {
 			}
 		}
 		file_secrets_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSecretResponse); i {
+			switch v := v.(*ListSecretsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -886,7 +1187,7 @@ func file_secrets_proto_init() {
 			}
 		}
 		file_secrets_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateSecretRequest); i {
+			switch v := v.(*GetSecretRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -898,7 +1199,7 @@ func file_secrets_proto_init() {
 			}
 		}
 		file_secrets_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateSecretResponse); i {
+			switch v := v.(*GetSecretResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -910,7 +1211,7 @@ func file_secrets_proto_init() {
 			}
 		}
 		file_secrets_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteSecretRequest); i {
+			switch v := v.(*GetSecretByNameRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -922,6 +1223,42 @@ func file_secrets_proto_init() {
 			}
 		}
 		file_secrets_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSecretRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_secrets_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSecretResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_secrets_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSecretRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_secrets_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteSecretResponse); i {
 			case 0:
 				return &v.state
@@ -940,7 +1277,7 @@ func file_secrets_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_secrets_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Secrets_Create_FullMethodName = "/goph.keeper.v1.Secrets/Create"
-	Secrets_List_FullMethodName   = "/goph.keeper.v1.Secrets/List"
-	Secrets_Get_FullMethodName    = "/goph.keeper.v1.Secrets/Get"
-	Secrets_Update_FullMethodName = "/goph.keeper.v1.Secrets/Update"
-	Secrets_Delete_FullMethodName = "/goph.keeper.v1.Secrets/Delete"
+	Secrets_Create_FullMethodName      = "/goph.keeper.v1.Secrets/Create"
+	Secrets_CreateBatch_FullMethodName = "/goph.keeper.v1.Secrets/CreateBatch"
+	Secrets_List_FullMethodName        = "/goph.keeper.v1.Secrets/List"
+	Secrets_Get_FullMethodName         = "/goph.keeper.v1.Secrets/Get"
+	Secrets_GetByName_FullMethodName   = "/goph.keeper.v1.Secrets/GetByName"
+	Secrets_Update_FullMethodName      = "/goph.keeper.v1.Secrets/Update"
+	Secrets_Delete_FullMethodName      = "/goph.keeper.v1.Secrets/Delete"
 )
 
 // SecretsClient is the client API for Secrets service.
@@ -32,10 +34,15 @@ const (
 type SecretsClient interface {
 	// Store new secret.
 	Create(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*CreateSecretResponse, error)
+	// Store several secrets at once, used to import data from other password managers.
+	// Replaced secrets are deleted in the same transaction.
+	CreateBatch(ctx context.Context, in *CreateSecretsRequest, opts ...grpc.CallOption) (*CreateSecretsResponse, error)
 	// List brief secrets without data for the current user.
 	List(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error)
 	// Get a secret with data.
 	Get(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
+	// Get a secret with data by its name within a folder.
+	GetByName(ctx context.Context, in *GetSecretByNameRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
 	// Change a secret and/or stored data.
 	Update(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*UpdateSecretResponse, error)
 	// Remove a secret.
@@ -59,6 +66,15 @@ func (c *secretsClient) Create(ctx context.Context, in *CreateSecretRequest, opt
 	return out, nil
 }
 
+func (c *secretsClient) CreateBatch(ctx context.Context, in *CreateSecretsRequest, opts ...grpc.CallOption) (*CreateSecretsResponse, error) {
+	out := new(CreateSecretsResponse)
+	err := c.cc.Invoke(ctx, Secrets_CreateBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *secretsClient) List(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error) {
 	out := new(ListSecretsResponse)
 	err := c.cc.Invoke(ctx, Secrets_List_FullMethodName, in, out, opts...)
@@ -77,6 +93,15 @@ func (c *secretsClient) Get(ctx context.Context, in *GetSecretRequest, opts ...g
 	return out, nil
 }
 
+func (c *secretsClient) GetByName(ctx context.Context, in *GetSecretByNameRequest, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+	out := new(GetSecretResponse)
+	err := c.cc.Invoke(ctx, Secrets_GetByName_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *secretsClient) Update(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*UpdateSecretResponse, error) {
 	out := new(UpdateSecretResponse)
 	err := c.cc.Invoke(ctx, Secrets_Update_FullMethodName, in, out, opts...)
@@ -101,10 +126,15 @@ func (c *secretsClient) Delete(ctx context.Context, in *DeleteSecretRequest, opt
 type SecretsServer interface {
 	// Store new secret.
 	Create(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error)
+	// Store several secrets at once, used to import data from other password managers.
+	// Replaced secrets are deleted in the same transaction.
+	CreateBatch(context.Context, *CreateSecretsRequest) (*CreateSecretsResponse, error)
 	// List brief secrets without data for the current user.
 	List(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error)
 	// Get a secret with data.
 	Get(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
+	// Get a secret with data by its name within a folder.
+	GetByName(context.Context, *GetSecretByNameRequest) (*GetSecretResponse, error)
 	// Change a secret and/or stored data.
 	Update(context.Context, *UpdateSecretRequest) (*UpdateSecretResponse, error)
 	// Remove a secret.
@@ -119,12 +149,18 @@ type UnimplementedSecretsServer struct {
 func (UnimplementedSecretsServer) Create(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
+func (UnimplementedSecretsServer) CreateBatch(context.Context, *CreateSecretsRequest) (*CreateSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBatch not implemented")
+}
 func (UnimplementedSecretsServer) List(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
 func (UnimplementedSecretsServer) Get(context.Context, *GetSecretRequest) (*GetSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedSecretsServer) GetByName(context.Context, *GetSecretByNameRequest) (*GetSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByName not implemented")
+}
 func (UnimplementedSecretsServer) Update(context.Context, *UpdateSecretRequest) (*UpdateSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
@@ -162,6 +198,24 @@ func _Secrets_Create_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Secrets_CreateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsServer).CreateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Secrets_CreateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsServer).CreateBatch(ctx, req.(*CreateSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Secrets_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSecretsRequest)
 	if err := dec(in); err != nil {
@@ -198,6 +252,24 @@ func _Secrets_Get_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Secrets_GetByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsServer).GetByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Secrets_GetByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsServer).GetByName(ctx, req.(*GetSecretByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Secrets_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateSecretRequest)
 	if err := dec(in); err != nil {
@@ -245,6 +317,10 @@ var Secrets_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Create",
 			Handler:    _Secrets_Create_Handler,
 		},
+		{
+			MethodName: "CreateBatch",
+			Handler:    _Secrets_CreateBatch_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _Secrets_List_Handler,
@@ -253,6 +329,10 @@ var Secrets_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _Secrets_Get_Handler,
 		},
+		{
+			MethodName: "GetByName",
+			Handler:    _Secrets_GetByName_Handler,
+		},
 		{
 			MethodName: "Update",
 			Handler:    _Secrets_Update_Handler,
@@ -27,6 +27,20 @@ func (m *SecretsClientMock) Create(
 	return args.Get(0).(*CreateSecretResponse), args.Error(1)
 }
 
+func (m *SecretsClientMock) CreateBatch(
+	ctx context.Context,
+	in *CreateSecretsRequest,
+	opts ...grpc.CallOption,
+) (*CreateSecretsResponse, error) {
+	args := m.Called(ctx, in, opts)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CreateSecretsResponse), args.Error(1)
+}
+
 func (m *SecretsClientMock) List(
 	ctx context.Context,
 	in *ListSecretsRequest,
@@ -55,6 +69,20 @@ func (m *SecretsClientMock) Get(
 	return args.Get(0).(*GetSecretResponse), args.Error(1)
 }
 
+func (m *SecretsClientMock) GetByName(
+	ctx context.Context,
+	in *GetSecretByNameRequest,
+	opts ...grpc.CallOption,
+) (*GetSecretResponse, error) {
+	args := m.Called(ctx, in, opts)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetSecretResponse), args.Error(1)
+}
+
 func (m *SecretsClientMock) Update(
 	ctx context.Context,
 	in *UpdateSecretRequest,